@@ -26,6 +26,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	cloudprovider "k8s.io/cloud-provider"
@@ -96,9 +97,10 @@ func GCEConfString(logger klog.Logger) (string, error) {
 	return configString, nil
 }
 
-// NewGCEClient returns a client to the GCE environment. This will block until
-// a valid configuration file can be read.
-func NewGCEClient(logger klog.Logger) *gce.Cloud {
+// NewGCEClient returns a client to the GCE environment and the rate limiter
+// configured on it. This will block until a valid configuration file can be
+// read.
+func NewGCEClient(logger klog.Logger) (*gce.Cloud, cloud.RateLimiter) {
 	var configReader func() io.Reader
 	if flags.F.ConfigFilePath != "" {
 		allConfig, err := GCEConfString(logger)
@@ -112,10 +114,28 @@ func NewGCEClient(logger klog.Logger) *gce.Cloud {
 		configReader = func() io.Reader { return nil }
 	}
 
-	return GCEClientForConfigReader(configReader, logger)
+	rl := newGCERateLimiter(logger)
+	return gceClientForConfigReader(configReader, rl, logger), rl
 }
 
 func GCEClientForConfigReader(configReader func() io.Reader, logger klog.Logger) *gce.Cloud {
+	return gceClientForConfigReader(configReader, newGCERateLimiter(logger), logger)
+}
+
+// newGCERateLimiter returns the rate limiter configured by the GCE rate limit
+// flags, or nil if none are set.
+func newGCERateLimiter(logger klog.Logger) cloud.RateLimiter {
+	rl, err := ratelimit.NewGCERateLimiter(flags.F.GCERateLimit.Values(), flags.F.GCEOperationPollInterval, logger)
+	if err != nil {
+		klog.Fatalf("Error configuring rate limiting: %v", err)
+	}
+	if rl == nil {
+		return nil
+	}
+	return rl
+}
+
+func gceClientForConfigReader(configReader func() io.Reader, rl cloud.RateLimiter, logger klog.Logger) *gce.Cloud {
 	// Creating the cloud interface involves resolving the metadata server to get
 	// an oauth token. If this fails, the token provider assumes it's not on GCE.
 	// No errors are thrown. So we need to keep retrying till it works because
@@ -123,21 +143,17 @@ func GCEClientForConfigReader(configReader func() io.Reader, logger klog.Logger)
 	for {
 		provider, err := cloudprovider.GetCloudProvider("gce", configReader())
 		if err == nil {
-			cloud := provider.(*gce.Cloud)
+			gceCloud := provider.(*gce.Cloud)
 			// Configure GCE rate limiting
-			rl, err := ratelimit.NewGCERateLimiter(flags.F.GCERateLimit.Values(), flags.F.GCEOperationPollInterval, logger)
-			if err != nil {
-				klog.Fatalf("Error configuring rate limiting: %v", err)
-			}
-			cloud.SetRateLimiter(rl)
+			gceCloud.SetRateLimiter(rl)
 			// If this controller is scheduled on a node without compute/rw
 			// it won't be allowed to list backends. We can assume that the
 			// user has no need for Ingress in this case. If they grant
 			// permissions to the node they will have to restart the controller
 			// manually to re-create the client.
 			// TODO: why do we bail with success out if there is a permission error???
-			if _, err = cloud.ListGlobalBackendServices(); err == nil || utils.IsHTTPErrorCode(err, http.StatusForbidden) {
-				return cloud
+			if _, err = gceCloud.ListGlobalBackendServices(); err == nil || utils.IsHTTPErrorCode(err, http.StatusForbidden) {
+				return gceCloud
 			}
 			logger.Info("Failed to list backend services, retrying", "err", err)
 		} else {
@@ -32,22 +32,31 @@ import (
 	flag "github.com/spf13/pflag"
 	crdclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/frontendconfig"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/ingparams"
+	ingparamsclient "k8s.io/ingress-gce/pkg/ingparams/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/instancegroups"
 	"k8s.io/ingress-gce/pkg/l4lb"
 	"k8s.io/ingress-gce/pkg/multiproject/sharedcontext"
 	"k8s.io/ingress-gce/pkg/network"
 	"k8s.io/ingress-gce/pkg/psc"
+	"k8s.io/ingress-gce/pkg/serverlessneg"
+	serverlessnegclient "k8s.io/ingress-gce/pkg/serverlessneg/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/serviceattachment"
 	serviceattachmentclient "k8s.io/ingress-gce/pkg/serviceattachment/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/staticaddress"
+	staticaddressclient "k8s.io/ingress-gce/pkg/staticaddress/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/svcneg"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -56,10 +65,13 @@ import (
 	ingctx "k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller"
 	"k8s.io/ingress-gce/pkg/neg"
+	"k8s.io/ingress-gce/pkg/neg/sharding"
 	"k8s.io/ingress-gce/pkg/neg/syncers/labels"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 
 	"k8s.io/ingress-gce/cmd/glbc/app"
+	"k8s.io/ingress-gce/pkg/backendbucket"
+	backendbucketclient "k8s.io/ingress-gce/pkg/backendbucket/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/backendconfig"
 	"k8s.io/ingress-gce/pkg/crd"
 	"k8s.io/ingress-gce/pkg/firewalls"
@@ -151,6 +163,66 @@ func main() {
 		}
 	}
 
+	var ingParamsClient ingparamsclient.Interface
+	if flags.F.EnableIngressClassParams {
+		ingParamsCRDMeta := ingparams.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(ingParamsCRDMeta, false); err != nil {
+			klog.Fatalf("Failed to ensure GCPIngressParams CRD: %v", err)
+		}
+
+		ingParamsClient, err = ingparamsclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create GCPIngressParams client: %v", err)
+		}
+	}
+
+	var gatewayClient dynamic.Interface
+	if flags.F.EnableGateway {
+		gatewayClient, err = dynamic.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create Gateway client: %v", err)
+		}
+	}
+
+	var backendBucketClient backendbucketclient.Interface
+	if flags.F.EnableBackendBuckets {
+		backendBucketCRDMeta := backendbucket.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(backendBucketCRDMeta, true); err != nil {
+			klog.Fatalf("Failed to ensure BackendBucket CRD: %v", err)
+		}
+
+		backendBucketClient, err = backendbucketclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create BackendBucket client: %v", err)
+		}
+	}
+
+	var serverlessNEGClient serverlessnegclient.Interface
+	if flags.F.EnableServerlessNEGs {
+		serverlessNEGCRDMeta := serverlessneg.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(serverlessNEGCRDMeta, true); err != nil {
+			klog.Fatalf("Failed to ensure ServerlessNEG CRD: %v", err)
+		}
+
+		serverlessNEGClient, err = serverlessnegclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create ServerlessNEG client: %v", err)
+		}
+	}
+
+	var staticAddressClient staticaddressclient.Interface
+	if flags.F.EnableStaticAddresses {
+		staticAddressCRDMeta := staticaddress.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(staticAddressCRDMeta, true); err != nil {
+			klog.Fatalf("Failed to ensure StaticAddress CRD: %v", err)
+		}
+
+		staticAddressClient, err = staticaddressclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create StaticAddress client: %v", err)
+		}
+	}
+
 	var firewallCRClient firewallcrclient.Interface
 	if flags.F.EnableFirewallCR {
 		firewallCRClient, err = firewallcrclient.NewForConfig(kubeConfig)
@@ -230,7 +302,7 @@ func main() {
 		rootLogger.Info("Multi-project mode is enabled, starting project-syncer")
 	}
 
-	cloud := app.NewGCEClient(rootLogger)
+	cloud, cloudRateLimiter := app.NewGCEClient(rootLogger)
 
 	if flags.F.OverrideComputeAPIEndpoint != "" {
 		// Globally set the domain for all urls generated by GoogleCloudPlatform/k8s-cloud-provider.
@@ -243,6 +315,7 @@ func main() {
 
 	defaultBackendServicePort := app.DefaultBackendServicePort(kubeClient, rootLogger)
 	ctxConfig := ingctx.ControllerContextConfig{
+		CloudRateLimiter:              cloudRateLimiter,
 		Namespace:                     flags.F.WatchNamespace,
 		ResyncPeriod:                  flags.F.ResyncPeriod,
 		NumL4Workers:                  flags.F.NumL4Workers,
@@ -265,8 +338,16 @@ func main() {
 		EnableL4NetLBNEGs:             flags.F.EnableL4NetLBNEG,
 		EnableL4NetLBNEGsDefault:      flags.F.EnableL4NetLBNEGDefault,
 		EnableL4MixedProtocol:         flags.F.EnableL4MixedProtocol,
+		EnableL4ILBMultipleFwdRules:   flags.F.EnableL4ILBMultipleForwardingRules,
 	}
-	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, firewallCRClient, svcNegClient, svcAttachmentClient, networkClient, nodeTopologyClient, eventRecorderKubeClient, cloud, namer, kubeSystemUID, ctxConfig, rootLogger)
+	extClients := ingctx.ExtensionClients{
+		IngParams:     ingParamsClient,
+		Gateway:       gatewayClient,
+		BackendBucket: backendBucketClient,
+		ServerlessNEG: serverlessNEGClient,
+		StaticAddress: staticAddressClient,
+	}
+	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, firewallCRClient, svcNegClient, svcAttachmentClient, networkClient, nodeTopologyClient, extClients, eventRecorderKubeClient, cloud, namer, kubeSystemUID, ctxConfig, rootLogger)
 	go app.RunHTTPServer(ctx.HealthCheck, rootLogger)
 
 	hostname, err := os.Hostname()
@@ -310,7 +391,9 @@ func main() {
 		runControllers(ctx, option, logger)
 	}
 
-	if flags.F.LeaderElection.LeaderElect {
+	// With sharding, the NEG controller runs on every replica, and the
+	// replicas split the Services among themselves.
+	if flags.F.LeaderElection.LeaderElect && flags.F.NEGShards == 0 {
 		runNEG = func() {
 			logger := rootLogger.WithName("NEG Controller")
 			logger.Info("Start running NEG leader election",
@@ -323,6 +406,8 @@ func main() {
 			leaderelection.RunOrDie(context.Background(), *negElectionConfig)
 			logger.Info("NEG Controller exited.")
 		}
+	}
+	if flags.F.LeaderElection.LeaderElect {
 		runIngress = func() {
 			logger := rootLogger.WithName("Other controllers")
 			logger.Info("Start running Ingress leader election",
@@ -420,6 +505,24 @@ func runControllers(ctx *ingctx.ControllerContext, option runOption, logger klog
 		logger.V(0).Info("PSC Controller started")
 	}
 
+	if flags.F.EnableBackendBuckets {
+		backendBucketController := backendbucket.NewController(ctx, option.stopCh, logger)
+		runWithWg(backendBucketController.Run, option.wg)
+		logger.V(0).Info("Backend bucket controller started")
+	}
+
+	if flags.F.EnableServerlessNEGs {
+		serverlessNEGController := serverlessneg.NewController(ctx, option.stopCh, logger)
+		runWithWg(serverlessNEGController.Run, option.wg)
+		logger.V(0).Info("Serverless NEG controller started")
+	}
+
+	if flags.F.EnableStaticAddresses {
+		staticAddressController := staticaddress.NewController(ctx, option.stopCh, logger)
+		runWithWg(staticAddressController.Run, option.wg)
+		logger.V(0).Info("Static address controller started")
+	}
+
 	go app.RunSIGTERMHandler(option.closeStopCh, logger)
 
 	ctx.Start(option.stopCh)
@@ -489,7 +592,19 @@ func runNEGController(ctx *ingctx.ControllerContext, option runOption, logger kl
 	}
 
 	if flags.F.EnableNEGController {
-		negController := createNEGController(ctx, option.stopCh, logger)
+		var shards *sharding.Coordinator
+		if flags.F.NEGShards > 0 {
+			shards = sharding.NewCoordinator(option.client.CoordinationV1(), sharding.Config{
+				Namespace:     flags.F.LeaderElection.LockObjectNamespace,
+				LeasePrefix:   negLockName,
+				Identity:      option.id,
+				NumShards:     flags.F.NEGShards,
+				LeaseDuration: flags.F.LeaderElection.LeaseDuration.Duration,
+				RenewDeadline: flags.F.LeaderElection.RenewDeadline.Duration,
+				RetryPeriod:   flags.F.LeaderElection.RetryPeriod.Duration,
+			}, logger)
+		}
+		negController := createNEGController(ctx, shards, option.stopCh, logger)
 		go runWithWg(negController.Run, option.wg)
 		logger.V(0).Info("negController started")
 	}
@@ -504,7 +619,7 @@ func runNEGController(ctx *ingctx.ControllerContext, option runOption, logger kl
 	ctx.Start(option.stopCh)
 }
 
-func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{}, logger klog.Logger) *neg.Controller {
+func createNEGController(ctx *ingctx.ControllerContext, shards *sharding.Coordinator, stopCh <-chan struct{}, logger klog.Logger) *neg.Controller {
 	zoneGetter := ctx.ZoneGetter
 
 	// In NonGCP mode, use the zone specified in gce.conf directly.
@@ -521,13 +636,17 @@ func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{},
 		asmServiceNEGSkipNamespaces = cmconfig.ASMServiceNEGSkipNamespaces
 	}
 
-	lpConfig := labels.PodLabelPropagationConfig{}
+	defaultLPConfig := labels.PodLabelPropagationConfig{}
 	if flags.F.EnableNEGLabelPropagation {
 		lpConfigEnvVar := os.Getenv("LABEL_PROPAGATION_CONFIG")
-		if err := json.Unmarshal([]byte(lpConfigEnvVar), &lpConfig); err != nil {
+		if err := json.Unmarshal([]byte(lpConfigEnvVar), &defaultLPConfig); err != nil {
 			logger.Error(err, "Failed to retrieve pod label propagation config")
 		}
 	}
+	var lpConfig labels.PodLabelPropagationConfigSource = defaultLPConfig
+	if flags.F.EnableNEGLabelPropagation && flags.F.LabelPropagationConfigMapName != "" {
+		lpConfig = startLabelPropagationConfigSource(ctx, defaultLPConfig, stopCh, logger)
+	}
 
 	// The following adapter will use Network Selflink as Network Url instead of the NetworkUrl itself.
 	// Network Selflink is always composed by the network name even if the cluster was initialized with Network Id.
@@ -546,6 +665,7 @@ func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{},
 		ctx.EventRecorderClient,
 		ctx.KubeSystemUID,
 		ctx.IngressInformer,
+		ctx.FrontendConfigInformer,
 		ctx.ServiceInformer,
 		ctx.PodInformer,
 		ctx.NodeInformer,
@@ -557,6 +677,7 @@ func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{},
 		ctx.HasSynced,
 		ctx.L4Namer,
 		ctx.DefaultBackendSvcPort,
+		ctx.IngressClassifier,
 		negtypes.NewAdapterWithRateLimitSpecs(ctx.Cloud, flags.F.GCERateLimit.Values(), adapter),
 		zoneGetter,
 		ctx.ClusterNamer,
@@ -573,6 +694,7 @@ func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{},
 		flags.F.EnableMultiNetworking,
 		ctx.EnableIngressRegionalExternal,
 		flags.F.EnableL4NetLBNEG,
+		shards,
 		stopCh,
 		logger,
 	)
@@ -581,6 +703,31 @@ func createNEGController(ctx *ingctx.ControllerContext, stopCh <-chan struct{},
 	return negController
 }
 
+// startLabelPropagationConfigSource returns a pod label propagation config
+// source reloading the config from the ConfigMap set by flags, and starts the
+// informer watching the ConfigMap. It waits for the informer to sync, so that
+// the NEG syncers start with the config of the ConfigMap rather than the
+// default config.
+func startLabelPropagationConfigSource(ctx *ingctx.ControllerContext, defaultConfig labels.PodLabelPropagationConfig, stopCh <-chan struct{}, logger klog.Logger) *labels.ConfigMapConfigSource {
+	namespace, name := flags.F.LabelPropagationConfigMapNamespace, flags.F.LabelPropagationConfigMapName
+	logger.Info("Loading pod label propagation config from ConfigMap", "configMap", klog.KRef(namespace, name))
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(
+		ctx.KubeClient,
+		ctx.ResyncPeriod,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+			listOptions.FieldSelector = fmt.Sprintf("metadata.name=%s", name)
+		}))
+	source := labels.NewConfigMapConfigSource(namespace, name, defaultConfig, ctx.Recorder(namespace), logger)
+	informer := informerFactory.Core().V1().ConfigMaps().Informer()
+	source.RegisterInformer(informer)
+	informerFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		logger.Error(nil, "Failed to sync the pod label propagation ConfigMap informer, using the default config until it syncs", "configMap", klog.KRef(namespace, name))
+	}
+	return source
+}
+
 // runWithWg is a convenience wrapper that do a wg.Add(1), and runs the given
 // function in a goroutine with a deferred wg.Done().
 // We need to make sure wg.Add(1) when the counter is zero is executed before
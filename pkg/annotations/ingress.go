@@ -17,8 +17,11 @@ limitations under the License.
 package annotations
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/networking/v1"
 )
@@ -56,6 +59,20 @@ const (
 	// to the target proxies of the Ingress.
 	PreSharedCertKey = "ingress.gcp.kubernetes.io/pre-shared-cert"
 
+	// CertificateMapKey represents the Certificate Manager certificate map
+	// for the Ingress controller to use. If specified, the target https proxy
+	// of the Ingress serves the certificates of the map instead of the
+	// pre-shared certificates and the certificates of the TLS Secrets. The
+	// controller *does not* manage this certificate map, it is the users
+	// responsibility to create/delete it. It is only supported for global
+	// external Ingresses.
+	CertificateMapKey = "networking.gke.io/certmap"
+
+	// ManagedCertificatesKey lists the ManagedCertificate resources of the
+	// Ingress. The Google-managed SSL certificates they provision are attached
+	// to the target https proxy as pre-shared certificates.
+	ManagedCertificatesKey = "networking.gke.io/managed-certificates"
+
 	// IngressClassKey picks a specific "class" for the Ingress. The controller
 	// only processes Ingresses with this annotation either unset, or set
 	// to either gceIngressClass or the empty string.
@@ -87,6 +104,18 @@ const (
 	//     networking.gke.io/v1beta1.FrontendConfig: 'my-frontendconfig'
 	FrontendConfigKey = "networking.gke.io/v1beta1.FrontendConfig"
 
+	// TrafficSplitKey is the annotation key used by controller to split the
+	// traffic of the Ingress paths, and of the default backend, that
+	// reference a Service port between multiple Services. The value is a JSON
+	// map from "<service name>:<service port>" to the Services that receive
+	// the traffic, weighted in percent. The port must be referenced the same
+	// way as in the Ingress backend, by number or by name. The weights of
+	// each split must add up to 100.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/traffic-split: '{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "my-app-canary", "servicePort": {"number": 80}, "weight": 10}]}'
+	TrafficSplitKey = "networking.gke.io/traffic-split"
+
 	// UrlMapKey is the annotation key used by controller to record GCP URL map.
 	UrlMapKey = StatusPrefix + "/url-map"
 	// UrlMapKey is the annotation key used by controller to record GCP URL map used for Https Redirects only.
@@ -109,6 +138,51 @@ const (
 	StaticIPKey = StatusPrefix + "/static-ip"
 )
 
+// ErrTrafficSplitAnnotationInvalid is returned when the traffic split
+// annotation cannot be parsed.
+var ErrTrafficSplitAnnotationInvalid = errors.New("traffic split annotation is invalid")
+
+// TrafficSplitTarget is the Service port referenced by the Ingress whose
+// traffic is split.
+type TrafficSplitTarget struct {
+	ServiceName string
+	ServicePort v1.ServiceBackendPort
+}
+
+// String returns the "<service name>:<service port>" form used as key in the
+// traffic split annotation.
+func (t TrafficSplitTarget) String() string {
+	if t.ServicePort.Name != "" {
+		return t.ServiceName + ":" + t.ServicePort.Name
+	}
+	return t.ServiceName + ":" + strconv.Itoa(int(t.ServicePort.Number))
+}
+
+// parseTrafficSplitTarget parses a "<service name>:<service port>" key of the
+// traffic split annotation.
+func parseTrafficSplitTarget(key string) (TrafficSplitTarget, error) {
+	i := strings.LastIndex(key, ":")
+	if i <= 0 || i == len(key)-1 {
+		return TrafficSplitTarget{}, fmt.Errorf("%w: key %q is not of the form <service name>:<service port>", ErrTrafficSplitAnnotationInvalid, key)
+	}
+	target := TrafficSplitTarget{ServiceName: key[:i]}
+	port := key[i+1:]
+	if number, err := strconv.Atoi(port); err == nil {
+		target.ServicePort.Number = int32(number)
+	} else {
+		target.ServicePort.Name = port
+	}
+	return target, nil
+}
+
+// TrafficSplitBackend is a Service port that receives a percentage of the
+// traffic of a split.
+type TrafficSplitBackend struct {
+	ServiceName string                `json:"serviceName"`
+	ServicePort v1.ServiceBackendPort `json:"servicePort"`
+	Weight      int32                 `json:"weight"`
+}
+
 // Ingress represents ingress annotations.
 type Ingress struct {
 	v map[string]string
@@ -146,6 +220,24 @@ func (ing *Ingress) UseNamedTLS() string {
 	return val
 }
 
+// CertificateMap returns the name of the Certificate Manager certificate map.
+// Empty by default.
+func (ing *Ingress) CertificateMap() string {
+	return ing.v[CertificateMapKey]
+}
+
+// ManagedCertificates returns the comma-separated names of the
+// ManagedCertificate resources of the Ingress. Empty by default.
+func (ing *Ingress) ManagedCertificates() string {
+	return ing.v[ManagedCertificatesKey]
+}
+
+// StaticAddress returns the name of the StaticAddress resource referenced by
+// the Ingress. Empty by default.
+func (ing *Ingress) StaticAddress() string {
+	return ing.v[StaticAddressKey]
+}
+
 func (ing *Ingress) StaticIPName() (string, error) {
 	globalIp := ing.GlobalStaticIPName()
 	regionalIp := ing.RegionalStaticIPName()
@@ -206,3 +298,42 @@ func (ing *Ingress) FrontendConfig() string {
 	}
 	return val
 }
+
+// TrafficSplits returns the traffic splits of the Ingress keyed by the Service
+// port whose traffic is split. It returns nil if the annotation is not set.
+func (ing *Ingress) TrafficSplits() (map[TrafficSplitTarget][]TrafficSplitBackend, error) {
+	val, ok := ing.v[TrafficSplitKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var rawSplits map[string][]TrafficSplitBackend
+	if err := json.Unmarshal([]byte(val), &rawSplits); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTrafficSplitAnnotationInvalid, err)
+	}
+	splits := make(map[TrafficSplitTarget][]TrafficSplitBackend, len(rawSplits))
+	for key, backends := range rawSplits {
+		target, err := parseTrafficSplitTarget(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(backends) == 0 {
+			return nil, fmt.Errorf("%w: no backends for %q", ErrTrafficSplitAnnotationInvalid, key)
+		}
+		var total int32
+		for _, backend := range backends {
+			if backend.ServiceName == "" {
+				return nil, fmt.Errorf("%w: backend without serviceName for %q", ErrTrafficSplitAnnotationInvalid, key)
+			}
+			if backend.Weight < 0 || backend.Weight > 100 {
+				return nil, fmt.Errorf("%w: weight %d of backend %q is not between 0 and 100", ErrTrafficSplitAnnotationInvalid, backend.Weight, backend.ServiceName)
+			}
+			total += backend.Weight
+		}
+		if total != 100 {
+			return nil, fmt.Errorf("%w: weights for %q add up to %d, want 100", ErrTrafficSplitAnnotationInvalid, key, total)
+		}
+		splits[target] = backends
+	}
+	return splits, nil
+}
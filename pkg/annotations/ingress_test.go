@@ -17,6 +17,8 @@ limitations under the License.
 package annotations
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/networking/v1"
@@ -29,6 +31,8 @@ func TestIngress(t *testing.T) {
 		ing          *v1.Ingress
 		allowHTTP    bool
 		useNamedTLS  string
+		certMap      string
+		managedCerts string
 		staticIPName string
 		ingressClass string
 		wantErr      bool
@@ -59,15 +63,19 @@ func TestIngress(t *testing.T) {
 			ing: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						AllowHTTPKey:          "false",
-						IngressClassKey:       "gce",
-						PreSharedCertKey:      "shared-cert-key",
-						GlobalStaticIPNameKey: "1.2.3.4",
+						AllowHTTPKey:           "false",
+						IngressClassKey:        "gce",
+						PreSharedCertKey:       "shared-cert-key",
+						CertificateMapKey:      "cert-map",
+						ManagedCertificatesKey: "managed-cert",
+						GlobalStaticIPNameKey:  "1.2.3.4",
 					},
 				},
 			},
 			allowHTTP:    false,
 			useNamedTLS:  "shared-cert-key",
+			certMap:      "cert-map",
+			managedCerts: "managed-cert",
 			staticIPName: "1.2.3.4",
 			ingressClass: "gce",
 		},
@@ -80,6 +88,12 @@ func TestIngress(t *testing.T) {
 		if x := ing.UseNamedTLS(); x != tc.useNamedTLS {
 			t.Errorf("ingress %+v; UseNamedTLS() = %v, want %v", tc.ing, x, tc.useNamedTLS)
 		}
+		if x := ing.CertificateMap(); x != tc.certMap {
+			t.Errorf("ingress %+v; CertificateMap() = %v, want %v", tc.ing, x, tc.certMap)
+		}
+		if x := ing.ManagedCertificates(); x != tc.managedCerts {
+			t.Errorf("ingress %+v; ManagedCertificates() = %v, want %v", tc.ing, x, tc.managedCerts)
+		}
 		staticIp, err := ing.StaticIPName()
 		if (err != nil) != tc.wantErr {
 			t.Errorf("ingress: %+v, err = %v, wantErr = %v", tc.ing, err, tc.wantErr)
@@ -92,3 +106,84 @@ func TestIngress(t *testing.T) {
 		}
 	}
 }
+
+func TestTrafficSplits(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		annotation *string
+		want       map[TrafficSplitTarget][]TrafficSplitBackend
+		wantErr    bool
+	}{
+		{
+			desc: "No annotation",
+		},
+		{
+			desc:       "Valid split",
+			annotation: strPtr(`{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "my-app-canary", "servicePort": {"name": "http"}, "weight": 10}]}`),
+			want: map[TrafficSplitTarget][]TrafficSplitBackend{
+				{ServiceName: "my-app", ServicePort: v1.ServiceBackendPort{Number: 80}}: {
+					{ServiceName: "my-app", ServicePort: v1.ServiceBackendPort{Number: 80}, Weight: 90},
+					{ServiceName: "my-app-canary", ServicePort: v1.ServiceBackendPort{Name: "http"}, Weight: 10},
+				},
+			},
+		},
+		{
+			desc:       "Splits of two ports of the same Service",
+			annotation: strPtr(`{"my-app:http": [{"serviceName": "my-app-canary", "servicePort": {"name": "http"}, "weight": 100}], "my-app:8080": [{"serviceName": "my-app", "servicePort": {"number": 8080}, "weight": 100}]}`),
+			want: map[TrafficSplitTarget][]TrafficSplitBackend{
+				{ServiceName: "my-app", ServicePort: v1.ServiceBackendPort{Name: "http"}}: {
+					{ServiceName: "my-app-canary", ServicePort: v1.ServiceBackendPort{Name: "http"}, Weight: 100},
+				},
+				{ServiceName: "my-app", ServicePort: v1.ServiceBackendPort{Number: 8080}}: {
+					{ServiceName: "my-app", ServicePort: v1.ServiceBackendPort{Number: 8080}, Weight: 100},
+				},
+			},
+		},
+		{
+			desc:       "Key without port",
+			annotation: strPtr(`{"my-app": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 100}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "Malformed JSON",
+			annotation: strPtr(`{"my-app": [`),
+			wantErr:    true,
+		},
+		{
+			desc:       "Weights do not add up to 100",
+			annotation: strPtr(`{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "Weight out of range",
+			annotation: strPtr(`{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 110}, {"serviceName": "my-app-canary", "servicePort": {"number": 80}, "weight": -10}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "No backends",
+			annotation: strPtr(`{"my-app:80": []}`),
+			wantErr:    true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ing := &v1.Ingress{}
+			if tc.annotation != nil {
+				ing.Annotations = map[string]string{TrafficSplitKey: *tc.annotation}
+			}
+			got, err := FromIngress(ing).TrafficSplits()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("TrafficSplits() = _, %v, wantErr = %v", err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTrafficSplitAnnotationInvalid) {
+				t.Errorf("TrafficSplits() = _, %v, want ErrTrafficSplitAnnotationInvalid", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("TrafficSplits() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// ILBForwardingRulesKey is the annotation key on an L4 ILB Service to
+	// create additional forwarding rules, each with its own VIP, pointing at
+	// the backend service of the Service. The value is a JSON list of
+	// ILBForwardingRule.
+	// Example:
+	// '[{"name":"all","allPorts":true},{"name":"web","ports":[80,443],"address":"web-vip"}]'
+	ILBForwardingRulesKey = "networking.gke.io/internal-load-balancer-forwarding-rules"
+	// AdditionalForwardingRulesKey is the annotation key used by l4 controller
+	// to record the GCP forwarding rule names of the additional forwarding
+	// rules, as a JSON map from rule name to forwarding rule name.
+	AdditionalForwardingRulesKey = ServiceStatusPrefix + "/additional-" + ForwardingRuleResource + "s"
+
+	// maxILBForwardingRules is the maximum number of additional forwarding
+	// rules of a Service.
+	maxILBForwardingRules = 5
+	// maxILBForwardingRuleNameLength is the maximum length of the name of an
+	// additional forwarding rule, which is part of the GCE resource names.
+	maxILBForwardingRuleNameLength = 10
+)
+
+var (
+	ErrILBForwardingRulesInvalid = errors.New("internal load balancer forwarding rules annotation is invalid")
+
+	ilbForwardingRuleNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// ILBForwardingRule is an additional forwarding rule of an L4 ILB Service.
+type ILBForwardingRule struct {
+	// Name identifies the forwarding rule in the Service. It must be a
+	// lowercase RFC 1035 label of at most 10 characters.
+	Name string `json:"name"`
+	// Ports are the Service ports forwarded by the rule. If empty, the rule
+	// forwards the same ports as the main forwarding rule.
+	Ports []int32 `json:"ports,omitempty"`
+	// AllPorts forwards all the ports of the VIP. It cannot be used with
+	// Ports.
+	AllPorts bool `json:"allPorts,omitempty"`
+	// Address is the name of a reserved regional IPv4 address used as VIP.
+	// If empty, an ephemeral address from the subnet of the Service is used.
+	Address string `json:"address,omitempty"`
+}
+
+// ILBForwardingRules returns the additional forwarding rules of the Service
+// and true if the annotation is found. The Service ports referenced by the
+// rules are validated against servicePorts.
+func (svc *Service) ILBForwardingRules(servicePorts []int32) ([]ILBForwardingRule, bool, error) {
+	val, ok := svc.v[ILBForwardingRulesKey]
+	if !ok {
+		return nil, false, nil
+	}
+	var rules []ILBForwardingRule
+	if err := json.Unmarshal([]byte(val), &rules); err != nil {
+		return nil, true, fmt.Errorf("%w: %v", ErrILBForwardingRulesInvalid, err)
+	}
+	if len(rules) > maxILBForwardingRules {
+		return nil, true, fmt.Errorf("%w: %d rules, at most %d are allowed", ErrILBForwardingRulesInvalid, len(rules), maxILBForwardingRules)
+	}
+
+	validPorts := make(map[int32]bool)
+	for _, port := range servicePorts {
+		validPorts[port] = true
+	}
+	names := make(map[string]bool)
+	for _, rule := range rules {
+		if len(rule.Name) > maxILBForwardingRuleNameLength || !ilbForwardingRuleNameRegexp.MatchString(rule.Name) {
+			return nil, true, fmt.Errorf("%w: invalid rule name %q", ErrILBForwardingRulesInvalid, rule.Name)
+		}
+		if names[rule.Name] {
+			return nil, true, fmt.Errorf("%w: duplicate rule name %q", ErrILBForwardingRulesInvalid, rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.AllPorts && len(rule.Ports) > 0 {
+			return nil, true, fmt.Errorf("%w: rule %q sets both ports and allPorts", ErrILBForwardingRulesInvalid, rule.Name)
+		}
+		for _, port := range rule.Ports {
+			if !validPorts[port] {
+				return nil, true, fmt.Errorf("%w: rule %q references port %d which is not a Service port", ErrILBForwardingRulesInvalid, rule.Name, port)
+			}
+		}
+	}
+	return rules, true, nil
+}
+
+// AdditionalForwardingRules returns the names of the additional forwarding
+// rules recorded by the l4 controller, keyed by rule name.
+func (svc *Service) AdditionalForwardingRules() (map[string]string, error) {
+	val, ok := svc.v[AdditionalForwardingRulesKey]
+	if !ok {
+		return nil, nil
+	}
+	frNames := make(map[string]string)
+	if err := json.Unmarshal([]byte(val), &frNames); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %w", AdditionalForwardingRulesKey, err)
+	}
+	return frNames, nil
+}
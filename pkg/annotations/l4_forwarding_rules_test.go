@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestILBForwardingRules(t *testing.T) {
+	t.Parallel()
+
+	servicePorts := []int32{80, 443, 8080}
+	testCases := []struct {
+		desc          string
+		annotations   map[string]string
+		wantRules     []ILBForwardingRule
+		wantFound     bool
+		wantErr       bool
+		wantFRNames   map[string]string
+		wantFRNameErr bool
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc: "per-port and all ports rules",
+			annotations: map[string]string{
+				ILBForwardingRulesKey:        `[{"name":"all","allPorts":true},{"name":"web","ports":[80,443],"address":"web-vip"}]`,
+				AdditionalForwardingRulesKey: `{"all":"k8s2-tcp-all-uid-ns-svc-hash"}`,
+			},
+			wantRules: []ILBForwardingRule{
+				{Name: "all", AllPorts: true},
+				{Name: "web", Ports: []int32{80, 443}, Address: "web-vip"},
+			},
+			wantFound:   true,
+			wantFRNames: map[string]string{"all": "k8s2-tcp-all-uid-ns-svc-hash"},
+		},
+		{
+			desc:          "invalid json",
+			annotations:   map[string]string{ILBForwardingRulesKey: `[{"name":`, AdditionalForwardingRulesKey: `{`},
+			wantFound:     true,
+			wantErr:       true,
+			wantFRNameErr: true,
+		},
+		{
+			desc:        "invalid rule name",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"All_Ports"}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+		{
+			desc:        "too long rule name",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"abcdefghijk"}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+		{
+			desc:        "duplicate rule name",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"web"},{"name":"web","allPorts":true}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+		{
+			desc:        "ports and all ports",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"web","ports":[80],"allPorts":true}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+		{
+			desc:        "port which is not a service port",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"web","ports":[81]}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+		{
+			desc:        "too many rules",
+			annotations: map[string]string{ILBForwardingRulesKey: `[{"name":"a"},{"name":"b"},{"name":"c"},{"name":"d"},{"name":"e"},{"name":"f"}]`},
+			wantFound:   true,
+			wantErr:     true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			svc := FromService(&v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}})
+
+			rules, found, err := svc.ILBForwardingRules(servicePorts)
+			if found != tc.wantFound {
+				t.Errorf("ILBForwardingRules() found = %v, want %v", found, tc.wantFound)
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ILBForwardingRules() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrILBForwardingRulesInvalid) {
+				t.Errorf("ILBForwardingRules() error = %v, want %v", err, ErrILBForwardingRulesInvalid)
+			}
+			if diff := cmp.Diff(tc.wantRules, rules); diff != "" {
+				t.Errorf("ILBForwardingRules() returned diff (-want +got):\n%s", diff)
+			}
+
+			frNames, err := svc.AdditionalForwardingRules()
+			if gotErr := err != nil; gotErr != tc.wantFRNameErr {
+				t.Fatalf("AdditionalForwardingRules() error = %v, want error: %v", err, tc.wantFRNameErr)
+			}
+			if diff := cmp.Diff(tc.wantFRNames, frNames); diff != "" {
+				t.Errorf("AdditionalForwardingRules() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
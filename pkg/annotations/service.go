@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 )
 
@@ -50,6 +51,26 @@ const (
 	// on the Service, and is applied by the NEG Controller.
 	NEGStatusKey = "cloud.google.com/neg-status"
 
+	// NEGEndpointTypeKey is the annotation key on a Service without a
+	// selector, whose EndpointSlices are managed manually, to select the type
+	// of NEG its endpoints are materialized as. The valid values are
+	// "internet" for INTERNET_IP_PORT NEGs and "hybrid" for
+	// NON_GCP_PRIVATE_IP_PORT NEGs. ExternalName Services do not need this
+	// annotation and always use INTERNET_FQDN_PORT NEGs.
+	NEGEndpointTypeKey = "cloud.google.com/neg-endpoint-type"
+	// NEGEndpointTypeInternet selects INTERNET_IP_PORT NEGs.
+	NEGEndpointTypeInternet = "internet"
+	// NEGEndpointTypeHybrid selects NON_GCP_PRIVATE_IP_PORT NEGs.
+	NEGEndpointTypeHybrid = "hybrid"
+
+	// NEGGCPolicyKey is the annotation key on a Service to select the garbage
+	// collection policy of its NEGs. With the value "Retain", the NEG
+	// controller does not delete the NEGs of the Service when they are no
+	// longer needed, as long as the Service has the annotation.
+	NEGGCPolicyKey = "cloud.google.com/neg-gc-policy"
+	// NEGGCPolicyRetain opts the NEGs of a Service out of garbage collection.
+	NEGGCPolicyRetain = "Retain"
+
 	// BetaBackendConfigKey is a stringified JSON with two fields:
 	// - "ports": a map of port names or port numbers to backendConfig names
 	// - "default": denotes the default backendConfig name for all ports except
@@ -125,6 +146,7 @@ const (
 	FirewallForHealthcheckResource     = "firewall-rule-for-hc"
 	FirewallForHealthcheckIPv6Resource = FirewallRuleForHealthcheckKey + IPv6Suffix
 	AddressResource                    = "address"
+	AddressIPv6Resource                = AddressResource + IPv6Suffix
 	// TODO(slavik): import this from gce_annotations when it will be merged in k8s
 	RBSAnnotationKey = "cloud.google.com/l4-rbs"
 	RBSEnabled       = "enabled"
@@ -141,6 +163,29 @@ const (
 	WeightedL4AnnotationKey = "networking.gke.io/weighted-load-balancing"
 	// Service annotation value for using pods-per-node Weighted load balancing in both ILB and NetlB
 	WeightedL4AnnotationPodsPerNode = "pods-per-node"
+
+	// StaticAddressKey is the annotation key used by Services and Ingresses
+	// to use the IP address of a StaticAddress resource in the same namespace.
+	// The value is the name of the StaticAddress resource. It takes precedence
+	// over the other ways of specifying a static IP address.
+	StaticAddressKey = "networking.gke.io/static-address"
+
+	// ServiceDirectoryRegistrationKey is the annotation key used by L4 ILB
+	// Services to register their forwarding rule in Service Directory.
+	// The value is a JSON object, e.g.
+	// {"namespace": "my-namespace", "service": "my-service"}
+	ServiceDirectoryRegistrationKey = "networking.gke.io/service-directory-registration"
+	// MirroringCollectorKey is the annotation key used by L4 ILB Services to
+	// mark their forwarding rule as a collector for Packet Mirroring.
+	// The only supported value is "true".
+	MirroringCollectorKey = "networking.gke.io/mirroring-collector"
+	// L4FailoverPolicyKey is the annotation key used by L4 ILB Services to
+	// configure the failover policy of their backend service and to select
+	// the failover backends. Failover backends are whole zones, as the
+	// backends are zonal NEGs. The value is a JSON object, e.g.
+	// {"failoverNodeSelector": "role=standby", "failoverRatio": 0.5,
+	//  "dropTrafficIfUnhealthy": true, "disableConnectionDrainOnFailover": true}
+	L4FailoverPolicyKey = "networking.gke.io/l4-failover-policy"
 )
 
 // NegAnnotation is the format of the annotation associated with the
@@ -159,6 +204,18 @@ type NegAnnotation struct {
 	// ExposedPorts maps ServicePort to attributes of the NEG that should be
 	// associated with the ServicePort.
 	ExposedPorts map[int32]NegAttributes `json:"exposed_ports,omitempty"`
+	// NameTemplate is the template of the names of the exposed NEGs without a
+	// custom name. It must use the variables {namespace}, {service} and
+	// {port}, and can use {cluster}, e.g.
+	// "{cluster}-{namespace}-{service}-{port}". It overrides
+	// the NEG name template of the controller, and cannot be used with
+	// Ingress enabled.
+	NameTemplate string `json:"name_template,omitempty"`
+	// AdoptExisting indicates that the NEG controller takes ownership of
+	// exposed NEGs which already exist and were not created by a NEG
+	// controller, e.g. NEGs created by Terraform before the cluster exists.
+	// Only empty NEGs of the expected type and network are adopted.
+	AdoptExisting bool `json:"adopt_existing,omitempty"`
 }
 
 // THCAnnotation is the format of the annotation associated with the THCAnnotationKey key.
@@ -369,6 +426,7 @@ var (
 	ErrBackendConfigInvalidJSON       = errors.New("BackendConfig annotation is invalid json")
 	ErrBackendConfigAnnotationMissing = errors.New("BackendConfig annotation is missing")
 	ErrNEGAnnotationInvalid           = errors.New("NEG annotation is invalid.")
+	ErrNEGEndpointTypeInvalid         = errors.New("NEG endpoint type annotation is invalid")
 	ErrTHCAnnotationInvalid           = errors.New("THC annotation is invalid")
 )
 
@@ -389,6 +447,27 @@ func (svc *Service) NEGAnnotation() (*NegAnnotation, bool, error) {
 	return &res, true, nil
 }
 
+// NEGEndpointType returns the value of the NEG endpoint type annotation and
+// true if the annotation is found.
+func (svc *Service) NEGEndpointType() (string, bool, error) {
+	val, ok := svc.v[NEGEndpointTypeKey]
+	if !ok {
+		return "", false, nil
+	}
+	switch val {
+	case NEGEndpointTypeInternet, NEGEndpointTypeHybrid:
+		return val, true, nil
+	default:
+		return "", true, fmt.Errorf("%w: %q", ErrNEGEndpointTypeInvalid, val)
+	}
+}
+
+// RetainNEGs returns true if the NEG garbage collection policy annotation
+// opts the NEGs of the Service out of garbage collection.
+func (svc *Service) RetainNEGs() bool {
+	return svc.v[NEGGCPolicyKey] == NEGGCPolicyRetain
+}
+
 // IsThcAnnotated returns true if a THC annotation is found and its value is true.
 func (svc *Service) IsThcAnnotated() (bool, error) {
 	var res THCAnnotation
@@ -476,3 +555,90 @@ func (svc *Service) GetInternalLoadBalancerAnnotationSubnet() string {
 	}
 	return ""
 }
+
+// StaticAddress returns the name of the StaticAddress resource referenced by
+// the Service. Empty by default.
+func (svc *Service) StaticAddress() string {
+	return svc.v[StaticAddressKey]
+}
+
+// ServiceDirectoryRegistration is the value of the
+// ServiceDirectoryRegistrationKey annotation.
+type ServiceDirectoryRegistration struct {
+	// Namespace is the Service Directory namespace to register in.
+	Namespace string `json:"namespace"`
+	// Service is the Service Directory service to register as.
+	Service string `json:"service"`
+}
+
+var ErrServiceDirectoryRegistrationInvalid = errors.New("service directory registration annotation is invalid, expected {\"namespace\": ..., \"service\": ...}")
+
+// ServiceDirectoryRegistration returns the Service Directory registration
+// requested by the Service, or nil if the annotation is not set.
+func (svc *Service) ServiceDirectoryRegistration() (*ServiceDirectoryRegistration, error) {
+	val, ok := svc.v[ServiceDirectoryRegistrationKey]
+	if !ok {
+		return nil, nil
+	}
+	ret := &ServiceDirectoryRegistration{}
+	if err := json.Unmarshal([]byte(val), ret); err != nil {
+		return nil, ErrServiceDirectoryRegistrationInvalid
+	}
+	if ret.Namespace == "" || ret.Service == "" {
+		return nil, ErrServiceDirectoryRegistrationInvalid
+	}
+	return ret, nil
+}
+
+// IsMirroringCollector returns true if the Service requests its forwarding
+// rule to be a Packet Mirroring collector.
+func (svc *Service) IsMirroringCollector() bool {
+	return svc.v[MirroringCollectorKey] == "true"
+}
+
+// FailoverPolicy is the value of the L4FailoverPolicyKey annotation.
+type FailoverPolicy struct {
+	// FailoverNodeSelector is a label selector for the failover nodes.
+	// Backends are per zone, so a zone is a failover backend when all of
+	// its nodes match the selector, e.g.
+	// "topology.kubernetes.io/zone=us-central1-c" marks a whole zone.
+	FailoverNodeSelector string `json:"failoverNodeSelector,omitempty"`
+	// DropTrafficIfUnhealthy drops the traffic when all primary and failover
+	// backends are unhealthy.
+	DropTrafficIfUnhealthy bool `json:"dropTrafficIfUnhealthy,omitempty"`
+	// FailoverRatio is the ratio of healthy primary backends below which
+	// traffic fails over. Must be between 0 and 1.
+	FailoverRatio float64 `json:"failoverRatio,omitempty"`
+	// DisableConnectionDrainOnFailover disables connection draining when
+	// traffic fails over or back.
+	DisableConnectionDrainOnFailover bool `json:"disableConnectionDrainOnFailover,omitempty"`
+}
+
+// NodeSelector returns the parsed FailoverNodeSelector. An empty selector
+// matches no nodes.
+func (p *FailoverPolicy) NodeSelector() (labels.Selector, error) {
+	if p.FailoverNodeSelector == "" {
+		return labels.Nothing(), nil
+	}
+	return labels.Parse(p.FailoverNodeSelector)
+}
+
+// FailoverPolicy returns the failover policy requested by the Service, or nil
+// if the annotation is not set.
+func (svc *Service) FailoverPolicy() (*FailoverPolicy, error) {
+	val, ok := svc.v[L4FailoverPolicyKey]
+	if !ok {
+		return nil, nil
+	}
+	ret := &FailoverPolicy{}
+	if err := json.Unmarshal([]byte(val), ret); err != nil {
+		return nil, fmt.Errorf("failover policy annotation is invalid json: %w", err)
+	}
+	if ret.FailoverRatio < 0 || ret.FailoverRatio > 1 {
+		return nil, fmt.Errorf("failover policy annotation has invalid failoverRatio %v, must be between 0 and 1", ret.FailoverRatio)
+	}
+	if _, err := ret.NodeSelector(); err != nil {
+		return nil, fmt.Errorf("failover policy annotation has invalid failoverNodeSelector: %w", err)
+	}
+	return ret, nil
+}
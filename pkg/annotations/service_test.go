@@ -715,3 +715,111 @@ func TestHasStrongSessionAffinityAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceDirectoryRegistration(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		svc     *v1.Service
+		want    *ServiceDirectoryRegistration
+		wantErr error
+	}{
+		{
+			desc: "annotation not specified",
+			svc:  &v1.Service{},
+		},
+		{
+			desc: "valid registration",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceDirectoryRegistrationKey: `{"namespace": "ns1", "service": "svc1"}`,
+					},
+				},
+			},
+			want: &ServiceDirectoryRegistration{Namespace: "ns1", Service: "svc1"},
+		},
+		{
+			desc: "invalid json",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceDirectoryRegistrationKey: `{"namespace": "ns1"`,
+					},
+				},
+			},
+			wantErr: ErrServiceDirectoryRegistrationInvalid,
+		},
+		{
+			desc: "missing service",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceDirectoryRegistrationKey: `{"namespace": "ns1"}`,
+					},
+				},
+			},
+			wantErr: ErrServiceDirectoryRegistrationInvalid,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FromService(tc.svc).ServiceDirectoryRegistration()
+			if err != tc.wantErr {
+				t.Errorf("ServiceDirectoryRegistration() returned error %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ServiceDirectoryRegistration() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFailoverPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		value   *string
+		want    *FailoverPolicy
+		wantErr bool
+	}{
+		{
+			desc: "annotation not specified",
+		},
+		{
+			desc:  "valid policy",
+			value: strPtr(`{"failoverNodeSelector": "role=standby", "failoverRatio": 0.5, "dropTrafficIfUnhealthy": true}`),
+			want: &FailoverPolicy{
+				FailoverNodeSelector:   "role=standby",
+				FailoverRatio:          0.5,
+				DropTrafficIfUnhealthy: true,
+			},
+		},
+		{
+			desc:    "invalid json",
+			value:   strPtr(`{"failoverRatio": `),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid ratio",
+			value:   strPtr(`{"failoverRatio": 1.5}`),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid selector",
+			value:   strPtr(`{"failoverNodeSelector": "role in standby"}`),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := &v1.Service{}
+			if tc.value != nil {
+				svc.Annotations = map[string]string{L4FailoverPolicyKey: *tc.value}
+			}
+			got, err := FromService(svc).FailoverPolicy()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("FailoverPolicy() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FailoverPolicy() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
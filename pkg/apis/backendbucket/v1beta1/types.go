@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackendBucket is a Cloud Storage bucket that can be used as the backend
+// of Ingress paths through a resource backend.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type BackendBucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendBucketSpec   `json:"spec,omitempty"`
+	Status BackendBucketStatus `json:"status,omitempty"`
+}
+
+// BackendBucketSpec is the spec for a BackendBucket resource
+// +k8s:openapi-gen=true
+type BackendBucketSpec struct {
+	// BucketName is the name of the Cloud Storage bucket that serves the
+	// content.
+	// +required
+	BucketName string `json:"bucketName"`
+	// Cdn configures Cloud CDN for the backend bucket.
+	// +optional
+	Cdn *CDNConfig `json:"cdn,omitempty"`
+}
+
+// CDNConfig contains configuration for Cloud CDN of a backend bucket.
+// +k8s:openapi-gen=true
+type CDNConfig struct {
+	Enabled                 bool    `json:"enabled"`
+	CacheMode               *string `json:"cacheMode,omitempty"`
+	ClientTtl               *int64  `json:"clientTtl,omitempty"`
+	DefaultTtl              *int64  `json:"defaultTtl,omitempty"`
+	MaxTtl                  *int64  `json:"maxTtl,omitempty"`
+	NegativeCaching         *bool   `json:"negativeCaching,omitempty"`
+	ServeWhileStale         *int64  `json:"serveWhileStale,omitempty"`
+	SignedUrlCacheMaxAgeSec *int64  `json:"signedUrlCacheMaxAgeSec,omitempty"`
+}
+
+// BackendBucketStatus is the status for a BackendBucket resource
+// +k8s:openapi-gen=true
+type BackendBucketStatus struct {
+	// SelfLink is the URL of the compute BackendBucket managed for this
+	// resource.
+	// +optional
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Last time the controller updated the status.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// BackendBucketList is a list of BackendBucket resources
+type BackendBucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BackendBucket `json:"items"`
+}
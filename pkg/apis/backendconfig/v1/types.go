@@ -47,6 +47,20 @@ type BackendConfigSpec struct {
 	HealthCheck           *HealthCheckConfig           `json:"healthCheck,omitempty"`
 	// Logging specifies the configuration for access logs.
 	Logging *LogConfig `json:"logging,omitempty"`
+	// LoadBalancingPolicy specifies the load balancing algorithm used
+	// within a locality. Removing it does not reset the algorithm of the
+	// backend service.
+	LoadBalancingPolicy *LoadBalancingPolicyConfig `json:"loadBalancingPolicy,omitempty"`
+	// ConsistentHash specifies the consistent hash settings used by the
+	// RING_HASH and MAGLEV load balancing policies. Removing it does not
+	// reset the consistent hash settings of the backend service.
+	ConsistentHash *ConsistentHashConfig `json:"consistentHash,omitempty"`
+	// CircuitBreakers specifies the limits on connections and requests to
+	// the backends.
+	CircuitBreakers *CircuitBreakersConfig `json:"circuitBreakers,omitempty"`
+	// OutlierDetection specifies how unhealthy backend endpoints are
+	// ejected from the load balancing pool.
+	OutlierDetection *OutlierDetectionConfig `json:"outlierDetection,omitempty"`
 }
 
 // BackendConfigStatus is the status for a BackendConfig resource
@@ -245,3 +259,106 @@ type LogConfig struct {
 	// requests are reported. The default value is 1.0.
 	SampleRate *float64 `json:"sampleRate,omitempty"`
 }
+
+// LoadBalancingPolicyConfig contains configuration for the load balancing
+// algorithm. These settings are only honored by the managed (Envoy based)
+// load balancers.
+// +k8s:openapi-gen=true
+type LoadBalancingPolicyConfig struct {
+	// LocalityLbPolicy is the load balancing algorithm used within the scope
+	// of the locality. One of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM,
+	// ORIGINAL_DESTINATION or MAGLEV. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/backendServices.
+	LocalityLbPolicy string `json:"localityLbPolicy,omitempty"`
+}
+
+// ConsistentHashConfig contains configuration for consistent hash based load
+// balancing. It only takes effect when LocalityLbPolicy is RING_HASH or MAGLEV.
+// +k8s:openapi-gen=true
+type ConsistentHashConfig struct {
+	// HttpCookie describes the HTTP cookie used as the hash key. It is only
+	// applicable when the session affinity is HTTP_COOKIE.
+	HttpCookie *ConsistentHashHttpCookieConfig `json:"httpCookie,omitempty"`
+	// HttpHeaderName is the name of the header whose value is used as the
+	// hash key. It is only applicable when the session affinity is HEADER_FIELD.
+	HttpHeaderName string `json:"httpHeaderName,omitempty"`
+	// MinimumRingSize is the minimum number of virtual nodes to use for the
+	// hash ring. Defaults to 1024.
+	MinimumRingSize *int64 `json:"minimumRingSize,omitempty"`
+}
+
+// ConsistentHashHttpCookieConfig contains configuration for the cookie used
+// as the consistent hash key. The cookie is generated if it is not present.
+// +k8s:openapi-gen=true
+type ConsistentHashHttpCookieConfig struct {
+	// Name of the cookie.
+	Name string `json:"name,omitempty"`
+	// Path to set for the cookie.
+	Path string `json:"path,omitempty"`
+	// Lifetime of the cookie in seconds.
+	TtlSec *int64 `json:"ttlSec,omitempty"`
+}
+
+// CircuitBreakersConfig contains configuration for circuit breakers. Limits
+// that are not specified keep their existing value on the backend service.
+// +k8s:openapi-gen=true
+type CircuitBreakersConfig struct {
+	// MaxConnections is the maximum number of connections to the backend
+	// service.
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// MaxPendingRequests is the maximum number of pending requests allowed
+	// to the backend service.
+	MaxPendingRequests *int64 `json:"maxPendingRequests,omitempty"`
+	// MaxRequests is the maximum number of parallel requests allowed to the
+	// backend service.
+	MaxRequests *int64 `json:"maxRequests,omitempty"`
+	// MaxRequestsPerConnection is the maximum number of requests for a single
+	// connection to the backend service. Setting it to 1 disables keep alive.
+	MaxRequestsPerConnection *int64 `json:"maxRequestsPerConnection,omitempty"`
+	// MaxRetries is the maximum number of parallel retries allowed to the
+	// backend service.
+	MaxRetries *int64 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetectionConfig contains configuration for outlier detection.
+// Settings that are not specified keep their existing value on the backend
+// service. See
+// https://cloud.google.com/compute/docs/reference/rest/v1/backendServices.
+// +k8s:openapi-gen=true
+type OutlierDetectionConfig struct {
+	// BaseEjectionTimeSec is the base time in seconds that a backend endpoint
+	// is ejected for.
+	BaseEjectionTimeSec *int64 `json:"baseEjectionTimeSec,omitempty"`
+	// ConsecutiveErrors is the number of consecutive errors before a backend
+	// endpoint is ejected.
+	ConsecutiveErrors *int64 `json:"consecutiveErrors,omitempty"`
+	// ConsecutiveGatewayFailure is the number of consecutive gateway failures
+	// before a backend endpoint is ejected.
+	ConsecutiveGatewayFailure *int64 `json:"consecutiveGatewayFailure,omitempty"`
+	// EnforcingConsecutiveErrors is the percentage chance that a backend
+	// endpoint is ejected when consecutive errors are detected.
+	EnforcingConsecutiveErrors *int64 `json:"enforcingConsecutiveErrors,omitempty"`
+	// EnforcingConsecutiveGatewayFailure is the percentage chance that a
+	// backend endpoint is ejected when consecutive gateway failures are
+	// detected.
+	EnforcingConsecutiveGatewayFailure *int64 `json:"enforcingConsecutiveGatewayFailure,omitempty"`
+	// EnforcingSuccessRate is the percentage chance that a backend endpoint
+	// is ejected when an outlier is detected through success rate statistics.
+	EnforcingSuccessRate *int64 `json:"enforcingSuccessRate,omitempty"`
+	// IntervalSec is the time in seconds between ejection analysis sweeps.
+	IntervalSec *int64 `json:"intervalSec,omitempty"`
+	// MaxEjectionPercent is the maximum percentage of backend endpoints that
+	// can be ejected.
+	MaxEjectionPercent *int64 `json:"maxEjectionPercent,omitempty"`
+	// SuccessRateMinimumHosts is the number of backend endpoints that must
+	// have enough request volume to detect success rate outliers.
+	SuccessRateMinimumHosts *int64 `json:"successRateMinimumHosts,omitempty"`
+	// SuccessRateRequestVolume is the minimum number of requests in one
+	// interval for a backend endpoint to be included in success rate outlier
+	// detection.
+	SuccessRateRequestVolume *int64 `json:"successRateRequestVolume,omitempty"`
+	// SuccessRateStdevFactor determines the ejection threshold for success
+	// rate outlier ejection. It is divided by a thousand, so a factor of 1.9
+	// is specified as 1900.
+	SuccessRateStdevFactor *int64 `json:"successRateStdevFactor,omitempty"`
+}
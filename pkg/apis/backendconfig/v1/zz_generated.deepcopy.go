@@ -139,6 +139,26 @@ func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 		*out = new(LogConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancingPolicy != nil {
+		in, out := &in.LoadBalancingPolicy, &out.LoadBalancingPolicy
+		*out = new(LoadBalancingPolicyConfig)
+		**out = **in
+	}
+	if in.ConsistentHash != nil {
+		in, out := &in.ConsistentHash, &out.ConsistentHash
+		*out = new(ConsistentHashConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = new(CircuitBreakersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -304,6 +324,47 @@ func (in *CacheKeyPolicy) DeepCopy() *CacheKeyPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakersConfig) DeepCopyInto(out *CircuitBreakersConfig) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequestsPerConnection != nil {
+		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakersConfig.
+func (in *CircuitBreakersConfig) DeepCopy() *CircuitBreakersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDrainingConfig) DeepCopyInto(out *ConnectionDrainingConfig) {
 	*out = *in
@@ -320,6 +381,53 @@ func (in *ConnectionDrainingConfig) DeepCopy() *ConnectionDrainingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashConfig) DeepCopyInto(out *ConsistentHashConfig) {
+	*out = *in
+	if in.HttpCookie != nil {
+		in, out := &in.HttpCookie, &out.HttpCookie
+		*out = new(ConsistentHashHttpCookieConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinimumRingSize != nil {
+		in, out := &in.MinimumRingSize, &out.MinimumRingSize
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashConfig.
+func (in *ConsistentHashConfig) DeepCopy() *ConsistentHashConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashHttpCookieConfig) DeepCopyInto(out *ConsistentHashHttpCookieConfig) {
+	*out = *in
+	if in.TtlSec != nil {
+		in, out := &in.TtlSec, &out.TtlSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashHttpCookieConfig.
+func (in *ConsistentHashHttpCookieConfig) DeepCopy() *ConsistentHashHttpCookieConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashHttpCookieConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomRequestHeadersConfig) DeepCopyInto(out *CustomRequestHeadersConfig) {
 	*out = *in
@@ -434,6 +542,22 @@ func (in *IAPConfig) DeepCopy() *IAPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancingPolicyConfig) DeepCopyInto(out *LoadBalancingPolicyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancingPolicyConfig.
+func (in *LoadBalancingPolicyConfig) DeepCopy() *LoadBalancingPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancingPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogConfig) DeepCopyInto(out *LogConfig) {
 	*out = *in
@@ -487,6 +611,77 @@ func (in *OAuthClientCredentials) DeepCopy() *OAuthClientCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionConfig) DeepCopyInto(out *OutlierDetectionConfig) {
+	*out = *in
+	if in.BaseEjectionTimeSec != nil {
+		in, out := &in.BaseEjectionTimeSec, &out.BaseEjectionTimeSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsecutiveErrors != nil {
+		in, out := &in.ConsecutiveErrors, &out.ConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsecutiveGatewayFailure != nil {
+		in, out := &in.ConsecutiveGatewayFailure, &out.ConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveErrors != nil {
+		in, out := &in.EnforcingConsecutiveErrors, &out.EnforcingConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveGatewayFailure != nil {
+		in, out := &in.EnforcingConsecutiveGatewayFailure, &out.EnforcingConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingSuccessRate != nil {
+		in, out := &in.EnforcingSuccessRate, &out.EnforcingSuccessRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IntervalSec != nil {
+		in, out := &in.IntervalSec, &out.IntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateMinimumHosts != nil {
+		in, out := &in.SuccessRateMinimumHosts, &out.SuccessRateMinimumHosts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateRequestVolume != nil {
+		in, out := &in.SuccessRateRequestVolume, &out.SuccessRateRequestVolume
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateStdevFactor != nil {
+		in, out := &in.SuccessRateStdevFactor, &out.SuccessRateStdevFactor
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionConfig.
+func (in *OutlierDetectionConfig) DeepCopy() *OutlierDetectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityPolicyConfig) DeepCopyInto(out *SecurityPolicyConfig) {
 	*out = *in
@@ -30,22 +30,27 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":               schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":           schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BypassCacheOnRequestHeader":  schema_pkg_apis_backendconfig_v1_BypassCacheOnRequestHeader(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                   schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":              schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig":    schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig":  schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig": schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig":           schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig":                   schema_pkg_apis_backendconfig_v1_IAPConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig":                   schema_pkg_apis_backendconfig_v1_LogConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy":       schema_pkg_apis_backendconfig_v1_NegativeCachingPolicy(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OAuthClientCredentials":      schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig":        schema_pkg_apis_backendconfig_v1_SecurityPolicyConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig":       schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey":                schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":                  schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":              schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BypassCacheOnRequestHeader":     schema_pkg_apis_backendconfig_v1_BypassCacheOnRequestHeader(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                      schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":                 schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig":          schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig":       schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig":           schema_pkg_apis_backendconfig_v1_ConsistentHashConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashHttpCookieConfig": schema_pkg_apis_backendconfig_v1_ConsistentHashHttpCookieConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig":     schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig":    schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig":              schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig":                      schema_pkg_apis_backendconfig_v1_IAPConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LoadBalancingPolicyConfig":      schema_pkg_apis_backendconfig_v1_LoadBalancingPolicyConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig":                      schema_pkg_apis_backendconfig_v1_LogConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy":          schema_pkg_apis_backendconfig_v1_NegativeCachingPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OAuthClientCredentials":         schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig":         schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig":           schema_pkg_apis_backendconfig_v1_SecurityPolicyConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig":          schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey":                   schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref),
 	}
 }
 
@@ -154,11 +159,35 @@ func schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref common.ReferenceCall
 							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig"),
 						},
 					},
+					"loadBalancingPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadBalancingPolicy specifies the load balancing algorithm used within a locality. Removing it does not reset the algorithm of the backend service.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LoadBalancingPolicyConfig"),
+						},
+					},
+					"consistentHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsistentHash specifies the consistent hash settings used by the RING_HASH and MAGLEV load balancing policies. Removing it does not reset the consistent hash settings of the backend service.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig"),
+						},
+					},
+					"circuitBreakers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CircuitBreakers specifies the limits on connections and requests to the backends.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig"),
+						},
+					},
+					"outlierDetection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutlierDetection specifies how unhealthy backend endpoints are ejected from the load balancing pool.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LoadBalancingPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
 	}
 }
 
@@ -358,6 +387,54 @@ func schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CircuitBreakersConfig contains configuration for circuit breakers. Limits that are not specified keep their existing value on the backend service.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxConnections": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxConnections is the maximum number of connections to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxPendingRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxPendingRequests is the maximum number of pending requests allowed to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequests is the maximum number of parallel requests allowed to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequestsPerConnection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequestsPerConnection is the maximum number of requests for a single connection to the backend service. Setting it to 1 disables keep alive.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries is the maximum number of parallel retries allowed to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -378,6 +455,75 @@ func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_ConsistentHashConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsistentHashConfig contains configuration for consistent hash based load balancing. It only takes effect when LocalityLbPolicy is RING_HASH or MAGLEV.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"httpCookie": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HttpCookie describes the HTTP cookie used as the hash key. It is only applicable when the session affinity is HTTP_COOKIE.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashHttpCookieConfig"),
+						},
+					},
+					"httpHeaderName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HttpHeaderName is the name of the header whose value is used as the hash key. It is only applicable when the session affinity is HEADER_FIELD.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"minimumRingSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinimumRingSize is the minimum number of virtual nodes to use for the hash ring. Defaults to 1024.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashHttpCookieConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_ConsistentHashHttpCookieConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsistentHashHttpCookieConfig contains configuration for the cookie used as the consistent hash key. The cookie is generated if it is not present.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the cookie.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path to set for the cookie.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ttlSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lifetime of the cookie in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -522,6 +668,26 @@ func schema_pkg_apis_backendconfig_v1_IAPConfig(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_LoadBalancingPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoadBalancingPolicyConfig contains configuration for the load balancing algorithm. These settings are only honored by the managed (Envoy based) load balancers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"localityLbPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalityLbPolicy is the load balancing algorithm used within the scope of the locality. One of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM, ORIGINAL_DESTINATION or MAGLEV. See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_LogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -612,6 +778,96 @@ func schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OutlierDetectionConfig contains configuration for outlier detection. Settings that are not specified keep their existing value on the backend service. See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"baseEjectionTimeSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BaseEjectionTimeSec is the base time in seconds that a backend endpoint is ejected for.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"consecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveErrors is the number of consecutive errors before a backend endpoint is ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"consecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveGatewayFailure is the number of consecutive gateway failures before a backend endpoint is ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveErrors is the percentage chance that a backend endpoint is ejected when consecutive errors are detected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveGatewayFailure is the percentage chance that a backend endpoint is ejected when consecutive gateway failures are detected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingSuccessRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingSuccessRate is the percentage chance that a backend endpoint is ejected when an outlier is detected through success rate statistics.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"intervalSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IntervalSec is the time in seconds between ejection analysis sweeps.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxEjectionPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxEjectionPercent is the maximum percentage of backend endpoints that can be ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateMinimumHosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateMinimumHosts is the number of backend endpoints that must have enough request volume to detect success rate outliers.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateRequestVolume": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateRequestVolume is the minimum number of requests in one interval for a backend endpoint to be included in success rate outlier detection.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateStdevFactor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateStdevFactor determines the ejection threshold for success rate outlier ejection. It is divided by a thousand, so a factor of 1.9 is specified as 1900.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_SecurityPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -37,6 +37,18 @@ type FrontendConfig struct {
 type FrontendConfigSpec struct {
 	SslPolicy       *string              `json:"sslPolicy,omitempty"`
 	RedirectToHttps *HttpsRedirectConfig `json:"redirectToHttps,omitempty"`
+	// RouteRules configure advanced routing for the hosts of the Ingress.
+	// Requests for a host with route rules are matched against the route
+	// rules in order, before the paths of the Ingress spec.
+	RouteRules []RouteRule `json:"routeRules,omitempty"`
+	// CustomErrorResponsePolicy replaces the error responses of the backends
+	// with custom content. It is only supported for global external Ingresses.
+	CustomErrorResponsePolicy *CustomErrorResponsePolicy `json:"customErrorResponsePolicy,omitempty"`
+	// EdgeSecurityPolicy is the name of the Cloud Armor edge security policy
+	// attached to every backend service of the load balancer. An empty string
+	// detaches the policy, the backend services are left unchanged if it is
+	// not set. It is only supported for global external Ingresses.
+	EdgeSecurityPolicy *string `json:"edgeSecurityPolicy,omitempty"`
 }
 
 // HttpsRedirectConfig representing the configuration of Https redirects
@@ -48,6 +60,127 @@ type HttpsRedirectConfig struct {
 	ResponseCodeName string `json:"responseCodeName,omitempty"`
 }
 
+// RouteRule routes the requests that satisfy its match conditions to one
+// or more weighted backends, optionally rewriting the URL.
+// +k8s:openapi-gen=true
+type RouteRule struct {
+	// Host is the Ingress host the rule applies to.
+	// Rules without a host apply to requests for the hosts which do not have
+	// an Ingress rule of their own.
+	Host string `json:"host,omitempty"`
+	// Match is the set of conditions a request must satisfy.
+	Match RouteMatch `json:"match,omitempty"`
+	// Backends are the Services the matching requests are sent to.
+	// For internal and regional external Ingresses, a Service that is not
+	// also a backend of the Ingress spec must have NEGs enabled through the
+	// cloud.google.com/neg annotation.
+	Backends []RouteBackend `json:"backends"`
+	// UrlRewrite rewrites the URL of the matching requests before they are
+	// sent to the backends.
+	UrlRewrite *UrlRewrite `json:"urlRewrite,omitempty"`
+}
+
+// RouteMatch represents the conditions a request must satisfy to match a
+// RouteRule. At most one of PathPrefix and FullPath may be set, if neither
+// is set every path matches.
+// +k8s:openapi-gen=true
+type RouteMatch struct {
+	PathPrefix      string                `json:"pathPrefix,omitempty"`
+	FullPath        string                `json:"fullPath,omitempty"`
+	Headers         []HeaderMatch         `json:"headers,omitempty"`
+	QueryParameters []QueryParameterMatch `json:"queryParameters,omitempty"`
+}
+
+// HeaderMatch matches a request header. At most one of ExactMatch,
+// PrefixMatch, RegexMatch and PresentMatch may be set.
+// +k8s:openapi-gen=true
+type HeaderMatch struct {
+	Name         string `json:"name"`
+	ExactMatch   string `json:"exactMatch,omitempty"`
+	PrefixMatch  string `json:"prefixMatch,omitempty"`
+	RegexMatch   string `json:"regexMatch,omitempty"`
+	PresentMatch bool   `json:"presentMatch,omitempty"`
+	// InvertMatch negates the result of the match.
+	InvertMatch bool `json:"invertMatch,omitempty"`
+}
+
+// QueryParameterMatch matches a query parameter. At most one of ExactMatch,
+// RegexMatch and PresentMatch may be set.
+// +k8s:openapi-gen=true
+type QueryParameterMatch struct {
+	Name         string `json:"name"`
+	ExactMatch   string `json:"exactMatch,omitempty"`
+	RegexMatch   string `json:"regexMatch,omitempty"`
+	PresentMatch bool   `json:"presentMatch,omitempty"`
+}
+
+// RouteBackend references a Service port in the namespace of the Ingress.
+// +k8s:openapi-gen=true
+type RouteBackend struct {
+	ServiceName string             `json:"serviceName"`
+	ServicePort ServiceBackendPort `json:"servicePort"`
+	// Weight is the relative share of the matching requests sent to this
+	// backend, between 0 and 1000. It is only used when the rule has more
+	// than one backend.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// ServiceBackendPort is the Service port being referenced, either by name
+// or by number.
+// +k8s:openapi-gen=true
+type ServiceBackendPort struct {
+	Name   string `json:"name,omitempty"`
+	Number int32  `json:"number,omitempty"`
+}
+
+// UrlRewrite represents the URL rewrite applied to matching requests.
+// +k8s:openapi-gen=true
+type UrlRewrite struct {
+	// PathPrefixRewrite replaces the matched path prefix of the request.
+	PathPrefixRewrite string `json:"pathPrefixRewrite,omitempty"`
+	// HostRewrite replaces the host header of the request.
+	HostRewrite string `json:"hostRewrite,omitempty"`
+}
+
+// CustomErrorResponsePolicy maps error response codes of the backends to
+// custom error content served from a BackendBucket or a Service.
+// +k8s:openapi-gen=true
+type CustomErrorResponsePolicy struct {
+	// ErrorBackendBucket is the name of the BackendBucket that serves the
+	// custom error content. Exactly one of ErrorBackendBucket and
+	// ErrorService must be set.
+	ErrorBackendBucket string `json:"errorBackendBucket,omitempty"`
+	// ErrorService is the Service port that serves the custom error content.
+	// The Service must be a backend of the Ingress.
+	ErrorService *ErrorServiceBackend `json:"errorService,omitempty"`
+	// ErrorResponseRules map response codes to error content. A rule for a
+	// specific code takes precedence over a rule for its class of codes.
+	ErrorResponseRules []CustomErrorResponseRule `json:"errorResponseRules"`
+}
+
+// CustomErrorResponseRule returns the content at Path for the responses
+// whose code matches one of MatchResponseCodes.
+// +k8s:openapi-gen=true
+type CustomErrorResponseRule struct {
+	// MatchResponseCodes are response codes between 400 and 599, or the
+	// classes of codes 4xx and 5xx.
+	MatchResponseCodes []string `json:"matchResponseCodes"`
+	// Path is the path of the error content in the BackendBucket or the
+	// Service, such as /errors/5xx.html.
+	Path string `json:"path"`
+	// OverrideResponseCode is the response code returned with the error
+	// content. The response code of the backend is returned if it is not set.
+	OverrideResponseCode int32 `json:"overrideResponseCode,omitempty"`
+}
+
+// ErrorServiceBackend references the Service port, in the namespace of the
+// Ingress, that serves custom error content.
+// +k8s:openapi-gen=true
+type ErrorServiceBackend struct {
+	ServiceName string             `json:"serviceName"`
+	ServicePort ServiceBackendPort `json:"servicePort"`
+}
+
 // FrontendConfigStatus is the status for a FrontendConfig resource
 type FrontendConfigStatus struct{}
 
@@ -25,6 +25,72 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomErrorResponsePolicy) DeepCopyInto(out *CustomErrorResponsePolicy) {
+	*out = *in
+	if in.ErrorService != nil {
+		in, out := &in.ErrorService, &out.ErrorService
+		*out = new(ErrorServiceBackend)
+		**out = **in
+	}
+	if in.ErrorResponseRules != nil {
+		in, out := &in.ErrorResponseRules, &out.ErrorResponseRules
+		*out = make([]CustomErrorResponseRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomErrorResponsePolicy.
+func (in *CustomErrorResponsePolicy) DeepCopy() *CustomErrorResponsePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomErrorResponsePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomErrorResponseRule) DeepCopyInto(out *CustomErrorResponseRule) {
+	*out = *in
+	if in.MatchResponseCodes != nil {
+		in, out := &in.MatchResponseCodes, &out.MatchResponseCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomErrorResponseRule.
+func (in *CustomErrorResponseRule) DeepCopy() *CustomErrorResponseRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomErrorResponseRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorServiceBackend) DeepCopyInto(out *ErrorServiceBackend) {
+	*out = *in
+	out.ServicePort = in.ServicePort
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorServiceBackend.
+func (in *ErrorServiceBackend) DeepCopy() *ErrorServiceBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorServiceBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendConfig) DeepCopyInto(out *FrontendConfig) {
 	*out = *in
@@ -99,6 +165,23 @@ func (in *FrontendConfigSpec) DeepCopyInto(out *FrontendConfigSpec) {
 		*out = new(HttpsRedirectConfig)
 		**out = **in
 	}
+	if in.RouteRules != nil {
+		in, out := &in.RouteRules, &out.RouteRules
+		*out = make([]RouteRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CustomErrorResponsePolicy != nil {
+		in, out := &in.CustomErrorResponsePolicy, &out.CustomErrorResponsePolicy
+		*out = new(CustomErrorResponsePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EdgeSecurityPolicy != nil {
+		in, out := &in.EdgeSecurityPolicy, &out.EdgeSecurityPolicy
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -128,6 +211,22 @@ func (in *FrontendConfigStatus) DeepCopy() *FrontendConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderMatch) DeepCopyInto(out *HeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderMatch.
+func (in *HeaderMatch) DeepCopy() *HeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpsRedirectConfig) DeepCopyInto(out *HttpsRedirectConfig) {
 	*out = *in
@@ -143,3 +242,121 @@ func (in *HttpsRedirectConfig) DeepCopy() *HttpsRedirectConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryParameterMatch) DeepCopyInto(out *QueryParameterMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryParameterMatch.
+func (in *QueryParameterMatch) DeepCopy() *QueryParameterMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryParameterMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteBackend) DeepCopyInto(out *RouteBackend) {
+	*out = *in
+	out.ServicePort = in.ServicePort
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteBackend.
+func (in *RouteBackend) DeepCopy() *RouteBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make([]QueryParameterMatch, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatch.
+func (in *RouteMatch) DeepCopy() *RouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRule) DeepCopyInto(out *RouteRule) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]RouteBackend, len(*in))
+		copy(*out, *in)
+	}
+	if in.UrlRewrite != nil {
+		in, out := &in.UrlRewrite, &out.UrlRewrite
+		*out = new(UrlRewrite)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRule.
+func (in *RouteRule) DeepCopy() *RouteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBackendPort) DeepCopyInto(out *ServiceBackendPort) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBackendPort.
+func (in *ServiceBackendPort) DeepCopy() *ServiceBackendPort {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBackendPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UrlRewrite) DeepCopyInto(out *UrlRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UrlRewrite.
+func (in *UrlRewrite) DeepCopy() *UrlRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(UrlRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -30,9 +30,135 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":      schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":  schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig": schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsePolicy": schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponsePolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule":   schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponseRule(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ErrorServiceBackend":       schema_pkg_apis_frontendconfig_v1beta1_ErrorServiceBackend(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":            schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":        schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderMatch":               schema_pkg_apis_frontendconfig_v1beta1_HeaderMatch(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig":       schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.QueryParameterMatch":       schema_pkg_apis_frontendconfig_v1beta1_QueryParameterMatch(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteBackend":              schema_pkg_apis_frontendconfig_v1beta1_RouteBackend(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteMatch":                schema_pkg_apis_frontendconfig_v1beta1_RouteMatch(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteRule":                 schema_pkg_apis_frontendconfig_v1beta1_RouteRule(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ServiceBackendPort":        schema_pkg_apis_frontendconfig_v1beta1_ServiceBackendPort(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewrite":                schema_pkg_apis_frontendconfig_v1beta1_UrlRewrite(ref),
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponsePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomErrorResponsePolicy maps error response codes of the backends to custom error content served from a BackendBucket or a Service.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"errorBackendBucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorBackendBucket is the name of the BackendBucket that serves the custom error content. Exactly one of ErrorBackendBucket and ErrorService must be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"errorService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorService is the Service port that serves the custom error content. The Service must be a backend of the Ingress.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ErrorServiceBackend"),
+						},
+					},
+					"errorResponseRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorResponseRules map response codes to error content. A rule for a specific code takes precedence over a rule for its class of codes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"errorResponseRules"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ErrorServiceBackend"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponseRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomErrorResponseRule returns the content at Path for the responses whose code matches one of MatchResponseCodes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"matchResponseCodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MatchResponseCodes are response codes between 400 and 599, or the classes of codes 4xx and 5xx.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the error content in the BackendBucket or the Service, such as /errors/5xx.html.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"overrideResponseCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OverrideResponseCode is the response code returned with the error content. The response code of the backend is returned if it is not set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"matchResponseCodes", "path"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_ErrorServiceBackend(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ErrorServiceBackend references the Service port, in the namespace of the Ingress, that serves custom error content.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"servicePort": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ServiceBackendPort"),
+						},
+					},
+				},
+				Required: []string{"serviceName", "servicePort"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ServiceBackendPort"},
 	}
 }
 
@@ -100,11 +226,90 @@ func schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref common.Refere
 							Ref: ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"),
 						},
 					},
+					"routeRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RouteRules configure advanced routing for the hosts of the Ingress. Requests for a host with route rules are matched against the route rules in order, before the paths of the Ingress spec.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteRule"),
+									},
+								},
+							},
+						},
+					},
+					"customErrorResponsePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CustomErrorResponsePolicy replaces the error responses of the backends with custom content. It is only supported for global external Ingresses.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsePolicy"),
+						},
+					},
+					"edgeSecurityPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdgeSecurityPolicy is the name of the Cloud Armor edge security policy attached to every backend service of the load balancer. An empty string detaches the policy, the backend services are left unchanged if it is not set. It is only supported for global external Ingresses.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"},
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsePolicy", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteRule"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_HeaderMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HeaderMatch matches a request header. At most one of ExactMatch, PrefixMatch, RegexMatch and PresentMatch may be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"exactMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"prefixMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"regexMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"presentMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"invertMatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InvertMatch negates the result of the match.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
@@ -135,3 +340,233 @@ func schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref common.Refer
 		},
 	}
 }
+
+func schema_pkg_apis_frontendconfig_v1beta1_QueryParameterMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QueryParameterMatch matches a query parameter. At most one of ExactMatch, RegexMatch and PresentMatch may be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"exactMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"regexMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"presentMatch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_RouteBackend(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouteBackend references a Service port in the namespace of the Ingress.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"servicePort": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ServiceBackendPort"),
+						},
+					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Weight is the relative share of the matching requests sent to this backend, between 0 and 1000. It is only used when the rule has more than one backend.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"serviceName", "servicePort"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ServiceBackendPort"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_RouteMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouteMatch represents the conditions a request must satisfy to match a RouteRule. At most one of PathPrefix and FullPath may be set, if neither is set every path matches.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"fullPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderMatch"),
+									},
+								},
+							},
+						},
+					},
+					"queryParameters": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.QueryParameterMatch"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderMatch", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.QueryParameterMatch"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_RouteRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouteRule routes the requests that satisfy its match conditions to one or more weighted backends, optionally rewriting the URL.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host is the Ingress host the rule applies to. Rules without a host apply to requests for the hosts which do not have an Ingress rule of their own.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"match": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Match is the set of conditions a request must satisfy.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteMatch"),
+						},
+					},
+					"backends": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backends are the Services the matching requests are sent to. For internal and regional external Ingresses, a Service that is not also a backend of the Ingress spec must have NEGs enabled through the cloud.google.com/neg annotation.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteBackend"),
+									},
+								},
+							},
+						},
+					},
+					"urlRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UrlRewrite rewrites the URL of the matching requests before they are sent to the backends.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewrite"),
+						},
+					},
+				},
+				Required: []string{"backends"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteBackend", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteMatch", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewrite"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_ServiceBackendPort(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBackendPort is the Service port being referenced, either by name or by number.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"number": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_UrlRewrite(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UrlRewrite represents the URL rewrite applied to matching requests.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pathPrefixRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathPrefixRewrite replaces the matched path prefix of the request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostRewrite replaces the host header of the request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -41,6 +41,11 @@ type GCPIngressParamsSpec struct {
 	// The default is external load balancing, so Internal will default to false.
 	// +required
 	Internal bool `json:"internal"`
+	// Regional specifies whether an external load balancer should be
+	// provisioned in the cluster's region instead of globally. Internal load
+	// balancers are always regional, so Regional is ignored when Internal is set.
+	// +optional
+	Regional bool `json:"regional,omitempty"`
 }
 
 // GCPIngressParamsStatus is the status for a GCPIngressParams resource
@@ -97,6 +97,13 @@ func schema_pkg_apis_ingparams_v1beta1_GCPIngressParamsSpec(ref common.Reference
 							Format:      "",
 						},
 					},
+					"regional": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regional specifies whether an external load balancer should be provisioned in the cluster's region instead of globally. Internal load balancers are always regional, so Regional is ignored when Internal is set.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"internal"},
 			},
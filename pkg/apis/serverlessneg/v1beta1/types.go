@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerlessNEG is a serverless network endpoint group that can be used as
+// the backend of Ingress paths through a resource backend.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type ServerlessNEG struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerlessNEGSpec   `json:"spec,omitempty"`
+	Status ServerlessNEGStatus `json:"status,omitempty"`
+}
+
+// ServerlessNEGSpec is the spec for a ServerlessNEG resource. Exactly one of
+// CloudRun, AppEngine and CloudFunction must be set.
+// +k8s:openapi-gen=true
+type ServerlessNEGSpec struct {
+	// Region is the region of the serverless application.
+	// +required
+	Region string `json:"region"`
+	// CloudRun targets a Cloud Run service.
+	// +optional
+	CloudRun *CloudRunTarget `json:"cloudRun,omitempty"`
+	// AppEngine targets an App Engine service.
+	// +optional
+	AppEngine *AppEngineTarget `json:"appEngine,omitempty"`
+	// CloudFunction targets a Cloud Function.
+	// +optional
+	CloudFunction *CloudFunctionTarget `json:"cloudFunction,omitempty"`
+}
+
+// CloudRunTarget identifies a Cloud Run service. Either Service or UrlMask
+// must be set.
+// +k8s:openapi-gen=true
+type CloudRunTarget struct {
+	// Service is the name of the Cloud Run service.
+	// +optional
+	Service string `json:"service,omitempty"`
+	// Tag is the revision tag of the Cloud Run service.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// UrlMask maps the request URL to Cloud Run services and tags.
+	// +optional
+	UrlMask string `json:"urlMask,omitempty"`
+}
+
+// AppEngineTarget identifies an App Engine service. An empty target routes to
+// the default service of the App Engine application.
+// +k8s:openapi-gen=true
+type AppEngineTarget struct {
+	// Service is the name of the App Engine service.
+	// +optional
+	Service string `json:"service,omitempty"`
+	// Version is the version of the App Engine service.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// UrlMask maps the request URL to App Engine services and versions.
+	// +optional
+	UrlMask string `json:"urlMask,omitempty"`
+}
+
+// CloudFunctionTarget identifies a Cloud Function. Either Function or UrlMask
+// must be set.
+// +k8s:openapi-gen=true
+type CloudFunctionTarget struct {
+	// Function is the name of the Cloud Function.
+	// +optional
+	Function string `json:"function,omitempty"`
+	// UrlMask maps the request URL to Cloud Functions.
+	// +optional
+	UrlMask string `json:"urlMask,omitempty"`
+}
+
+// ServerlessNEGStatus is the status for a ServerlessNEG resource
+// +k8s:openapi-gen=true
+type ServerlessNEGStatus struct {
+	// NetworkEndpointGroup is the URL of the regional serverless network
+	// endpoint group managed for this resource.
+	// +optional
+	NetworkEndpointGroup string `json:"networkEndpointGroup,omitempty"`
+
+	// BackendService is the URL of the global backend service that serves
+	// the network endpoint group.
+	// +optional
+	BackendService string `json:"backendService,omitempty"`
+
+	// Last time the controller updated the status.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ServerlessNEGList is a list of ServerlessNEG resources
+type ServerlessNEGList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ServerlessNEG `json:"items"`
+}
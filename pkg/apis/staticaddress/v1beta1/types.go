@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReclaimPolicyDelete deletes the compute Address when the StaticAddress
+	// is deleted.
+	ReclaimPolicyDelete = "Delete"
+	// ReclaimPolicyRetain keeps the compute Address when the StaticAddress is
+	// deleted, so that a StaticAddress with the same name adopts it again.
+	ReclaimPolicyRetain = "Retain"
+
+	// ConsumerKindService is the kind of consumers that are Services.
+	ConsumerKindService = "Service"
+	// ConsumerKindIngress is the kind of consumers that are Ingresses.
+	ConsumerKindIngress = "Ingress"
+)
+
+// StaticAddress is an IP address reserved in GCE that Services and Ingresses
+// in the same namespace reference by name. The address is kept as long as
+// the StaticAddress exists, independently of its consumers.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type StaticAddress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StaticAddressSpec   `json:"spec,omitempty"`
+	Status StaticAddressStatus `json:"status,omitempty"`
+}
+
+// StaticAddressSpec is the spec for a StaticAddress resource
+// +k8s:openapi-gen=true
+type StaticAddressSpec struct {
+	// AddressType is the type of the address, either EXTERNAL or INTERNAL.
+	// Defaults to EXTERNAL.
+	// +optional
+	AddressType string `json:"addressType,omitempty"`
+	// Global reserves a global address that can only be used by external
+	// Ingresses. Regional addresses are reserved in the region of the cluster.
+	// +optional
+	Global bool `json:"global,omitempty"`
+	// NetworkTier is the network tier of a regional external address, either
+	// PREMIUM or STANDARD. Defaults to PREMIUM.
+	// +optional
+	NetworkTier string `json:"networkTier,omitempty"`
+	// Subnetwork is the subnetwork that an internal address is reserved in.
+	// Defaults to the subnetwork of the cluster.
+	// +optional
+	Subnetwork string `json:"subnetwork,omitempty"`
+	// Address is the specific IP address to reserve. An IP address is
+	// allocated by GCE if it is not set.
+	// +optional
+	Address string `json:"address,omitempty"`
+	// ReclaimPolicy is what happens to the compute Address when the
+	// StaticAddress is deleted, either Delete or Retain. Defaults to Delete.
+	// +optional
+	ReclaimPolicy string `json:"reclaimPolicy,omitempty"`
+}
+
+// StaticAddressStatus is the status for a StaticAddress resource
+// +k8s:openapi-gen=true
+type StaticAddressStatus struct {
+	// AddressName is the name of the compute Address.
+	// +optional
+	AddressName string `json:"addressName,omitempty"`
+	// Address is the reserved IP address.
+	// +optional
+	Address string `json:"address,omitempty"`
+	// Region is the region of the address. It is empty for global addresses.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// NetworkTier is the network tier of the address.
+	// +optional
+	NetworkTier string `json:"networkTier,omitempty"`
+	// SelfLink is the URL of the compute Address.
+	// +optional
+	SelfLink string `json:"selfLink,omitempty"`
+	// Consumers are the Services and Ingresses that reference the address.
+	// +optional
+	Consumers []StaticAddressConsumer `json:"consumers,omitempty"`
+
+	// Last time the controller updated the status.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// StaticAddressConsumer is a Service or Ingress that references a
+// StaticAddress.
+// +k8s:openapi-gen=true
+type StaticAddressConsumer struct {
+	// Kind is either Service or Ingress.
+	Kind string `json:"kind"`
+	// Name is the name of the consumer in the namespace of the StaticAddress.
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// StaticAddressList is a list of StaticAddress resources
+type StaticAddressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StaticAddress `json:"items"`
+}
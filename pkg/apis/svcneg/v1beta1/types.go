@@ -54,6 +54,166 @@ type ServiceNetworkEndpointGroupStatus struct {
 	// Last time the NEG syncer syncs associated NEGs.
 	// +optional
 	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Checkpoint of the network endpoints in the NEGs, written by the NEG
+	// syncer so that a restarted controller can resume without listing
+	// every NEG.
+	// +optional
+	Checkpoint *NegCheckpoint `json:"checkpoint,omitempty"`
+
+	// Summary of the state of the network endpoints of the Service, written
+	// by the NEG syncer when endpoint summaries are enabled.
+	// +optional
+	EndpointSummary *NegEndpointSummary `json:"endpointSummary,omitempty"`
+}
+
+// NegCheckpoint records the network endpoints committed to the NEGs and the
+// attach and detach operations that were still in flight when it was taken.
+// +k8s:openapi-gen=true
+type NegCheckpoint struct {
+	// Time at which the recorded endpoints were listed from the NEGs. A
+	// checkpoint written by a syncer that resumed from an earlier checkpoint
+	// keeps the time of the earlier one.
+	// +required
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Network endpoints committed to each NEG.
+	// +optional
+	// +listType=atomic
+	EndpointGroups []CheckpointEndpointGroup `json:"endpointGroups,omitempty"`
+
+	// Operations that had not completed when the checkpoint was taken, or
+	// that were about to be issued after it was taken. The outcome of these
+	// operations is unknown to a restarted controller.
+	// +optional
+	// +listType=atomic
+	PendingOperations []CheckpointOperation `json:"pendingOperations,omitempty"`
+}
+
+// CheckpointEndpointGroup is the set of network endpoints committed to the
+// NEG in a zone and subnet.
+// +k8s:openapi-gen=true
+type CheckpointEndpointGroup struct {
+	// Zone of the NEG. Empty for global NEGs.
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet of the NEG.
+	Subnet string `json:"subnet,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Endpoints []CheckpointEndpoint `json:"endpoints,omitempty"`
+}
+
+// CheckpointEndpoint identifies a network endpoint in a NEG.
+// +k8s:openapi-gen=true
+type CheckpointEndpoint struct {
+	IP   string `json:"ip,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+	FQDN string `json:"fqdn,omitempty"`
+	Port string `json:"port,omitempty"`
+	Node string `json:"node,omitempty"`
+}
+
+// CheckpointOperation is an attach or detach operation of a network endpoint.
+// +k8s:openapi-gen=true
+type CheckpointOperation struct {
+	// Operation is either Attach or Detach.
+	// +required
+	Operation string `json:"operation"`
+
+	// Zone of the NEG. Empty for global NEGs.
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet of the NEG.
+	Subnet string `json:"subnet,omitempty"`
+
+	// +required
+	Endpoint CheckpointEndpoint `json:"endpoint"`
+}
+
+// NegEndpointSummary counts the network endpoints of the Service by state.
+// Its size is bounded by the number of NEGs and exclusion reasons, not by the
+// number of endpoints.
+// +k8s:openapi-gen=true
+type NegEndpointSummary struct {
+	// Time at which the summary was computed.
+	// +required
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// Endpoint counts for each NEG.
+	// +optional
+	// +listType=atomic
+	NetworkEndpointGroups []NegEndpointGroupSummary `json:"networkEndpointGroups,omitempty"`
+
+	// Counts of the Service endpoints that are not included in any NEG,
+	// by reason.
+	// +optional
+	// +listType=atomic
+	ExcludedEndpoints []ExcludedEndpointCount `json:"excludedEndpoints,omitempty"`
+}
+
+// NegEndpointGroupSummary counts the network endpoints of a NEG by state.
+// +k8s:openapi-gen=true
+type NegEndpointGroupSummary struct {
+	// Name of the NEG.
+	// +required
+	Name string `json:"name"`
+
+	// Zone of the NEG. Empty for global NEGs.
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet of the NEG.
+	Subnet string `json:"subnet,omitempty"`
+
+	// Number of endpoints attached to the NEG.
+	Attached int64 `json:"attached"`
+
+	// Number of endpoints being attached to the NEG.
+	PendingAttach int64 `json:"pendingAttach"`
+
+	// Number of endpoints being detached from the NEG.
+	PendingDetach int64 `json:"pendingDetach"`
+
+	// Number of endpoints that only the degraded mode calculation includes
+	// in the NEG. Only computed when degraded mode or its metrics are
+	// enabled.
+	DegradedModeOnly int64 `json:"degradedModeOnly"`
+
+	// Last health status of the endpoints, as observed by the readiness
+	// reflector. Only present for NEGs whose pods have a NEG readiness gate.
+	// +optional
+	Health *NegHealthSummary `json:"health,omitempty"`
+}
+
+// NegHealthSummary counts the network endpoints of a NEG by health state.
+// +k8s:openapi-gen=true
+type NegHealthSummary struct {
+	// Time at which the health status was polled.
+	// +required
+	LastPollTime metav1.Time `json:"lastPollTime"`
+
+	// Number of endpoints that are healthy for at least one backend service.
+	Healthy int64 `json:"healthy"`
+
+	// Number of health checked endpoints that are not healthy for any
+	// backend service.
+	Unhealthy int64 `json:"unhealthy"`
+
+	// Number of endpoints without a health status.
+	Unknown int64 `json:"unknown"`
+}
+
+// ExcludedEndpointCount is the number of endpoints excluded from the NEGs for
+// a reason.
+// +k8s:openapi-gen=true
+type ExcludedEndpointCount struct {
+	// Reason the endpoints are excluded, e.g. PodTerminal or NodeNotFound.
+	// +required
+	Reason string `json:"reason"`
+
+	// +required
+	Count int64 `json:"count"`
 }
 
 // NegObjectReference is the object reference to the NEG resource in GCE
@@ -76,6 +236,14 @@ type NegObjectReference struct {
 	// Current condition of this network endpoint group.
 	// If state is empty, it should be considered the ACTIVE state.
 	State NegState `json:"state,omitempty"`
+
+	// Time at which the NEG controller scheduled the deletion of this network
+	// endpoint group, by moving it into the TO_BE_DELETED state.
+	ToBeDeletedTime *metav1.Time `json:"toBeDeletedTime,omitempty"`
+
+	// Adopted indicates that the network endpoint group was not created by
+	// the NEG controller, and was adopted with its original description.
+	Adopted bool `json:"adopted,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -102,6 +270,9 @@ const (
 	VmIpPortEndpointType      = NetworkEndpointType("GCE_VM_IP_PORT")
 	VmIpEndpointType          = NetworkEndpointType("GCE_VM_IP")
 	NonGCPPrivateEndpointType = NetworkEndpointType("NON_GCP_PRIVATE_IP_PORT")
+	InternetFQDNEndpointType  = NetworkEndpointType("INTERNET_FQDN_PORT")
+	InternetIPEndpointType    = NetworkEndpointType("INTERNET_IP_PORT")
+	ServerlessEndpointType    = NetworkEndpointType("SERVERLESS")
 )
 
 // TODO: Replace Condition with standard Condition
@@ -136,6 +307,15 @@ const (
 	// Synced means all NEGs are being synced.
 	// The LastSyncTime represents the time when the last sync took place.
 	Synced = "Synced"
+	// TopologyAware means the NEG endpoints follow the topology hints of the
+	// EndpointSlices of the Service. It is only set for Services that use
+	// topology-aware routing.
+	TopologyAware = "TopologyAware"
+	// DegradedMode means the NEG endpoints are calculated in degraded mode.
+	// The reason is the cause of degraded mode, and the message compares the
+	// normal and degraded mode calculations. It is only set when degraded
+	// mode is enabled.
+	DegradedMode = "DegradedMode"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -25,6 +25,60 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointEndpoint) DeepCopyInto(out *CheckpointEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointEndpoint.
+func (in *CheckpointEndpoint) DeepCopy() *CheckpointEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointEndpointGroup) DeepCopyInto(out *CheckpointEndpointGroup) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]CheckpointEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointEndpointGroup.
+func (in *CheckpointEndpointGroup) DeepCopy() *CheckpointEndpointGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointEndpointGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointOperation) DeepCopyInto(out *CheckpointOperation) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointOperation.
+func (in *CheckpointOperation) DeepCopy() *CheckpointOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -42,9 +96,125 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedEndpointCount) DeepCopyInto(out *ExcludedEndpointCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedEndpointCount.
+func (in *ExcludedEndpointCount) DeepCopy() *ExcludedEndpointCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedEndpointCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegCheckpoint) DeepCopyInto(out *NegCheckpoint) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.EndpointGroups != nil {
+		in, out := &in.EndpointGroups, &out.EndpointGroups
+		*out = make([]CheckpointEndpointGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]CheckpointOperation, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegCheckpoint.
+func (in *NegCheckpoint) DeepCopy() *NegCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(NegCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegEndpointGroupSummary) DeepCopyInto(out *NegEndpointGroupSummary) {
+	*out = *in
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(NegHealthSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegEndpointGroupSummary.
+func (in *NegEndpointGroupSummary) DeepCopy() *NegEndpointGroupSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NegEndpointGroupSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegEndpointSummary) DeepCopyInto(out *NegEndpointSummary) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.NetworkEndpointGroups != nil {
+		in, out := &in.NetworkEndpointGroups, &out.NetworkEndpointGroups
+		*out = make([]NegEndpointGroupSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedEndpoints != nil {
+		in, out := &in.ExcludedEndpoints, &out.ExcludedEndpoints
+		*out = make([]ExcludedEndpointCount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegEndpointSummary.
+func (in *NegEndpointSummary) DeepCopy() *NegEndpointSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NegEndpointSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegHealthSummary) DeepCopyInto(out *NegHealthSummary) {
+	*out = *in
+	in.LastPollTime.DeepCopyInto(&out.LastPollTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegHealthSummary.
+func (in *NegHealthSummary) DeepCopy() *NegHealthSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NegHealthSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NegObjectReference) DeepCopyInto(out *NegObjectReference) {
 	*out = *in
+	if in.ToBeDeletedTime != nil {
+		in, out := &in.ToBeDeletedTime, &out.ToBeDeletedTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -141,7 +311,9 @@ func (in *ServiceNetworkEndpointGroupStatus) DeepCopyInto(out *ServiceNetworkEnd
 	if in.NetworkEndpointGroups != nil {
 		in, out := &in.NetworkEndpointGroups, &out.NetworkEndpointGroups
 		*out = make([]NegObjectReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -151,6 +323,16 @@ func (in *ServiceNetworkEndpointGroupStatus) DeepCopyInto(out *ServiceNetworkEnd
 		}
 	}
 	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(NegCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointSummary != nil {
+		in, out := &in.EndpointSummary, &out.EndpointSummary
+		*out = new(NegEndpointSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
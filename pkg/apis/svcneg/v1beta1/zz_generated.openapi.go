@@ -30,13 +30,155 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpoint":                schema_pkg_apis_svcneg_v1beta1_CheckpointEndpoint(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpointGroup":           schema_pkg_apis_svcneg_v1beta1_CheckpointEndpointGroup(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointOperation":               schema_pkg_apis_svcneg_v1beta1_CheckpointOperation(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition":                         schema_pkg_apis_svcneg_v1beta1_Condition(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ExcludedEndpointCount":             schema_pkg_apis_svcneg_v1beta1_ExcludedEndpointCount(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegCheckpoint":                     schema_pkg_apis_svcneg_v1beta1_NegCheckpoint(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointGroupSummary":           schema_pkg_apis_svcneg_v1beta1_NegEndpointGroupSummary(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointSummary":                schema_pkg_apis_svcneg_v1beta1_NegEndpointSummary(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegHealthSummary":                  schema_pkg_apis_svcneg_v1beta1_NegHealthSummary(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference":                schema_pkg_apis_svcneg_v1beta1_NegObjectReference(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceNetworkEndpointGroup":       schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroup(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceNetworkEndpointGroupStatus": schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupStatus(ref),
 	}
 }
 
+func schema_pkg_apis_svcneg_v1beta1_CheckpointEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CheckpointEndpoint identifies a network endpoint in a NEG.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ip": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ipv6": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"fqdn": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_CheckpointEndpointGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CheckpointEndpointGroup is the set of network endpoints committed to the NEG in a zone and subnet.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone of the NEG. Empty for global NEGs.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subnet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subnet of the NEG.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpoint"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpoint"},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_CheckpointOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CheckpointOperation is an attach or detach operation of a network endpoint.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"operation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operation is either Attach or Detach.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone of the NEG. Empty for global NEGs.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subnet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subnet of the NEG.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpoint"),
+						},
+					},
+				},
+				Required: []string{"operation", "endpoint"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpoint"},
+	}
+}
+
 func schema_pkg_apis_svcneg_v1beta1_Condition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -99,6 +241,280 @@ func schema_pkg_apis_svcneg_v1beta1_Condition(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_pkg_apis_svcneg_v1beta1_ExcludedEndpointCount(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExcludedEndpointCount is the number of endpoints excluded from the NEGs for a reason.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason the endpoints are excluded, e.g. PodTerminal or NodeNotFound.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+				},
+				Required: []string{"reason", "count"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_NegCheckpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NegCheckpoint records the network endpoints committed to the NEGs and the attach and detach operations that were still in flight when it was taken.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which the recorded endpoints were listed from the NEGs. A checkpoint written by a syncer that resumed from an earlier checkpoint keeps the time of the earlier one.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"endpointGroups": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Network endpoints committed to each NEG.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpointGroup"),
+									},
+								},
+							},
+						},
+					},
+					"pendingOperations": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Operations that had not completed when the checkpoint was taken, or that were about to be issued after it was taken. The outcome of these operations is unknown to a restarted controller.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointOperation"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"timestamp"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointEndpointGroup", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.CheckpointOperation"},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_NegEndpointGroupSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NegEndpointGroupSummary counts the network endpoints of a NEG by state.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the NEG.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone of the NEG. Empty for global NEGs.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subnet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subnet of the NEG.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"attached": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints attached to the NEG.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"pendingAttach": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints being attached to the NEG.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"pendingDetach": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints being detached from the NEG.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"degradedModeOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints that only the degraded mode calculation includes in the NEG. Only computed when degraded mode or its metrics are enabled.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last health status of the endpoints, as observed by the readiness reflector. Only present for NEGs whose pods have a NEG readiness gate.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegHealthSummary"),
+						},
+					},
+				},
+				Required: []string{"name", "attached", "pendingAttach", "pendingDetach", "degradedModeOnly"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegHealthSummary"},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_NegEndpointSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NegEndpointSummary counts the network endpoints of the Service by state. Its size is bounded by the number of NEGs and exclusion reasons, not by the number of endpoints.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which the summary was computed.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"networkEndpointGroups": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint counts for each NEG.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointGroupSummary"),
+									},
+								},
+							},
+						},
+					},
+					"excludedEndpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Counts of the Service endpoints that are not included in any NEG, by reason.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ExcludedEndpointCount"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"lastUpdateTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ExcludedEndpointCount", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointGroupSummary"},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_NegHealthSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NegHealthSummary counts the network endpoints of a NEG by health state.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastPollTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which the health status was polled.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"healthy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints that are healthy for at least one backend service.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"unhealthy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of health checked endpoints that are not healthy for any backend service.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"unknown": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of endpoints without a health status.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"lastPollTime", "healthy", "unhealthy", "unknown"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_svcneg_v1beta1_NegObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -142,10 +558,25 @@ func schema_pkg_apis_svcneg_v1beta1_NegObjectReference(ref common.ReferenceCallb
 							Format:      "",
 						},
 					},
+					"toBeDeletedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which the NEG controller scheduled the deletion of this network endpoint group, by moving it into the TO_BE_DELETED state.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"adopted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Adopted indicates that the network endpoint group was not created by the NEG controller, and was adopted with its original description.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"id"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -252,10 +683,22 @@ func schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupStatus(ref common
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"checkpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checkpoint of the network endpoints in the NEGs, written by the NEG syncer so that a restarted controller can resume without listing every NEG.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegCheckpoint"),
+						},
+					},
+					"endpointSummary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Summary of the state of the network endpoints of the Service, written by the NEG syncer when endpoint summaries are enabled.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointSummary"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegCheckpoint", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegEndpointSummary", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference"},
 	}
 }
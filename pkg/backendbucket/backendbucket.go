@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendbucket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+	apisbackendbucket "k8s.io/ingress-gce/pkg/apis/backendbucket"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+	"k8s.io/ingress-gce/pkg/crd"
+)
+
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisbackendbucket.GroupName,
+		apisbackendbucket.Kind,
+		"BackendBucketList",
+		"backendbucket",
+		"backendbuckets",
+		[]*crd.Version{
+			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1.BackendBucket", backendbucketv1beta1.GetOpenAPIDefinitions, false),
+		},
+	)
+	return meta
+}
+
+// description is stored in the description of the compute BackendBucket
+// to identify the BackendBucket CR that manages it.
+type description struct {
+	BackendBucket string `json:"networking.gke.io/backend-bucket"`
+}
+
+// toComputeBackendBucket returns the compute BackendBucket with the given
+// name that is expected for the given BackendBucket CR.
+func toComputeBackendBucket(bb *backendbucketv1beta1.BackendBucket, name string) (*compute.BackendBucket, error) {
+	desc, err := json.Marshal(description{BackendBucket: fmt.Sprintf("%s/%s", bb.Namespace, bb.Name)})
+	if err != nil {
+		return nil, err
+	}
+	ret := &compute.BackendBucket{
+		Name:        name,
+		BucketName:  bb.Spec.BucketName,
+		Description: string(desc),
+	}
+	cdn := bb.Spec.Cdn
+	if cdn == nil || !cdn.Enabled {
+		return ret, nil
+	}
+	ret.EnableCdn = true
+	policy := &compute.BackendBucketCdnPolicy{}
+	if cdn.CacheMode != nil {
+		policy.CacheMode = *cdn.CacheMode
+	}
+	if cdn.ClientTtl != nil {
+		policy.ClientTtl = *cdn.ClientTtl
+		policy.ForceSendFields = append(policy.ForceSendFields, "ClientTtl")
+	}
+	if cdn.DefaultTtl != nil {
+		policy.DefaultTtl = *cdn.DefaultTtl
+		policy.ForceSendFields = append(policy.ForceSendFields, "DefaultTtl")
+	}
+	if cdn.MaxTtl != nil {
+		policy.MaxTtl = *cdn.MaxTtl
+		policy.ForceSendFields = append(policy.ForceSendFields, "MaxTtl")
+	}
+	if cdn.NegativeCaching != nil {
+		policy.NegativeCaching = *cdn.NegativeCaching
+		policy.ForceSendFields = append(policy.ForceSendFields, "NegativeCaching")
+	}
+	if cdn.ServeWhileStale != nil {
+		policy.ServeWhileStale = *cdn.ServeWhileStale
+		policy.ForceSendFields = append(policy.ForceSendFields, "ServeWhileStale")
+	}
+	if cdn.SignedUrlCacheMaxAgeSec != nil {
+		policy.SignedUrlCacheMaxAgeSec = *cdn.SignedUrlCacheMaxAgeSec
+		policy.ForceSendFields = append(policy.ForceSendFields, "SignedUrlCacheMaxAgeSec")
+	}
+	ret.CdnPolicy = policy
+	return ret, nil
+}
+
+// needsUpdate returns true if the fields of the existing compute BackendBucket
+// that are managed by the controller differ from the expected ones. Fields of
+// the CDN policy that are not set in the BackendBucket CR are defaulted by GCE
+// and are not compared.
+func needsUpdate(existing, expected *compute.BackendBucket) bool {
+	if existing.BucketName != expected.BucketName || existing.EnableCdn != expected.EnableCdn || existing.Description != expected.Description {
+		return true
+	}
+	if expected.CdnPolicy == nil {
+		return false
+	}
+	if existing.CdnPolicy == nil {
+		return true
+	}
+	e, x := existing.CdnPolicy, expected.CdnPolicy
+	if x.CacheMode != "" && e.CacheMode != x.CacheMode {
+		return true
+	}
+	for _, field := range x.ForceSendFields {
+		switch field {
+		case "ClientTtl":
+			if e.ClientTtl != x.ClientTtl {
+				return true
+			}
+		case "DefaultTtl":
+			if e.DefaultTtl != x.DefaultTtl {
+				return true
+			}
+		case "MaxTtl":
+			if e.MaxTtl != x.MaxTtl {
+				return true
+			}
+		case "NegativeCaching":
+			if e.NegativeCaching != x.NegativeCaching {
+				return true
+			}
+		case "ServeWhileStale":
+			if e.ServeWhileStale != x.ServeWhileStale {
+				return true
+			}
+		case "SignedUrlCacheMaxAgeSec":
+			if e.SignedUrlCacheMaxAgeSec != x.SignedUrlCacheMaxAgeSec {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendbucket
+
+import (
+	context2 "context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+	backendbucketclient "k8s.io/ingress-gce/pkg/backendbucket/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/common/crcontroller"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+	"k8s.io/ingress-gce/pkg/utils/slice"
+	"k8s.io/klog/v2"
+)
+
+// gcPeriod is the period of the garbage collection of compute BackendBuckets
+// whose BackendBucket CR no longer exists, and of the retried deletion of the
+// compute BackendBuckets that were still used by url maps.
+const gcPeriod = 2 * time.Minute
+
+// cloudBackendBuckets is the subset of the compute API used to manage
+// backend buckets.
+type cloudBackendBuckets interface {
+	Get(name string) (*compute.BackendBucket, error)
+	List() ([]*compute.BackendBucket, error)
+	Create(backendBucket *compute.BackendBucket) error
+	Update(backendBucket *compute.BackendBucket) error
+	Delete(name string) error
+}
+
+// gceBackendBuckets implements cloudBackendBuckets with the compute API.
+type gceBackendBuckets struct {
+	cloud       *gce.Cloud
+	rateLimiter cloud.RateLimiter
+	logger      klog.Logger
+}
+
+func (g *gceBackendBuckets) Get(name string) (*compute.BackendBucket, error) {
+	return composite.GetBackendBucket(g.cloud, g.rateLimiter, name, g.logger)
+}
+
+func (g *gceBackendBuckets) List() ([]*compute.BackendBucket, error) {
+	return composite.ListBackendBuckets(g.cloud, g.rateLimiter, g.logger)
+}
+
+func (g *gceBackendBuckets) Create(backendBucket *compute.BackendBucket) error {
+	return composite.CreateBackendBucket(g.cloud, g.rateLimiter, backendBucket, g.logger)
+}
+
+func (g *gceBackendBuckets) Update(backendBucket *compute.BackendBucket) error {
+	return composite.UpdateBackendBucket(g.cloud, g.rateLimiter, backendBucket, g.logger)
+}
+
+func (g *gceBackendBuckets) Delete(name string) error {
+	return composite.DeleteBackendBucket(g.cloud, g.rateLimiter, name, g.logger)
+}
+
+// Controller manages the compute BackendBuckets of BackendBucket CRs.
+type Controller struct {
+	controller *crcontroller.Controller
+
+	cloud    cloudBackendBuckets
+	project  string
+	client   backendbucketclient.Interface
+	namer    namer.BackendBucketNamer
+	lister   cache.Indexer
+	recorder func(string) record.EventRecorder
+
+	stopCh <-chan struct{}
+
+	logger klog.Logger
+}
+
+// NewController returns a controller that manages the compute BackendBuckets
+// of BackendBucket CRs.
+func NewController(ctx *context.ControllerContext, stopCh <-chan struct{}, logger klog.Logger) *Controller {
+	logger = logger.WithName("BackendBucketController")
+	c := &Controller{
+		cloud:    &gceBackendBuckets{cloud: ctx.Cloud, rateLimiter: ctx.CloudRateLimiter, logger: logger},
+		project:  ctx.Cloud.ProjectID(),
+		client:   ctx.BackendBucketClient,
+		namer:    namer.NewBackendBucketNamer(ctx.ClusterNamer, string(ctx.KubeSystemUID)),
+		lister:   ctx.BackendBucketInformer.GetIndexer(),
+		recorder: ctx.Recorder,
+		stopCh:   stopCh,
+		logger:   logger,
+	}
+	c.controller = crcontroller.New(crcontroller.Config{
+		Kind:      "BackendBucket",
+		Informer:  ctx.BackendBucketInformer,
+		Recorder:  ctx.Recorder,
+		HasSynced: ctx.HasSynced,
+		Sync:      c.processBackendBucket,
+		GC:        c.gc,
+		GCPeriod:  gcPeriod,
+	}, logger)
+	return c
+}
+
+// Run waits for the initial sync and processes keys in the queue until
+// signaled.
+func (c *Controller) Run() {
+	c.controller.Run(c.stopCh)
+}
+
+// processBackendBucket ensures that the compute BackendBucket of the
+// BackendBucket CR with the given key matches its spec, or that it is deleted
+// if the CR is being deleted.
+func (c *Controller) processBackendBucket(key string) error {
+	obj, exists, err := c.lister.GetByKey(key)
+	if err != nil {
+		return fmt.Errorf("errored getting backend bucket from store: %w", err)
+	}
+	if !exists {
+		// The finalizer ensures that the compute BackendBucket was deleted.
+		return nil
+	}
+	bb := obj.(*backendbucketv1beta1.BackendBucket)
+	name := c.namer.BackendBucket(bb.Namespace, bb.Name, string(bb.UID))
+	bbLogger := c.logger.WithValues("backendBucketKey", key, "backendBucketName", name)
+
+	if !bb.DeletionTimestamp.IsZero() {
+		return c.deleteBackendBucket(bb, name, bbLogger)
+	}
+
+	bb, err = c.ensureFinalizer(bb)
+	if err != nil {
+		return fmt.Errorf("errored adding finalizer on BackendBucket CR %s: %w", key, err)
+	}
+
+	expected, err := toComputeBackendBucket(bb, name)
+	if err != nil {
+		return err
+	}
+	existing, err := c.cloud.Get(name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return fmt.Errorf("failed to get compute BackendBucket %s: %w", name, err)
+	}
+	if existing == nil {
+		bbLogger.V(2).Info("Creating backend bucket")
+		if err := c.cloud.Create(expected); err != nil {
+			return fmt.Errorf("failed to create compute BackendBucket %s: %w", name, err)
+		}
+		c.recorder(bb.Namespace).Eventf(bb, v1.EventTypeNormal, "BackendBucketCreated", "Backend bucket %s was successfully created.", name)
+	} else if needsUpdate(existing, expected) {
+		bbLogger.V(2).Info("Updating backend bucket")
+		if err := c.cloud.Update(expected); err != nil {
+			return fmt.Errorf("failed to update compute BackendBucket %s: %w", name, err)
+		}
+		c.recorder(bb.Namespace).Eventf(bb, v1.EventTypeNormal, "BackendBucketUpdated", "Backend bucket %s was successfully updated.", name)
+	}
+
+	return c.updateStatus(bb, cloud.SelfLink(meta.VersionGA, c.project, "backendBuckets", meta.GlobalKey(name)))
+}
+
+// deleteBackendBucket deletes the compute BackendBucket of the given CR and
+// removes the finalizer. Deletion fails while the backend bucket is used by a
+// url map; it is retried every gc period until the Ingresses no longer
+// reference it.
+func (c *Controller) deleteBackendBucket(bb *backendbucketv1beta1.BackendBucket, name string, bbLogger klog.Logger) error {
+	if !common.HasGivenFinalizer(bb.ObjectMeta, common.BackendBucketFinalizerKey) {
+		return nil
+	}
+	bbLogger.V(2).Info("Deleting backend bucket")
+	if err := utils.IgnoreHTTPNotFound(c.cloud.Delete(name)); err != nil {
+		if utils.IsInUsedByError(err) {
+			return &crcontroller.InUseError{Resource: fmt.Sprintf("Backend bucket %s", name), Err: err}
+		}
+		return fmt.Errorf("failed to delete compute BackendBucket %s: %w", name, err)
+	}
+	updated := bb.DeepCopy()
+	updated.Finalizers = slice.RemoveString(updated.Finalizers, common.BackendBucketFinalizerKey, nil)
+	_, err := c.patch(bb, updated)
+	return err
+}
+
+// gc deletes the compute BackendBuckets of this cluster whose BackendBucket
+// CR no longer exists, e.g. because its finalizer was removed by hand.
+// Backend buckets that are still used by url maps are kept until the url
+// maps are updated or garbage collected.
+func (c *Controller) gc() error {
+	expected := sets.New[string]()
+	for _, obj := range c.lister.List() {
+		bb := obj.(*backendbucketv1beta1.BackendBucket)
+		expected.Insert(c.namer.BackendBucket(bb.Namespace, bb.Name, string(bb.UID)))
+	}
+	backendBuckets, err := c.cloud.List()
+	if err != nil {
+		return fmt.Errorf("failed to list compute BackendBuckets: %w", err)
+	}
+	var errs []error
+	for _, backendBucket := range backendBuckets {
+		if !c.namer.IsBackendBucket(backendBucket.Name) || expected.Has(backendBucket.Name) || !managedByCR(backendBucket) {
+			continue
+		}
+		c.logger.V(2).Info("Garbage collecting backend bucket", "backendBucketName", backendBucket.Name)
+		err := utils.IgnoreHTTPNotFound(c.cloud.Delete(backendBucket.Name))
+		if utils.IsInUsedByError(err) {
+			c.logger.V(2).Info("Backend bucket is still used by a url map, not garbage collecting", "backendBucketName", backendBucket.Name)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete compute BackendBucket %s: %w", backendBucket.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+// managedByCR returns true if the description of the given compute
+// BackendBucket identifies a BackendBucket CR.
+func managedByCR(backendBucket *compute.BackendBucket) bool {
+	var desc description
+	if err := json.Unmarshal([]byte(backendBucket.Description), &desc); err != nil {
+		return false
+	}
+	return desc.BackendBucket != ""
+}
+
+// ensureFinalizer ensures that the BackendBucket finalizer exists on the
+// given CR.
+func (c *Controller) ensureFinalizer(bb *backendbucketv1beta1.BackendBucket) (*backendbucketv1beta1.BackendBucket, error) {
+	if common.HasGivenFinalizer(bb.ObjectMeta, common.BackendBucketFinalizerKey) {
+		return bb, nil
+	}
+	updated := bb.DeepCopy()
+	updated.Finalizers = append(updated.Finalizers, common.BackendBucketFinalizerKey)
+	return c.patch(bb, updated)
+}
+
+// updateStatus updates the status of the given CR with the self link of its
+// compute BackendBucket.
+func (c *Controller) updateStatus(bb *backendbucketv1beta1.BackendBucket, selfLink string) error {
+	if bb.Status.SelfLink == selfLink {
+		return nil
+	}
+	updated := bb.DeepCopy()
+	updated.Status.SelfLink = selfLink
+	updated.Status.LastSyncTime = metav1.Now()
+	_, err := c.client.NetworkingV1beta1().BackendBuckets(bb.Namespace).UpdateStatus(context2.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// patch patches the original CR to the updated CR.
+func (c *Controller) patch(original, updated *backendbucketv1beta1.BackendBucket) (*backendbucketv1beta1.BackendBucket, error) {
+	patchBytes, err := patch.MergePatchBytes(original, updated)
+	if err != nil {
+		return original, err
+	}
+	return c.client.NetworkingV1beta1().BackendBuckets(original.Namespace).Patch(context2.Background(), updated.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+}
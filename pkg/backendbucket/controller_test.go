@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendbucket
+
+import (
+	context2 "context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+	backendbucketfake "k8s.io/ingress-gce/pkg/backendbucket/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/common/crcontroller"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testNamespace = "test-namespace"
+	kubeSystemUID = "kube-system-uid"
+)
+
+// fakeBackendBuckets is an in-memory cloudBackendBuckets.
+type fakeBackendBuckets struct {
+	buckets map[string]*compute.BackendBucket
+	inUse   bool
+}
+
+func (f *fakeBackendBuckets) Get(name string) (*compute.BackendBucket, error) {
+	bb, ok := f.buckets[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return bb, nil
+}
+
+func (f *fakeBackendBuckets) List() ([]*compute.BackendBucket, error) {
+	var ret []*compute.BackendBucket
+	for _, bb := range f.buckets {
+		ret = append(ret, bb)
+	}
+	return ret, nil
+}
+
+func (f *fakeBackendBuckets) Create(backendBucket *compute.BackendBucket) error {
+	f.buckets[backendBucket.Name] = backendBucket
+	return nil
+}
+
+func (f *fakeBackendBuckets) Update(backendBucket *compute.BackendBucket) error {
+	f.buckets[backendBucket.Name] = backendBucket
+	return nil
+}
+
+func (f *fakeBackendBuckets) Delete(name string) error {
+	if f.inUse {
+		return &googleapi.Error{Code: http.StatusBadRequest, Message: "The backend_bucket resource '" + name + "' is already being used by 'url-map'"}
+	}
+	if _, ok := f.buckets[name]; !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+	delete(f.buckets, name)
+	return nil
+}
+
+func newTestController() (*Controller, *fakeBackendBuckets) {
+	fakeCloud := &fakeBackendBuckets{buckets: map[string]*compute.BackendBucket{}}
+	return &Controller{
+		cloud:    fakeCloud,
+		project:  "mock-project",
+		client:   backendbucketfake.NewSimpleClientset(),
+		namer:    namer.NewBackendBucketNamer(namer.NewNamer("cluster-uid", "", klog.TODO()), kubeSystemUID),
+		lister:   cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+		recorder: func(string) record.EventRecorder { return record.NewFakeRecorder(100) },
+		logger:   klog.TODO(),
+	}, fakeCloud
+}
+
+func TestProcessBackendBucket(t *testing.T) {
+	c, fakeCloud := newTestController()
+	bbs := c.client.NetworkingV1beta1().BackendBuckets(testNamespace)
+	bb := &backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "static", UID: "bb-uid"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "my-bucket"},
+	}
+	test.CreateInLister(t, bbs, c.lister, bb)
+	key := testNamespace + "/static"
+	name := c.namer.BackendBucket(testNamespace, "static", "bb-uid")
+
+	if err := c.processBackendBucket(key); err != nil {
+		t.Fatalf("processBackendBucket(%s) = %v", key, err)
+	}
+	got, err := fakeCloud.Get(name)
+	if err != nil {
+		t.Fatalf("Get(%s) = %v", name, err)
+	}
+	if got.BucketName != "my-bucket" || got.EnableCdn {
+		t.Errorf("Got compute BackendBucket %+v, want bucket my-bucket without CDN", got)
+	}
+	bb = test.SyncLister(t, bbs, c.lister, "static")
+	if !common.HasGivenFinalizer(bb.ObjectMeta, common.BackendBucketFinalizerKey) {
+		t.Errorf("Finalizer %s was not added, got %v", common.BackendBucketFinalizerKey, bb.Finalizers)
+	}
+	if bbName, err := utils.KeyName(bb.Status.SelfLink); err != nil || bbName != name {
+		t.Errorf("Status.SelfLink = %q, want link to %s", bb.Status.SelfLink, name)
+	}
+
+	// Enabling CDN updates the compute BackendBucket.
+	bb = bb.DeepCopy()
+	bb.Spec.Cdn = &backendbucketv1beta1.CDNConfig{Enabled: true, CacheMode: ptr.To("CACHE_ALL_STATIC"), DefaultTtl: ptr.To[int64](3600)}
+	bb, err = bbs.Update(context2.TODO(), bb, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Update(%s) = %v", bb.Name, err)
+	}
+	test.SyncLister(t, bbs, c.lister, "static")
+	if err := c.processBackendBucket(key); err != nil {
+		t.Fatalf("processBackendBucket(%s) = %v", key, err)
+	}
+	got, _ = fakeCloud.Get(name)
+	if !got.EnableCdn || got.CdnPolicy == nil || got.CdnPolicy.CacheMode != "CACHE_ALL_STATIC" || got.CdnPolicy.DefaultTtl != 3600 {
+		t.Errorf("Got compute BackendBucket %+v, want CDN with CACHE_ALL_STATIC and default TTL 3600", got)
+	}
+
+	// Deletion is deferred while the backend bucket is used by a url map.
+	bb = test.SyncLister(t, bbs, c.lister, "static").DeepCopy()
+	now := metav1.Now()
+	bb.DeletionTimestamp = &now
+	if err := c.lister.Update(bb); err != nil {
+		t.Fatalf("lister.Update(%s) = %v", bb.Name, err)
+	}
+	fakeCloud.inUse = true
+	var inUseErr *crcontroller.InUseError
+	if err := c.processBackendBucket(key); !errors.As(err, &inUseErr) {
+		t.Errorf("processBackendBucket(%s) = %v, want InUseError while the backend bucket is in use", key, err)
+	}
+	if _, err := fakeCloud.Get(name); err != nil {
+		t.Errorf("Backend bucket %s was deleted while in use", name)
+	}
+
+	fakeCloud.inUse = false
+	if err := c.processBackendBucket(key); err != nil {
+		t.Fatalf("processBackendBucket(%s) = %v", key, err)
+	}
+	if _, err := fakeCloud.Get(name); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("Get(%s) = %v, want not found", name, err)
+	}
+	bb = test.SyncLister(t, bbs, c.lister, "static")
+	if common.HasGivenFinalizer(bb.ObjectMeta, common.BackendBucketFinalizerKey) {
+		t.Errorf("Finalizer %s was not removed, got %v", common.BackendBucketFinalizerKey, bb.Finalizers)
+	}
+}
+
+func TestGC(t *testing.T) {
+	c, fakeCloud := newTestController()
+	test.CreateInLister(t, c.client.NetworkingV1beta1().BackendBuckets(testNamespace), c.lister, &backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "static", UID: "bb-uid"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "my-bucket"},
+	})
+	if err := c.processBackendBucket(testNamespace + "/static"); err != nil {
+		t.Fatalf("processBackendBucket() = %v", err)
+	}
+	kept := c.namer.BackendBucket(testNamespace, "static", "bb-uid")
+
+	// The CR of the orphaned backend bucket was deleted without the finalizer.
+	orphan, err := toComputeBackendBucket(&backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "deleted"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "old-bucket"},
+	}, c.namer.BackendBucket(testNamespace, "deleted", "deleted-uid"))
+	if err != nil {
+		t.Fatalf("toComputeBackendBucket() = %v", err)
+	}
+	fakeCloud.Create(orphan)
+	// Backend buckets that are not managed by this cluster are not touched.
+	fakeCloud.Create(&compute.BackendBucket{Name: "user-backend-bucket"})
+
+	if err := c.gc(); err != nil {
+		t.Fatalf("gc() = %v", err)
+	}
+	for name, wantExists := range map[string]bool{kept: true, orphan.Name: false, "user-backend-bucket": true} {
+		_, err := fakeCloud.Get(name)
+		if gotExists := err == nil; gotExists != wantExists {
+			t.Errorf("Backend bucket %s exists = %v, want %v", name, gotExists, wantExists)
+		}
+	}
+}
@@ -36,8 +36,34 @@ var supportedAffinities = map[string]bool{
 	"NONE":             true,
 	"CLIENT_IP":        true,
 	"GENERATED_COOKIE": true,
+	"HEADER_FIELD":     true,
+	"HTTP_COOKIE":      true,
 }
 
+// managedAffinities are the affinities that are only supported by the
+// managed (Envoy based) load balancers.
+var managedAffinities = map[string]bool{
+	"HEADER_FIELD": true,
+	"HTTP_COOKIE":  true,
+}
+
+var supportedLocalityLbPolicies = map[string]bool{
+	"ROUND_ROBIN":          true,
+	"LEAST_REQUEST":        true,
+	"RING_HASH":            true,
+	"RANDOM":               true,
+	"ORIGINAL_DESTINATION": true,
+	"MAGLEV":               true,
+}
+
+const (
+	// maxRingSize is the largest minimumRingSize accepted by GCE.
+	maxRingSize = 8388608
+	// maxDurationSec is the largest number of seconds accepted by GCE for a
+	// duration.
+	maxDurationSec = 315576000000
+)
+
 func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
 	if beConfig == nil {
 		return nil
@@ -51,7 +77,7 @@ func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.Backend
 		return err
 	}
 
-	if err := validateSessionAffinity(kubeClient, beConfig); err != nil {
+	if err := validateSessionAffinity(kubeClient, beConfig, servicePort); err != nil {
 		return err
 	}
 
@@ -59,6 +85,22 @@ func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.Backend
 		return err
 	}
 
+	if err := validateLoadBalancingPolicy(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateConsistentHash(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateCircuitBreakers(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateOutlierDetection(beConfig, servicePort); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -98,15 +140,20 @@ func validateIAP(kubeClient kubernetes.Interface, beConfig *backendconfigv1.Back
 	return nil
 }
 
-func validateSessionAffinity(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
+func validateSessionAffinity(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
 	if beConfig.Spec.SessionAffinity == nil {
 		return nil
 	}
 
-	if beConfig.Spec.SessionAffinity.AffinityType != "" {
-		if _, ok := supportedAffinities[beConfig.Spec.SessionAffinity.AffinityType]; !ok {
-			return fmt.Errorf("unsupported AffinityType: %s, should be one of NONE, CLIENT_IP, or GENERATED_COOKIE",
-				beConfig.Spec.SessionAffinity.AffinityType)
+	if affinityType := beConfig.Spec.SessionAffinity.AffinityType; affinityType != "" {
+		if _, ok := supportedAffinities[affinityType]; !ok {
+			return fmt.Errorf("unsupported AffinityType: %s, should be one of NONE, CLIENT_IP, GENERATED_COOKIE, HEADER_FIELD or HTTP_COOKIE",
+				affinityType)
+		}
+		if managedAffinities[affinityType] {
+			if err := validateManagedLoadBalancer("AffinityType "+affinityType, servicePort); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -133,6 +180,140 @@ func validateLogging(beConfig *backendconfigv1.BackendConfig) error {
 	return nil
 }
 
+// namedInt64 is an optional integer setting along with its name, used to
+// report which setting failed validation.
+type namedInt64 struct {
+	name  string
+	value *int64
+}
+
+// validateManagedLoadBalancer returns an error if the service port is not
+// served by one of the managed (Envoy based) load balancers, which are the
+// only ones that support advanced traffic management settings.
+func validateManagedLoadBalancer(feature string, servicePort *utils.ServicePort) error {
+	if servicePort != nil && !servicePort.L7ILBEnabled && !servicePort.L7XLBRegionalEnabled {
+		return fmt.Errorf("%s configuration is only supported for internal and regional external Ingresses", feature)
+	}
+	return nil
+}
+
+func validateLoadBalancingPolicy(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	if beConfig.Spec.LoadBalancingPolicy == nil {
+		return nil
+	}
+	if err := validateManagedLoadBalancer("LoadBalancingPolicy", servicePort); err != nil {
+		return err
+	}
+
+	if _, ok := supportedLocalityLbPolicies[beConfig.Spec.LoadBalancingPolicy.LocalityLbPolicy]; !ok {
+		return fmt.Errorf("unsupported LocalityLbPolicy: %q, should be one of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM, ORIGINAL_DESTINATION or MAGLEV",
+			beConfig.Spec.LoadBalancingPolicy.LocalityLbPolicy)
+	}
+	return nil
+}
+
+func validateConsistentHash(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	consistentHash := beConfig.Spec.ConsistentHash
+	if consistentHash == nil {
+		return nil
+	}
+	if err := validateManagedLoadBalancer("ConsistentHash", servicePort); err != nil {
+		return err
+	}
+
+	lbPolicy := beConfig.Spec.LoadBalancingPolicy
+	if lbPolicy == nil || (lbPolicy.LocalityLbPolicy != "RING_HASH" && lbPolicy.LocalityLbPolicy != "MAGLEV") {
+		return fmt.Errorf("ConsistentHash requires LocalityLbPolicy to be RING_HASH or MAGLEV")
+	}
+
+	var affinityType string
+	if beConfig.Spec.SessionAffinity != nil {
+		affinityType = beConfig.Spec.SessionAffinity.AffinityType
+	}
+	if consistentHash.HttpCookie != nil && consistentHash.HttpHeaderName != "" {
+		return fmt.Errorf("only one of HttpCookie and HttpHeaderName can be specified in ConsistentHash")
+	}
+	if consistentHash.HttpCookie != nil {
+		if affinityType != "HTTP_COOKIE" {
+			return fmt.Errorf("ConsistentHash HttpCookie requires AffinityType to be HTTP_COOKIE")
+		}
+		if ttl := consistentHash.HttpCookie.TtlSec; ttl != nil && (*ttl < 0 || *ttl > maxDurationSec) {
+			return fmt.Errorf("unsupported HttpCookie TtlSec: %d, should be between 0 and %d", *ttl, int64(maxDurationSec))
+		}
+	}
+	if consistentHash.HttpHeaderName != "" && affinityType != "HEADER_FIELD" {
+		return fmt.Errorf("ConsistentHash HttpHeaderName requires AffinityType to be HEADER_FIELD")
+	}
+	if size := consistentHash.MinimumRingSize; size != nil && (*size < 1 || *size > maxRingSize) {
+		return fmt.Errorf("unsupported MinimumRingSize: %d, should be between 1 and %d", *size, maxRingSize)
+	}
+	return nil
+}
+
+func validateCircuitBreakers(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	circuitBreakers := beConfig.Spec.CircuitBreakers
+	if circuitBreakers == nil {
+		return nil
+	}
+	if err := validateManagedLoadBalancer("CircuitBreakers", servicePort); err != nil {
+		return err
+	}
+
+	for _, field := range []namedInt64{
+		{"MaxConnections", circuitBreakers.MaxConnections},
+		{"MaxPendingRequests", circuitBreakers.MaxPendingRequests},
+		{"MaxRequests", circuitBreakers.MaxRequests},
+		{"MaxRequestsPerConnection", circuitBreakers.MaxRequestsPerConnection},
+		{"MaxRetries", circuitBreakers.MaxRetries},
+	} {
+		if value := field.value; value != nil && *value < 1 {
+			return fmt.Errorf("unsupported %s: %d, should be greater than 0", field.name, *value)
+		}
+	}
+	return nil
+}
+
+func validateOutlierDetection(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	outlierDetection := beConfig.Spec.OutlierDetection
+	if outlierDetection == nil {
+		return nil
+	}
+	if err := validateManagedLoadBalancer("OutlierDetection", servicePort); err != nil {
+		return err
+	}
+
+	for _, field := range []namedInt64{
+		{"EnforcingConsecutiveErrors", outlierDetection.EnforcingConsecutiveErrors},
+		{"EnforcingConsecutiveGatewayFailure", outlierDetection.EnforcingConsecutiveGatewayFailure},
+		{"EnforcingSuccessRate", outlierDetection.EnforcingSuccessRate},
+		{"MaxEjectionPercent", outlierDetection.MaxEjectionPercent},
+	} {
+		if value := field.value; value != nil && (*value < 0 || *value > 100) {
+			return fmt.Errorf("unsupported %s: %d, should be between 0 and 100", field.name, *value)
+		}
+	}
+	for _, field := range []namedInt64{
+		{"BaseEjectionTimeSec", outlierDetection.BaseEjectionTimeSec},
+		{"IntervalSec", outlierDetection.IntervalSec},
+	} {
+		if value := field.value; value != nil && (*value < 0 || *value > maxDurationSec) {
+			return fmt.Errorf("unsupported %s: %d, should be between 0 and %d", field.name, *value, int64(maxDurationSec))
+		}
+	}
+	for _, field := range []namedInt64{
+		{"ConsecutiveErrors", outlierDetection.ConsecutiveErrors},
+		{"ConsecutiveGatewayFailure", outlierDetection.ConsecutiveGatewayFailure},
+		{"SuccessRateMinimumHosts", outlierDetection.SuccessRateMinimumHosts},
+		{"SuccessRateRequestVolume", outlierDetection.SuccessRateRequestVolume},
+		{"SuccessRateStdevFactor", outlierDetection.SuccessRateStdevFactor},
+	} {
+		if value := field.value; value != nil && *value < 0 {
+			return fmt.Errorf("unsupported %s: %d, should not be negative", field.name, *value)
+		}
+	}
+	return nil
+}
+
 func validateCDN(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
 	if beConfig.Spec.Cdn == nil || beConfig.Spec.Cdn.Enabled == false {
 		return nil
@@ -256,6 +256,34 @@ func TestValidateSessionAffinity(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			desc: "header field affinity on external load balancer",
+			beConfig: &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
+						AffinityType: "HEADER_FIELD",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "http cookie affinity on external load balancer",
+			beConfig: &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
+						AffinityType: "HTTP_COOKIE",
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			desc: "unsupported ttl value",
 			beConfig: &backendconfigv1.BackendConfig{
@@ -479,3 +507,183 @@ func TestValidateCDN(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTrafficPolicy(t *testing.T) {
+	l7ilbServicePort := &utils.ServicePort{L7ILBEnabled: true}
+	negative := int64(-1)
+	zero := int64(0)
+	ten := int64(10)
+	tooLarge := int64(101)
+
+	for _, tc := range []struct {
+		desc        string
+		spec        backendconfigv1.BackendConfigSpec
+		servicePort *utils.ServicePort
+		expectError bool
+	}{
+		{
+			desc: "valid locality lb policy",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "LEAST_REQUEST"},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: false,
+		},
+		{
+			desc: "unsupported locality lb policy",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "WRONG_POLICY"},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "locality lb policy on external load balancer",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "LEAST_REQUEST"},
+			},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc: "locality lb policy on regional external load balancer",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "RANDOM"},
+			},
+			servicePort: &utils.ServicePort{L7XLBRegionalEnabled: true},
+			expectError: false,
+		},
+		{
+			desc: "valid consistent hash on header",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "RING_HASH"},
+				SessionAffinity:     &backendconfigv1.SessionAffinityConfig{AffinityType: "HEADER_FIELD"},
+				ConsistentHash:      &backendconfigv1.ConsistentHashConfig{HttpHeaderName: "x-user", MinimumRingSize: &ten},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: false,
+		},
+		{
+			desc: "valid consistent hash on cookie",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "MAGLEV"},
+				SessionAffinity:     &backendconfigv1.SessionAffinityConfig{AffinityType: "HTTP_COOKIE"},
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpCookie: &backendconfigv1.ConsistentHashHttpCookieConfig{Name: "session", TtlSec: &ten},
+				},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: false,
+		},
+		{
+			desc: "consistent hash without hash based lb policy",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "ROUND_ROBIN"},
+				SessionAffinity:     &backendconfigv1.SessionAffinityConfig{AffinityType: "HEADER_FIELD"},
+				ConsistentHash:      &backendconfigv1.ConsistentHashConfig{HttpHeaderName: "x-user"},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "consistent hash header without header field affinity",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "RING_HASH"},
+				ConsistentHash:      &backendconfigv1.ConsistentHashConfig{HttpHeaderName: "x-user"},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "consistent hash with both cookie and header",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "RING_HASH"},
+				SessionAffinity:     &backendconfigv1.SessionAffinityConfig{AffinityType: "HTTP_COOKIE"},
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpCookie:     &backendconfigv1.ConsistentHashHttpCookieConfig{Name: "session"},
+					HttpHeaderName: "x-user",
+				},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "consistent hash with invalid ring size",
+			spec: backendconfigv1.BackendConfigSpec{
+				LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{LocalityLbPolicy: "RING_HASH"},
+				ConsistentHash:      &backendconfigv1.ConsistentHashConfig{MinimumRingSize: &zero},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "valid circuit breakers",
+			spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{MaxConnections: &ten, MaxRetries: &ten},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: false,
+		},
+		{
+			desc: "circuit breakers with zero limit",
+			spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{MaxRequests: &zero},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "valid outlier detection",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+					ConsecutiveErrors:    &ten,
+					EnforcingSuccessRate: &zero,
+					IntervalSec:          &ten,
+				},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: false,
+		},
+		{
+			desc: "outlier detection with invalid percentage",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{MaxEjectionPercent: &tooLarge},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "outlier detection with negative interval",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{IntervalSec: &negative},
+			},
+			servicePort: l7ilbServicePort,
+			expectError: true,
+		},
+		{
+			desc: "outlier detection on external load balancer",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{ConsecutiveErrors: &ten},
+			},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: tc.spec,
+			}
+			kubeClient := fake.NewSimpleClientset()
+			err := Validate(kubeClient, beConfig, tc.servicePort)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect error but got: %v", err)
+			}
+		})
+	}
+}
@@ -90,6 +90,8 @@ type L4BackendServiceParams struct {
 	NetworkInfo              *network.NetworkInfo
 	ConnectionTrackingPolicy *composite.BackendServiceConnectionTrackingPolicy
 	LocalityLbPolicy         LocalityLBPolicyType
+	// FailoverPolicy is only supported by L4 ILB backend services.
+	FailoverPolicy *composite.BackendServiceFailoverPolicy
 }
 
 // ensureDescription updates the BackendService Description with the expected value
@@ -114,12 +116,11 @@ func (p *Pool) Create(sp utils.ServicePort, hcLink string, beLogger klog.Logger)
 
 	version := features.VersionFromServicePort(&sp)
 	be := &composite.BackendService{
-		Version:      version,
-		Name:         name,
-		Protocol:     string(sp.Protocol),
-		Port:         namedPort.Port,
-		PortName:     namedPort.Name,
-		HealthChecks: []string{hcLink},
+		Version:  version,
+		Name:     name,
+		Protocol: string(sp.Protocol),
+		Port:     namedPort.Port,
+		PortName: namedPort.Name,
 		// LogConfig is using GA API so this is not considered for computing API version.
 		LogConfig: &composite.BackendServiceLogConfig{
 			Enable: true,
@@ -128,6 +129,11 @@ func (p *Pool) Create(sp utils.ServicePort, hcLink string, beLogger klog.Logger)
 		},
 	}
 
+	// Backend services of internet NEGs have no health check.
+	if hcLink != "" {
+		be.HealthChecks = []string{hcLink}
+	}
+
 	if sp.L7ILBEnabled {
 		// This enables l7-ILB and advanced traffic management features
 		be.LoadBalancingScheme = "INTERNAL_MANAGED"
@@ -359,6 +365,7 @@ func (p *Pool) EnsureL4BackendService(params L4BackendServiceParams, beLogger kl
 		SessionAffinity:     utils.TranslateAffinityType(params.SessionAffinity, beLogger),
 		LoadBalancingScheme: params.Scheme,
 		LocalityLbPolicy:    string(params.LocalityLbPolicy),
+		FailoverPolicy:      params.FailoverPolicy,
 	}
 
 	// We need this configuration only for Strong Session Affinity feature
@@ -434,7 +441,8 @@ func backendSvcEqual(newBS, oldBS *composite.BackendService, compareConnectionTr
 		newBS.SessionAffinity == oldBS.SessionAffinity &&
 		newBS.LoadBalancingScheme == oldBS.LoadBalancingScheme &&
 		utils.EqualStringSets(newBS.HealthChecks, oldBS.HealthChecks) &&
-		newBS.Network == oldBS.Network
+		newBS.Network == oldBS.Network &&
+		failoverPolicyEqual(newBS.FailoverPolicy, oldBS.FailoverPolicy)
 
 	// Compare only for backendSvc that uses Strong Session Affinity feature
 	if compareConnectionTracking {
@@ -450,6 +458,16 @@ func backendSvcEqual(newBS, oldBS *composite.BackendService, compareConnectionTr
 	return svcsEqual
 }
 
+// failoverPolicyEqual returns true if both failover policies are equal.
+func failoverPolicyEqual(a, b *composite.BackendServiceFailoverPolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.DisableConnectionDrainOnFailover == b.DisableConnectionDrainOnFailover &&
+		a.DropTrafficIfUnhealthy == b.DropTrafficIfUnhealthy &&
+		a.FailoverRatio == b.FailoverRatio
+}
+
 // connectionTrackingPolicyEqual returns true if both elements are equal
 // and return false if at least one parameter is different
 func connectionTrackingPolicyEqual(a, b *composite.BackendServiceConnectionTrackingPolicy) bool {
@@ -168,6 +168,8 @@ func TestEnsureL4BackendServiceUpdate(t *testing.T) {
 		affinityType        string
 		updatedAffinityType string
 		schemeType          string
+		failoverPolicy      *composite.BackendServiceFailoverPolicy
+		updatedFailover     *composite.BackendServiceFailoverPolicy
 		expectUpdate        utils.ResourceSyncStatus
 	}{
 		{
@@ -203,6 +205,44 @@ func TestEnsureL4BackendServiceUpdate(t *testing.T) {
 			schemeType:          string(cloud.SchemeInternal),
 			expectUpdate:        utils.ResourceUpdate,
 		},
+		{
+			desc:                "Test add failover policy",
+			serviceName:         "test-service",
+			serviceNamespace:    "test-ns",
+			protocol:            "TCP",
+			updatedProtocol:     "TCP",
+			affinityType:        string(v1.ServiceAffinityNone),
+			updatedAffinityType: string(v1.ServiceAffinityNone),
+			schemeType:          string(cloud.SchemeInternal),
+			updatedFailover:     &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5},
+			expectUpdate:        utils.ResourceUpdate,
+		},
+		{
+			desc:                "Test same failover policy",
+			serviceName:         "test-service",
+			serviceNamespace:    "test-ns",
+			protocol:            "TCP",
+			updatedProtocol:     "TCP",
+			affinityType:        string(v1.ServiceAffinityNone),
+			updatedAffinityType: string(v1.ServiceAffinityNone),
+			schemeType:          string(cloud.SchemeInternal),
+			failoverPolicy:      &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5, DropTrafficIfUnhealthy: true},
+			updatedFailover:     &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5, DropTrafficIfUnhealthy: true},
+			expectUpdate:        utils.ResourceResync,
+		},
+		{
+			desc:                "Test update failover policy",
+			serviceName:         "test-service",
+			serviceNamespace:    "test-ns",
+			protocol:            "TCP",
+			updatedProtocol:     "TCP",
+			affinityType:        string(v1.ServiceAffinityNone),
+			updatedAffinityType: string(v1.ServiceAffinityNone),
+			schemeType:          string(cloud.SchemeInternal),
+			failoverPolicy:      &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5},
+			updatedFailover:     &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5, DisableConnectionDrainOnFailover: true},
+			expectUpdate:        utils.ResourceUpdate,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			namespacedName := types.NamespacedName{Name: tc.serviceName, Namespace: tc.serviceNamespace}
@@ -221,6 +261,7 @@ func TestEnsureL4BackendServiceUpdate(t *testing.T) {
 				Scheme:          tc.schemeType,
 				NamespacedName:  namespacedName,
 				NetworkInfo:     network,
+				FailoverPolicy:  tc.failoverPolicy,
 			}
 			_, updated, err := backendPool.EnsureL4BackendService(backendParams, klog.TODO())
 			if err != nil {
@@ -246,6 +287,7 @@ func TestEnsureL4BackendServiceUpdate(t *testing.T) {
 				Scheme:          tc.schemeType,
 				NamespacedName:  namespacedName,
 				NetworkInfo:     network,
+				FailoverPolicy:  tc.updatedFailover,
 			}
 			_, updated, err = backendPool.EnsureL4BackendService(updatedBackendParams, klog.TODO())
 			if err != nil {
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// EnsureCircuitBreakers reads the CircuitBreakers configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+func EnsureCircuitBreakers(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.CircuitBreakers == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{CircuitBreakers: be.CircuitBreakers}
+	applyCircuitBreakersSettings(sp, beTemp)
+	if !reflect.DeepEqual(beTemp.CircuitBreakers, be.CircuitBreakers) {
+		be.CircuitBreakers = beTemp.CircuitBreakers
+		logger.V(2).Info("Updated CircuitBreakers settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// applyCircuitBreakersSettings applies the CircuitBreakers settings specified
+// in the BackendConfig to the passed in composite.BackendService. Limits which
+// are not specified retain their existing value. The existing settings are
+// copied rather than modified in place. A GCE API call still needs to be made
+// to actually persist the changes.
+func applyCircuitBreakersSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.CircuitBreakers
+	circuitBreakers := &composite.CircuitBreakers{}
+	if be.CircuitBreakers != nil {
+		*circuitBreakers = *be.CircuitBreakers
+	}
+	setInt64(&circuitBreakers.MaxConnections, config.MaxConnections)
+	setInt64(&circuitBreakers.MaxPendingRequests, config.MaxPendingRequests)
+	setInt64(&circuitBreakers.MaxRequests, config.MaxRequests)
+	setInt64(&circuitBreakers.MaxRequestsPerConnection, config.MaxRequestsPerConnection)
+	setInt64(&circuitBreakers.MaxRetries, config.MaxRetries)
+	be.CircuitBreakers = circuitBreakers
+}
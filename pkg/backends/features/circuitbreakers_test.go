@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureCircuitBreakers(t *testing.T) {
+	maxConnections := int64(100)
+	maxRetries := int64(3)
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+		expected       *composite.CircuitBreakers
+	}{
+		{
+			desc: "circuit breakers missing from spec, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "circuit breakers not set on backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxConnections: &maxConnections,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+			expected:       &composite.CircuitBreakers{MaxConnections: maxConnections},
+		},
+		{
+			desc: "circuit breakers differing, update needed and unspecified limits retained",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxRetries: &maxRetries,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					ConnectTimeout: &composite.Duration{Seconds: 5},
+					MaxConnections: maxConnections,
+					MaxRetries:     1,
+				},
+			},
+			updateExpected: true,
+			expected: &composite.CircuitBreakers{
+				ConnectTimeout: &composite.Duration{Seconds: 5},
+				MaxConnections: maxConnections,
+				MaxRetries:     maxRetries,
+			},
+		},
+		{
+			desc: "circuit breakers identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxConnections: &maxConnections,
+							MaxRetries:     &maxRetries,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					MaxConnections: maxConnections,
+					MaxRetries:     maxRetries,
+				},
+			},
+			updateExpected: false,
+			expected: &composite.CircuitBreakers{
+				MaxConnections: maxConnections,
+				MaxRetries:     maxRetries,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureCircuitBreakers(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if !reflect.DeepEqual(tc.be.CircuitBreakers, tc.expected) {
+				t.Errorf("%v: expected CircuitBreakers %+v but got %+v", tc.desc, tc.expected, tc.be.CircuitBreakers)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// EnsureConsistentHash reads the ConsistentHash configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten. The BackendService is left untouched if the
+// BackendConfig has no ConsistentHash, so removing it does not reset the
+// settings.
+func EnsureConsistentHash(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.ConsistentHash == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{ConsistentHash: be.ConsistentHash}
+	applyConsistentHashSettings(sp, beTemp)
+	if !reflect.DeepEqual(beTemp.ConsistentHash, be.ConsistentHash) {
+		be.ConsistentHash = beTemp.ConsistentHash
+		logger.V(2).Info("Updated ConsistentHash settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// applyConsistentHashSettings applies the ConsistentHash settings specified in
+// the BackendConfig to the passed in composite.BackendService. Settings which
+// are not specified retain their existing value. The existing settings are
+// copied rather than modified in place. A GCE API call still needs to be made
+// to actually persist the changes.
+func applyConsistentHashSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.ConsistentHash
+	consistentHash := &composite.ConsistentHashLoadBalancerSettings{}
+	if be.ConsistentHash != nil {
+		*consistentHash = *be.ConsistentHash
+	}
+	consistentHash.HttpHeaderName = config.HttpHeaderName
+	consistentHash.HttpCookie = nil
+	if config.HttpCookie != nil {
+		consistentHash.HttpCookie = &composite.ConsistentHashLoadBalancerSettingsHttpCookie{
+			Name: config.HttpCookie.Name,
+			Path: config.HttpCookie.Path,
+		}
+		if config.HttpCookie.TtlSec != nil {
+			consistentHash.HttpCookie.Ttl = &composite.Duration{Seconds: *config.HttpCookie.TtlSec}
+		}
+	}
+	if config.MinimumRingSize != nil {
+		consistentHash.MinimumRingSize = *config.MinimumRingSize
+	}
+	be.ConsistentHash = consistentHash
+}
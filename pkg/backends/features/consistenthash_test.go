@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureConsistentHash(t *testing.T) {
+	ringSize := int64(2048)
+	cookieTTL := int64(60)
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+		expected       *composite.ConsistentHashLoadBalancerSettings
+	}{
+		{
+			desc: "consistent hash missing from spec, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{},
+				},
+			},
+			be: &composite.BackendService{
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{HttpHeaderName: "x-user"},
+			},
+			updateExpected: false,
+			expected:       &composite.ConsistentHashLoadBalancerSettings{HttpHeaderName: "x-user"},
+		},
+		{
+			desc: "consistent hash not set on backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+							HttpHeaderName:  "x-user",
+							MinimumRingSize: &ringSize,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+			expected: &composite.ConsistentHashLoadBalancerSettings{
+				HttpHeaderName:  "x-user",
+				MinimumRingSize: ringSize,
+			},
+		},
+		{
+			desc: "http cookie differing, update needed and header removed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+							HttpCookie: &backendconfigv1.ConsistentHashHttpCookieConfig{
+								Name:   "session",
+								Path:   "/",
+								TtlSec: &cookieTTL,
+							},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{
+					HttpHeaderName:  "x-user",
+					MinimumRingSize: ringSize,
+				},
+			},
+			updateExpected: true,
+			expected: &composite.ConsistentHashLoadBalancerSettings{
+				HttpCookie: &composite.ConsistentHashLoadBalancerSettingsHttpCookie{
+					Name: "session",
+					Path: "/",
+					Ttl:  &composite.Duration{Seconds: cookieTTL},
+				},
+				MinimumRingSize: ringSize,
+			},
+		},
+		{
+			desc: "consistent hash identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+							HttpHeaderName: "x-user",
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{
+					HttpHeaderName:  "x-user",
+					MinimumRingSize: ringSize,
+				},
+			},
+			updateExpected: false,
+			expected: &composite.ConsistentHashLoadBalancerSettings{
+				HttpHeaderName:  "x-user",
+				MinimumRingSize: ringSize,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureConsistentHash(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if !reflect.DeepEqual(tc.be.ConsistentHash, tc.expected) {
+				t.Errorf("%v: expected ConsistentHash %+v but got %+v", tc.desc, tc.expected, tc.be.ConsistentHash)
+			}
+		})
+	}
+}
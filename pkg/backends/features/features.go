@@ -141,3 +141,10 @@ var (
 func IsLowerVersion(v1, v2 meta.Version) bool {
 	return versionMap[v1] < versionMap[v2]
 }
+
+// setInt64 overwrites dst with the value of src if src is specified.
+func setInt64(dst *int64, src *int64) {
+	if src != nil {
+		*dst = *src
+	}
+}
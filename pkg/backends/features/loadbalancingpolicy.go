@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// EnsureLoadBalancingPolicy reads the LoadBalancingPolicy configuration
+// specified in the ServicePort.BackendConfig and applies it to the
+// BackendService. It returns true if there were existing settings on the
+// BackendService that were overwritten. The BackendService is left untouched
+// if the BackendConfig has no LoadBalancingPolicy, so removing it does not
+// reset the algorithm.
+func EnsureLoadBalancingPolicy(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.LoadBalancingPolicy == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	applyLoadBalancingPolicySettings(sp, beTemp)
+	if beTemp.LocalityLbPolicy != be.LocalityLbPolicy {
+		applyLoadBalancingPolicySettings(sp, be)
+		logger.V(2).Info("Updated LocalityLbPolicy settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name), "localityLbPolicy", be.LocalityLbPolicy)
+		return true
+	}
+	return false
+}
+
+// applyLoadBalancingPolicySettings applies the LoadBalancingPolicy settings
+// specified in the BackendConfig to the passed in composite.BackendService. A
+// GCE API call still needs to be made to actually persist the changes.
+func applyLoadBalancingPolicySettings(sp utils.ServicePort, be *composite.BackendService) {
+	be.LocalityLbPolicy = sp.BackendConfig.Spec.LoadBalancingPolicy.LocalityLbPolicy
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureLoadBalancingPolicy(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc: "load balancing policy missing from spec, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{},
+				},
+			},
+			be: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "locality lb policy differing, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{
+							LocalityLbPolicy: "LEAST_REQUEST",
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				LocalityLbPolicy: "ROUND_ROBIN",
+			},
+			updateExpected: true,
+		},
+		{
+			desc: "locality lb policy identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						LoadBalancingPolicy: &backendconfigv1.LoadBalancingPolicyConfig{
+							LocalityLbPolicy: "MAGLEV",
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				LocalityLbPolicy: "MAGLEV",
+			},
+			updateExpected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureLoadBalancingPolicy(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if lbPolicy := tc.sp.BackendConfig.Spec.LoadBalancingPolicy; lbPolicy != nil && tc.be.LocalityLbPolicy != lbPolicy.LocalityLbPolicy {
+				t.Errorf("%v: expected LocalityLbPolicy %q but got %q", tc.desc, lbPolicy.LocalityLbPolicy, tc.be.LocalityLbPolicy)
+			}
+		})
+	}
+}
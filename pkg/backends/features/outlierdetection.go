@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// EnsureOutlierDetection reads the OutlierDetection configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+func EnsureOutlierDetection(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.OutlierDetection == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{OutlierDetection: be.OutlierDetection}
+	applyOutlierDetectionSettings(sp, beTemp)
+	if !reflect.DeepEqual(beTemp.OutlierDetection, be.OutlierDetection) {
+		be.OutlierDetection = beTemp.OutlierDetection
+		logger.V(2).Info("Updated OutlierDetection settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// applyOutlierDetectionSettings applies the OutlierDetection settings
+// specified in the BackendConfig to the passed in composite.BackendService.
+// Settings which are not specified retain their existing value. The existing
+// settings are copied rather than modified in place. A GCE API call still
+// needs to be made to actually persist the changes.
+func applyOutlierDetectionSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.OutlierDetection
+	outlierDetection := &composite.OutlierDetection{}
+	if be.OutlierDetection != nil {
+		*outlierDetection = *be.OutlierDetection
+	}
+	if config.BaseEjectionTimeSec != nil {
+		outlierDetection.BaseEjectionTime = &composite.Duration{Seconds: *config.BaseEjectionTimeSec}
+	}
+	if config.IntervalSec != nil {
+		outlierDetection.Interval = &composite.Duration{Seconds: *config.IntervalSec}
+	}
+	setInt64(&outlierDetection.ConsecutiveErrors, config.ConsecutiveErrors)
+	setInt64(&outlierDetection.ConsecutiveGatewayFailure, config.ConsecutiveGatewayFailure)
+	setInt64(&outlierDetection.EnforcingConsecutiveErrors, config.EnforcingConsecutiveErrors)
+	setInt64(&outlierDetection.EnforcingConsecutiveGatewayFailure, config.EnforcingConsecutiveGatewayFailure)
+	setInt64(&outlierDetection.EnforcingSuccessRate, config.EnforcingSuccessRate)
+	setInt64(&outlierDetection.MaxEjectionPercent, config.MaxEjectionPercent)
+	setInt64(&outlierDetection.SuccessRateMinimumHosts, config.SuccessRateMinimumHosts)
+	setInt64(&outlierDetection.SuccessRateRequestVolume, config.SuccessRateRequestVolume)
+	setInt64(&outlierDetection.SuccessRateStdevFactor, config.SuccessRateStdevFactor)
+	be.OutlierDetection = outlierDetection
+}
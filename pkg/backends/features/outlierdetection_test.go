@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureOutlierDetection(t *testing.T) {
+	consecutiveErrors := int64(3)
+	intervalSec := int64(10)
+	maxEjectionPercent := int64(20)
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+		expected       *composite.OutlierDetection
+	}{
+		{
+			desc: "outlier detection missing from spec, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{ConsecutiveErrors: 5},
+			},
+			updateExpected: false,
+			expected:       &composite.OutlierDetection{ConsecutiveErrors: 5},
+		},
+		{
+			desc: "outlier detection not set on backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+							IntervalSec:       &intervalSec,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+			expected: &composite.OutlierDetection{
+				ConsecutiveErrors: consecutiveErrors,
+				Interval:          &composite.Duration{Seconds: intervalSec},
+			},
+		},
+		{
+			desc: "outlier detection differing, update needed and unspecified settings retained",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							MaxEjectionPercent: &maxEjectionPercent,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					BaseEjectionTime:   &composite.Duration{Seconds: 30},
+					MaxEjectionPercent: 50,
+				},
+			},
+			updateExpected: true,
+			expected: &composite.OutlierDetection{
+				BaseEjectionTime:   &composite.Duration{Seconds: 30},
+				MaxEjectionPercent: maxEjectionPercent,
+			},
+		},
+		{
+			desc: "outlier detection identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+							IntervalSec:       &intervalSec,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					ConsecutiveErrors: consecutiveErrors,
+					Interval:          &composite.Duration{Seconds: intervalSec},
+				},
+			},
+			updateExpected: false,
+			expected: &composite.OutlierDetection{
+				ConsecutiveErrors: consecutiveErrors,
+				Interval:          &composite.Duration{Seconds: intervalSec},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureOutlierDetection(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if !reflect.DeepEqual(tc.be.OutlierDetection, tc.expected) {
+				t.Errorf("%v: expected OutlierDetection %+v but got %+v", tc.desc, tc.expected, tc.be.OutlierDetection)
+			}
+		})
+	}
+}
@@ -29,6 +29,9 @@ import (
 type GroupKey struct {
 	Zone string
 	Name string
+	// Failover marks the backends of the group as failover backends. Only
+	// supported by the NEG linker for L4 ILB backend services.
+	Failover bool
 }
 
 // Linker is an interface to link backends with their associated groups.
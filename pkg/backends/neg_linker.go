@@ -83,6 +83,7 @@ func (nl *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 	}
 
 	newBackends := backendsForNEGs(negSelfLinks.negsToAdd, &sp)
+	setFailoverBackends(newBackends, groups)
 	// Historically, we merged the old backends with the new backends to ensure
 	// that we don't detach NEGs when zones contract. Given that now we primarily
 	// use SvcNEGs as the source-of-truth for calculating backends, and since
@@ -137,6 +138,10 @@ type backendNegUrls struct {
 func (nl *negLinker) getNegSelfLinks(sp utils.ServicePort, groups []GroupKey) (backendNegUrls, error) {
 	version := befeatures.VersionFromServicePort(&sp)
 
+	if sp.GlobalNEG {
+		return nl.getGlobalNegSelfLinks(sp, version)
+	}
+
 	if nl.enableMultiSubnetClusterPhase1 {
 		negName := sp.NEGName()
 		svcNegKey := fmt.Sprintf("%s/%s", sp.ID.Service.Namespace, negName)
@@ -183,6 +188,24 @@ func (nl *negLinker) getNegSelfLinks(sp utils.ServicePort, groups []GroupKey) (b
 	return urls, nil
 }
 
+// getGlobalNegSelfLinks returns the url of the global NEG of the service
+// port. Internet NEGs do not belong to any zone, so there is a single NEG
+// regardless of the zones of the instance groups.
+func (nl *negLinker) getGlobalNegSelfLinks(sp utils.ServicePort, version meta.Version) (backendNegUrls, error) {
+	negName := sp.NEGName()
+	svcNegKey := fmt.Sprintf("%s/%s", sp.ID.Service.Namespace, negName)
+	// The zone of global NEG urls is empty.
+	if negUrl, ok := getNegUrlFromSvcneg(svcNegKey, types.GlobalZone, nl.svcNegLister, nl.logger); ok {
+		return backendNegUrls{negsToAdd: []string{negUrl}}, nil
+	}
+	nl.logger.V(4).Info("Falling back to use NEG API to retrieve NEG url for global NEG", "negName", negName)
+	neg, err := nl.negGetter.GetNetworkEndpointGroup(negName, types.GlobalZone, version, nl.logger)
+	if err != nil {
+		return backendNegUrls{}, err
+	}
+	return backendNegUrls{negsToAdd: []string{neg.SelfLink}}, nil
+}
+
 type backendDiff struct {
 	old     sets.String
 	new     sets.String
@@ -280,6 +303,9 @@ func diffBackends(old, new []*composite.Backend, logger klog.Logger) *backendDif
 			// value (e.g. CapacityScaler is 1.0), you will need to set that
 			// value when creating a new Backend to avoid a false positive when
 			// computing diffs.
+			if oldBe.Failover != be.Failover {
+				d.changed.Insert(beGroup)
+			}
 			if flags.F.EnableTrafficScaling {
 				var changed bool
 				changed = changed || oldBe.MaxRatePerEndpoint != be.MaxRatePerEndpoint
@@ -298,11 +324,36 @@ func (d *backendDiff) isEqual() bool         { return d.old.Equal(d.new) && d.ch
 func (d *backendDiff) toRemove() sets.String { return d.old.Difference(d.new) }
 func (d *backendDiff) toAdd() sets.String    { return d.new.Difference(d.old) }
 
+// setFailoverBackends marks the backends whose NEG is in the zone of a
+// failover group as failover backends.
+func setFailoverBackends(backends []*composite.Backend, groups []GroupKey) {
+	failoverZones := sets.NewString()
+	for _, group := range groups {
+		if group.Failover {
+			failoverZones.Insert(group.Zone)
+		}
+	}
+	for _, be := range backends {
+		key, err := getNegMergeGroupKey(be.Group)
+		if err != nil {
+			continue
+		}
+		be.Failover = failoverZones.Has(key.Zone)
+	}
+}
+
 func backendsForNEGs(negSelfLinks []string, sp *utils.ServicePort) []*composite.Backend {
 	var backends []*composite.Backend
 	for _, neg := range negSelfLinks {
 		newBackend := &composite.Backend{Group: neg}
 
+		// Global NEGs are internet NEGs, whose backends do not support
+		// balancing modes.
+		if key, err := getNegMergeGroupKey(neg); err == nil && key.Type() == meta.Global {
+			backends = append(backends, newBackend)
+			continue
+		}
+
 		switch getNegType(*sp) {
 		case types.VmIpEndpointType:
 			// Setting MaxConnectionsPerEndpoint is not supported for L4 ILB
@@ -23,6 +23,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
@@ -230,6 +231,77 @@ func TestLinkWithDifferentSvcPorts(t *testing.T) {
 	}
 }
 
+func TestLinkGlobalInternetNEG(t *testing.T) {
+	t.Parallel()
+
+	zones := []GroupKey{{Zone: testZone1}, {Zone: testZone2}}
+	svcPort := utils.ServicePort{
+		ID:           utils.ServicePortID{Service: types.NamespacedName{Namespace: "ns", Name: "name"}},
+		Port:         80,
+		NodePort:     30001,
+		Protocol:     annotations.ProtocolHTTPS,
+		NEGEnabled:   true,
+		GlobalNEG:    true,
+		BackendNamer: defaultNamer,
+	}
+
+	for _, populateSvcNeg := range []bool{true, false} {
+		t.Run(fmt.Sprintf("populateSvcNeg=%v", populateSvcNeg), func(t *testing.T) {
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			fakeNEG := negtypes.NewAdapter(fakeGCE)
+			syncer := newTestSyncer(fakeGCE)
+			linker := newTestNEGLinker(fakeNEG, fakeGCE)
+
+			neg := &composite.NetworkEndpointGroup{
+				Name:                svcPort.NEGName(),
+				NetworkEndpointType: string(negtypes.InternetFQDNEndpointType),
+			}
+			if err := fakeNEG.CreateNetworkEndpointGroup(neg, negtypes.GlobalZone, klog.TODO()); err != nil {
+				t.Fatalf("CreateNetworkEndpointGroup(%s) = %v", neg.Name, err)
+			}
+			neg, err := fakeNEG.GetNetworkEndpointGroup(svcPort.NEGName(), negtypes.GlobalZone, meta.VersionGA, klog.TODO())
+			if err != nil {
+				t.Fatalf("GetNetworkEndpointGroup(%s) = %v", svcPort.NEGName(), err)
+			}
+			if populateSvcNeg {
+				svcNeg := &v1beta1.ServiceNetworkEndpointGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: svcPort.NEGName(), Namespace: "ns"},
+					Status: v1beta1.ServiceNetworkEndpointGroupStatus{
+						NetworkEndpointGroups: []v1beta1.NegObjectReference{{SelfLink: neg.SelfLink}},
+					},
+				}
+				if err := linker.svcNegLister.Add(svcNeg); err != nil {
+					t.Fatalf("Failed to add svcneg: %v", err)
+				}
+			}
+
+			if err := syncer.Sync([]utils.ServicePort{svcPort}, klog.TODO()); err != nil {
+				t.Fatalf("Sync(%v) = %v", svcPort.ID, err)
+			}
+			if err := linker.Link(svcPort, zones); err != nil {
+				t.Fatalf("Link(%v) = %v", svcPort.ID, err)
+			}
+
+			bs, err := composite.GetBackendService(fakeGCE, meta.GlobalKey(svcPort.BackendName()), meta.VersionGA, klog.TODO())
+			if err != nil {
+				t.Fatalf("GetBackendService(%s) = %v", svcPort.BackendName(), err)
+			}
+			if len(bs.HealthChecks) != 0 {
+				t.Errorf("Got health checks %v, want none for an internet NEG backend", bs.HealthChecks)
+			}
+			if len(bs.Backends) != 1 {
+				t.Fatalf("Got %d backends, want 1: %+v", len(bs.Backends), bs.Backends)
+			}
+			if !utils.EqualResourceIDs(bs.Backends[0].Group, neg.SelfLink) {
+				t.Errorf("Got backend group %q, want %q", bs.Backends[0].Group, neg.SelfLink)
+			}
+			if bs.Backends[0].BalancingMode != "" {
+				t.Errorf("Got balancing mode %q, want none for an internet NEG backend", bs.Backends[0].BalancingMode)
+			}
+		})
+	}
+}
+
 func TestLinkWithNEGUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -281,6 +353,28 @@ func TestLinkWithNEGUpdates(t *testing.T) {
 			},
 			expectedBackends: []*composite.Backend{{Group: negUrl1}, {Group: negUrl2}},
 		},
+		{
+			desc:         "Mark a zone as failover",
+			prevGroups:   []GroupKey{{Zone: testZone1}, {Zone: testZone2}},
+			prevBackends: []*composite.Backend{{Group: negUrl1}, {Group: negUrl2}},
+			currGroups:   []GroupKey{{Zone: testZone1}, {Zone: testZone2, Failover: true}},
+			currentNegObjRef: []v1beta1.NegObjectReference{
+				createNegRef(testZone1, negName, ""),
+				createNegRef(testZone2, negName, ""),
+			},
+			expectedBackends: []*composite.Backend{{Group: negUrl1}, {Group: negUrl2, Failover: true}},
+		},
+		{
+			desc:         "Unmark a failover zone",
+			prevGroups:   []GroupKey{{Zone: testZone1}, {Zone: testZone2, Failover: true}},
+			prevBackends: []*composite.Backend{{Group: negUrl1}, {Group: negUrl2, Failover: true}},
+			currGroups:   []GroupKey{{Zone: testZone1}, {Zone: testZone2}},
+			currentNegObjRef: []v1beta1.NegObjectReference{
+				createNegRef(testZone1, negName, ""),
+				createNegRef(testZone2, negName, ""),
+			},
+			expectedBackends: []*composite.Backend{{Group: negUrl1}, {Group: negUrl2}},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -960,6 +1054,12 @@ func TestDiffBackends(t *testing.T) {
 			new:     []*composite.Backend{{Group: "a", CapacityScaler: 0.5}},
 			changed: sets.NewString("a"),
 		},
+		{
+			name:    "update failover",
+			old:     []*composite.Backend{{Group: "a"}},
+			new:     []*composite.Backend{{Group: "a", Failover: true}},
+			changed: sets.NewString("a"),
+		},
 		{
 			name:    "no change",
 			old:     []*composite.Backend{{Group: "a", CapacityScaler: 1.0}},
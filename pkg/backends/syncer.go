@@ -80,10 +80,15 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 	)
 	be, getErr := s.backendPool.Get(beName, version, scope, beLogger)
 
-	// Ensure health check for backend service exists.
-	hcLink, err := s.ensureHealthCheck(sp, beLogger)
-	if err != nil {
-		return fmt.Errorf("error ensuring health check: %w", err)
+	// Ensure health check for backend service exists. Backend services of
+	// internet NEGs do not support health checks.
+	var hcLink string
+	var err error
+	if !sp.GlobalNEG {
+		hcLink, err = s.ensureHealthCheck(sp, beLogger)
+		if err != nil {
+			return fmt.Errorf("error ensuring health check: %w", err)
+		}
 	}
 
 	// Verify existence of a backend service for the proper port
@@ -111,6 +116,10 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 		needUpdate = features.EnsureCustomRequestHeaders(sp, be, beLogger) || needUpdate
 		needUpdate = features.EnsureCustomResponseHeaders(sp, be, beLogger) || needUpdate
 		needUpdate = features.EnsureLogging(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureLoadBalancingPolicy(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureConsistentHash(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureCircuitBreakers(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureOutlierDetection(sp, be, beLogger) || needUpdate
 
 		updateIAP, err := features.EnsureIAP(sp, be, beLogger)
 		if err != nil {
@@ -332,8 +341,17 @@ func ensureProtocol(be *composite.BackendService, p utils.ServicePort) (needsUpd
 	return true
 }
 
-// ensureHealthCheckLink updates the BackendService HealthCheck with the expected value
+// ensureHealthCheckLink updates the BackendService HealthCheck with the expected value.
+// An empty hcLink removes the health check from the BackendService.
 func ensureHealthCheckLink(be *composite.BackendService, hcLink string) (needsUpdate bool) {
+	if hcLink == "" {
+		if len(be.HealthChecks) == 0 {
+			return false
+		}
+		be.HealthChecks = nil
+		return true
+	}
+
 	existingHCLink := getHealthCheckLink(be)
 
 	if utils.EqualResourceIDs(existingHCLink, hcLink) {
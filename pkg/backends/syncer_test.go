@@ -681,4 +681,9 @@ func TestEnsureBackendServiceHealthCheckLink(t *testing.T) {
 	if needsHcUpdate {
 		t.Fatalf("Expected ensureHealthCheckLink for healthcheck with the same name to return false, got %v", needsHcUpdate)
 	}
+
+	needsHcUpdate = ensureHealthCheckLink(be, "")
+	if !needsHcUpdate || len(be.HealthChecks) != 0 {
+		t.Fatalf("Expected ensureHealthCheckLink for an empty link to remove the healthcheck, got %v, %v", needsHcUpdate, be.HealthChecks)
+	}
 }
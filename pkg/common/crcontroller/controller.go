@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crcontroller contains the scaffolding shared by the controllers
+// that manage the GCE resources of custom resources referenced by Ingresses,
+// such as BackendBuckets and ServerlessNEGs.
+package crcontroller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// InUseError is returned by a sync function when the GCE resources of a
+// custom resource that is being deleted cannot be deleted because they are
+// still used by a url map. The key is not retried; custom resources pending
+// deletion are resynced every gc period instead.
+type InUseError struct {
+	// Resource is the name of the GCE resource that is in use.
+	Resource string
+	Err      error
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("%s is still in use: %v", e.Resource, e.Err)
+}
+
+func (e *InUseError) Unwrap() error {
+	return e.Err
+}
+
+// Object is a custom resource processed by a Controller.
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// Config configures a Controller.
+type Config struct {
+	// Kind is the kind of the custom resource, used in logs and event
+	// reasons, e.g. "BackendBucket".
+	Kind string
+	// Informer is the informer of the custom resources.
+	Informer cache.SharedIndexInformer
+	// Recorder returns the event recorder of a namespace.
+	Recorder func(string) record.EventRecorder
+	// HasSynced returns true once all informers have synced.
+	HasSynced func() bool
+	// Sync reconciles the custom resource with the given key.
+	Sync func(key string) error
+	// GC, if set, is run every GCPeriod to delete GCE resources whose custom
+	// resource no longer exists.
+	GC func() error
+	// GCPeriod is the period of GC and of the resync of the custom resources
+	// that are pending deletion.
+	GCPeriod time.Duration
+}
+
+// Controller processes the keys of custom resources in a rate limited queue
+// and reports sync errors as events on the custom resources.
+type Controller struct {
+	Config
+
+	queue  workqueue.RateLimitingInterface
+	lister cache.Indexer
+	logger klog.Logger
+}
+
+// New returns a Controller that enqueues the custom resources of the given
+// informer when they are added, when their spec changes or when they are
+// being deleted. Status updates do not change the generation, so they are
+// not processed.
+func New(cfg Config, logger klog.Logger) *Controller {
+	c := &Controller{
+		Config: cfg,
+		queue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		lister: cfg.Informer.GetIndexer(),
+		logger: logger,
+	}
+	cfg.Informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.Enqueue,
+		UpdateFunc: func(old, cur interface{}) {
+			oldObj := old.(Object)
+			curObj := cur.(Object)
+			if oldObj.GetGeneration() != curObj.GetGeneration() || curObj.GetDeletionTimestamp() != nil {
+				c.Enqueue(cur)
+			}
+		},
+	})
+	return c
+}
+
+// Run waits for the initial sync and processes keys in the queue until
+// signaled.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	wait.PollUntil(5*time.Second, func() (bool, error) {
+		c.logger.V(2).Info("Waiting for initial sync")
+		return c.HasSynced(), nil
+	}, stopCh)
+
+	c.logger.V(2).Info("Starting controller", "kind", c.Kind)
+	defer func() {
+		c.logger.V(2).Info("Shutting down controller", "kind", c.Kind)
+		c.queue.ShutDown()
+	}()
+
+	go wait.Until(c.worker, time.Second, stopCh)
+	if c.GCPeriod > 0 {
+		go wait.Until(c.gc, c.GCPeriod, stopCh)
+	}
+
+	<-stopCh
+}
+
+// Enqueue adds the key of the given custom resource to the queue.
+func (c *Controller) Enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		c.logger.Error(err, "Failed to generate key", "kind", c.Kind)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// worker keeps processing keys in the queue until the queue is shut down.
+func (c *Controller) worker() {
+	for {
+		key, quit := c.queue.Get()
+		if quit {
+			return
+		}
+		err := c.Sync(key.(string))
+		c.handleErr(err, key.(string))
+		c.queue.Done(key)
+	}
+}
+
+// handleErr requeues the key and reports the error as an event on the custom
+// resource. Keys of custom resources whose GCE resources are still in use
+// are not requeued.
+func (c *Controller) handleErr(err error, key string) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+	var obj Object
+	if item, exists, getErr := c.lister.GetByKey(key); getErr != nil {
+		c.logger.Info("Failed to retrieve object from the store", "kind", c.Kind, "key", key, "err", getErr)
+	} else if exists {
+		obj = item.(Object)
+	}
+
+	var inUseErr *InUseError
+	if errors.As(err, &inUseErr) {
+		c.logger.Info("GCE resource is still in use, deferring deletion", "kind", c.Kind, "key", key, "resource", inUseErr.Resource)
+		if obj != nil {
+			c.Recorder(obj.GetNamespace()).Eventf(obj, v1.EventTypeWarning, c.Kind+"InUse", "%s is still used by a url map and will be deleted once no Ingress references %s %s", inUseErr.Resource, c.Kind, obj.GetName())
+		}
+		c.queue.Forget(key)
+		return
+	}
+
+	eventMsg := fmt.Sprintf("error processing %s %q: %q", c.Kind, key, err)
+	c.logger.Error(err, eventMsg)
+	if obj != nil {
+		c.Recorder(obj.GetNamespace()).Eventf(obj, v1.EventTypeWarning, "Process"+c.Kind+"Failed", eventMsg)
+	}
+	c.queue.AddRateLimited(key)
+}
+
+// gc enqueues the custom resources that are pending deletion, so that their
+// GCE resources are deleted once Ingresses no longer reference them, and runs
+// the GC function of the controller.
+func (c *Controller) gc() {
+	for _, item := range c.lister.List() {
+		if obj := item.(Object); obj.GetDeletionTimestamp() != nil {
+			c.Enqueue(obj)
+		}
+	}
+	if c.GC == nil {
+		return
+	}
+	if err := c.GC(); err != nil {
+		c.logger.Error(err, "Failed to garbage collect GCE resources", "kind", c.Kind)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crcontroller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+func newTestController(t *testing.T, recorder *record.FakeRecorder, objs ...*v1.ConfigMap) *Controller {
+	t.Helper()
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &v1.ConfigMap{}, 0, cache.Indexers{})
+	for _, obj := range objs {
+		if err := informer.GetIndexer().Add(obj); err != nil {
+			t.Fatalf("Add(%s) = %v", obj.Name, err)
+		}
+	}
+	return New(Config{
+		Kind:      "ConfigMap",
+		Informer:  informer,
+		Recorder:  func(string) record.EventRecorder { return recorder },
+		HasSynced: func() bool { return true },
+		Sync:      func(string) error { return nil },
+		GCPeriod:  time.Minute,
+	}, klog.TODO())
+}
+
+func TestHandleErr(t *testing.T) {
+	obj := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cm"}}
+	key := "ns/cm"
+
+	for _, tc := range []struct {
+		desc        string
+		err         error
+		wantRequeue bool
+		wantReason  string
+	}{
+		{
+			desc: "success",
+		},
+		{
+			desc:        "error",
+			err:         fmt.Errorf("failed"),
+			wantRequeue: true,
+			wantReason:  "ProcessConfigMapFailed",
+		},
+		{
+			desc:       "in use",
+			err:        fmt.Errorf("wrapped: %w", &InUseError{Resource: "Backend bucket bb", Err: fmt.Errorf("in use")}),
+			wantReason: "ConfigMapInUse",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			c := newTestController(t, recorder, obj)
+			c.handleErr(tc.err, key)
+			if got := c.queue.NumRequeues(key); (got > 0) != tc.wantRequeue {
+				t.Errorf("NumRequeues(%s) = %d, want requeue? %v", key, got, tc.wantRequeue)
+			}
+			select {
+			case event := <-recorder.Events:
+				if tc.wantReason == "" || !strings.Contains(event, tc.wantReason) {
+					t.Errorf("Got event %q, want reason %q", event, tc.wantReason)
+				}
+			default:
+				if tc.wantReason != "" {
+					t.Errorf("Got no event, want reason %q", tc.wantReason)
+				}
+			}
+		})
+	}
+}
+
+func TestGCEnqueuesObjectsPendingDeletion(t *testing.T) {
+	now := metav1.Now()
+	c := newTestController(t, record.NewFakeRecorder(10),
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "live"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "deleting", DeletionTimestamp: &now, Finalizers: []string{"finalizer"}}},
+	)
+	gcCalled := false
+	c.GC = func() error {
+		gcCalled = true
+		return nil
+	}
+	c.gc()
+	if !gcCalled {
+		t.Errorf("GC was not called")
+	}
+	if got := c.queue.Len(); got != 1 {
+		t.Fatalf("queue.Len() = %d, want 1", got)
+	}
+	if key, _ := c.queue.Get(); key != "ns/deleting" {
+		t.Errorf("Got key %v, want ns/deleting", key)
+	}
+}
@@ -0,0 +1,39 @@
+package operator
+
+import (
+	v1 "k8s.io/api/networking/v1"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// doesIngressReferenceBackendBucket returns true if the passed in Ingress
+// references the passed in BackendBucket through a resource backend.
+func doesIngressReferenceBackendBucket(ing *v1.Ingress, bb *backendbucketv1beta1.BackendBucket) bool {
+	if ing.Namespace != bb.Namespace {
+		return false
+	}
+	return doesIngressReferenceResource(ing, bb.Name, utils.BackendToBackendBucketName)
+}
+
+// doesIngressReferenceResource returns true if one of the backends of the
+// passed in Ingress references a resource with the given name. resourceName
+// returns the name of the resource referenced by a backend, or false if the
+// backend does not reference a resource of the expected kind.
+func doesIngressReferenceResource(ing *v1.Ingress, name string, resourceName func(v1.IngressBackend) (string, bool)) bool {
+	if ing.Spec.DefaultBackend != nil {
+		if n, ok := resourceName(*ing.Spec.DefaultBackend); ok && n == name {
+			return true
+		}
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if n, ok := resourceName(path.Backend); ok && n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
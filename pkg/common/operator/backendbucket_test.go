@@ -0,0 +1,82 @@
+package operator
+
+import (
+	"testing"
+
+	api_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/apis/backendbucket"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+)
+
+func TestDoesIngressReferenceBackendBucket(t *testing.T) {
+	t.Parallel()
+
+	bb := &backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "static"},
+	}
+	apiGroup := backendbucket.GroupName
+	resourceBackend := func(kind, name string) v1.IngressBackend {
+		return v1.IngressBackend{
+			Resource: &api_v1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: kind, Name: name},
+		}
+	}
+	ingressWithPath := func(namespace string, backend v1.IngressBackend) *v1.Ingress {
+		return &v1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ing"},
+			Spec: v1.IngressSpec{
+				Rules: []v1.IngressRule{{
+					IngressRuleValue: v1.IngressRuleValue{
+						HTTP: &v1.HTTPIngressRuleValue{
+							Paths: []v1.HTTPIngressPath{{Path: "/static", Backend: backend}},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		ing      *v1.Ingress
+		expected bool
+	}{
+		{
+			desc: "ingress with service backend",
+			ing: ingressWithPath("test", v1.IngressBackend{
+				Service: &v1.IngressServiceBackend{Name: "static", Port: v1.ServiceBackendPort{Number: 80}},
+			}),
+			expected: false,
+		},
+		{
+			desc:     "ingress with other backend bucket",
+			ing:      ingressWithPath("test", resourceBackend(backendbucket.Kind, "other")),
+			expected: false,
+		},
+		{
+			desc:     "ingress with other resource kind",
+			ing:      ingressWithPath("test", resourceBackend("StorageBucket", "static")),
+			expected: false,
+		},
+		{
+			desc:     "ingress in different namespace",
+			ing:      ingressWithPath("other", resourceBackend(backendbucket.Kind, "static")),
+			expected: false,
+		},
+		{
+			desc:     "ingress with expected backend bucket",
+			ing:      ingressWithPath("test", resourceBackend(backendbucket.Kind, "static")),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := doesIngressReferenceBackendBucket(tc.ing, bb)
+			if result != tc.expected {
+				t.Fatalf("Expected result to be %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package operator
 import (
 	"fmt"
 
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/common/typed"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
@@ -57,14 +60,16 @@ func (op *IngressesOperator) Partition(f func(*v1.Ingress) bool) (*IngressesOper
 	return Ingresses(i), Ingresses(ci)
 }
 
-// ReferencesService returns the Ingresses that references the given Service.
-func (op *IngressesOperator) ReferencesService(svc *api_v1.Service) *IngressesOperator {
+// ReferencesService returns the Ingresses that references the given Service,
+// either directly or through the route rules of the FrontendConfig they
+// reference among feConfigs.
+func (op *IngressesOperator) ReferencesService(svc *api_v1.Service, feConfigs []*frontendconfigv1beta1.FrontendConfig) *IngressesOperator {
 	dupes := map[string]bool{}
 
 	var i []*v1.Ingress
 	for _, ing := range op.i {
 		key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
-		if doesIngressReferenceService(ing, svc) && !dupes[key] {
+		if doesIngressReferenceService(ing, feConfigs, svc) && !dupes[key] {
 			i = append(i, ing)
 			dupes[key] = true
 		}
@@ -73,7 +78,8 @@ func (op *IngressesOperator) ReferencesService(svc *api_v1.Service) *IngressesOp
 }
 
 // ReferencesBackendConfig returns the Ingresses that references the given BackendConfig.
-func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.BackendConfig, svcsOp *ServicesOperator) *IngressesOperator {
+// feConfigs are the FrontendConfigs whose route rules may reference Services.
+func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.BackendConfig, svcsOp *ServicesOperator, feConfigs []*frontendconfigv1beta1.FrontendConfig) *IngressesOperator {
 	dupes := map[string]bool{}
 
 	var i []*v1.Ingress
@@ -81,7 +87,7 @@ func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.B
 	for _, ing := range op.i {
 		for _, svc := range svcs {
 			key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
-			if doesIngressReferenceService(ing, svc) && !dupes[key] {
+			if doesIngressReferenceService(ing, feConfigs, svc) && !dupes[key] {
 				i = append(i, ing)
 				dupes[key] = true
 			}
@@ -105,13 +111,72 @@ func (op *IngressesOperator) ReferencesFrontendConfig(feConfig *frontendconfigv1
 	return Ingresses(i)
 }
 
+// ReferencesBackendBucket returns the Ingresses that reference the given BackendBucket.
+func (op *IngressesOperator) ReferencesBackendBucket(bb *backendbucketv1beta1.BackendBucket) *IngressesOperator {
+	dupes := map[string]bool{}
+
+	var i []*v1.Ingress
+	for _, ing := range op.i {
+		key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
+		if doesIngressReferenceBackendBucket(ing, bb) && !dupes[key] {
+			i = append(i, ing)
+			dupes[key] = true
+		}
+	}
+	return Ingresses(i)
+}
+
+// ReferencesServerlessNEG returns the Ingresses that reference the given ServerlessNEG.
+func (op *IngressesOperator) ReferencesServerlessNEG(sneg *serverlessnegv1beta1.ServerlessNEG) *IngressesOperator {
+	dupes := map[string]bool{}
+
+	var i []*v1.Ingress
+	for _, ing := range op.i {
+		key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
+		if doesIngressReferenceServerlessNEG(ing, sneg) && !dupes[key] {
+			i = append(i, ing)
+			dupes[key] = true
+		}
+	}
+	return Ingresses(i)
+}
+
+// ReferencesStaticAddress returns the Ingresses that reference the given StaticAddress.
+func (op *IngressesOperator) ReferencesStaticAddress(sa *staticaddressv1beta1.StaticAddress) *IngressesOperator {
+	var i []*v1.Ingress
+	for _, ing := range op.i {
+		if doesIngressReferenceStaticAddress(ing, sa) {
+			i = append(i, ing)
+		}
+	}
+	return Ingresses(i)
+}
+
+// ReferencesIngressClass returns the Ingresses that select one of the given
+// IngressClasses through spec.ingressClassName.
+func (op *IngressesOperator) ReferencesIngressClass(classNames ...string) *IngressesOperator {
+	names := map[string]bool{}
+	for _, name := range classNames {
+		names[name] = true
+	}
+
+	var i []*v1.Ingress
+	for _, ing := range op.i {
+		if ing.Spec.IngressClassName != nil && names[*ing.Spec.IngressClassName] {
+			i = append(i, ing)
+		}
+	}
+	return Ingresses(i)
+}
+
 // ReferencesSvcNeg returns the Ingresses that reference the NEGs in the given NEG CR.
-func (op *IngressesOperator) ReferencesSvcNeg(negCr *negv1beta1.ServiceNetworkEndpointGroup, serviceCache *typed.ServiceStore) *IngressesOperator {
+// feConfigs are the FrontendConfigs whose route rules may reference Services.
+func (op *IngressesOperator) ReferencesSvcNeg(negCr *negv1beta1.ServiceNetworkEndpointGroup, serviceCache *typed.ServiceStore, feConfigs []*frontendconfigv1beta1.FrontendConfig) *IngressesOperator {
 	svcName := negCr.GetLabels()[negtypes.NegCRServiceNameKey]
 	svc, exists, err := serviceCache.GetByKey(utils.ServiceKeyFunc(negCr.Namespace, svcName))
 	if err != nil || !exists {
 		return &IngressesOperator{}
 	}
 
-	return op.ReferencesService(svc)
+	return op.ReferencesService(svc, feConfigs)
 }
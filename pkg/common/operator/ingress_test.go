@@ -57,7 +57,7 @@ func TestReferencesSvcNeg(t *testing.T) {
 		HealthCheckPath:               "/",
 		EnableIngressRegionalExternal: true,
 	}
-	ctx := context.NewControllerContext(kubeClient, nil, nil, nil, nil, nil, nil, nil, kubeClient /*kube client to be used for events*/, gceClient, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
+	ctx := context.NewControllerContext(kubeClient, nil, nil, nil, nil, nil, nil, nil, context.ExtensionClients{}, kubeClient /*kube client to be used for events*/, gceClient, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
 
 	if err := addTestService(ctx); err != nil {
 		t.Fatalf("Failed to add test service: %v", err)
@@ -100,7 +100,7 @@ func TestReferencesSvcNeg(t *testing.T) {
 				Status: negv1beta1.ServiceNetworkEndpointGroupStatus{},
 			}
 
-			gotIngresses := ingOperator.ReferencesSvcNeg(testServiceNeg, ctx.Services()).AsList()
+			gotIngresses := ingOperator.ReferencesSvcNeg(testServiceNeg, ctx.Services(), nil).AsList()
 			if len(gotIngresses) != tc.expectedIngressCount {
 				t.Errorf("Got %d matching ingress, expected %d", len(gotIngresses), tc.expectedIngressCount)
 			}
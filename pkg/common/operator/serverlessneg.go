@@ -0,0 +1,16 @@
+package operator
+
+import (
+	v1 "k8s.io/api/networking/v1"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// doesIngressReferenceServerlessNEG returns true if the passed in Ingress
+// references the passed in ServerlessNEG through a resource backend.
+func doesIngressReferenceServerlessNEG(ing *v1.Ingress, sneg *serverlessnegv1beta1.ServerlessNEG) bool {
+	if ing.Namespace != sneg.Namespace {
+		return false
+	}
+	return doesIngressReferenceResource(ing, sneg.Name, utils.BackendToServerlessNEGName)
+}
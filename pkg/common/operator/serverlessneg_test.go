@@ -0,0 +1,67 @@
+package operator
+
+import (
+	"testing"
+
+	api_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/apis/backendbucket"
+	"k8s.io/ingress-gce/pkg/apis/serverlessneg"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+)
+
+func TestDoesIngressReferenceServerlessNEG(t *testing.T) {
+	t.Parallel()
+
+	sneg := &serverlessnegv1beta1.ServerlessNEG{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "run"},
+	}
+	apiGroup := serverlessneg.GroupName
+	ingressWithDefaultBackend := func(namespace, kind, name string) *v1.Ingress {
+		return &v1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ing"},
+			Spec: v1.IngressSpec{
+				DefaultBackend: &v1.IngressBackend{
+					Resource: &api_v1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: kind, Name: name},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		ing      *v1.Ingress
+		expected bool
+	}{
+		{
+			desc:     "ingress with backend bucket of the same name",
+			ing:      ingressWithDefaultBackend("test", backendbucket.Kind, "run"),
+			expected: false,
+		},
+		{
+			desc:     "ingress with other serverless NEG",
+			ing:      ingressWithDefaultBackend("test", serverlessneg.Kind, "other"),
+			expected: false,
+		},
+		{
+			desc:     "ingress in different namespace",
+			ing:      ingressWithDefaultBackend("other", serverlessneg.Kind, "run"),
+			expected: false,
+		},
+		{
+			desc:     "ingress with expected serverless NEG",
+			ing:      ingressWithDefaultBackend("test", serverlessneg.Kind, "run"),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := doesIngressReferenceServerlessNEG(tc.ing, sneg)
+			if result != tc.expected {
+				t.Fatalf("Expected result to be %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"k8s.io/klog/v2"
 
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
 
 	api_v1 "k8s.io/api/core/v1"
@@ -47,13 +48,14 @@ func (op *ServicesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.Ba
 }
 
 // ReferencedByIngress returns the Services that are referenced by the passed in Ingress.
-func (op *ServicesOperator) ReferencedByIngress(ing *v1.Ingress) *ServicesOperator {
+// feConfigs are the FrontendConfigs whose route rules may reference Services.
+func (op *ServicesOperator) ReferencedByIngress(ing *v1.Ingress, feConfigs []*frontendconfigv1beta1.FrontendConfig) *ServicesOperator {
 	dupes := map[string]bool{}
 
 	var s []*api_v1.Service
 	for _, svc := range op.s {
 		key := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
-		if doesIngressReferenceService(ing, svc) && !dupes[key] {
+		if doesIngressReferenceService(ing, feConfigs, svc) && !dupes[key] {
 			s = append(s, svc)
 			dupes[key] = true
 		}
@@ -61,15 +63,20 @@ func (op *ServicesOperator) ReferencedByIngress(ing *v1.Ingress) *ServicesOperat
 	return Services(s, op.logger)
 }
 
-// doesIngressReferenceService returns true if the passed in Ingress directly references
-// the passed in Service.
-func doesIngressReferenceService(ing *v1.Ingress, svc *api_v1.Service) bool {
+// doesIngressReferenceService returns true if the passed in Ingress references
+// the passed in Service, either directly or through the route rules of the
+// FrontendConfig it references among feConfigs.
+func doesIngressReferenceService(ing *v1.Ingress, feConfigs []*frontendconfigv1beta1.FrontendConfig, svc *api_v1.Service) bool {
 	if ing.Namespace != svc.Namespace {
 		return false
 	}
 
+	var feConfig *frontendconfigv1beta1.FrontendConfig
+	if matches := FrontendConfigs(feConfigs).ReferencedByIngress(ing).AsList(); len(matches) > 0 {
+		feConfig = matches[0]
+	}
 	doesReference := false
-	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+	utils.TraverseIngressBackends(ing, feConfig, func(id utils.ServicePortID) bool {
 		if id.Service.Name == svc.Name {
 			doesReference = true
 			return true
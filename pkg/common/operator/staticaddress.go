@@ -0,0 +1,16 @@
+package operator
+
+import (
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
+)
+
+// doesIngressReferenceStaticAddress returns true if the passed in Ingress
+// references the passed in StaticAddress through its annotation.
+func doesIngressReferenceStaticAddress(ing *v1.Ingress, sa *staticaddressv1beta1.StaticAddress) bool {
+	if ing.Namespace != sa.Namespace {
+		return false
+	}
+	return annotations.FromIngress(ing).StaticAddress() == sa.Name
+}
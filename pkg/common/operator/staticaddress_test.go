@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
+)
+
+func TestDoesIngressReferenceStaticAddress(t *testing.T) {
+	t.Parallel()
+
+	sa := &staticaddressv1beta1.StaticAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "ip"},
+	}
+	ingressWithAnnotations := func(namespace string, annotations map[string]string) *v1.Ingress {
+		return &v1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ing", Annotations: annotations},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		ing      *v1.Ingress
+		expected bool
+	}{
+		{
+			desc:     "ingress without annotation",
+			ing:      ingressWithAnnotations("test", nil),
+			expected: false,
+		},
+		{
+			desc:     "ingress with static IP name of the same name",
+			ing:      ingressWithAnnotations("test", map[string]string{annotations.GlobalStaticIPNameKey: "ip"}),
+			expected: false,
+		},
+		{
+			desc:     "ingress with other static address",
+			ing:      ingressWithAnnotations("test", map[string]string{annotations.StaticAddressKey: "other"}),
+			expected: false,
+		},
+		{
+			desc:     "ingress in different namespace",
+			ing:      ingressWithAnnotations("other", map[string]string{annotations.StaticAddressKey: "ip"}),
+			expected: false,
+		},
+		{
+			desc:     "ingress with expected static address",
+			ing:      ingressWithAnnotations("test", map[string]string{annotations.StaticAddressKey: "ip"}),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := doesIngressReferenceStaticAddress(tc.ing, sa)
+			if result != tc.expected {
+				t.Fatalf("Expected result to be %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
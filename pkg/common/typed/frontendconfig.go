@@ -31,8 +31,12 @@ func (s *FrontendConfigStore) Delete(b *frontendconfigv1beta1.FrontendConfig) er
 	return s.store.Delete(b)
 }
 
-// List implements Store.
+// List implements Store. It returns nil if the FrontendConfig CRD is not
+// enabled.
 func (s *FrontendConfigStore) List() []*frontendconfigv1beta1.FrontendConfig {
+	if s.store == nil {
+		return nil
+	}
 	var ret []*frontendconfigv1beta1.FrontendConfig
 	for _, obj := range s.store.List() {
 		ret = append(ret, obj.(*frontendconfigv1beta1.FrontendConfig))
@@ -18,9 +18,11 @@ limitations under the License.
 package composite
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	computealpha "google.golang.org/api/compute/v0.alpha"
 	computebeta "google.golang.org/api/compute/v0.beta"
@@ -154,6 +156,23 @@ func SetSslCertificateForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, ta
 	}
 }
 
+// SetCertificateMapForTargetHttpsProxy() sets the Certificate Manager certificate map for a target https proxy
+func SetCertificateMapForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, certificateMapLink string, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("TargetHttpsProxy", "set_certificate_map", key.Region, key.Zone, string(meta.VersionGA))
+
+	// Set name in case it is not present in the key
+	key.Name = targetHttpsProxy.Name
+	logger.V(3).Info("setting CertificateMap for TargetHttpsProxy", "key", key)
+
+	if key.Type() == meta.Regional {
+		return fmt.Errorf("SetCertificateMap() is not supported for regional Target Https Proxies")
+	}
+	req := &compute.TargetHttpsProxiesSetCertificateMapRequest{CertificateMap: certificateMapLink}
+	return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetCertificateMap(ctx, key, req))
+}
+
 // SetSslPolicyForTargetHttpsProxy() sets the url map for a target proxy
 func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, SslPolicyLink string, logger klog.Logger) error {
 	ctx, cancel := cloud.ContextWithCallTimeout()
@@ -305,6 +324,158 @@ func SetSecurityPolicy(gceCloud *gce.Cloud, backendService *BackendService, secu
 	}
 }
 
+// SetEdgeSecurityPolicy sets the cloud armor edge security policy for a
+// backend service. An empty security policy detaches the edge security policy.
+// The cloud provider does not wrap this method, so the compute API is called
+// directly, throttled by rl, and the operation is waited on explicitly.
+func SetEdgeSecurityPolicy(gceCloud *gce.Cloud, rl cloud.RateLimiter, backendService *BackendService, edgeSecurityPolicy string, logger klog.Logger) error {
+	key := meta.GlobalKey(backendService.Name)
+	if backendService.Scope != meta.Global {
+		return fmt.Errorf("cloud armor edge security policies not supported for %s backend service %s", backendService.Scope, backendService.Name)
+	}
+
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendService", "set_edge_security_policy", key.Region, key.Zone, string(meta.VersionGA))
+	logger.V(3).Info("setting edge security policy for backend service", "key", key)
+
+	ref := &compute.SecurityPolicyReference{}
+	if edgeSecurityPolicy != "" {
+		ref.SecurityPolicy = cloud.SelfLink(meta.VersionGA, gceCloud.ProjectID(), "securityPolicies", meta.GlobalKey(edgeSecurityPolicy))
+	}
+	err := rateLimited(ctx, gceCloud, rl, "BackendServices", "SetEdgeSecurityPolicy", func() error {
+		op, err := gceCloud.ComputeServices().GA.BackendServices.SetEdgeSecurityPolicy(gceCloud.ProjectID(), key.Name, ref).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		return waitGlobalOperation(ctx, gceCloud, op)
+	})
+	return mc.Observe(err)
+}
+
+// GetBackendBucket returns the global backend bucket with the given name.
+// Backend buckets are not wrapped by the cloud provider, so the GA compute
+// API is called directly, throttled by rl.
+func GetBackendBucket(gceCloud *gce.Cloud, rl cloud.RateLimiter, name string, logger klog.Logger) (*compute.BackendBucket, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "get", "", "", string(meta.VersionGA))
+	logger.V(3).Info("getting BackendBucket", "name", name)
+
+	var bb *compute.BackendBucket
+	err := rateLimited(ctx, gceCloud, rl, "BackendBuckets", "Get", func() error {
+		var err error
+		bb, err = gceCloud.ComputeServices().GA.BackendBuckets.Get(gceCloud.ProjectID(), name).Context(ctx).Do()
+		return err
+	})
+	return bb, mc.Observe(err)
+}
+
+// ListBackendBuckets returns all global backend buckets of the project.
+func ListBackendBuckets(gceCloud *gce.Cloud, rl cloud.RateLimiter, logger klog.Logger) ([]*compute.BackendBucket, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "list", "", "", string(meta.VersionGA))
+	logger.V(3).Info("listing BackendBuckets")
+
+	var bbs []*compute.BackendBucket
+	err := rateLimited(ctx, gceCloud, rl, "BackendBuckets", "List", func() error {
+		return gceCloud.ComputeServices().GA.BackendBuckets.List(gceCloud.ProjectID()).Pages(ctx, func(page *compute.BackendBucketList) error {
+			bbs = append(bbs, page.Items...)
+			return nil
+		})
+	})
+	return bbs, mc.Observe(err)
+}
+
+// CreateBackendBucket creates the given global backend bucket.
+func CreateBackendBucket(gceCloud *gce.Cloud, rl cloud.RateLimiter, backendBucket *compute.BackendBucket, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "create", "", "", string(meta.VersionGA))
+	logger.V(3).Info("creating BackendBucket", "name", backendBucket.Name)
+
+	err := rateLimited(ctx, gceCloud, rl, "BackendBuckets", "Insert", func() error {
+		op, err := gceCloud.ComputeServices().GA.BackendBuckets.Insert(gceCloud.ProjectID(), backendBucket).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		return waitGlobalOperation(ctx, gceCloud, op)
+	})
+	return mc.Observe(err)
+}
+
+// UpdateBackendBucket updates the given global backend bucket.
+func UpdateBackendBucket(gceCloud *gce.Cloud, rl cloud.RateLimiter, backendBucket *compute.BackendBucket, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "update", "", "", string(meta.VersionGA))
+	logger.V(3).Info("updating BackendBucket", "name", backendBucket.Name)
+
+	err := rateLimited(ctx, gceCloud, rl, "BackendBuckets", "Update", func() error {
+		op, err := gceCloud.ComputeServices().GA.BackendBuckets.Update(gceCloud.ProjectID(), backendBucket.Name, backendBucket).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		return waitGlobalOperation(ctx, gceCloud, op)
+	})
+	return mc.Observe(err)
+}
+
+// DeleteBackendBucket deletes the global backend bucket with the given name.
+func DeleteBackendBucket(gceCloud *gce.Cloud, rl cloud.RateLimiter, name string, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "delete", "", "", string(meta.VersionGA))
+	logger.V(3).Info("deleting BackendBucket", "name", name)
+
+	err := rateLimited(ctx, gceCloud, rl, "BackendBuckets", "Delete", func() error {
+		op, err := gceCloud.ComputeServices().GA.BackendBuckets.Delete(gceCloud.ProjectID(), name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		return waitGlobalOperation(ctx, gceCloud, op)
+	})
+	return mc.Observe(err)
+}
+
+// waitGlobalOperation waits for the given global operation to complete and
+// returns its error, if any.
+func waitGlobalOperation(ctx context.Context, gceCloud *gce.Cloud, op *compute.Operation) error {
+	op, err := gceCloud.ComputeServices().GA.GlobalOperations.Wait(gceCloud.ProjectID(), op.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if op.Status != "DONE" {
+		return fmt.Errorf("operation %s on %s is %s", op.Name, op.TargetLink, op.Status)
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return fmt.Errorf("operation %s on %s failed: %s", op.Name, op.TargetLink, op.Error.Errors[0].Message)
+	}
+	return nil
+}
+
+// rateLimited calls fn once rl accepts the GA compute operation on service,
+// mirroring the throttling the cloud provider applies to the calls it wraps.
+// The result of fn is reported back to rl. A nil rl does not throttle.
+func rateLimited(ctx context.Context, gceCloud *gce.Cloud, rl cloud.RateLimiter, service, operation string, fn func() error) error {
+	if rl == nil {
+		return fn()
+	}
+	key := &cloud.RateLimitKey{
+		ProjectID: gceCloud.ProjectID(),
+		Operation: operation,
+		Version:   meta.VersionGA,
+		Service:   service,
+	}
+	if err := rl.Accept(ctx, key); err != nil {
+		return err
+	}
+	err := fn()
+	rl.Observe(ctx, err, key)
+	return err
+}
+
 func AddSignedUrlKey(gceCloud *gce.Cloud, key *meta.Key, backendService *BackendService, signedUrlKey *SignedUrlKey, logger klog.Logger) error {
 	ctx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
@@ -350,3 +521,177 @@ func DeleteSignedUrlKey(gceCloud *gce.Cloud, key *meta.Key, backendService *Back
 		return mc.Observe(gceCloud.Compute().BackendServices().DeleteSignedUrlKey(ctx, key, keyName))
 	}
 }
+
+// GetRegionalNetworkEndpointGroup returns the regional network endpoint group
+// with the given key. The generated NetworkEndpointGroup functions only
+// support zonal keys, while serverless network endpoint groups are regional.
+func GetRegionalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) (*NetworkEndpointGroup, error) {
+	if key.Type() != meta.Regional {
+		return nil, fmt.Errorf("Key %v not valid for regional resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "get", key.Region, key.Zone, string(meta.VersionGA))
+	logger.V(3).Info("Getting ga regional NetworkEndpointGroup", "name", key.Name)
+
+	ga, err := gceCloud.Compute().RegionNetworkEndpointGroups().Get(ctx, key)
+	if err = mc.Observe(err); err != nil {
+		return nil, err
+	}
+	neg, err := GAToNetworkEndpointGroup(ga)
+	if err != nil {
+		return nil, err
+	}
+	neg.Scope = meta.Regional
+	neg.Version = meta.VersionGA
+	return neg, nil
+}
+
+// CreateRegionalNetworkEndpointGroup creates the given regional network
+// endpoint group.
+func CreateRegionalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup, logger klog.Logger) error {
+	if key.Type() != meta.Regional {
+		return fmt.Errorf("Key %v not valid for regional resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "create", key.Region, key.Zone, string(meta.VersionGA))
+
+	ga, err := networkEndpointGroup.ToGA()
+	if err != nil {
+		return err
+	}
+	logger.Info("Creating ga regional NetworkEndpointGroup", "name", ga.Name)
+	return mc.Observe(gceCloud.Compute().RegionNetworkEndpointGroups().Insert(ctx, key, ga))
+}
+
+// DeleteRegionalNetworkEndpointGroup deletes the regional network endpoint
+// group with the given key.
+func DeleteRegionalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) error {
+	if key.Type() != meta.Regional {
+		return fmt.Errorf("Key %v not valid for regional resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "delete", key.Region, key.Zone, string(meta.VersionGA))
+	logger.Info("Deleting ga regional NetworkEndpointGroup", "name", key.Name)
+	return mc.Observe(gceCloud.Compute().RegionNetworkEndpointGroups().Delete(ctx, key))
+}
+
+// GetGlobalNetworkEndpointGroup returns the global network endpoint group
+// with the given key. Internet network endpoint groups are global, which the
+// generated NetworkEndpointGroup functions do not support.
+func GetGlobalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) (*NetworkEndpointGroup, error) {
+	if key.Type() != meta.Global {
+		return nil, fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "get", key.Region, key.Zone, string(meta.VersionGA))
+	logger.V(3).Info("Getting ga global NetworkEndpointGroup", "name", key.Name)
+
+	ga, err := gceCloud.Compute().GlobalNetworkEndpointGroups().Get(ctx, key)
+	if err = mc.Observe(err); err != nil {
+		return nil, err
+	}
+	neg, err := GAToNetworkEndpointGroup(ga)
+	if err != nil {
+		return nil, err
+	}
+	neg.Scope = meta.Global
+	neg.Version = meta.VersionGA
+	return neg, nil
+}
+
+// CreateGlobalNetworkEndpointGroup creates the given global network endpoint
+// group.
+func CreateGlobalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup, logger klog.Logger) error {
+	if key.Type() != meta.Global {
+		return fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "create", key.Region, key.Zone, string(meta.VersionGA))
+
+	ga, err := networkEndpointGroup.ToGA()
+	if err != nil {
+		return err
+	}
+	logger.Info("Creating ga global NetworkEndpointGroup", "name", ga.Name)
+	return mc.Observe(gceCloud.Compute().GlobalNetworkEndpointGroups().Insert(ctx, key, ga))
+}
+
+// DeleteGlobalNetworkEndpointGroup deletes the global network endpoint group
+// with the given key.
+func DeleteGlobalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) error {
+	if key.Type() != meta.Global {
+		return fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "delete", key.Region, key.Zone, string(meta.VersionGA))
+	logger.Info("Deleting ga global NetworkEndpointGroup", "name", key.Name)
+	return mc.Observe(gceCloud.Compute().GlobalNetworkEndpointGroups().Delete(ctx, key))
+}
+
+// AttachGlobalNetworkEndpoints attaches the network endpoints in req to the
+// global network endpoint group with the given key.
+func AttachGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, req *NetworkEndpointGroupsAttachEndpointsRequest, logger klog.Logger) error {
+	if key.Type() != meta.Global {
+		return fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "attach", key.Region, key.Zone, string(meta.VersionGA))
+
+	gaReq := &compute.GlobalNetworkEndpointGroupsAttachEndpointsRequest{}
+	if err := copyViaJSON(gaReq, req); err != nil {
+		return err
+	}
+	logger.Info("Attaching to ga global NetworkEndpointGroup", "name", key.Name)
+	return mc.Observe(gceCloud.Compute().GlobalNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, gaReq))
+}
+
+// DetachGlobalNetworkEndpoints detaches the network endpoints in req from the
+// global network endpoint group with the given key.
+func DetachGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, req *NetworkEndpointGroupsDetachEndpointsRequest, logger klog.Logger) error {
+	if key.Type() != meta.Global {
+		return fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "detach", key.Region, key.Zone, string(meta.VersionGA))
+
+	gaReq := &compute.GlobalNetworkEndpointGroupsDetachEndpointsRequest{}
+	if err := copyViaJSON(gaReq, req); err != nil {
+		return err
+	}
+	logger.Info("Detaching from ga global NetworkEndpointGroup", "name", key.Name)
+	return mc.Observe(gceCloud.Compute().GlobalNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, gaReq))
+}
+
+// ListGlobalNetworkEndpoints lists the network endpoints in the global network
+// endpoint group with the given key. Global network endpoint groups do not
+// report health status.
+func ListGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) ([]*NetworkEndpointWithHealthStatus, error) {
+	if key.Type() != meta.Global {
+		return nil, fmt.Errorf("Key %v not valid for global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "list", key.Region, key.Zone, string(meta.VersionGA))
+	logger.Info("Listing ga global NetworkEndpointGroup", "name", key.Name)
+
+	gaObjs, err := gceCloud.Compute().GlobalNetworkEndpointGroups().ListNetworkEndpoints(ctx, key, filter.None)
+	if err = mc.Observe(err); err != nil {
+		return nil, err
+	}
+	compositeObjs, err := toNetworkEndpointWithHealthStatusList(gaObjs)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range compositeObjs {
+		obj.Version = meta.VersionGA
+	}
+	return compositeObjs, nil
+}
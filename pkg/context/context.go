@@ -24,11 +24,13 @@ import (
 	informernetwork "github.com/GoogleCloudPlatform/gke-networking-api/client/network/informers/externalversions/network/v1"
 	nodetopologyclient "github.com/GoogleCloudPlatform/gke-networking-api/client/nodetopology/clientset/versioned"
 	informernodetopology "github.com/GoogleCloudPlatform/gke-networking-api/client/nodetopology/informers/externalversions/nodetopology/v1"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	informers "k8s.io/client-go/informers"
 	informerv1 "k8s.io/client-go/informers/core/v1"
 	discoveryinformer "k8s.io/client-go/informers/discovery/v1"
@@ -41,6 +43,8 @@ import (
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	sav1 "k8s.io/ingress-gce/pkg/apis/serviceattachment/v1"
 	sav1beta1 "k8s.io/ingress-gce/pkg/apis/serviceattachment/v1beta1"
+	backendbucketclient "k8s.io/ingress-gce/pkg/backendbucket/client/clientset/versioned"
+	informerbackendbucket "k8s.io/ingress-gce/pkg/backendbucket/client/informers/externalversions/backendbucket/v1beta1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/cmconfig"
@@ -49,10 +53,18 @@ import (
 	"k8s.io/ingress-gce/pkg/flags"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 	informerfrontendconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/informers/externalversions/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/gateway"
+	"k8s.io/ingress-gce/pkg/ingparams"
+	ingparamsclient "k8s.io/ingress-gce/pkg/ingparams/client/clientset/versioned"
+	informeringparams "k8s.io/ingress-gce/pkg/ingparams/client/informers/externalversions/ingparams/v1beta1"
 	"k8s.io/ingress-gce/pkg/instancegroups"
 	"k8s.io/ingress-gce/pkg/metrics"
+	serverlessnegclient "k8s.io/ingress-gce/pkg/serverlessneg/client/clientset/versioned"
+	informerserverlessneg "k8s.io/ingress-gce/pkg/serverlessneg/client/informers/externalversions/serverlessneg/v1beta1"
 	serviceattachmentclient "k8s.io/ingress-gce/pkg/serviceattachment/client/clientset/versioned"
 	informerserviceattachment "k8s.io/ingress-gce/pkg/serviceattachment/client/informers/externalversions/serviceattachment/v1"
+	staticaddressclient "k8s.io/ingress-gce/pkg/staticaddress/client/clientset/versioned"
+	informerstaticaddress "k8s.io/ingress-gce/pkg/staticaddress/client/informers/externalversions/staticaddress/v1beta1"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	informersvcneg "k8s.io/ingress-gce/pkg/svcneg/client/informers/externalversions/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -78,6 +90,18 @@ type ControllerContext struct {
 	FirewallClient      firewallclient.Interface
 	EventRecorderClient kubernetes.Interface
 	NodeTopologyClient  nodetopologyclient.Interface
+	// GatewayClient is used to read and update Gateway API objects. It is
+	// nil when Gateway support is not enabled.
+	GatewayClient dynamic.Interface
+	// BackendBucketClient is used to manage BackendBucket CRs. It is nil
+	// when backend buckets are not enabled.
+	BackendBucketClient backendbucketclient.Interface
+	// ServerlessNEGClient is used to manage ServerlessNEG CRs. It is nil
+	// when serverless NEGs are not enabled.
+	ServerlessNEGClient serverlessnegclient.Interface
+	// StaticAddressClient is used to manage StaticAddress CRs. It is nil
+	// when static addresses are not enabled.
+	StaticAddressClient staticaddressclient.Interface
 
 	Cloud *gce.Cloud
 
@@ -102,6 +126,23 @@ type ControllerContext struct {
 	NetworkInformer          cache.SharedIndexInformer
 	GKENetworkParamsInformer cache.SharedIndexInformer
 	NodeTopologyInformer     cache.SharedIndexInformer
+	IngressClassInformer     cache.SharedIndexInformer
+	IngParamsInformer        cache.SharedIndexInformer
+	GatewayClassInformer     cache.SharedIndexInformer
+	GatewayInformer          cache.SharedIndexInformer
+	HTTPRouteInformer        cache.SharedIndexInformer
+	BackendBucketInformer    cache.SharedIndexInformer
+	ServerlessNEGInformer    cache.SharedIndexInformer
+	StaticAddressInformer    cache.SharedIndexInformer
+
+	// IngressClassResolver resolves the GCPIngressParams of Ingresses that
+	// use spec.ingressClassName. It is nil when IngressClass parameters are
+	// not enabled.
+	IngressClassResolver *ingparams.Resolver
+	// IngressClassifier determines the type of load balancer requested by an
+	// Ingress. It only considers the ingress.class annotation when
+	// IngressClass parameters are not enabled.
+	IngressClassifier utils.IngressClassifier
 
 	ControllerMetrics *metrics.ControllerMetrics
 
@@ -148,6 +189,20 @@ type ControllerContextConfig struct {
 	EnableL4NetLBNEGs             bool
 	EnableL4NetLBNEGsDefault      bool
 	EnableL4MixedProtocol         bool
+	EnableL4ILBMultipleFwdRules   bool
+	// CloudRateLimiter is the rate limiter configured on the cloud. It
+	// throttles the compute API calls that the cloud provider does not wrap.
+	CloudRateLimiter cloud.RateLimiter
+}
+
+// ExtensionClients holds the clients of optional API extensions. A nil client
+// disables the informers and the controllers that depend on it.
+type ExtensionClients struct {
+	IngParams     ingparamsclient.Interface
+	Gateway       dynamic.Interface
+	BackendBucket backendbucketclient.Interface
+	ServerlessNEG serverlessnegclient.Interface
+	StaticAddress staticaddressclient.Interface
 }
 
 // NewControllerContext returns a new shared set of informers.
@@ -160,6 +215,7 @@ func NewControllerContext(
 	saClient serviceattachmentclient.Interface,
 	networkClient networkclient.Interface,
 	nodeTopologyClient nodetopologyclient.Interface,
+	extClients ExtensionClients,
 	eventRecorderClient kubernetes.Interface,
 	cloud *gce.Cloud,
 	clusterNamer *namer.Namer,
@@ -187,6 +243,10 @@ func NewControllerContext(
 		SAClient:                saClient,
 		EventRecorderClient:     eventRecorderClient,
 		NodeTopologyClient:      nodeTopologyClient,
+		GatewayClient:           extClients.Gateway,
+		BackendBucketClient:     extClients.BackendBucket,
+		ServerlessNEGClient:     extClients.ServerlessNEG,
+		StaticAddressClient:     extClients.StaticAddress,
 		Cloud:                   cloud,
 		ClusterNamer:            clusterNamer,
 		L4Namer:                 namer.NewL4Namer(string(kubeSystemUID), clusterNamer),
@@ -219,6 +279,31 @@ func NewControllerContext(
 		context.GKENetworkParamsInformer = informernetwork.NewGKENetworkParamSetInformer(networkClient, config.ResyncPeriod, utils.NewNamespaceIndexer())
 	}
 
+	if extClients.IngParams != nil {
+		context.IngressClassInformer = informernetworking.NewIngressClassInformer(kubeClient, config.ResyncPeriod, utils.NewNamespaceIndexer())
+		context.IngParamsInformer = informeringparams.NewGCPIngressParamsInformer(extClients.IngParams, config.ResyncPeriod, utils.NewNamespaceIndexer())
+		context.IngressClassResolver = ingparams.NewResolver(context.IngressClassInformer.GetIndexer(), context.IngParamsInformer.GetIndexer())
+		context.IngressClassifier = utils.IngressClassifier{Params: context.IngressClassResolver.ParamsForIngress}
+	}
+
+	if extClients.Gateway != nil {
+		context.GatewayClassInformer = gateway.NewGatewayClassInformer(extClients.Gateway, config.ResyncPeriod)
+		context.GatewayInformer = gateway.NewGatewayInformer(extClients.Gateway, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+		context.HTTPRouteInformer = gateway.NewHTTPRouteInformer(extClients.Gateway, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
+
+	if extClients.BackendBucket != nil {
+		context.BackendBucketInformer = informerbackendbucket.NewBackendBucketInformer(extClients.BackendBucket, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
+
+	if extClients.ServerlessNEG != nil {
+		context.ServerlessNEGInformer = informerserverlessneg.NewServerlessNEGInformer(extClients.ServerlessNEG, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
+
+	if extClients.StaticAddress != nil {
+		context.StaticAddressInformer = informerstaticaddress.NewStaticAddressInformer(extClients.StaticAddress, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
+
 	if flags.F.GKEClusterType == ClusterTypeRegional {
 		context.RegionalCluster = true
 	}
@@ -243,8 +328,12 @@ func NewControllerContext(
 		context.NodeInformer,
 		context.PodInformer,
 		context.EndpointSliceInformer,
+		context.FrontendConfigInformer,
+		context.BackendBucketInformer,
+		context.ServerlessNEGInformer,
 		context.KubeClient,
 		context,
+		context.IngressClassifier,
 		flags.F.EnableTransparentHealthChecks,
 		context.EnableIngressRegionalExternal,
 		logger,
@@ -319,6 +408,15 @@ func (ctx *ControllerContext) HasSynced() bool {
 	if ctx.SAInformer != nil {
 		funcs = append(funcs, ctx.SAInformer.HasSynced)
 	}
+	if ctx.BackendBucketInformer != nil {
+		funcs = append(funcs, ctx.BackendBucketInformer.HasSynced)
+	}
+	if ctx.ServerlessNEGInformer != nil {
+		funcs = append(funcs, ctx.ServerlessNEGInformer.HasSynced)
+	}
+	if ctx.StaticAddressInformer != nil {
+		funcs = append(funcs, ctx.StaticAddressInformer.HasSynced)
+	}
 	if ctx.NetworkInformer != nil {
 		funcs = append(funcs, ctx.NetworkInformer.HasSynced)
 	}
@@ -329,6 +427,21 @@ func (ctx *ControllerContext) HasSynced() bool {
 	if ctx.FirewallInformer != nil {
 		funcs = append(funcs, ctx.FirewallInformer.HasSynced)
 	}
+	if ctx.IngressClassInformer != nil {
+		funcs = append(funcs, ctx.IngressClassInformer.HasSynced)
+	}
+	if ctx.IngParamsInformer != nil {
+		funcs = append(funcs, ctx.IngParamsInformer.HasSynced)
+	}
+	if ctx.GatewayClassInformer != nil {
+		funcs = append(funcs, ctx.GatewayClassInformer.HasSynced)
+	}
+	if ctx.GatewayInformer != nil {
+		funcs = append(funcs, ctx.GatewayInformer.HasSynced)
+	}
+	if ctx.HTTPRouteInformer != nil {
+		funcs = append(funcs, ctx.HTTPRouteInformer.HasSynced)
+	}
 
 	for _, f := range funcs {
 		if !f() {
@@ -407,6 +520,15 @@ func (ctx *ControllerContext) Start(stopCh <-chan struct{}) {
 	if ctx.SAInformer != nil {
 		go ctx.SAInformer.Run(stopCh)
 	}
+	if ctx.BackendBucketInformer != nil {
+		go ctx.BackendBucketInformer.Run(stopCh)
+	}
+	if ctx.ServerlessNEGInformer != nil {
+		go ctx.ServerlessNEGInformer.Run(stopCh)
+	}
+	if ctx.StaticAddressInformer != nil {
+		go ctx.StaticAddressInformer.Run(stopCh)
+	}
 	if ctx.NetworkInformer != nil {
 		go ctx.NetworkInformer.Run(stopCh)
 	}
@@ -416,6 +538,21 @@ func (ctx *ControllerContext) Start(stopCh <-chan struct{}) {
 	if ctx.NodeTopologyInformer != nil {
 		go ctx.NodeTopologyInformer.Run(stopCh)
 	}
+	if ctx.IngressClassInformer != nil {
+		go ctx.IngressClassInformer.Run(stopCh)
+	}
+	if ctx.IngParamsInformer != nil {
+		go ctx.IngParamsInformer.Run(stopCh)
+	}
+	if ctx.GatewayClassInformer != nil {
+		go ctx.GatewayClassInformer.Run(stopCh)
+	}
+	if ctx.GatewayInformer != nil {
+		go ctx.GatewayInformer.Run(stopCh)
+	}
+	if ctx.HTTPRouteInformer != nil {
+		go ctx.HTTPRouteInformer.Run(stopCh)
+	}
 	// Export ingress usage metrics.
 	go ctx.ControllerMetrics.Run(stopCh)
 }
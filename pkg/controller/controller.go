@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
@@ -33,8 +34,12 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	ingparamsv1beta1 "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/common/operator"
@@ -49,6 +54,7 @@ import (
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
 	"k8s.io/ingress-gce/pkg/metrics"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/staticaddress"
 	ingsync "k8s.io/ingress-gce/pkg/sync"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -96,6 +102,15 @@ type LoadBalancerController struct {
 	// Ingress sync + GC implementation
 	ingSyncer ingsync.Syncer
 
+	// gatewayQueue is the queue of Gateways. It is nil when Gateway support
+	// is not enabled.
+	gatewayQueue utils.TaskQueue
+	// gatewaySvcPorts caches the service ports of the load balancer of each
+	// Gateway, keyed by Gateway key, as of its last sync so that GC does not
+	// translate every Gateway. It is protected by gatewaySvcPortsLock.
+	gatewaySvcPorts     map[string][]utils.ServicePort
+	gatewaySvcPortsLock sync.Mutex
+
 	// Ingress usage metrics.
 	metrics metrics.IngressMetricsCollector
 
@@ -135,7 +150,7 @@ func NewLoadBalancerController(
 		stopCh:                         stopCh,
 		hasSynced:                      ctx.HasSynced,
 		instancePool:                   ctx.InstancePool,
-		l7Pool:                         loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID, logger), logger),
+		l7Pool:                         loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.CloudRateLimiter, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID, logger), ctx.IngressClassifier, logger),
 		backendSyncer:                  backends.NewBackendSyncer(backendPool, healthChecker, ctx.Cloud, ctx.Translator),
 		negLinker:                      backends.NewNEGLinker(backendPool, negtypes.NewAdapter(ctx.Cloud), ctx.Cloud, ctx.SvcNegInformer.GetIndexer(), logger),
 		igLinker:                       backends.NewInstanceGroupLinker(ctx.InstancePool, backendPool, logger),
@@ -145,7 +160,7 @@ func NewLoadBalancerController(
 		logger:                         logger,
 	}
 
-	lbc.ingSyncer = ingsync.NewIngressSyncer(&lbc, logger)
+	lbc.ingSyncer = ingsync.NewIngressSyncer(&lbc, ctx.IngressClassifier, logger)
 	lbc.ingQueue = utils.NewPeriodicTaskQueueWithMultipleWorkers("ingress", "ingresses", flags.F.NumIngressWorkers, lbc.sync, logger)
 
 	// Ingress event handlers.
@@ -153,7 +168,7 @@ func NewLoadBalancerController(
 		AddFunc: func(obj interface{}) {
 			addIng := obj.(*v1.Ingress)
 			ingLogger := logger.WithValues("ingressKey", common.NamespacedName(addIng))
-			if !utils.IsGLBCIngress(addIng) {
+			if !lbc.isGLBCIngress(addIng) {
 				if !flags.F.EnableIngressGlobalExternal && annotations.FromIngress(addIng).IngressClass() == annotations.GceIngressClass {
 					lbc.ctx.Recorder(addIng.Namespace).Eventf(addIng, apiv1.EventTypeWarning, events.SyncIngress, "Ingress class \"gce\" is not supported in this environment. Please use \"gce-regional-external\".")
 				}
@@ -177,7 +192,7 @@ func NewLoadBalancerController(
 				return
 			}
 
-			if !utils.IsGLBCIngress(delIng) {
+			if !lbc.isGLBCIngress(delIng) {
 				ingLogger.Info("Ignoring delete for ingress based on annotation", "annotation", annotations.IngressClassKey)
 				return
 			}
@@ -188,7 +203,7 @@ func NewLoadBalancerController(
 		UpdateFunc: func(old, cur interface{}) {
 			curIng := cur.(*v1.Ingress)
 			ingLogger := logger.WithValues("ingressKey", common.NamespacedName(curIng))
-			if !utils.IsGLBCIngress(curIng) {
+			if !lbc.isGLBCIngress(curIng) {
 				// Ingress needs to be enqueued if a ingress finalizer exists.
 				// An existing finalizer means that
 				// 1. Ingress update for class change.
@@ -218,13 +233,13 @@ func NewLoadBalancerController(
 	ctx.ServiceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			svc := obj.(*apiv1.Service)
-			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc).AsList()
+			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc, ctx.FrontendConfigs().List()).AsList()
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			if !reflect.DeepEqual(old, cur) {
 				svc := cur.(*apiv1.Service)
-				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc).AsList()
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc, ctx.FrontendConfigs().List()).AsList()
 				lbc.ingQueue.Enqueue(convert(ings)...)
 			}
 		},
@@ -236,14 +251,14 @@ func NewLoadBalancerController(
 		AddFunc: func(obj interface{}) {
 			logger.Info("obj added", "type", fmt.Sprintf("%T", obj))
 			beConfig := obj.(*backendconfigv1.BackendConfig)
-			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger)).AsList()
+			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger), ctx.FrontendConfigs().List()).AsList()
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			if !reflect.DeepEqual(old, cur) {
 				logger.Info("obj updated", "type", fmt.Sprintf("%T", cur))
 				beConfig := cur.(*backendconfigv1.BackendConfig)
-				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger)).AsList()
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger), ctx.FrontendConfigs().List()).AsList()
 				lbc.ingQueue.Enqueue(convert(ings)...)
 			}
 		},
@@ -267,7 +282,7 @@ func NewLoadBalancerController(
 				}
 			}
 
-			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger)).AsList()
+			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger), ctx.FrontendConfigs().List()).AsList()
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 	})
@@ -313,6 +328,175 @@ func NewLoadBalancerController(
 		})
 	}
 
+	// BackendBucket event handlers. Ingresses are resynced when the status of a
+	// BackendBucket they reference is updated with its compute BackendBucket.
+	if ctx.BackendBucketInformer != nil {
+		ctx.BackendBucketInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				bb := obj.(*backendbucketv1beta1.BackendBucket)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendBucket(bb).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					bb := cur.(*backendbucketv1beta1.BackendBucket)
+					logger.Info("BackendBucket updated", "backendBucketName", klog.KRef(bb.Namespace, bb.Name))
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendBucket(bb).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				bb, ok := obj.(*backendbucketv1beta1.BackendBucket)
+				if !ok {
+					// This can happen if the watch is closed and misses the delete event
+					state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+					if !stateOk {
+						logger.Error(nil, "Wanted cache.DeleteFinalStateUnknown of backendbucket obj", "got", fmt.Sprintf("%+v", obj), "gotType", fmt.Sprintf("%T", obj))
+						return
+					}
+					if bb, ok = state.Obj.(*backendbucketv1beta1.BackendBucket); !ok {
+						logger.Error(nil, "Wanted backendbucket obj", "got", fmt.Sprintf("%+v", state.Obj), "gotType", fmt.Sprintf("%T", state.Obj))
+						return
+					}
+				}
+
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendBucket(bb).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+	}
+
+	// ServerlessNEG event handlers. Ingresses are resynced when the status of a
+	// ServerlessNEG they reference is updated with its backend service.
+	if ctx.ServerlessNEGInformer != nil {
+		ctx.ServerlessNEGInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				sneg := obj.(*serverlessnegv1beta1.ServerlessNEG)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServerlessNEG(sneg).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					sneg := cur.(*serverlessnegv1beta1.ServerlessNEG)
+					logger.Info("ServerlessNEG updated", "serverlessNEGName", klog.KRef(sneg.Namespace, sneg.Name))
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServerlessNEG(sneg).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				sneg, ok := obj.(*serverlessnegv1beta1.ServerlessNEG)
+				if !ok {
+					// This can happen if the watch is closed and misses the delete event
+					state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+					if !stateOk {
+						logger.Error(nil, "Wanted cache.DeleteFinalStateUnknown of serverlessneg obj", "got", fmt.Sprintf("%+v", obj), "gotType", fmt.Sprintf("%T", obj))
+						return
+					}
+					if sneg, ok = state.Obj.(*serverlessnegv1beta1.ServerlessNEG); !ok {
+						logger.Error(nil, "Wanted serverlessneg obj", "got", fmt.Sprintf("%+v", state.Obj), "gotType", fmt.Sprintf("%T", state.Obj))
+						return
+					}
+				}
+
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServerlessNEG(sneg).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+	}
+
+	// StaticAddress event handlers. Ingresses are resynced when the status of a
+	// StaticAddress they reference is updated with its compute Address.
+	if ctx.StaticAddressInformer != nil {
+		ctx.StaticAddressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				sa := obj.(*staticaddressv1beta1.StaticAddress)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesStaticAddress(sa).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldSA := old.(*staticaddressv1beta1.StaticAddress)
+				curSA := cur.(*staticaddressv1beta1.StaticAddress)
+				if oldSA.Status.AddressName != curSA.Status.AddressName || oldSA.Status.Address != curSA.Status.Address {
+					logger.Info("StaticAddress updated", "staticAddressName", klog.KRef(curSA.Namespace, curSA.Name))
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesStaticAddress(curSA).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+		})
+	}
+
+	// IngressClass and GCPIngressParams event handlers.
+	if ctx.IngressClassResolver != nil {
+		ctx.IngressClassInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				ingClass := obj.(*v1.IngressClass)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClass.Name).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					ingClass := cur.(*v1.IngressClass)
+					logger.Info("IngressClass updated", "ingressClass", ingClass.Name)
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClass.Name).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				ingClass, ok := obj.(*v1.IngressClass)
+				if !ok {
+					// This can happen if the watch is closed and misses the delete event
+					state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+					if !stateOk {
+						logger.Error(nil, "Wanted cache.DeleteFinalStateUnknown of ingressclass obj", "got", fmt.Sprintf("%+v", obj), "gotType", fmt.Sprintf("%T", obj))
+						return
+					}
+					if ingClass, ok = state.Obj.(*v1.IngressClass); !ok {
+						logger.Error(nil, "Wanted ingressclass obj", "got", fmt.Sprintf("%+v", state.Obj), "gotType", fmt.Sprintf("%T", state.Obj))
+						return
+					}
+				}
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClass.Name).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+
+		ctx.IngParamsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				params := obj.(*ingparamsv1beta1.GCPIngressParams)
+				ingClasses := ctx.IngressClassResolver.IngressClassesForParams(params)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClasses...).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					params := cur.(*ingparamsv1beta1.GCPIngressParams)
+					logger.Info("GCPIngressParams updated", "gcpIngressParams", params.Name)
+					ingClasses := ctx.IngressClassResolver.IngressClassesForParams(params)
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClasses...).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				params, ok := obj.(*ingparamsv1beta1.GCPIngressParams)
+				if !ok {
+					// This can happen if the watch is closed and misses the delete event
+					state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+					if !stateOk {
+						logger.Error(nil, "Wanted cache.DeleteFinalStateUnknown of gcpingressparams obj", "got", fmt.Sprintf("%+v", obj), "gotType", fmt.Sprintf("%T", obj))
+						return
+					}
+					if params, ok = state.Obj.(*ingparamsv1beta1.GCPIngressParams); !ok {
+						logger.Error(nil, "Wanted gcpingressparams obj", "got", fmt.Sprintf("%+v", state.Obj), "gotType", fmt.Sprintf("%T", state.Obj))
+						return
+					}
+				}
+				ingClasses := ctx.IngressClassResolver.IngressClassesForParams(params)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesIngressClass(ingClasses...).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+	}
+
 	if enableMultiSubnetClusterPhase1 {
 		// SvcNeg event handlers.
 		ctx.SvcNegInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -322,7 +506,7 @@ func NewLoadBalancerController(
 
 				if !reflect.DeepEqual(oldSvcNeg.Status.NetworkEndpointGroups, newSvcNeg.Status.NetworkEndpointGroups) {
 					logger.Info("svcneg updated", "namespace", newSvcNeg.Namespace, "name", newSvcNeg.Name)
-					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesSvcNeg(newSvcNeg, ctx.Services()).AsList()
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesSvcNeg(newSvcNeg, ctx.Services(), ctx.FrontendConfigs().List()).AsList()
 					lbc.ingQueue.Enqueue(convert(ings)...)
 				}
 			},
@@ -330,6 +514,10 @@ func NewLoadBalancerController(
 		})
 	}
 
+	if ctx.GatewayInformer != nil {
+		lbc.initGateways()
+	}
+
 	// Register health check on controller context.
 	ctx.AddHealthCheck("ingress", func() error {
 		name := "k8s-ingress-svc-acct-permission-check-probe"
@@ -360,6 +548,9 @@ func (lbc *LoadBalancerController) Run() {
 	}()
 	lbc.logger.Info("Starting loadbalancer controller")
 	go lbc.ingQueue.Run()
+	if lbc.gatewayQueue != nil {
+		go lbc.gatewayQueue.Run()
+	}
 
 	<-lbc.stopCh
 	lbc.logger.Info("Shutting down Loadbalancer Controller")
@@ -376,12 +567,28 @@ func (lbc *LoadBalancerController) Stop() {
 	if !lbc.shutdown {
 		lbc.logger.Info("Shutting down controller queues.")
 		lbc.ingQueue.Shutdown()
+		if lbc.gatewayQueue != nil {
+			lbc.gatewayQueue.Shutdown()
+		}
 		lbc.shutdown = true
 	}
 }
 
 // SyncBackends implements Controller.
 func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klog.Logger) error {
+	ingLogger = ingLogger.WithName("SyncBackends")
+
+	// We expect state to be a syncState
+	syncState, ok := state.(*syncState)
+	if !ok {
+		return fmt.Errorf("expected state type to be syncState, type was %T", state)
+	}
+	return lbc.syncBackends(syncState.ing, syncState.urlMap.AllServicePorts(), ingLogger)
+}
+
+// syncBackends syncs the backend services of the given ingress and links
+// them to their instance groups or NEGs.
+func (lbc *LoadBalancerController) syncBackends(ing *v1.Ingress, ingSvcPorts []utils.ServicePort, ingLogger klog.Logger) error {
 	// TODO: Only lock per resource
 	// It is incredibly tricky to get an efficient synchronization method here.
 	// For now, we are effectively making backend syncing single-threaded to avoid
@@ -391,19 +598,11 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klo
 	// as being in an error state in the UI
 	lbc.backendLock.Lock()
 	defer lbc.backendLock.Unlock()
-	ingLogger = ingLogger.WithName("SyncBackends")
-
-	// We expect state to be a syncState
-	syncState, ok := state.(*syncState)
-	if !ok {
-		return fmt.Errorf("expected state type to be syncState, type was %T", state)
-	}
-	ingSvcPorts := syncState.urlMap.AllServicePorts()
 
 	// Only sync instance group when IG is used for this ingress
 	if len(nodePorts(ingSvcPorts)) > 0 {
-		if err := lbc.syncInstanceGroup(syncState.ing, ingSvcPorts, ingLogger); err != nil {
-			ingLogger.Error(err, "Failed to sync instance group", "ingress", syncState.ing)
+		if err := lbc.syncInstanceGroup(ing, ingSvcPorts, ingLogger); err != nil {
+			ingLogger.Error(err, "Failed to sync instance group", "ingress", ing)
 			return err
 		}
 	} else {
@@ -482,17 +681,44 @@ func (lbc *LoadBalancerController) syncInstanceGroup(ing *v1.Ingress, ingSvcPort
 	return nil
 }
 
+// isGLBCIngress returns true if the given Ingress should be processed by this
+// controller. Ingresses whose IngressClass parameters cannot be resolved are
+// processed as well, so that the error is surfaced when syncing them.
+func (lbc *LoadBalancerController) isGLBCIngress(ing *v1.Ingress) bool {
+	isGLBCIngress, err := lbc.ctx.IngressClassifier.IsGLBCIngress(ing)
+	return isGLBCIngress || err != nil
+}
+
+// isGCEIngress returns true if the given Ingress matches the class managed by
+// this controller. Ingresses whose IngressClass parameters cannot be resolved
+// are considered to match, so that their resources are not garbage collected.
+func (lbc *LoadBalancerController) isGCEIngress(ing *v1.Ingress) bool {
+	isGCEIngress, err := lbc.ctx.IngressClassifier.IsGCEIngress(ing)
+	return isGCEIngress || err != nil
+}
+
+// needsCleanup returns true if the given Ingress needs to have its associated
+// resources deleted. Ingresses whose IngressClass parameters cannot be
+// resolved are not cleaned up; the error is surfaced when syncing them.
+func (lbc *LoadBalancerController) needsCleanup(ing *v1.Ingress) bool {
+	needsCleanup, err := lbc.ctx.IngressClassifier.NeedsCleanup(ing)
+	return needsCleanup && err == nil
+}
+
 // GCBackends implements Controller.
 func (lbc *LoadBalancerController) GCBackends(toKeep []*v1.Ingress, ingLogger klog.Logger) error {
 	// Only GCE ingress associated resources are managed by this controller.
-	GCEIngresses := operator.Ingresses(toKeep).Filter(utils.IsGCEIngress).AsList()
+	GCEIngresses := operator.Ingresses(toKeep).Filter(lbc.isGCEIngress).AsList()
 	svcPortsToKeep := lbc.ToSvcPorts(GCEIngresses)
+	// Backends of Gateways are synced by the same backend pool.
+	gatewaySvcPorts := lbc.gatewayServicePorts()
+	svcPortsToKeep = append(svcPortsToKeep, gatewaySvcPorts...)
 	if err := lbc.backendSyncer.GC(svcPortsToKeep, ingLogger); err != nil {
 		return err
 	}
 	// TODO(ingress#120): Move this to the backend pool so it mirrors creation
-	// Do not delete instance group if there exists a GLBC ingress.
-	if len(toKeep) == 0 {
+	// Do not delete instance group if there exists a GLBC ingress or Gateway.
+	if len(toKeep) == 0 && len(gatewaySvcPorts) == 0 {
 		igName := lbc.ctx.ClusterNamer.InstanceGroup()
 		ingLogger.Info("Deleting instance group", "instanceGroup", igName)
 		if err := lbc.instancePool.DeleteInstanceGroup(igName, ingLogger); err != err {
@@ -591,8 +817,9 @@ func (lbc *LoadBalancerController) preSyncGC(key string, scope meta.KeyType, ing
 
 	allIngresses := lbc.ctx.Ingresses().List()
 	// Determine if the ingress needs to be GCed.
-	if !ingExists || utils.NeedsCleanup(ing) {
-		frontendGCAlgorithm := frontendGCAlgorithm(ingExists, false, ing, ingLogger)
+	needsCleanup := !ingExists || lbc.needsCleanup(ing)
+	if needsCleanup {
+		frontendGCAlgorithm := frontendGCAlgorithm(ingExists, false, needsCleanup, ing, ingLogger)
 		// GC will find GCE resources that were used for this ingress and delete them.
 		err := lbc.ingSyncer.GC(allIngresses, ing, frontendGCAlgorithm, scope, ingLogger)
 		// Skip emitting an event if ingress does not exist as we cannot retrieve ingress namespace.
@@ -644,7 +871,7 @@ func (lbc *LoadBalancerController) postSyncGC(key string, syncErr error, oldScop
 	// it could have been caused by quota issues; therefore, garbage collecting now may
 	// free up enough quota for the next sync to pass.
 	allIngresses := lbc.ctx.Ingresses().List()
-	frontendGCAlgorithm := frontendGCAlgorithm(ingExists, oldScope != nil, ing, ingLogger)
+	frontendGCAlgorithm := frontendGCAlgorithm(ingExists, oldScope != nil, ingExists && lbc.needsCleanup(ing), ing, ingLogger)
 	if gcErr := lbc.ingSyncer.GC(allIngresses, ing, frontendGCAlgorithm, newScope, ingLogger); gcErr != nil {
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.GarbageCollection, "Error during garbage collection: %v", gcErr)
 		return fmt.Errorf("error during sync %v, error during GC %v", syncErr, gcErr)
@@ -667,8 +894,14 @@ func (lbc *LoadBalancerController) sync(key string) error {
 		return fmt.Errorf("error getting Ingress for key %s: %v", key, err)
 	}
 
-	// Capture GC state for ingress.
-	scope := features.ScopeFromIngress(ing)
+	// Capture GC state for ingress. Surface IngressClass parameters that
+	// cannot be resolved instead of falling back to a global external load
+	// balancer.
+	scope, err := features.ScopeFromIngress(ing, lbc.ctx.IngressClassifier)
+	if err != nil {
+		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, "Error resolving IngressClass parameters: %v", err)
+		return err
+	}
 	needSync, err := lbc.preSyncGC(key, scope, ingExists, ing, ingLogger)
 	if err != nil {
 		return err
@@ -822,10 +1055,20 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1.Ingress, urlMap *utils.
 	if err != nil {
 		return nil, err
 	}
+	if name := annotations.StaticAddress(); name != "" && lbc.ctx.StaticAddressInformer != nil {
+		if staticIPName != "" {
+			return nil, utils.NewUserError(fmt.Errorf("both static-address and static-ip-name annotations cannot be specified"))
+		}
+		staticIPName, err = lbc.staticAddressName(ing, name)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &loadbalancers.L7RuntimeInfo{
 		TLS:            tls,
 		TLSName:        annotations.UseNamedTLS(),
+		CertificateMap: annotations.CertificateMap(),
 		Ingress:        ing,
 		AllowHTTP:      annotations.AllowHTTP(),
 		StaticIPName:   staticIPName,
@@ -834,6 +1077,32 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1.Ingress, urlMap *utils.
 	}, nil
 }
 
+// staticAddressName returns the name of the compute Address of the
+// StaticAddress with the given name that is referenced by the Ingress.
+// External Ingresses use global addresses, and regional Ingresses use
+// regional addresses of the type matching the load balancer.
+func (lbc *LoadBalancerController) staticAddressName(ing *v1.Ingress, name string) (string, error) {
+	isL7ILB, err := lbc.ctx.IngressClassifier.IsL7ILB(ing)
+	if err != nil {
+		return "", err
+	}
+	isL7XLBRegional, err := lbc.ctx.IngressClassifier.IsL7XLBRegional(ing)
+	if err != nil {
+		return "", err
+	}
+	global, addrType := true, cloud.SchemeExternal
+	if isL7ILB {
+		global, addrType = false, cloud.SchemeInternal
+	} else if isL7XLBRegional {
+		global = false
+	}
+	sa, err := staticaddress.AddressFor(lbc.ctx.StaticAddressInformer.GetIndexer(), ing.Namespace, name, global, addrType)
+	if err != nil {
+		return "", err
+	}
+	return sa.Status.AddressName, nil
+}
+
 func updateAnnotations(client kubernetes.Interface, ing *v1.Ingress, newAnnotations map[string]string, ingLogger klog.Logger) error {
 	if reflect.DeepEqual(ing.Annotations, newAnnotations) {
 		return nil
@@ -924,14 +1193,14 @@ func (lbc *LoadBalancerController) ensureFinalizer(ing *v1.Ingress, ingLogger kl
 //   - Finalizer enabled    :    all backends
 //   - Finalizer disabled   :    v1 frontends and all backends
 //   - Scope changed        :    v2 frontends for all scope
-func frontendGCAlgorithm(ingExists bool, scopeChange bool, ing *v1.Ingress, ingLogger klog.Logger) utils.FrontendGCAlgorithm {
+func frontendGCAlgorithm(ingExists bool, scopeChange bool, needsCleanup bool, ing *v1.Ingress, ingLogger klog.Logger) utils.FrontendGCAlgorithm {
 	// If ingress does not exist, that means its pre-finalizer era.
 	// Run GC via v1 naming scheme.
 	if !ingExists {
 		return utils.CleanupV1FrontendResources
 	}
 	// Determine if we do not need to delete current ingress.
-	if !utils.NeedsCleanup(ing) {
+	if !needsCleanup {
 		// GC backends only if current ingress does not need cleanup and finalizers is enabled.
 		if flags.F.FinalizerAdd {
 			if scopeChange {
@@ -89,7 +89,7 @@ func newLoadBalancerController() *LoadBalancerController {
 		HealthCheckPath:               "/",
 		EnableIngressRegionalExternal: true,
 	}
-	ctx := context.NewControllerContext(kubeClient, backendConfigClient, nil, nil, svcNegClient, nil, nil, nil, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
+	ctx := context.NewControllerContext(kubeClient, backendConfigClient, nil, nil, svcNegClient, nil, nil, nil, context.ExtensionClients{}, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
 	lbc := NewLoadBalancerController(ctx, stopCh, klog.TODO())
 	// TODO(rramkumar): Fix this so we don't have to override with our fake
 	lbc.instancePool = instancegroups.NewManager(&instancegroups.ManagerConfig{
@@ -100,7 +100,7 @@ func newLoadBalancerController() *LoadBalancerController {
 		ZoneGetter: fakeZoneGetter,
 		MaxIGSize:  1000,
 	})
-	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), klog.TODO())
+	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, nil, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), utils.IngressClassifier{}, klog.TODO())
 
 	lbc.hasSynced = func() bool { return true }
 
@@ -48,6 +48,26 @@ func (e ErrSvcNotFound) Error() string {
 	return fmt.Sprintf("could not find service %q", e.Service)
 }
 
+// ErrBackendBucketNotFound is returned when a BackendBucket is not found.
+type ErrBackendBucketNotFound struct {
+	BackendBucket types.NamespacedName
+}
+
+// Error returns the name of the missing BackendBucket.
+func (e ErrBackendBucketNotFound) Error() string {
+	return fmt.Sprintf("could not find BackendBucket %q", e.BackendBucket)
+}
+
+// ErrServerlessNEGNotFound is returned when a ServerlessNEG is not found.
+type ErrServerlessNEGNotFound struct {
+	ServerlessNEG types.NamespacedName
+}
+
+// Error returns the name of the missing ServerlessNEG.
+func (e ErrServerlessNEGNotFound) Error() string {
+	return fmt.Sprintf("could not find ServerlessNEG %q", e.ServerlessNEG)
+}
+
 // ErrSvcPortNotFound is returned when a service's port is not found.
 type ErrSvcPortNotFound struct {
 	utils.ServicePortID
@@ -58,6 +78,17 @@ func (e ErrSvcPortNotFound) Error() string {
 	return fmt.Sprintf("could not find port %q in service %q", e.ServicePortID.Port.String(), e.ServicePortID.Service)
 }
 
+// ErrSvcPortNotExposedAsNEG is returned when a service's port is not exposed
+// as a standalone NEG.
+type ErrSvcPortNotExposedAsNEG struct {
+	utils.ServicePortID
+}
+
+// Error returns the port name/number of the service which is not exposed.
+func (e ErrSvcPortNotExposedAsNEG) Error() string {
+	return fmt.Sprintf("port %q of service %q is not exposed as a NEG with the default name through the %q annotation", e.ServicePortID.Port.String(), e.ServicePortID.Service, annotations.NEGAnnotationKey)
+}
+
 // ErrSvcAppProtosParsing is returned when the service is malformed.
 type ErrSvcAppProtosParsing struct {
 	Service types.NamespacedName
@@ -0,0 +1,446 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/gateway"
+	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/klog/v2"
+)
+
+// initGateways sets up the queue and the event handlers of Gateways. The load
+// balancer of a Gateway is provisioned through the same pools as the load
+// balancer of an Ingress: a Gateway is translated into an Ingress that is not
+// persisted and that carries its naming and load balancer type.
+func (lbc *LoadBalancerController) initGateways() {
+	ctx := lbc.ctx
+	logger := lbc.logger
+	lbc.gatewayQueue = utils.NewPeriodicTaskQueueWithMultipleWorkers("gateway", "gateways", flags.F.NumIngressWorkers, lbc.syncGateway, logger)
+	lbc.gatewaySvcPorts = map[string][]utils.ServicePort{}
+
+	// GatewayClass event handlers.
+	enqueueClassGateways := func(obj interface{}) {
+		class, err := gateway.GatewayClassFromUnstructured(obj)
+		if err != nil {
+			logger.Error(err, "Invalid GatewayClass")
+			return
+		}
+		for _, obj := range ctx.GatewayInformer.GetStore().List() {
+			gw, err := gateway.GatewayFromUnstructured(obj)
+			if err != nil {
+				logger.Error(err, "Invalid Gateway")
+				continue
+			}
+			if gw.Spec.GatewayClassName == class.Name {
+				lbc.gatewayQueue.Enqueue(obj)
+			}
+		}
+	}
+	ctx.GatewayClassInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueClassGateways,
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				enqueueClassGateways(cur)
+			}
+		},
+		DeleteFunc: enqueueClassGateways,
+	})
+
+	// Gateway event handlers.
+	ctx.GatewayInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			lbc.gatewayQueue.Enqueue(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if reflect.DeepEqual(old, cur) {
+				logger.V(3).Info("Periodic enqueueing of gateway")
+			}
+			lbc.gatewayQueue.Enqueue(cur)
+		},
+		// Deletions are handled through the finalizer.
+	})
+
+	// HTTPRoute event handlers.
+	enqueueParents := func(obj interface{}) {
+		route, err := gateway.HTTPRouteFromUnstructured(obj)
+		if err != nil {
+			logger.Error(err, "Invalid HTTPRoute")
+			return
+		}
+		for _, parent := range gateway.ParentGateways(route) {
+			lbc.gatewayQueue.Enqueue(cache.ExplicitKey(parent.String()))
+		}
+	}
+	ctx.HTTPRouteInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueParents,
+		UpdateFunc: func(old, cur interface{}) {
+			oldRoute, err := gateway.HTTPRouteFromUnstructured(old)
+			if err != nil {
+				logger.Error(err, "Invalid HTTPRoute")
+				return
+			}
+			curRoute, err := gateway.HTTPRouteFromUnstructured(cur)
+			if err != nil {
+				logger.Error(err, "Invalid HTTPRoute")
+				return
+			}
+			// Status updates, including the ones of this controller, do not
+			// change the load balancer.
+			if !reflect.DeepEqual(oldRoute.Spec, curRoute.Spec) {
+				// Gateways that the route detached from need to be synced too.
+				for _, parent := range gateway.ParentGateways(oldRoute) {
+					lbc.gatewayQueue.Enqueue(cache.ExplicitKey(parent.String()))
+				}
+				for _, parent := range gateway.ParentGateways(curRoute) {
+					lbc.gatewayQueue.Enqueue(cache.ExplicitKey(parent.String()))
+				}
+			}
+		},
+		DeleteFunc: enqueueParents,
+	})
+
+	// Service event handlers.
+	enqueueReferencingGateways := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		svc, ok := obj.(*apiv1.Service)
+		if !ok {
+			logger.Error(nil, "Unexpected object type in Service event", "type", fmt.Sprintf("%T", obj))
+			return
+		}
+		for _, route := range lbc.httpRoutes() {
+			if gateway.ReferencesService(route, svc) {
+				for _, parent := range gateway.ParentGateways(route) {
+					lbc.gatewayQueue.Enqueue(cache.ExplicitKey(parent.String()))
+				}
+			}
+		}
+	}
+	ctx.ServiceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueReferencingGateways,
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				enqueueReferencingGateways(cur)
+			}
+		},
+		// The routes to a deleted Service no longer resolve.
+		DeleteFunc: enqueueReferencingGateways,
+	})
+}
+
+// syncGateway manages Gateway create/updates/deletes events from queue.
+func (lbc *LoadBalancerController) syncGateway(key string) error {
+	syncTrackingId := rand.Int31()
+	gwLogger := lbc.logger.WithValues("gatewayKey", key, "syncId", syncTrackingId)
+	if !lbc.hasSynced() {
+		time.Sleep(context.StoreSyncPollPeriod)
+		return fmt.Errorf("waiting for stores to sync")
+	}
+	gwLogger.Info("Syncing gateway")
+
+	obj, exists, err := lbc.ctx.GatewayInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return fmt.Errorf("error getting Gateway for key %s: %w", key, err)
+	}
+	if !exists {
+		// The load balancer was deleted before the finalizer was removed.
+		gwLogger.Info("Gateway does not exist. Skipping sync")
+		return nil
+	}
+	u := obj.(*unstructured.Unstructured)
+	gw, err := gateway.GatewayFromUnstructured(u)
+	if err != nil {
+		return fmt.Errorf("invalid Gateway %s: %w", key, err)
+	}
+
+	if gw.DeletionTimestamp != nil || !gateway.IsGCEGateway(gw, lbc.gatewayClass(gw.Spec.GatewayClassName)) {
+		if !common.HasGivenFinalizer(gw.ObjectMeta, common.GatewayFinalizerKey) {
+			gwLogger.Info("Gateway does not need to be synced. Skipping sync")
+			return nil
+		}
+		return lbc.cleanupGateway(key, gw, u, gwLogger)
+	}
+
+	if u, err = gateway.EnsureFinalizer(lbc.ctx.GatewayClient, u, gwLogger); err != nil {
+		return err
+	}
+
+	ip, syncErr := lbc.syncGatewayLoadBalancer(key, gw, u, gwLogger)
+	if syncErr != nil {
+		lbc.ctx.Recorder(gw.Namespace).Eventf(u, apiv1.EventTypeWarning, events.SyncIngress, "Error syncing to GCP: %v", syncErr)
+	}
+	if err := lbc.updateGatewayStatus(gw, u, ip, syncErr); err != nil {
+		gwLogger.Error(err, "Failed to update Gateway status")
+		if syncErr == nil {
+			syncErr = err
+		}
+	}
+	if err := lbc.updateRouteStatuses(gw, true); err != nil {
+		gwLogger.Error(err, "Failed to update HTTPRoute status")
+		if syncErr == nil {
+			syncErr = err
+		}
+	}
+
+	// Garbage collect the backends that the Gateway does not use anymore, even
+	// if an error occurred, as for Ingresses.
+	if gcErr := lbc.gcGatewayBackends(gwLogger); gcErr != nil {
+		return fmt.Errorf("error during sync %v, error during GC %v", syncErr, gcErr)
+	}
+	return syncErr
+}
+
+// syncGatewayLoadBalancer syncs the backends and the frontend of the load
+// balancer of the given Gateway, and returns its IP address.
+func (lbc *LoadBalancerController) syncGatewayLoadBalancer(key string, gw *gateway.Gateway, u *unstructured.Unstructured, gwLogger klog.Logger) (string, error) {
+	if err := gateway.ValidateListeners(gw); err != nil {
+		return "", fmt.Errorf("invalid gateway spec: %w", err)
+	}
+
+	urlMap, errs, warnings := lbc.Translator.TranslateGateway(gw, lbc.httpRoutes(), lbc.ctx.DefaultBackendSvcPort.ID, lbc.ctx.ClusterNamer)
+	if errs != nil {
+		msg := fmt.Errorf("invalid gateway routes: %v", utils.JoinErrs(errs))
+		lbc.ctx.Recorder(gw.Namespace).Eventf(u, apiv1.EventTypeWarning, events.TranslateIngress, "Translation failed: %v", msg)
+		return "", msg
+	}
+	if warnings {
+		msg := "THC annotation is present for at least one Service, but the Transparent Health Checks feature is not enabled."
+		lbc.ctx.Recorder(gw.Namespace).Event(u, apiv1.EventTypeWarning, "THCAnnotationWithoutFlag", msg)
+	}
+
+	svcPorts := urlMap.AllServicePorts()
+	lbc.gatewaySvcPortsLock.Lock()
+	lbc.gatewaySvcPorts[key] = svcPorts
+	lbc.gatewaySvcPortsLock.Unlock()
+
+	ing := gateway.ToIngress(gw)
+	if err := lbc.syncBackends(ing, svcPorts, gwLogger.WithName("SyncBackends")); err != nil {
+		return "", err
+	}
+	lb, err := lbc.toRuntimeInfo(ing, urlMap, gwLogger)
+	if err != nil {
+		return "", err
+	}
+	l7, err := lbc.l7Pool.Ensure(lb)
+	if err != nil {
+		return "", err
+	}
+
+	// Clean up the load balancer in the previous scope if the class of the
+	// Gateway changed between a global and a regional class.
+	oldScope, err := lbc.l7Pool.FrontendScopeChangeGC(ing, gwLogger)
+	if err != nil {
+		return l7.GetIP(), err
+	}
+	if oldScope != nil {
+		lbc.gcLock.Lock()
+		defer lbc.gcLock.Unlock()
+		if err := lbc.l7Pool.GCv2(ing, *oldScope); err != nil {
+			return l7.GetIP(), err
+		}
+	}
+	return l7.GetIP(), nil
+}
+
+// cleanupGateway deletes the load balancer of the given Gateway, removes its
+// entries from the status of HTTPRoutes and removes its finalizer.
+func (lbc *LoadBalancerController) cleanupGateway(key string, gw *gateway.Gateway, u *unstructured.Unstructured, gwLogger klog.Logger) error {
+	gwLogger.Info("Deleting load balancer of gateway")
+	ing := gateway.ToIngress(gw)
+
+	scope, err := features.ScopeFromIngress(ing, lbc.ctx.IngressClassifier)
+	if err != nil {
+		return err
+	}
+	lbc.gcLock.Lock()
+	err = lbc.l7Pool.GCv2(ing, scope)
+	lbc.gcLock.Unlock()
+	if err != nil {
+		lbc.ctx.Recorder(gw.Namespace).Eventf(u, apiv1.EventTypeWarning, events.GarbageCollection, "Error: %v", err)
+		return err
+	}
+	lbc.gatewaySvcPortsLock.Lock()
+	delete(lbc.gatewaySvcPorts, key)
+	lbc.gatewaySvcPortsLock.Unlock()
+	if err := lbc.gcGatewayBackends(gwLogger); err != nil {
+		return err
+	}
+	if err := lbc.updateRouteStatuses(gw, false); err != nil {
+		return err
+	}
+	return gateway.EnsureDeleteFinalizer(lbc.ctx.GatewayClient, u, gwLogger)
+}
+
+// updateGatewayStatus updates the addresses and the conditions of the given
+// Gateway if they changed.
+func (lbc *LoadBalancerController) updateGatewayStatus(gw *gateway.Gateway, u *unstructured.Unstructured, ip string, syncErr error) error {
+	status := gateway.Status(gw, ip, syncErr)
+	if reflect.DeepEqual(status, gw.Status) {
+		return nil
+	}
+	return gateway.UpdateStatus(lbc.ctx.GatewayClient, u, status)
+}
+
+// updateRouteStatuses updates the entries of the given Gateway in the status
+// of the HTTPRoutes. If accepted is true, the routes attached to the Gateway
+// get its conditions, otherwise they lose its entry. Routes that detached
+// from the Gateway always lose its entry.
+func (lbc *LoadBalancerController) updateRouteStatuses(gw *gateway.Gateway, accepted bool) error {
+	var errs []error
+	for _, obj := range lbc.ctx.HTTPRouteInformer.GetStore().List() {
+		route, err := gateway.HTTPRouteFromUnstructured(obj)
+		if err != nil {
+			lbc.logger.Error(err, "Invalid HTTPRoute")
+			continue
+		}
+		status := gateway.RemoveRouteStatus(route, gw)
+		if accepted && gateway.IsAttached(route, gw) {
+			_, routeErrs := gateway.RouteRules([]*gateway.HTTPRoute{route})
+			var acceptErr error
+			if len(routeErrs) > 0 {
+				acceptErr = utils.JoinErrs(routeErrs)
+			}
+			status = gateway.RouteStatus(route, gw, acceptErr, lbc.resolveRouteBackends(route))
+		}
+		if equality.Semantic.DeepEqual(status, route.Status) {
+			continue
+		}
+		if err := gateway.UpdateRouteStatus(lbc.ctx.GatewayClient, obj.(*unstructured.Unstructured), status); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+// resolveRouteBackends returns an error if a Service referenced by the given
+// HTTPRoute does not exist.
+func (lbc *LoadBalancerController) resolveRouteBackends(route *gateway.HTTPRoute) error {
+	var missing []string
+	for _, id := range gateway.ServiceRefs(route) {
+		if _, exists, err := lbc.ctx.Services().GetByKey(id.String()); err != nil || !exists {
+			missing = append(missing, id.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("backendRefs reference missing Services %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// gcGatewayBackends deletes the backends that are neither used by Ingresses
+// nor by Gateways.
+func (lbc *LoadBalancerController) gcGatewayBackends(gwLogger klog.Logger) error {
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+	return lbc.GCBackends(lbc.ctx.Ingresses().List(), gwLogger)
+}
+
+// gatewayServicePorts returns the service ports used by the Gateways that
+// are handled by this controller. It is used for GC. The service ports of a
+// Gateway are the ones of its last sync, Gateways that have not been synced
+// yet since the controller started are translated.
+func (lbc *LoadBalancerController) gatewayServicePorts() []utils.ServicePort {
+	var knownPorts []utils.ServicePort
+	var routes []*gateway.HTTPRoute
+	lbc.gatewaySvcPortsLock.Lock()
+	defer lbc.gatewaySvcPortsLock.Unlock()
+	for _, gw := range lbc.gateways() {
+		key := types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}.String()
+		if svcPorts, ok := lbc.gatewaySvcPorts[key]; ok {
+			knownPorts = append(knownPorts, svcPorts...)
+			continue
+		}
+		if routes == nil {
+			routes = lbc.httpRoutes()
+		}
+		urlMap, _, _ := lbc.Translator.TranslateGateway(gw, routes, lbc.ctx.DefaultBackendSvcPort.ID, lbc.ctx.ClusterNamer)
+		knownPorts = append(knownPorts, urlMap.AllServicePorts()...)
+	}
+	return knownPorts
+}
+
+// gatewayClass returns the GatewayClass with the given name, nil if it does
+// not exist.
+func (lbc *LoadBalancerController) gatewayClass(name string) *gateway.GatewayClass {
+	obj, exists, err := lbc.ctx.GatewayClassInformer.GetStore().GetByKey(name)
+	if err != nil || !exists {
+		return nil
+	}
+	class, err := gateway.GatewayClassFromUnstructured(obj)
+	if err != nil {
+		lbc.logger.Error(err, "Invalid GatewayClass", "gatewayClass", name)
+		return nil
+	}
+	return class
+}
+
+// gateways returns the Gateways whose load balancer is managed by this
+// controller, excluding Gateways that are being deleted.
+func (lbc *LoadBalancerController) gateways() []*gateway.Gateway {
+	if lbc.ctx.GatewayInformer == nil {
+		return nil
+	}
+	var gateways []*gateway.Gateway
+	for _, obj := range lbc.ctx.GatewayInformer.GetStore().List() {
+		gw, err := gateway.GatewayFromUnstructured(obj)
+		if err != nil {
+			lbc.logger.Error(err, "Invalid Gateway")
+			continue
+		}
+		if gw.DeletionTimestamp == nil && gateway.IsGCEGateway(gw, lbc.gatewayClass(gw.Spec.GatewayClassName)) {
+			gateways = append(gateways, gw)
+		}
+	}
+	return gateways
+}
+
+// httpRoutes returns all HTTPRoutes.
+func (lbc *LoadBalancerController) httpRoutes() []*gateway.HTTPRoute {
+	if lbc.ctx.HTTPRouteInformer == nil {
+		return nil
+	}
+	var routes []*gateway.HTTPRoute
+	for _, obj := range lbc.ctx.HTTPRouteInformer.GetStore().List() {
+		route, err := gateway.HTTPRouteFromUnstructured(obj)
+		if err != nil {
+			lbc.logger.Error(err, "Invalid HTTPRoute")
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
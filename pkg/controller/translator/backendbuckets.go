@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
+	"k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// getBackendBucket returns the name of the compute BackendBucket managed for
+// the BackendBucket CR with the given namespace and name. The CR must have
+// been synced by the backend bucket controller, so that the url map does not
+// reference a backend bucket that does not exist yet.
+func (t *Translator) getBackendBucket(id types.NamespacedName, params *getServicePortParams) (string, error) {
+	if t.BackendBucketInformer == nil {
+		return "", fmt.Errorf("BackendBucket %q is referenced, but backend buckets are not enabled", id)
+	}
+	if params.isL7ILB || params.isL7XLBRegional {
+		return "", fmt.Errorf("BackendBucket %q is referenced, but backend buckets are only supported for global external Ingresses", id)
+	}
+	obj, exists, err := t.BackendBucketInformer.GetIndexer().GetByKey(id.String())
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.ErrBackendBucketNotFound{BackendBucket: id}
+	}
+	bb := obj.(*backendbucketv1beta1.BackendBucket)
+	if bb.Status.SelfLink == "" {
+		return "", fmt.Errorf("BackendBucket %q is not synced yet", id)
+	}
+	return utils.KeyName(bb.Status.SelfLink)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/gateway"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+// maxBackendWeight is the maximum weight of a backend service in a route rule.
+const maxBackendWeight = 1000
+
+// frontendConfigForIngress returns the FrontendConfig referenced by the given
+// Ingress. It returns nil if FrontendConfig is not enabled, or if the Ingress
+// does not reference an existing FrontendConfig. The latter is reported when
+// the frontend resources are synced.
+func (t *Translator) frontendConfigForIngress(ing *v1.Ingress) *frontendconfigv1beta1.FrontendConfig {
+	if t.FrontendConfigInformer == nil {
+		return nil
+	}
+	name := annotations.FromIngress(ing).FrontendConfig()
+	if name == "" {
+		return nil
+	}
+	obj, exists, err := t.FrontendConfigInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", ing.Namespace, name))
+	if err != nil || !exists {
+		return nil
+	}
+	feConfig, ok := obj.(*frontendconfigv1beta1.FrontendConfig)
+	if !ok {
+		return nil
+	}
+	return feConfig
+}
+
+// translateRouteRules adds the given route rules to the host rules of the
+// urlMap. source describes the object the route rules come from.
+func (t *Translator) translateRouteRules(ing *v1.Ingress, rules []frontendconfigv1beta1.RouteRule, source string, urlMap *utils.GCEURLMap, params *getServicePortParams, namer namer_util.BackendNamer) ([]error, bool) {
+	var errs []error
+	var warnings bool
+
+	// Preserve the order of the hosts as they appear in the route rules.
+	var hosts []string
+	routeRules := map[string][]utils.RouteRule{}
+	for i, rule := range rules {
+		if err := validateRouteRule(rule); err != nil {
+			errs = append(errs, fmt.Errorf("invalid route rule %d in %s: %w", i, source, err))
+			continue
+		}
+
+		routeRule := utils.RouteRule{
+			Match:      *rule.Match.DeepCopy(),
+			UrlRewrite: rule.UrlRewrite.DeepCopy(),
+		}
+		if routeRule.Match.PathPrefix == "" && routeRule.Match.FullPath == "" {
+			routeRule.Match.PathPrefix = "/"
+		}
+		for _, backend := range rule.Backends {
+			svcPortID, err := utils.BackendToServicePortID(v1.IngressBackend{
+				Service: &v1.IngressServiceBackend{
+					Name: backend.ServiceName,
+					Port: v1.ServiceBackendPort{Name: backend.ServicePort.Name, Number: backend.ServicePort.Number},
+				},
+			}, ing.Namespace)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			svcPort, err, warning := t.getServicePort(svcPortID, params, namer)
+			warnings = warnings || warning
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if svcPort != nil {
+				routeRule.Backends = append(routeRule.Backends, utils.WeightedBackend{Backend: *svcPort, Weight: int64(backend.Weight)})
+			}
+		}
+		if len(routeRule.Backends) != len(rule.Backends) {
+			continue
+		}
+
+		host := rule.Host
+		if host == "" {
+			host = DefaultHost
+		}
+		if _, ok := routeRules[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		routeRules[host] = append(routeRules[host], routeRule)
+	}
+
+	for _, host := range hosts {
+		urlMap.PutRouteRulesForHost(host, routeRules[host])
+	}
+	return errs, warnings
+}
+
+// TranslateGateway converts a Gateway and its attached HTTPRoutes into our
+// internal UrlMap representation. The backends of the HTTPRoutes must be
+// exposed as standalone NEGs. Requests that do not match any HTTPRoute are
+// sent to the system default backend.
+// The returned bool is for warnings, as for TranslateIngress.
+func (t *Translator) TranslateGateway(gw *gateway.Gateway, routes []*gateway.HTTPRoute, systemDefaultBackend utils.ServicePortID, namer namer_util.BackendNamer) (*utils.GCEURLMap, []error, bool) {
+	ing := gateway.ToIngress(gw)
+	urlMap, errs, warnings := t.TranslateIngress(ing, systemDefaultBackend, namer)
+
+	rules, routeErrs := gateway.RouteRules(gateway.AttachedRoutes(gw, routes))
+	errs = append(errs, routeErrs...)
+
+	// Errors determining the service port params are already part of errs.
+	params, _ := t.getServicePortParamsForIngress(ing)
+	params.standaloneNEG = true
+	source := fmt.Sprintf("Gateway %s/%s", gw.Namespace, gw.Name)
+	routeErrs, warning := t.translateRouteRules(ing, rules, source, urlMap, params, namer)
+	return urlMap, append(errs, routeErrs...), warnings || warning
+}
+
+// getWeightedBackends returns the weighted backends of the given traffic split.
+func (t *Translator) getWeightedBackends(ing *v1.Ingress, split []annotations.TrafficSplitBackend, params *getServicePortParams, namer namer_util.BackendNamer) ([]utils.WeightedBackend, []error, bool) {
+	var errs []error
+	var warnings bool
+	var backends []utils.WeightedBackend
+	for _, backend := range split {
+		svcPortID := utils.ServicePortID{
+			Service: types.NamespacedName{Namespace: ing.Namespace, Name: backend.ServiceName},
+			Port:    backend.ServicePort,
+		}
+		svcPort, err, warning := t.getServicePort(svcPortID, params, namer)
+		warnings = warnings || warning
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if svcPort != nil {
+			backends = append(backends, utils.WeightedBackend{Backend: *svcPort, Weight: int64(backend.Weight)})
+		}
+	}
+	return backends, errs, warnings
+}
+
+// validateRouteRule returns an error if the given route rule cannot be
+// translated into a UrlMap route rule.
+func validateRouteRule(rule frontendconfigv1beta1.RouteRule) error {
+	if rule.Match.PathPrefix != "" && rule.Match.FullPath != "" {
+		return fmt.Errorf("only one of pathPrefix and fullPath may be set")
+	}
+	for _, header := range rule.Match.Headers {
+		if header.Name == "" {
+			return fmt.Errorf("header match without name")
+		}
+		if countSet(header.ExactMatch != "", header.PrefixMatch != "", header.RegexMatch != "", header.PresentMatch) > 1 {
+			return fmt.Errorf("header match %q sets more than one match type", header.Name)
+		}
+	}
+	for _, param := range rule.Match.QueryParameters {
+		if param.Name == "" {
+			return fmt.Errorf("query parameter match without name")
+		}
+		if countSet(param.ExactMatch != "", param.RegexMatch != "", param.PresentMatch) > 1 {
+			return fmt.Errorf("query parameter match %q sets more than one match type", param.Name)
+		}
+	}
+	if len(rule.Backends) == 0 {
+		return fmt.Errorf("no backends")
+	}
+	var totalWeight int
+	for _, backend := range rule.Backends {
+		if backend.Weight < 0 || backend.Weight > maxBackendWeight {
+			return fmt.Errorf("weight %d of backend %s is not between 0 and %d", backend.Weight, backend.ServiceName, maxBackendWeight)
+		}
+		totalWeight += int(backend.Weight)
+	}
+	// The weight of a single backend is ignored, but a weighted split where
+	// every backend has weight 0 would not send traffic anywhere.
+	if len(rule.Backends) > 1 && totalWeight == 0 {
+		return fmt.Errorf("all backends have weight 0")
+	}
+	return nil
+}
+
+func countSet(values ...bool) int {
+	var count int
+	for _, v := range values {
+		if v {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned/fake"
+	informerfrontendconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/informers/externalversions/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/gateway"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestTranslateIngressRouteRules(t *testing.T) {
+	translator := fakeTranslator()
+	translator.FrontendConfigInformer = informerfrontendconfig.NewFrontendConfigInformer(frontendconfigclient.NewSimpleClientset(), apiv1.NamespaceAll, time.Second, utils.NewNamespaceIndexer())
+	svcLister := translator.ServiceInformer.GetIndexer()
+	for _, name := range []string{"first-service", "second-service"} {
+		svcLister.Add(test.NewService(types.NamespacedName{Name: name, Namespace: "default"}, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Port: 80}},
+		}))
+	}
+
+	firstService := utils.ServicePortID{Service: types.NamespacedName{Name: "first-service", Namespace: "default"}, Port: port80}
+	secondService := utils.ServicePortID{Service: types.NamespacedName{Name: "second-service", Namespace: "default"}, Port: port80}
+	routeBackend := func(name string, weight int32) frontendconfigv1beta1.RouteBackend {
+		return frontendconfigv1beta1.RouteBackend{ServiceName: name, ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 80}, Weight: weight}
+	}
+
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		v1.IngressSpec{
+			DefaultBackend: test.Backend("first-service", port80),
+			Rules: []v1.IngressRule{
+				{
+					Host: "foo.bar.com",
+					IngressRuleValue: v1.IngressRuleValue{
+						HTTP: &v1.HTTPIngressRuleValue{
+							Paths: []v1.HTTPIngressPath{
+								{Path: "/web", Backend: *test.Backend("first-service", port80)},
+							},
+						},
+					},
+				},
+			},
+		})
+	ing.Annotations = map[string]string{annotations.FrontendConfigKey: "my-feconfig"}
+
+	cases := []struct {
+		desc          string
+		routeRules    []frontendconfigv1beta1.RouteRule
+		wantErrCount  int
+		wantGCEURLMap *utils.GCEURLMap
+	}{
+		{
+			desc: "rewrite and header match on existing host",
+			routeRules: []frontendconfigv1beta1.RouteRule{
+				{
+					Host: "foo.bar.com",
+					Match: frontendconfigv1beta1.RouteMatch{
+						PathPrefix: "/api/",
+						Headers:    []frontendconfigv1beta1.HeaderMatch{{Name: "x-canary", ExactMatch: "true"}},
+					},
+					Backends:   []frontendconfigv1beta1.RouteBackend{routeBackend("second-service", 0)},
+					UrlRewrite: &frontendconfigv1beta1.UrlRewrite{PathPrefixRewrite: "/"},
+				},
+			},
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: firstService},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: firstService}}},
+						RouteRules: []utils.RouteRule{
+							{
+								Match: frontendconfigv1beta1.RouteMatch{
+									PathPrefix: "/api/",
+									Headers:    []frontendconfigv1beta1.HeaderMatch{{Name: "x-canary", ExactMatch: "true"}},
+								},
+								Backends:   []utils.WeightedBackend{{Backend: utils.ServicePort{ID: secondService}}},
+								UrlRewrite: &frontendconfigv1beta1.UrlRewrite{PathPrefixRewrite: "/"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "weighted backends on default host",
+			routeRules: []frontendconfigv1beta1.RouteRule{
+				{
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("first-service", 90), routeBackend("second-service", 10)},
+				},
+			},
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: firstService},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: firstService}}},
+					},
+					{
+						Hostname: DefaultHost,
+						RouteRules: []utils.RouteRule{
+							{
+								Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/"},
+								Backends: []utils.WeightedBackend{
+									{Backend: utils.ServicePort{ID: firstService}, Weight: 90},
+									{Backend: utils.ServicePort{ID: secondService}, Weight: 10},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "invalid and missing backends are skipped",
+			routeRules: []frontendconfigv1beta1.RouteRule{
+				{
+					Match:    frontendconfigv1beta1.RouteMatch{PathPrefix: "/a", FullPath: "/b"},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("first-service", 0)},
+				},
+				{
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("first-service", 2000), routeBackend("second-service", 1)},
+				},
+				{
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("missing-service", 0)},
+				},
+				{
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("first-service", 0), routeBackend("second-service", 0)},
+				},
+			},
+			wantErrCount: 4,
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: firstService},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: firstService}}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			feConfig := &frontendconfigv1beta1.FrontendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-feconfig", Namespace: "default"},
+				Spec:       frontendconfigv1beta1.FrontendConfigSpec{RouteRules: tc.routeRules},
+			}
+			if err := translator.FrontendConfigInformer.GetIndexer().Add(feConfig); err != nil {
+				t.Fatalf("Add(%v) = %v", feConfig, err)
+			}
+			defer translator.FrontendConfigInformer.GetIndexer().Delete(feConfig)
+
+			gotGCEURLMap, gotErrs, _ := translator.TranslateIngress(ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateIngress() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, tc.wantGCEURLMap) {
+				t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), tc.wantGCEURLMap.String())
+			}
+		})
+	}
+}
+
+func TestTranslateIngressTrafficSplit(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	for _, name := range []string{"my-app", "my-app-canary"} {
+		svcLister.Add(test.NewService(types.NamespacedName{Name: name, Namespace: "default"}, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Port: 80}},
+		}))
+	}
+
+	app := utils.ServicePortID{Service: types.NamespacedName{Name: "my-app", Namespace: "default"}, Port: port80}
+	canary := utils.ServicePortID{Service: types.NamespacedName{Name: "my-app-canary", Namespace: "default"}, Port: port80}
+
+	cases := []struct {
+		desc          string
+		trafficSplit  string
+		wantErrCount  int
+		wantGCEURLMap *utils.GCEURLMap
+	}{
+		{
+			desc:         "valid split",
+			trafficSplit: `{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "my-app-canary", "servicePort": {"number": 80}, "weight": 10}]}`,
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: app},
+				DefaultWeightedBackends: []utils.WeightedBackend{
+					{Backend: utils.ServicePort{ID: app}, Weight: 90},
+					{Backend: utils.ServicePort{ID: canary}, Weight: 10},
+				},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths: []utils.PathRule{
+							{
+								Path:    "/web",
+								Backend: utils.ServicePort{ID: app},
+								WeightedBackends: []utils.WeightedBackend{
+									{Backend: utils.ServicePort{ID: app}, Weight: 90},
+									{Backend: utils.ServicePort{ID: canary}, Weight: 10},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:         "split of another port",
+			trafficSplit: `{"my-app:8080": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "my-app-canary", "servicePort": {"number": 80}, "weight": 10}]}`,
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: app},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: app}}},
+					},
+				},
+			},
+		},
+		{
+			desc:         "missing canary service",
+			trafficSplit: `{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "missing", "servicePort": {"number": 80}, "weight": 10}]}`,
+			wantErrCount: 1,
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: app},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: app}}},
+					},
+				},
+			},
+		},
+		{
+			desc:         "invalid annotation",
+			trafficSplit: `{"my-app:80": [{"serviceName": "my-app", "servicePort": {"number": 80}, "weight": 90}]}`,
+			wantErrCount: 1,
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: app},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{ID: app}}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+				v1.IngressSpec{
+					DefaultBackend: test.Backend("my-app", port80),
+					Rules: []v1.IngressRule{
+						{
+							Host: "foo.bar.com",
+							IngressRuleValue: v1.IngressRuleValue{
+								HTTP: &v1.HTTPIngressRuleValue{
+									Paths: []v1.HTTPIngressPath{
+										{Path: "/web", Backend: *test.Backend("my-app", port80)},
+									},
+								},
+							},
+						},
+					},
+				})
+			ing.Annotations = map[string]string{annotations.TrafficSplitKey: tc.trafficSplit}
+
+			gotGCEURLMap, gotErrs, _ := translator.TranslateIngress(ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateIngress() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, tc.wantGCEURLMap) {
+				t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), tc.wantGCEURLMap.String())
+			}
+		})
+	}
+}
+
+func TestTranslateGateway(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	svcLister.Add(test.NewService(defaultBackend.ID.Service, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+	}))
+	exposed := test.NewService(types.NamespacedName{Name: "exposed", Namespace: "default"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeClusterIP,
+		Ports: []apiv1.ServicePort{{Port: 80}},
+	})
+	exposed.Annotations = map[string]string{annotations.NEGAnnotationKey: `{"exposed_ports":{"80":{}}}`}
+	svcLister.Add(exposed)
+	svcLister.Add(test.NewService(types.NamespacedName{Name: "not-exposed", Namespace: "default"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Port: 80}},
+	}))
+
+	port := int32(80)
+	gw := &gateway.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"},
+		Spec: gateway.GatewaySpec{
+			GatewayClassName: annotations.GceIngressClass,
+			Listeners:        []gateway.Listener{{Name: "http", Port: 80, Protocol: "HTTP"}},
+		},
+	}
+	route := func(svcName string) *gateway.HTTPRoute {
+		return &gateway.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-route", Namespace: "default"},
+			Spec: gateway.HTTPRouteSpec{
+				ParentRefs: []gateway.ParentReference{{Name: "my-gateway"}},
+				Hostnames:  []string{"foo.bar.com"},
+				Rules: []gateway.HTTPRouteRule{
+					{BackendRefs: []gateway.HTTPBackendRef{{Name: svcName, Port: &port}}},
+				},
+			},
+		}
+	}
+	exposedID := utils.ServicePortID{Service: types.NamespacedName{Name: "exposed", Namespace: "default"}, Port: port80}
+
+	cases := []struct {
+		desc          string
+		routes        []*gateway.HTTPRoute
+		wantErrCount  int
+		wantGCEURLMap *utils.GCEURLMap
+	}{
+		{
+			desc:   "backend exposed as NEG",
+			routes: []*gateway.HTTPRoute{route("exposed")},
+			wantGCEURLMap: &utils.GCEURLMap{
+				DefaultBackend: &utils.ServicePort{ID: defaultBackend.ID},
+				HostRules: []utils.HostRule{
+					{
+						Hostname: "foo.bar.com",
+						RouteRules: []utils.RouteRule{
+							{
+								Match:    frontendconfigv1beta1.RouteMatch{PathPrefix: "/"},
+								Backends: []utils.WeightedBackend{{Backend: utils.ServicePort{ID: exposedID}, Weight: 1}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:          "backend not exposed as NEG",
+			routes:        []*gateway.HTTPRoute{route("not-exposed")},
+			wantErrCount:  1,
+			wantGCEURLMap: &utils.GCEURLMap{DefaultBackend: &utils.ServicePort{ID: defaultBackend.ID}},
+		},
+		{
+			desc: "route of another gateway",
+			routes: []*gateway.HTTPRoute{func() *gateway.HTTPRoute {
+				r := route("exposed")
+				r.Spec.ParentRefs[0].Name = "other-gateway"
+				return r
+			}()},
+			wantGCEURLMap: &utils.GCEURLMap{DefaultBackend: &utils.ServicePort{ID: defaultBackend.ID}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotGCEURLMap, gotErrs, _ := translator.TranslateGateway(gw, tc.routes, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateGateway() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, tc.wantGCEURLMap) {
+				t.Errorf("TranslateGateway() = %+v\nwant\n%+v", gotGCEURLMap.String(), tc.wantGCEURLMap.String())
+			}
+			for _, sp := range gotGCEURLMap.AllServicePorts() {
+				if sp.ID == exposedID && !sp.NEGEnabled {
+					t.Errorf("TranslateGateway() returned backend %v without NEG", sp.ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// getServerlessNEG returns the name of the global backend service managed for
+// the ServerlessNEG CR with the given namespace and name. The CR must have
+// been synced by the serverless NEG controller, so that the url map does not
+// reference a backend service that does not exist yet.
+func (t *Translator) getServerlessNEG(id types.NamespacedName, params *getServicePortParams) (string, error) {
+	if t.ServerlessNEGInformer == nil {
+		return "", fmt.Errorf("ServerlessNEG %q is referenced, but serverless NEGs are not enabled", id)
+	}
+	if params.isL7ILB || params.isL7XLBRegional {
+		return "", fmt.Errorf("ServerlessNEG %q is referenced, but serverless NEGs are only supported for global external Ingresses", id)
+	}
+	obj, exists, err := t.ServerlessNEGInformer.GetIndexer().GetByKey(id.String())
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.ErrServerlessNEGNotFound{ServerlessNEG: id}
+	}
+	sneg := obj.(*serverlessnegv1beta1.ServerlessNEG)
+	if sneg.Status.BackendService == "" {
+		return "", fmt.Errorf("ServerlessNEG %q is not synced yet", id)
+	}
+	return utils.KeyName(sneg.Status.BackendService)
+}
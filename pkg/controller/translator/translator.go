@@ -39,6 +39,7 @@ import (
 	"k8s.io/ingress-gce/pkg/backendconfig"
 	"k8s.io/ingress-gce/pkg/controller/errors"
 	"k8s.io/ingress-gce/pkg/flags"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 )
@@ -57,13 +58,26 @@ const (
 type getServicePortParams struct {
 	isL7ILB         bool
 	isL7XLBRegional bool
+	// standaloneNEG requires the service port to be exposed as a standalone
+	// NEG, which is then used as the backend.
+	standaloneNEG bool
 }
 
-func (t *Translator) getServicePortParamsForIngress(ing *v1.Ingress) *getServicePortParams {
-	return &getServicePortParams{
-		isL7ILB:         utils.IsGCEL7ILBIngress(ing),
-		isL7XLBRegional: t.enableL7XLBRegional && utils.IsGCEL7XLBRegionalIngress(ing),
-	}
+// getServicePortParamsForIngress returns the service port params for the
+// given Ingress. If the type of load balancer requested by the Ingress cannot
+// be determined, it returns the params based on the ingress.class annotation
+// along with the error.
+func (t *Translator) getServicePortParamsForIngress(ing *v1.Ingress) (*getServicePortParams, error) {
+	isL7ILB, ilbErr := t.classifier.IsL7ILB(ing)
+	isL7XLBRegional, xlbErr := t.classifier.IsL7XLBRegional(ing)
+	params := &getServicePortParams{
+		isL7ILB:         isL7ILB,
+		isL7XLBRegional: t.enableL7XLBRegional && isL7XLBRegional,
+	}
+	if ilbErr != nil {
+		return params, ilbErr
+	}
+	return params, xlbErr
 }
 
 // NewTranslator returns a new Translator.
@@ -72,22 +86,30 @@ func NewTranslator(serviceInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
 	podInformer cache.SharedIndexInformer,
 	endpointSliceInformer cache.SharedIndexInformer,
+	frontendConfigInformer cache.SharedIndexInformer,
+	backendBucketInformer cache.SharedIndexInformer,
+	serverlessNEGInformer cache.SharedIndexInformer,
 	kubeClient kubernetes.Interface,
 	recorderGetter healthchecks.RecorderGetter,
+	classifier utils.IngressClassifier,
 	enableTHC,
 	enableL7XLBRegional bool,
 	logger klog.Logger,
 ) *Translator {
 	return &Translator{
-		ServiceInformer:       serviceInformer,
-		BackendConfigInformer: backendConfigInformer,
-		NodeInformer:          nodeInformer,
-		PodInformer:           podInformer,
-		EndpointSliceInformer: endpointSliceInformer,
-		KubeClient:            kubeClient,
-		enableTHC:             enableTHC,
-		enableL7XLBRegional:   enableL7XLBRegional,
-		logger:                logger.WithName("Translator"),
+		ServiceInformer:        serviceInformer,
+		BackendConfigInformer:  backendConfigInformer,
+		NodeInformer:           nodeInformer,
+		PodInformer:            podInformer,
+		EndpointSliceInformer:  endpointSliceInformer,
+		FrontendConfigInformer: frontendConfigInformer,
+		BackendBucketInformer:  backendBucketInformer,
+		ServerlessNEGInformer:  serverlessNEGInformer,
+		KubeClient:             kubeClient,
+		classifier:             classifier,
+		enableTHC:              enableTHC,
+		enableL7XLBRegional:    enableL7XLBRegional,
+		logger:                 logger.WithName("Translator"),
 	}
 }
 
@@ -98,9 +120,22 @@ type Translator struct {
 	NodeInformer          cache.SharedIndexInformer
 	PodInformer           cache.SharedIndexInformer
 	EndpointSliceInformer cache.SharedIndexInformer
+	// FrontendConfigInformer is used to translate the route rules of the
+	// FrontendConfig referenced by an Ingress. It is nil when FrontendConfig
+	// is not enabled.
+	FrontendConfigInformer cache.SharedIndexInformer
+	// BackendBucketInformer is used to translate the resource backends that
+	// reference BackendBuckets. It is nil when backend buckets are not enabled.
+	BackendBucketInformer cache.SharedIndexInformer
+	// ServerlessNEGInformer is used to translate the resource backends that
+	// reference ServerlessNEGs. It is nil when serverless NEGs are not enabled.
+	ServerlessNEGInformer cache.SharedIndexInformer
 	KubeClient            kubernetes.Interface
-	enableTHC             bool
-	enableL7XLBRegional   bool
+	// classifier determines the type of load balancer requested by an
+	// Ingress.
+	classifier          utils.IngressClassifier
+	enableTHC           bool
+	enableL7XLBRegional bool
 
 	logger klog.Logger
 }
@@ -156,6 +191,24 @@ func maybeEnableNEG(sp *utils.ServicePort, svc *api_v1.Service) error {
 	return nil
 }
 
+// enableStandaloneNEG enables NEG on the service port if the port is exposed
+// as a standalone NEG with the default NEG name.
+func enableStandaloneNEG(sp *utils.ServicePort, svc *api_v1.Service) error {
+	negAnnotation, ok, err := annotations.FromService(svc).NEGAnnotation()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.ErrSvcPortNotExposedAsNEG{ServicePortID: sp.ID}
+	}
+	attributes, exposed := negAnnotation.ExposedPorts[sp.Port]
+	if !exposed || attributes.Name != "" {
+		return errors.ErrSvcPortNotExposedAsNEG{ServicePortID: sp.ID}
+	}
+	sp.NEGEnabled = true
+	return nil
+}
+
 // setAppProtocol sets the app protocol on the service port
 func setAppProtocol(sp *utils.ServicePort, svc *api_v1.Service, port *api_v1.ServicePort) error {
 	appProtocols, err := annotations.FromService(svc).ApplicationProtocols()
@@ -294,9 +347,19 @@ func (t *Translator) getServicePort(id utils.ServicePortID, params *getServicePo
 		BackendNamer:         namer,
 	}
 
-	if err := maybeEnableNEG(svcPort, svc); err != nil {
+	if params.standaloneNEG {
+		if err := enableStandaloneNEG(svcPort, svc); err != nil {
+			return nil, err, false
+		}
+	} else if err := maybeEnableNEG(svcPort, svc); err != nil {
 		return nil, err, false
 	}
+	if svcPort.NEGEnabled {
+		// Errors are reported on the Service by the NEG controller, which does
+		// not create internet NEGs for invalid Services.
+		negType, _ := negtypes.ExternalNEGType(svc)
+		svcPort.GlobalNEG = negtypes.IsGlobalNetworkEndpointType(negType)
+	}
 
 	if err := setAppProtocol(svcPort, svc, port); err != nil {
 		return svcPort, err, false
@@ -323,7 +386,27 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 	var errs []error
 	var warnings bool
 	urlMap := utils.NewGCEURLMap(t.logger)
-	params := t.getServicePortParamsForIngress(ing)
+	params, err := t.getServicePortParamsForIngress(ing)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	trafficSplits, err := annotations.FromIngress(ing).TrafficSplits()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	// Resolve each traffic split once, even if the Service port is referenced
+	// by multiple paths.
+	weightedBackends := map[annotations.TrafficSplitTarget][]utils.WeightedBackend{}
+	for target, split := range trafficSplits {
+		backends, splitErrs, warning := t.getWeightedBackends(ing, split, params, namer)
+		warnings = warnings || warning
+		if len(splitErrs) > 0 {
+			errs = append(errs, splitErrs...)
+			continue
+		}
+		weightedBackends[target] = backends
+	}
 
 	for _, rule := range ing.Spec.Rules {
 		if rule.HTTP == nil {
@@ -332,6 +415,25 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 
 		pathRules := []utils.PathRule{}
 		for _, p := range rule.HTTP.Paths {
+			if resourceRule, ok, err := t.resourceBackendPathRule(p.Backend, ing.Namespace, params); ok {
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				paths, err := validateAndGetPaths(p)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				for _, path := range paths {
+					if path == "" {
+						path = DefaultPath
+					}
+					resourceRule.Path = path
+					pathRules = append(pathRules, resourceRule)
+				}
+				continue
+			}
 			svcPortID, err := utils.BackendToServicePortID(p.Backend, ing.Namespace)
 			if err != nil {
 				// Only error possible is Backend is not a Service Backend, so move to next path
@@ -357,7 +459,7 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 					if path == "" {
 						path = DefaultPath
 					}
-					pathRules = append(pathRules, utils.PathRule{Path: path, Backend: *svcPort})
+					pathRules = append(pathRules, utils.PathRule{Path: path, Backend: *svcPort, WeightedBackends: weightedBackends[trafficSplitTarget(svcPortID)]})
 				}
 			}
 		}
@@ -369,7 +471,24 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 		urlMap.PutPathRulesForHost(host, pathRules)
 	}
 
+	if feConfig := t.frontendConfigForIngress(ing); feConfig != nil && len(feConfig.Spec.RouteRules) > 0 {
+		source := fmt.Sprintf("FrontendConfig %s/%s", feConfig.Namespace, feConfig.Name)
+		routeErrs, warning := t.translateRouteRules(ing, feConfig.Spec.RouteRules, source, urlMap, params, namer)
+		errs = append(errs, routeErrs...)
+		warnings = warnings || warning
+	}
+
 	if ing.Spec.DefaultBackend != nil {
+		if resourceRule, ok, err := t.resourceBackendPathRule(*ing.Spec.DefaultBackend, ing.Namespace, params); ok {
+			if err != nil {
+				errs = append(errs, err)
+				return urlMap, errs, warnings
+			}
+			// The resource serves the default backend, the system default
+			// backend is only kept as the backend service of the url map.
+			urlMap.DefaultResource = &resourceRule
+			return t.translateSystemDefaultBackend(urlMap, systemDefaultBackend, params, namer, errs, warnings)
+		}
 		svcPortID, err := utils.BackendToServicePortID(*ing.Spec.DefaultBackend, ing.Namespace)
 		if err != nil {
 			errs = append(errs, err)
@@ -379,6 +498,7 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 		warnings = warnings || warning
 		if err == nil {
 			urlMap.DefaultBackend = svcPort
+			urlMap.DefaultWeightedBackends = weightedBackends[trafficSplitTarget(svcPortID)]
 			return urlMap, errs, warnings
 		}
 
@@ -386,6 +506,12 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 		return urlMap, errs, warnings
 	}
 
+	return t.translateSystemDefaultBackend(urlMap, systemDefaultBackend, params, namer, errs, warnings)
+}
+
+// translateSystemDefaultBackend sets the system default backend as the
+// default backend of the given url map.
+func (t *Translator) translateSystemDefaultBackend(urlMap *utils.GCEURLMap, systemDefaultBackend utils.ServicePortID, params *getServicePortParams, namer namer_util.BackendNamer, errs []error, warnings bool) (*utils.GCEURLMap, []error, bool) {
 	svcPort, err, warning := t.getServicePort(systemDefaultBackend, params, namer)
 	warnings = warnings || warning
 	if err == nil {
@@ -397,6 +523,27 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 	return urlMap, errs, warnings
 }
 
+// trafficSplitTarget returns the key of the traffic split of the given
+// Service port.
+func trafficSplitTarget(id utils.ServicePortID) annotations.TrafficSplitTarget {
+	return annotations.TrafficSplitTarget{ServiceName: id.Service.Name, ServicePort: id.Port}
+}
+
+// resourceBackendPathRule returns the path rule, without its path, for the
+// given backend if it references a BackendBucket or a ServerlessNEG. It
+// returns false if the backend does not reference a supported resource.
+func (t *Translator) resourceBackendPathRule(be v1.IngressBackend, namespace string, params *getServicePortParams) (utils.PathRule, bool, error) {
+	if bbName, ok := utils.BackendToBackendBucketName(be); ok {
+		backendBucket, err := t.getBackendBucket(types.NamespacedName{Namespace: namespace, Name: bbName}, params)
+		return utils.PathRule{BackendBucket: backendBucket}, true, err
+	}
+	if snegName, ok := utils.BackendToServerlessNEGName(be); ok {
+		backendService, err := t.getServerlessNEG(types.NamespacedName{Namespace: namespace, Name: snegName}, params)
+		return utils.PathRule{BackendService: backendService}, true, err
+	}
+	return utils.PathRule{}, false, nil
+}
+
 // validateAndGetPaths will validate the path based on the specified path type and will return the
 // the path rules that should be used. If no path type is provided, the path type will be assumed
 // to be ImplementationSpecific. If a non existent path type is provided, an error will be returned.
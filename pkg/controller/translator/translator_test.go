@@ -41,11 +41,19 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/apis/backendbucket"
+	backendbucketv1beta1 "k8s.io/ingress-gce/pkg/apis/backendbucket/v1beta1"
 	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/apis/serverlessneg"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	backendbucketclient "k8s.io/ingress-gce/pkg/backendbucket/client/clientset/versioned/fake"
+	informerbackendbucket "k8s.io/ingress-gce/pkg/backendbucket/client/informers/externalversions/backendbucket/v1beta1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
 	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
+	serverlessnegclient "k8s.io/ingress-gce/pkg/serverlessneg/client/clientset/versioned/fake"
+	informerserverlessneg "k8s.io/ingress-gce/pkg/serverlessneg/client/informers/externalversions/serverlessneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/endpointslices"
@@ -84,14 +92,20 @@ func configuredFakeTranslator() *Translator {
 	NodeInformer := informerv1.NewNodeInformer(client, resyncPeriod, utils.NewNamespaceIndexer())
 	EndpointSliceInformer := discoveryinformer.NewEndpointSliceInformer(client, namespace, 0,
 		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc, endpointslices.EndpointSlicesByServiceIndex: endpointslices.EndpointSlicesByServiceFunc})
+	BackendBucketInformer := informerbackendbucket.NewBackendBucketInformer(backendbucketclient.NewSimpleClientset(), namespace, resyncPeriod, utils.NewNamespaceIndexer())
+	ServerlessNEGInformer := informerserverlessneg.NewServerlessNEGInformer(serverlessnegclient.NewSimpleClientset(), namespace, resyncPeriod, utils.NewNamespaceIndexer())
 	return NewTranslator(
 		ServiceInformer,
 		BackendConfigInformer,
 		NodeInformer,
 		PodInformer,
 		EndpointSliceInformer,
+		nil,
+		BackendBucketInformer,
+		ServerlessNEGInformer,
 		client,
 		healthchecks.NewFakeRecorderGetter(0),
+		utils.IngressClassifier{},
 		false,
 		false,
 		klog.TODO(),
@@ -475,6 +489,30 @@ func TestGetServicePort(t *testing.T) {
 				NEGEnabled:           true,
 			},
 		},
+		{
+			desc:        "ExternalName service with NEG",
+			annotations: map[string]string{annotations.NEGAnnotationKey: `{"ingress":true}`},
+			spec: apiv1.ServiceSpec{
+				Type:         apiv1.ServiceTypeExternalName,
+				ExternalName: "example.com",
+				Ports:        []apiv1.ServicePort{{Name: "https", Port: 443}},
+			},
+			id: utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "https"}},
+			wantServicePort: &utils.ServicePort{
+				ID: utils.ServicePortID{
+					Service: types.NamespacedName{
+						Namespace: "default",
+						Name:      "foo",
+					},
+					Port: v1.ServiceBackendPort{Name: "https"},
+				},
+				Port:       443,
+				PortName:   "https",
+				Protocol:   "HTTP",
+				NEGEnabled: true,
+				GlobalNEG:  true,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -1386,3 +1424,218 @@ func TestSetThcOptInOnSvc(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateIngressBackendBucket(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	bbLister := translator.BackendBucketInformer.GetIndexer()
+
+	svc := test.NewService(types.NamespacedName{Name: "first-service", Namespace: "default"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Port: 80}},
+	})
+	svcLister.Add(svc)
+	bbLister.Add(&backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "my-bucket"},
+		Status: backendbucketv1beta1.BackendBucketStatus{
+			SelfLink: "https://www.googleapis.com/compute/v1/projects/mock-project/global/backendBuckets/k8s1-bb-static",
+		},
+	})
+	bbLister.Add(&backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-synced", Namespace: "default"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "my-bucket"},
+	})
+
+	apiGroup := backendbucket.GroupName
+	ingressWithBucket := func(bbName string) *v1.Ingress {
+		return test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+			v1.IngressSpec{
+				DefaultBackend: test.Backend("first-service", port80),
+				Rules: []v1.IngressRule{{
+					Host: "foo.bar",
+					IngressRuleValue: v1.IngressRuleValue{
+						HTTP: &v1.HTTPIngressRuleValue{
+							Paths: []v1.HTTPIngressPath{{
+								Path: "/static",
+								Backend: v1.IngressBackend{
+									Resource: &apiv1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: backendbucket.Kind, Name: bbName},
+								},
+							}},
+						},
+					},
+				}},
+			})
+	}
+	firstService := utils.ServicePort{ID: utils.ServicePortID{Service: types.NamespacedName{Name: "first-service", Namespace: "default"}, Port: port80}}
+
+	cases := []struct {
+		desc         string
+		ing          *v1.Ingress
+		wantErrCount int
+		wantPaths    []utils.PathRule
+	}{
+		{
+			desc:      "synced backend bucket",
+			ing:       ingressWithBucket("static"),
+			wantPaths: []utils.PathRule{{Path: "/static", BackendBucket: "k8s1-bb-static"}},
+		},
+		{
+			desc:         "backend bucket not synced",
+			ing:          ingressWithBucket("not-synced"),
+			wantErrCount: 1,
+		},
+		{
+			desc:         "missing backend bucket",
+			ing:          ingressWithBucket("missing"),
+			wantErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wantGCEURLMap := utils.NewGCEURLMap(klog.TODO())
+			wantGCEURLMap.DefaultBackend = &firstService
+			wantGCEURLMap.PutPathRulesForHost("foo.bar", tc.wantPaths)
+
+			gotGCEURLMap, gotErrs, _ := translator.TranslateIngress(tc.ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateIngress() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, wantGCEURLMap) {
+				t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), wantGCEURLMap.String())
+			}
+			if svcPorts := gotGCEURLMap.AllServicePorts(); len(svcPorts) != 1 {
+				t.Errorf("AllServicePorts() = %+v, want only the default backend", svcPorts)
+			}
+		})
+	}
+}
+
+func TestTranslateIngressDefaultBackendBucket(t *testing.T) {
+	translator := fakeTranslator()
+	translator.ServiceInformer.GetIndexer().Add(test.NewService(types.NamespacedName{Name: "default-http-backend", Namespace: "kube-system"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+	}))
+	translator.BackendBucketInformer.GetIndexer().Add(&backendbucketv1beta1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+		Spec:       backendbucketv1beta1.BackendBucketSpec{BucketName: "my-bucket"},
+		Status: backendbucketv1beta1.BackendBucketStatus{
+			SelfLink: "https://www.googleapis.com/compute/v1/projects/mock-project/global/backendBuckets/k8s1-bb-static",
+		},
+	})
+
+	apiGroup := backendbucket.GroupName
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		v1.IngressSpec{
+			DefaultBackend: &v1.IngressBackend{
+				Resource: &apiv1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: backendbucket.Kind, Name: "static"},
+			},
+		})
+
+	// The system default backend remains the backend service of the url map.
+	wantGCEURLMap := utils.NewGCEURLMap(klog.TODO())
+	wantGCEURLMap.DefaultBackend = &defaultBackend
+	wantGCEURLMap.DefaultResource = &utils.PathRule{BackendBucket: "k8s1-bb-static"}
+
+	gotGCEURLMap, gotErrs, _ := translator.TranslateIngress(ing, defaultBackend.ID, defaultNamer)
+	if len(gotErrs) != 0 {
+		t.Errorf("TranslateIngress() = _, %+v, want no errs", gotErrs)
+	}
+	if !utils.EqualMapping(gotGCEURLMap, wantGCEURLMap) {
+		t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), wantGCEURLMap.String())
+	}
+}
+
+func TestTranslateIngressServerlessNEG(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	snegLister := translator.ServerlessNEGInformer.GetIndexer()
+
+	svc := test.NewService(types.NamespacedName{Name: "first-service", Namespace: "default"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Port: 80}},
+	})
+	svcLister.Add(svc)
+	snegSpec := serverlessnegv1beta1.ServerlessNEGSpec{
+		Region:   "us-central1",
+		CloudRun: &serverlessnegv1beta1.CloudRunTarget{Service: "hello"},
+	}
+	snegLister.Add(&serverlessnegv1beta1.ServerlessNEG{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "default"},
+		Spec:       snegSpec,
+		Status: serverlessnegv1beta1.ServerlessNEGStatus{
+			NetworkEndpointGroup: "https://www.googleapis.com/compute/v1/projects/mock-project/regions/us-central1/networkEndpointGroups/k8s1-sneg-run",
+			BackendService:       "https://www.googleapis.com/compute/v1/projects/mock-project/global/backendServices/k8s1-sneg-run",
+		},
+	})
+	snegLister.Add(&serverlessnegv1beta1.ServerlessNEG{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-synced", Namespace: "default"},
+		Spec:       snegSpec,
+	})
+
+	apiGroup := serverlessneg.GroupName
+	ingressWithServerlessNEG := func(snegName string) *v1.Ingress {
+		return test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+			v1.IngressSpec{
+				DefaultBackend: test.Backend("first-service", port80),
+				Rules: []v1.IngressRule{{
+					Host: "foo.bar",
+					IngressRuleValue: v1.IngressRuleValue{
+						HTTP: &v1.HTTPIngressRuleValue{
+							Paths: []v1.HTTPIngressPath{{
+								Path: "/run",
+								Backend: v1.IngressBackend{
+									Resource: &apiv1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: serverlessneg.Kind, Name: snegName},
+								},
+							}},
+						},
+					},
+				}},
+			})
+	}
+	firstService := utils.ServicePort{ID: utils.ServicePortID{Service: types.NamespacedName{Name: "first-service", Namespace: "default"}, Port: port80}}
+
+	cases := []struct {
+		desc         string
+		ing          *v1.Ingress
+		wantErrCount int
+		wantPaths    []utils.PathRule
+	}{
+		{
+			desc:      "synced serverless NEG",
+			ing:       ingressWithServerlessNEG("run"),
+			wantPaths: []utils.PathRule{{Path: "/run", BackendService: "k8s1-sneg-run"}},
+		},
+		{
+			desc:         "serverless NEG not synced",
+			ing:          ingressWithServerlessNEG("not-synced"),
+			wantErrCount: 1,
+		},
+		{
+			desc:         "missing serverless NEG",
+			ing:          ingressWithServerlessNEG("missing"),
+			wantErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wantGCEURLMap := utils.NewGCEURLMap(klog.TODO())
+			wantGCEURLMap.DefaultBackend = &firstService
+			wantGCEURLMap.PutPathRulesForHost("foo.bar", tc.wantPaths)
+
+			gotGCEURLMap, gotErrs, _ := translator.TranslateIngress(tc.ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateIngress() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, wantGCEURLMap) {
+				t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), wantGCEURLMap.String())
+			}
+			if svcPorts := gotGCEURLMap.AllServicePorts(); len(svcPorts) != 1 {
+				t.Errorf("AllServicePorts() = %+v, want only the default backend", svcPorts)
+			}
+		})
+	}
+}
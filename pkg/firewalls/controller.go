@@ -128,21 +128,21 @@ func NewFirewallController(
 	ctx.IngressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			addIng := obj.(*v1.Ingress)
-			if !utils.IsGCEIngress(addIng) && !utils.IsGCEMultiClusterIngress(addIng) {
+			if !fwc.isGLBCIngress(addIng) {
 				return
 			}
 			fwc.queue.Enqueue(queueKey)
 		},
 		DeleteFunc: func(obj interface{}) {
 			delIng := obj.(*v1.Ingress)
-			if !utils.IsGCEIngress(delIng) && !utils.IsGCEMultiClusterIngress(delIng) {
+			if !fwc.isGLBCIngress(delIng) {
 				return
 			}
 			fwc.queue.Enqueue(queueKey)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curIng := cur.(*v1.Ingress)
-			if !utils.IsGCEIngress(curIng) && !utils.IsGCEMultiClusterIngress(curIng) {
+			if !fwc.isGLBCIngress(curIng) {
 				return
 			}
 			fwc.queue.Enqueue(queueKey)
@@ -153,7 +153,7 @@ func NewFirewallController(
 	ctx.ServiceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			svc := obj.(*apiv1.Service)
-			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc).AsList()
+			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc, ctx.FrontendConfigs().List()).AsList()
 			if len(ings) > 0 {
 				fwc.queue.Enqueue(queueKey)
 			}
@@ -161,7 +161,7 @@ func NewFirewallController(
 		UpdateFunc: func(old, cur interface{}) {
 			if !reflect.DeepEqual(old, cur) {
 				svc := cur.(*apiv1.Service)
-				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc).AsList()
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesService(svc, ctx.FrontendConfigs().List()).AsList()
 				if len(ings) > 0 {
 					fwc.queue.Enqueue(queueKey)
 				}
@@ -218,6 +218,14 @@ func (fwc *FirewallController) Run() {
 	<-fwc.stopCh
 }
 
+// isGLBCIngress returns true if the given Ingress should be processed by this
+// controller. Ingresses whose IngressClass parameters cannot be resolved are
+// processed as well.
+func (fwc *FirewallController) isGLBCIngress(ing *v1.Ingress) bool {
+	isGLBCIngress, err := fwc.ctx.IngressClassifier.IsGLBCIngress(ing)
+	return isGLBCIngress || err != nil
+}
+
 // This should only be called when the process is being terminated.
 func (fwc *FirewallController) shutdown() {
 	fwc.logger.Info("Shutting down Firewall Controller")
@@ -231,8 +239,11 @@ func (fwc *FirewallController) sync(key string) error {
 	}
 	fwc.logger.V(3).Info("Syncing firewall")
 
+	// Ingresses whose IngressClass parameters cannot be resolved are kept,
+	// so that their firewall rules are not removed.
 	gceIngresses := operator.Ingresses(fwc.ctx.Ingresses().List()).Filter(func(ing *v1.Ingress) bool {
-		return utils.IsGCEIngress(ing)
+		isGCEIngress, err := fwc.ctx.IngressClassifier.IsGCEIngress(ing)
+		return isGCEIngress || err != nil
 	}).AsList()
 
 	// If there are no more ingresses, then delete the firewall rule.
@@ -307,7 +318,11 @@ func (fwc *FirewallController) sync(key string) error {
 func (fwc *FirewallController) ilbFirewallSrcRange(gceIngresses []*v1.Ingress) (string, error) {
 	ilbEnabled := false
 	for _, ing := range gceIngresses {
-		if utils.IsGCEL7ILBIngress(ing) {
+		isL7ILB, err := fwc.ctx.IngressClassifier.IsL7ILB(ing)
+		if err != nil {
+			return "", err
+		}
+		if isL7ILB {
 			ilbEnabled = true
 			break
 		}
@@ -327,7 +342,11 @@ func (fwc *FirewallController) ilbFirewallSrcRange(gceIngresses []*v1.Ingress) (
 func (fwc *FirewallController) rxlbFirewallSrcRange(gceIngresses []*v1.Ingress) (string, error) {
 	rxlbEnabled := false
 	for _, ing := range gceIngresses {
-		if utils.IsGCEL7XLBRegionalIngress(ing) {
+		isL7XLBRegional, err := fwc.ctx.IngressClassifier.IsL7XLBRegional(ing)
+		if err != nil {
+			return "", err
+		}
+		if isL7XLBRegional {
 			rxlbEnabled = true
 			klog.Infof("Found Regional XLB Enabled on ingress %s/%s, requires regional xlb firewall.", ing.Namespace, ing.Name)
 			break
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/component-base/config"
 	leaderelectionconfig "k8s.io/component-base/config/options"
+	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
 )
 
@@ -76,6 +77,9 @@ var F = struct {
 	IngressClass                     string
 	KubeConfigFile                   string
 	NegGCPeriod                      time.Duration
+	NegGCRetentionPeriod             time.Duration
+	NegGCDryRun                      bool
+	NEGNameTemplate                  string
 	NumNegGCWorkers                  int
 	NodePortRanges                   PortRanges
 	ResyncPeriod                     time.Duration
@@ -120,10 +124,13 @@ var F = struct {
 	EnableMultipleIGs                        bool
 	EnableL4StrongSessionAffinity            bool
 	EnableNEGLabelPropagation                bool
+	LabelPropagationConfigMapNamespace       string
+	LabelPropagationConfigMapName            string
 	EnableMultiNetworking                    bool
 	MaxIGSize                                int
 	EnableDegradedMode                       bool
 	EnableDegradedModeMetrics                bool
+	DegradedModeExitSyncs                    int
 	EnableDualStackNEG                       bool
 	EnableFirewallCR                         bool
 	DisableFWEnforcement                     bool
@@ -142,6 +149,18 @@ var F = struct {
 	MultiProjectCRDProjectNameLabel          string
 	ClusterSliceAPIGroup                     string
 	EnableL4MixedProtocol                    bool
+	EnableIngressClassParams                 bool
+	EnableGateway                            bool
+	EnableBackendBuckets                     bool
+	EnableServerlessNEGs                     bool
+	EnableStaticAddresses                    bool
+	EnableNEGCheckpoint                      bool
+	EnableNEGEndpointSummary                 bool
+	EnableNEGTopologyAwareHints              bool
+	EnableL4NEGReadinessGate                 bool
+	NEGMaxConcurrentEndpointOperations       int
+	NEGShards                                int
+	EnableL4ILBMultipleForwardingRules       bool
 }{
 	GCERateLimitScale: 1.0,
 }
@@ -267,6 +286,9 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.StringVar(&F.LeaderElection.LockObjectName, "lock-object-name", F.LeaderElection.LockObjectName, "Define the name of the lock object.")
 	flag.DurationVar(&F.NegGCPeriod, "neg-gc-period", 120*time.Second,
 		`Relist and garbage collect NEGs this often.`)
+	flag.DurationVar(&F.NegGCRetentionPeriod, "neg-gc-retention-period", 0, "Period for which the NEGs of a ServiceNetworkEndpointGroup are kept in the TO_BE_DELETED state before NEG garbage collection deletes them. If zero, unused NEGs are deleted as soon as they are found.")
+	flag.BoolVar(&F.NegGCDryRun, "neg-gc-dry-run", false, "Only emit events and metrics for the NEGs NEG garbage collection would delete, without deleting them.")
+	flag.StringVar(&F.NEGNameTemplate, "neg-name-template", "", `Template of the names of the standalone NEGs exposed without a custom name, using the variables {namespace}, {service}, {port} and optionally {cluster}, e.g. "{cluster}-{namespace}-{service}-{port}". {cluster} is the value of --gke-cluster-name. If empty, NEG names are generated by the controller.`)
 	flag.IntVar(&F.NumNegGCWorkers, "num-neg-gc-workers", 10, "Number of goroutines created by NEG garbage collector. This value controls the maximum number of concurrent calls made to the GCE NEG Delete API.")
 	flag.BoolVar(&F.EnableReadinessReflector, "enable-readiness-reflector", true, "Enable NEG Readiness Reflector")
 	flag.BoolVar(&F.FinalizerAdd, "enable-finalizer-add",
@@ -298,8 +320,8 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.BoolVar(&F.EnableRecalculateUHCOnBCRemoval, "enable-recalculate-uhc-on-backendconfig-removal", false, "Recalculate health check parameters when BackendConfig is removed from service. This flag cannot be used without --enable-update-hc-description.")
 	flag.IntVar(&F.THCPort, "transparent-health-checks-port", 7877, "The port for Transparent Health Checks. It must be aligned with Transparent Health Check controller server. This flag only works when --enable-transparent-health-checks is enabled.")
 	flag.BoolVar(&F.EnablePinhole, "enable-pinhole", false, "Enable Pinhole firewall feature")
-	flag.BoolVar(&F.EnableL4ILBDualStack, "enable-l4ilb-dual-stack", false, "Enable Dual-Stack handling for L4 Internal Load Balancers")
-	flag.BoolVar(&F.EnableL4NetLBDualStack, "enable-l4netlb-dual-stack", false, "Enable Dual-Stack handling for L4 External Load Balancers")
+	flag.BoolVar(&F.EnableL4ILBDualStack, "enable-l4ilb-dual-stack", false, "Enable Dual-Stack handling for L4 Internal Load Balancers, including IPv6-only Services. If disabled, the IP families of Services are ignored and IPv6-only Services get IPv4 load balancers.")
+	flag.BoolVar(&F.EnableL4NetLBDualStack, "enable-l4netlb-dual-stack", false, "Enable Dual-Stack handling for L4 External Load Balancers, including IPv6-only Services. If disabled, the IP families of Services are ignored and IPv6-only Services get IPv4 load balancers.")
 	// StrongSessionAffinity is a restricted feature that is enabled on
 	// allow-listed projects only. If you need access to this feature for your
 	// External L4 Load Balancer, please contact Google Cloud support team.
@@ -311,7 +333,10 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.DurationVar(&F.NegMetricsExportInterval, "neg-metrics-export-interval", 5*time.Second, `Period for calculating and exporting internal neg controller metrics, not usage.`)
 	flag.BoolVar(&F.EnableDegradedMode, "enable-degraded-mode", false, `Enable degraded mode endpoint calculation and use results when error state is triggered. enabledDegradedMode also enables degrade mode correctness metrics with or without enabledDegradedModeMetrics.`)
 	flag.BoolVar(&F.EnableDegradedModeMetrics, "enable-degraded-mode-metrics", false, `Enable metrics collection for degraded mode, but uses normal mode calculation result when error state is triggered.`)
+	flag.IntVar(&F.DegradedModeExitSyncs, "degraded-mode-exit-syncs", 3, `Number of consecutive syncs in which the normal and degraded mode endpoint calculations agree before a NEG syncer exits degraded mode, so that a syncer whose endpoints flap between valid and invalid keeps using degraded mode. Only used with --enable-degraded-mode.`)
 	flag.BoolVar(&F.EnableNEGLabelPropagation, "enable-label-propagation", false, "Enable NEG endpoint label propagation")
+	flag.StringVar(&F.LabelPropagationConfigMapNamespace, "label-propagation-configmap-namespace", "kube-system", "Namespace of the ConfigMap holding the NEG endpoint label propagation config.")
+	flag.StringVar(&F.LabelPropagationConfigMapName, "label-propagation-configmap-name", "", `Name of the ConfigMap holding the NEG endpoint label propagation config under the "config" key. The config is reloaded when the ConfigMap changes, and a reloaded config only applies to NEG endpoints attached afterwards, as the labels of attached endpoints cannot be updated. It falls back to the LABEL_PROPAGATION_CONFIG environment variable when the ConfigMap does not exist. Disabled if empty.`)
 	flag.BoolVar(&F.EnableDualStackNEG, "enable-dual-stack-neg", false, `Enable support for Dual-Stack NEGs within the NEG Controller`)
 	flag.BoolVar(&F.EnableFirewallCR, "enable-firewall-cr", false, "Enable generating firewall CR")
 	flag.BoolVar(&F.DisableFWEnforcement, "disable-fw-enforcement", false, "Disable Ingress controller to enforce the firewall rules. If set to true, Ingress Controller stops creating GCE firewall rules. We can only enable this if enable-firewall-cr sets to true.")
@@ -332,6 +357,18 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.StringVar(&F.MultiProjectCRDProjectNameLabel, "multi-project-crd-project-name-label", "", "The label key for project name of Project in a Project CRD in the Multi-Project cluster.")
 	flag.BoolVar(&F.EnableL4MixedProtocol, "enable-l4-mixed-protocol", false, "Enable support for mixed protocol L4 load balancers.")
 	flag.StringVar(&F.ClusterSliceAPIGroup, "cluster-slice-api-group", "", "The API group for the ClusterSlice CRD.")
+	flag.BoolVar(&F.EnableIngressClassParams, "enable-ingress-class-params", false, "Enable selecting the L7 load balancer type of an Ingress through the GCPIngressParams referenced by its IngressClass.")
+	flag.BoolVar(&F.EnableGateway, "enable-gateway", false, "Enable provisioning L7 load balancers for Gateway API Gateways and HTTPRoutes. Only the Gateways of the GatewayClasses gce, gce-internal and gce-regional-external with controllerName networking.gke.io/ingress-gce are handled.")
+	flag.BoolVar(&F.EnableBackendBuckets, "enable-backend-buckets", false, "Enable BackendBucket CRs as the resource backends of Ingress paths.")
+	flag.BoolVar(&F.EnableServerlessNEGs, "enable-serverless-negs", false, "Enable ServerlessNEG CRs as the resource backends of Ingress paths, to route to Cloud Run, App Engine and Cloud Functions.")
+	flag.BoolVar(&F.EnableStaticAddresses, "enable-static-addresses", false, "Enable StaticAddress CRs to reserve IP addresses that L4 Services and Ingresses reference by name.")
+	flag.BoolVar(&F.EnableNEGCheckpoint, "enable-neg-checkpoint", false, "Record the NEG endpoints and in-flight operations in the ServiceNetworkEndpointGroup status, so that a restarted NEG controller can resume without listing every NEG.")
+	flag.BoolVar(&F.EnableNEGEndpointSummary, "enable-neg-endpoint-summary", false, "Write a summary of the endpoint states of each NEG, including attach state, exclusion reasons and health, in the ServiceNetworkEndpointGroup status.")
+	flag.BoolVar(&F.EnableNEGTopologyAwareHints, "enable-neg-topology-aware-hints", false, "Only include endpoints in the GCE_VM_IP_PORT NEGs of the zones their EndpointSlice topology hints assign them to, for Services that use topology-aware routing.")
+	flag.BoolVar(&F.EnableL4NEGReadinessGate, "enable-l4-neg-readiness-gate", false, "Evaluate the NEG readiness gate of pods backing L4 ILB and NetLB Services with externalTrafficPolicy Local, based on the attachment of their node to the GCE_VM_IP NEG. The pods must declare the cloud.google.com/load-balancer-neg-ready readiness gate.")
+	flag.IntVar(&F.NEGMaxConcurrentEndpointOperations, "neg-max-concurrent-endpoint-operations", 0, "Maximum number of concurrent calls made to the GCE NEG attach and detach endpoints APIs by all NEG syncers. The operations a syncer has pending for the same NEG are merged into fuller batches while they wait; operations on different NEGs are not merged, as each call updates a single NEG. If zero, each syncer makes its own calls without a shared limit.")
+	flag.BoolVar(&F.EnableL4ILBMultipleForwardingRules, "enable-l4-ilb-multiple-forwarding-rules", false, "Create the additional forwarding rules requested by the networking.gke.io/internal-load-balancer-forwarding-rules annotation of L4 ILB Services, each with its own VIP pointing at the backend service of the Service.")
+	flag.IntVar(&F.NEGShards, "neg-shards", 0, "Number of shards the Services are assigned to by consistent hashing, to run the NEG controller on every replica instead of a single leader. Each shard is owned by one replica through a Lease in the lock object namespace, and is given up when its Lease cannot be renewed within --leader-elect-renew-deadline. Requires the NEG CRD. If zero, the NEG controller runs on the leader for all Services.")
 }
 
 func Validate() {
@@ -343,6 +380,26 @@ func Validate() {
 	if F.THCPort != 7877 && !F.EnableTransparentHealthChecks {
 		klog.Fatalf("The flag --transparent-health-checks-port cannot be used without --enable-transparent-health-checks.")
 	}
+
+	if F.NEGShards < 0 {
+		klog.Fatalf("The flag --neg-shards must not be negative.")
+	}
+	if F.NEGShards > 0 {
+		// The NEG CRD is installed by the NEG controller, and is required
+		// to find the NEGs of the services of the other replicas.
+		if !F.EnableNEGController {
+			klog.Fatalf("The flag --neg-shards cannot be used without --enable-neg-controller, which installs the NEG CRD.")
+		}
+		if F.LeaderElection.RenewDeadline.Duration >= F.LeaderElection.LeaseDuration.Duration {
+			klog.Fatalf("The flag --neg-shards requires --leader-elect-renew-deadline to be shorter than --leader-elect-lease-duration.")
+		}
+	}
+
+	if F.NEGNameTemplate != "" {
+		if _, err := namer.ParseNEGNameTemplate(F.NEGNameTemplate); err != nil {
+			klog.Fatalf("The flag --neg-name-template is invalid: %v", err)
+		}
+	}
 }
 
 type RateLimitSpecs struct {
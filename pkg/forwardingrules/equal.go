@@ -31,7 +31,9 @@ func EqualIPv4(fr1, fr2 *composite.ForwardingRule) (bool, error) {
 		fr1.AllPorts == fr2.AllPorts &&
 		equalResourcePaths(fr1.Subnetwork, fr2.Subnetwork) &&
 		equalResourcePaths(fr1.Network, fr2.Network) &&
-		fr1.NetworkTier == fr2.NetworkTier, nil
+		fr1.NetworkTier == fr2.NetworkTier &&
+		fr1.IsMirroringCollector == fr2.IsMirroringCollector &&
+		equalServiceDirectoryRegistrations(fr1.ServiceDirectoryRegistrations, fr2.ServiceDirectoryRegistrations), nil
 }
 
 // EqualIPv6 compares IPv6 firewall rules and returns true if there are identical from LB point of view.
@@ -75,3 +77,17 @@ func equalPorts(existingPorts, newPorts []string, existingPortRange, newPortRang
 func equalResourcePaths(rp1, rp2 string) bool {
 	return rp1 == rp2 || utils.EqualResourceIDs(rp1, rp2)
 }
+
+// equalServiceDirectoryRegistrations compares the namespace and service of
+// the registrations. The region is ignored as it is filled in by GCE.
+func equalServiceDirectoryRegistrations(r1, r2 []*composite.ForwardingRuleServiceDirectoryRegistration) bool {
+	if len(r1) != len(r2) {
+		return false
+	}
+	for i := range r1 {
+		if r1[i].Namespace != r2[i].Namespace || r1[i].Service != r2[i].Service {
+			return false
+		}
+	}
+	return true
+}
@@ -346,6 +346,62 @@ func TestEqualIPv4(t *testing.T) {
 			},
 			expectEqual: true,
 		},
+		{
+			desc:       "mirroring collector mismatch",
+			oldFwdRule: fwdRuleTCP,
+			newFwdRule: &composite.ForwardingRule{
+				Name:                 "tcp-fwd-rule",
+				IPAddress:            "10.0.0.0",
+				Ports:                []string{"123"},
+				IPProtocol:           "TCP",
+				LoadBalancingScheme:  string(cloud.SchemeInternal),
+				BackendService:       "http://www.googleapis.com/projects/test/regions/us-central1/backendServices/bs1",
+				IsMirroringCollector: true,
+			},
+			expectEqual: false,
+		},
+		{
+			desc:       "service directory registration added",
+			oldFwdRule: fwdRuleTCP,
+			newFwdRule: &composite.ForwardingRule{
+				Name:                "tcp-fwd-rule",
+				IPAddress:           "10.0.0.0",
+				Ports:               []string{"123"},
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: string(cloud.SchemeInternal),
+				BackendService:      "http://www.googleapis.com/projects/test/regions/us-central1/backendServices/bs1",
+				ServiceDirectoryRegistrations: []*composite.ForwardingRuleServiceDirectoryRegistration{
+					{Namespace: "ns1", Service: "svc1"},
+				},
+			},
+			expectEqual: false,
+		},
+		{
+			desc: "same service directory registration, region set by GCE",
+			oldFwdRule: &composite.ForwardingRule{
+				Name:                "tcp-fwd-rule",
+				IPAddress:           "10.0.0.0",
+				Ports:               []string{"123"},
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: string(cloud.SchemeInternal),
+				BackendService:      "http://www.googleapis.com/projects/test/regions/us-central1/backendServices/bs1",
+				ServiceDirectoryRegistrations: []*composite.ForwardingRuleServiceDirectoryRegistration{
+					{Namespace: "ns1", Service: "svc1", ServiceDirectoryRegion: "us-central1"},
+				},
+			},
+			newFwdRule: &composite.ForwardingRule{
+				Name:                "tcp-fwd-rule",
+				IPAddress:           "10.0.0.0",
+				Ports:               []string{"123"},
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: string(cloud.SchemeInternal),
+				BackendService:      "http://www.googleapis.com/projects/test/regions/us-central1/backendServices/bs1",
+				ServiceDirectoryRegistrations: []*composite.ForwardingRuleServiceDirectoryRegistration{
+					{Namespace: "ns1", Service: "svc1"},
+				},
+			},
+			expectEqual: true,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			flags.F.EnableDiscretePortForwarding = tc.discretePortForwarding
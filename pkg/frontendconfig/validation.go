@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+// ValidateCustomErrorResponsePolicy returns an error if the given custom
+// error response policy cannot be translated to a UrlMap.
+func ValidateCustomErrorResponsePolicy(policy *frontendconfigv1beta1.CustomErrorResponsePolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if (policy.ErrorBackendBucket == "") == (policy.ErrorService == nil) {
+		return fmt.Errorf("custom error response policy must specify exactly one of errorBackendBucket and errorService")
+	}
+	if svc := policy.ErrorService; svc != nil {
+		if svc.ServiceName == "" {
+			return fmt.Errorf("custom error response policy errorService must specify serviceName")
+		}
+		if (svc.ServicePort.Name == "") == (svc.ServicePort.Number == 0) {
+			return fmt.Errorf("custom error response policy errorService must specify exactly one of servicePort name and number")
+		}
+	}
+	if len(policy.ErrorResponseRules) == 0 {
+		return fmt.Errorf("custom error response policy must specify at least one error response rule")
+	}
+
+	for _, rule := range policy.ErrorResponseRules {
+		if len(rule.MatchResponseCodes) == 0 {
+			return fmt.Errorf("custom error response rule for path %q must specify at least one response code", rule.Path)
+		}
+		for _, code := range rule.MatchResponseCodes {
+			if !validErrorResponseCode(code) {
+				return fmt.Errorf("invalid response code %q, must be 4xx, 5xx or a code between 400 and 599", code)
+			}
+		}
+		if !strings.HasPrefix(rule.Path, "/") || (len(rule.Path) > 1 && strings.HasSuffix(rule.Path, "/")) {
+			return fmt.Errorf("invalid custom error path %q, must start with a slash and must not end with a slash", rule.Path)
+		}
+		if rule.OverrideResponseCode != 0 && (rule.OverrideResponseCode < 200 || rule.OverrideResponseCode > 599) {
+			return fmt.Errorf("invalid override response code %d, must be between 200 and 599", rule.OverrideResponseCode)
+		}
+	}
+	return nil
+}
+
+// validErrorResponseCode returns true if the given code is a class of error
+// codes or an error code that a custom error response rule can match.
+func validErrorResponseCode(code string) bool {
+	if code == "4xx" || code == "5xx" {
+		return true
+	}
+	n, err := strconv.Atoi(code)
+	return err == nil && n >= 400 && n <= 599
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"testing"
+
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+func TestValidateCustomErrorResponsePolicy(t *testing.T) {
+	t.Parallel()
+
+	rules := []frontendconfigv1beta1.CustomErrorResponseRule{
+		{MatchResponseCodes: []string{"4xx", "503"}, Path: "/errors/default.html"},
+		{MatchResponseCodes: []string{"5xx"}, Path: "/errors/5xx.html", OverrideResponseCode: 200},
+	}
+	errorService := &frontendconfigv1beta1.ErrorServiceBackend{ServiceName: "errors", ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 80}}
+
+	testCases := []struct {
+		desc    string
+		policy  *frontendconfigv1beta1.CustomErrorResponsePolicy
+		wantErr bool
+	}{
+		{
+			desc: "Nil policy",
+		},
+		{
+			desc:   "Backend bucket",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: rules},
+		},
+		{
+			desc:   "Service",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorService: errorService, ErrorResponseRules: rules},
+		},
+		{
+			desc:    "Missing backend bucket and Service",
+			policy:  &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorResponseRules: rules},
+			wantErr: true,
+		},
+		{
+			desc:    "Both backend bucket and Service",
+			policy:  &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorService: errorService, ErrorResponseRules: rules},
+			wantErr: true,
+		},
+		{
+			desc: "Service without port",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{
+				ErrorService:       &frontendconfigv1beta1.ErrorServiceBackend{ServiceName: "errors"},
+				ErrorResponseRules: rules,
+			},
+			wantErr: true,
+		},
+		{
+			desc:    "Missing rules",
+			policy:  &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors"},
+			wantErr: true,
+		},
+		{
+			desc: "Code out of range",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{MatchResponseCodes: []string{"302"}, Path: "/errors/default.html"},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "Invalid class of codes",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{MatchResponseCodes: []string{"3xx"}, Path: "/errors/default.html"},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "Path with trailing slash",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{MatchResponseCodes: []string{"5xx"}, Path: "/errors/"},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "Invalid override response code",
+			policy: &frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{MatchResponseCodes: []string{"5xx"}, Path: "/errors/5xx.html", OverrideResponseCode: 700},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateCustomErrorResponsePolicy(tc.policy)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("ValidateCustomErrorResponsePolicy() = %v, want err? %v", err, tc.wantErr)
+			}
+		})
+	}
+}
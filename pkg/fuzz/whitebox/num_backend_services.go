@@ -45,7 +45,7 @@ func (t *numBackendServicesTest) Test(ing *v1.Ingress, fc *frontendconfig.Fronte
 		expectedBackendServices++
 	}
 
-	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+	utils.TraverseIngressBackends(ing, fc, func(id utils.ServicePortID) bool {
 		if _, ok := t.uniqSvcPorts[id]; !ok {
 			expectedBackendServices++
 			t.uniqSvcPorts[id] = true
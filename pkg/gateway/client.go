@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/strings/slices"
+)
+
+// EnsureFinalizer ensures that the Gateway finalizer exists on the given
+// Gateway. It returns the updated Gateway.
+func EnsureFinalizer(client dynamic.Interface, gw *unstructured.Unstructured, gwLogger klog.Logger) (*unstructured.Unstructured, error) {
+	if slices.Contains(gw.GetFinalizers(), common.GatewayFinalizerKey) {
+		return gw, nil
+	}
+	updated := gw.DeepCopy()
+	updated.SetFinalizers(append(gw.GetFinalizers(), common.GatewayFinalizerKey))
+	updated, err := client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error adding finalizer to Gateway %s/%s: %w", gw.GetNamespace(), gw.GetName(), err)
+	}
+	gwLogger.Info("Added finalizer", "finalizerKey", common.GatewayFinalizerKey)
+	return updated, nil
+}
+
+// EnsureDeleteFinalizer ensures that the Gateway finalizer is deleted from
+// the given Gateway.
+func EnsureDeleteFinalizer(client dynamic.Interface, gw *unstructured.Unstructured, gwLogger klog.Logger) error {
+	if !slices.Contains(gw.GetFinalizers(), common.GatewayFinalizerKey) {
+		return nil
+	}
+	updated := gw.DeepCopy()
+	updated.SetFinalizers(slices.Filter(nil, gw.GetFinalizers(), func(f string) bool { return f != common.GatewayFinalizerKey }))
+	if _, err := client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error removing finalizer from Gateway %s/%s: %w", gw.GetNamespace(), gw.GetName(), err)
+	}
+	gwLogger.Info("Removed finalizer", "finalizer", common.GatewayFinalizerKey)
+	return nil
+}
+
+// UpdateStatus replaces the status of the given Gateway.
+func UpdateStatus(client dynamic.Interface, gw *unstructured.Unstructured, status GatewayStatus) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	updated := gw.DeepCopy()
+	if err := unstructured.SetNestedMap(updated.Object, content, "status"); err != nil {
+		return err
+	}
+	if _, err := client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating status of Gateway %s/%s: %w", gw.GetNamespace(), gw.GetName(), err)
+	}
+	return nil
+}
+
+// UpdateRouteStatus replaces the status of the given HTTPRoute.
+func UpdateRouteStatus(client dynamic.Interface, route *unstructured.Unstructured, status HTTPRouteStatus) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	updated := route.DeepCopy()
+	if err := unstructured.SetNestedMap(updated.Object, content, "status"); err != nil {
+		return err
+	}
+	if _, err := client.Resource(HTTPRouteGVR).Namespace(route.GetNamespace()).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating status of HTTPRoute %s/%s: %w", route.GetNamespace(), route.GetName(), err)
+	}
+	return nil
+}
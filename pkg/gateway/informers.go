@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewGatewayClassInformer constructs a new informer for GatewayClasses,
+// which are cluster scoped.
+func NewGatewayClassInformer(client dynamic.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return newInformer(client, GatewayClassGVR, metav1.NamespaceAll, resyncPeriod, cache.Indexers{})
+}
+
+// NewGatewayInformer constructs a new informer for Gateways.
+func NewGatewayInformer(client dynamic.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return newInformer(client, GatewayGVR, namespace, resyncPeriod, indexers)
+}
+
+// NewHTTPRouteInformer constructs a new informer for HTTPRoutes.
+func NewHTTPRouteInformer(client dynamic.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return newInformer(client, HTTPRouteGVR, namespace, resyncPeriod, indexers)
+}
+
+// newInformer returns an informer that stores the objects of the given
+// resource as *unstructured.Unstructured.
+func newInformer(client dynamic.Interface, gvr schema.GroupVersionResource, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.Resource(gvr).Namespace(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Resource(gvr).Namespace(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&unstructured.Unstructured{},
+		resyncPeriod,
+		indexers,
+	)
+}
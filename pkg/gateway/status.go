@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionAccepted indicates whether the Gateway is handled by this
+	// controller.
+	ConditionAccepted = "Accepted"
+	// ConditionProgrammed indicates whether the load balancer of the
+	// Gateway has been synced.
+	ConditionProgrammed = "Programmed"
+	// ConditionResolvedRefs indicates whether the backendRefs of an
+	// HTTPRoute reference existing Services.
+	ConditionResolvedRefs = "ResolvedRefs"
+
+	reasonAccepted         = "Accepted"
+	reasonProgrammed       = "Programmed"
+	reasonInvalid          = "Invalid"
+	reasonResolvedRefs     = "ResolvedRefs"
+	reasonUnsupportedValue = "UnsupportedValue"
+	reasonBackendNotFound  = "BackendNotFound"
+
+	ipAddressType = "IPAddress"
+)
+
+// Status returns the status of the given Gateway after a sync. ip is the
+// address of its load balancer, empty if none has been allocated yet, and
+// syncErr is the error of the sync, if any. Conditions that do not change
+// keep their last transition time.
+func Status(gw *Gateway, ip string, syncErr error) GatewayStatus {
+	status := *gw.Status.DeepCopy()
+
+	status.Addresses = nil
+	if ip != "" {
+		addrType := ipAddressType
+		status.Addresses = []GatewayStatusAddress{{Type: &addrType, Value: ip}}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAccepted,
+		ObservedGeneration: gw.Generation,
+	})
+	programmed := metav1.Condition{
+		Type:               ConditionProgrammed,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonProgrammed,
+		ObservedGeneration: gw.Generation,
+	}
+	if syncErr != nil {
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = reasonInvalid
+		programmed.Message = syncErr.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, programmed)
+	return status
+}
+
+// RouteStatus returns the status of the given HTTPRoute after a sync of the
+// given Gateway, which the route attaches to. acceptErr is the error that
+// prevents the route from being translated, if any, and resolveErr the error
+// resolving its backendRefs, if any. The entries of other Gateways and other
+// controllers are kept.
+func RouteStatus(route *HTTPRoute, gw *Gateway, acceptErr, resolveErr error) HTTPRouteStatus {
+	status := RemoveRouteStatus(route, gw)
+	ref, _ := parentRef(route, gw)
+	parentStatus := RouteParentStatus{ParentRef: ref, ControllerName: ControllerName}
+	// Keep the last transition time of the conditions that do not change.
+	if i := parentStatusIndex(route, gw); i >= 0 {
+		for _, c := range route.Status.Parents[i].Conditions {
+			parentStatus.Conditions = append(parentStatus.Conditions, *c.DeepCopy())
+		}
+	}
+
+	accepted := metav1.Condition{
+		Type:               ConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAccepted,
+		ObservedGeneration: route.Generation,
+	}
+	if acceptErr != nil {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = reasonUnsupportedValue
+		accepted.Message = acceptErr.Error()
+	}
+	meta.SetStatusCondition(&parentStatus.Conditions, accepted)
+	resolvedRefs := metav1.Condition{
+		Type:               ConditionResolvedRefs,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonResolvedRefs,
+		ObservedGeneration: route.Generation,
+	}
+	if resolveErr != nil {
+		resolvedRefs.Status = metav1.ConditionFalse
+		resolvedRefs.Reason = reasonBackendNotFound
+		resolvedRefs.Message = resolveErr.Error()
+	}
+	meta.SetStatusCondition(&parentStatus.Conditions, resolvedRefs)
+
+	status.Parents = append(status.Parents, parentStatus)
+	return status
+}
+
+// RemoveRouteStatus returns the status of the given HTTPRoute without the
+// entry of this controller for the given Gateway. It is used when the route
+// detaches from the Gateway or when the Gateway is deleted.
+func RemoveRouteStatus(route *HTTPRoute, gw *Gateway) HTTPRouteStatus {
+	status := *route.Status.DeepCopy()
+	i := parentStatusIndex(route, gw)
+	if i < 0 {
+		return status
+	}
+	status.Parents = append(status.Parents[:i], status.Parents[i+1:]...)
+	return status
+}
+
+// parentStatusIndex returns the index of the entry of this controller for
+// the given Gateway in the status of the HTTPRoute, -1 if there is none.
+func parentStatusIndex(route *HTTPRoute, gw *Gateway) int {
+	for i, parent := range route.Status.Parents {
+		if parent.ControllerName != ControllerName {
+			continue
+		}
+		if key, ok := parentGateway(route, parent.ParentRef); ok && key.Namespace == gw.Namespace && key.Name == gw.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeepCopy returns a deep copy of the HTTPRouteStatus.
+func (in *HTTPRouteStatus) DeepCopy() *HTTPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := &HTTPRouteStatus{}
+	for _, parent := range in.Parents {
+		parentCopy := RouteParentStatus{
+			ParentRef:      *parent.ParentRef.DeepCopy(),
+			ControllerName: parent.ControllerName,
+		}
+		for _, c := range parent.Conditions {
+			parentCopy.Conditions = append(parentCopy.Conditions, *c.DeepCopy())
+		}
+		out.Parents = append(out.Parents, parentCopy)
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the ParentReference.
+func (in *ParentReference) DeepCopy() *ParentReference {
+	if in == nil {
+		return nil
+	}
+	return &ParentReference{
+		Group:       copyString(in.Group),
+		Kind:        copyString(in.Kind),
+		Namespace:   copyString(in.Namespace),
+		Name:        in.Name,
+		SectionName: copyString(in.SectionName),
+	}
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+// DeepCopy returns a deep copy of the GatewayStatus.
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := &GatewayStatus{}
+	for _, addr := range in.Addresses {
+		addrCopy := GatewayStatusAddress{Value: addr.Value}
+		if addr.Type != nil {
+			t := *addr.Type
+			addrCopy.Type = &t
+		}
+		out.Addresses = append(out.Addresses, addrCopy)
+	}
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, *c.DeepCopy())
+	}
+	return out
+}
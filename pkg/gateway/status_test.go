@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatus(t *testing.T) {
+	gw := newGateway("gce")
+	gw.Generation = 2
+
+	status := Status(gw, "1.2.3.4", nil)
+	if len(status.Addresses) != 1 || status.Addresses[0].Value != "1.2.3.4" || *status.Addresses[0].Type != "IPAddress" {
+		t.Errorf("Status().Addresses = %+v, want 1.2.3.4", status.Addresses)
+	}
+	for _, condType := range []string{ConditionAccepted, ConditionProgrammed} {
+		cond := meta.FindStatusCondition(status.Conditions, condType)
+		if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != 2 {
+			t.Errorf("Status() condition %s = %+v, want True for generation 2", condType, cond)
+		}
+	}
+
+	// A status that does not change is equal to the previous status.
+	gw.Status = status
+	if got := Status(gw, "1.2.3.4", nil); !reflect.DeepEqual(got, status) {
+		t.Errorf("Status() = %+v, want unchanged status %+v", got, status)
+	}
+
+	status = Status(gw, "", fmt.Errorf("sync failed"))
+	if len(status.Addresses) != 0 {
+		t.Errorf("Status().Addresses = %+v, want none", status.Addresses)
+	}
+	cond := meta.FindStatusCondition(status.Conditions, ConditionProgrammed)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Message != "sync failed" {
+		t.Errorf("Status() condition %s = %+v, want False with sync error", ConditionProgrammed, cond)
+	}
+}
+
+func TestRouteStatus(t *testing.T) {
+	gw := newGateway("gce")
+	route := newRoute("my-route", time.Now(), gw.Name, HTTPRouteRule{BackendRefs: []HTTPBackendRef{backendRef("my-app", 80)}})
+	route.Generation = 3
+	otherParent := RouteParentStatus{
+		ParentRef:      ParentReference{Name: "other-gateway"},
+		ControllerName: "example.com/other-controller",
+	}
+	route.Status.Parents = []RouteParentStatus{otherParent}
+
+	status := RouteStatus(route, gw, nil, fmt.Errorf("missing Service"))
+	if len(status.Parents) != 2 || !reflect.DeepEqual(status.Parents[0], otherParent) {
+		t.Fatalf("RouteStatus().Parents = %+v, want the entry of the other controller and the entry of %s", status.Parents, ControllerName)
+	}
+	parent := status.Parents[1]
+	if parent.ControllerName != ControllerName || parent.ParentRef.Name != gw.Name {
+		t.Errorf("RouteStatus() parent = %+v, want entry of %s for %s", parent, ControllerName, gw.Name)
+	}
+	if cond := meta.FindStatusCondition(parent.Conditions, ConditionAccepted); cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != 3 {
+		t.Errorf("RouteStatus() condition %s = %+v, want True for generation 3", ConditionAccepted, cond)
+	}
+	if cond := meta.FindStatusCondition(parent.Conditions, ConditionResolvedRefs); cond == nil || cond.Status != metav1.ConditionFalse || cond.Message != "missing Service" {
+		t.Errorf("RouteStatus() condition %s = %+v, want False with resolve error", ConditionResolvedRefs, cond)
+	}
+
+	// A status that does not change is equal to the previous status.
+	route.Status = status
+	if got := RouteStatus(route, gw, nil, fmt.Errorf("missing Service")); !reflect.DeepEqual(got, status) {
+		t.Errorf("RouteStatus() = %+v, want unchanged status %+v", got, status)
+	}
+
+	// Removing the entry of the Gateway keeps the entry of the other controller.
+	if got := RemoveRouteStatus(route, gw); len(got.Parents) != 1 || !reflect.DeepEqual(got.Parents[0], otherParent) {
+		t.Errorf("RemoveRouteStatus().Parents = %+v, want only %+v", got.Parents, otherParent)
+	}
+}
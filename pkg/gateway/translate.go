@@ -0,0 +1,412 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+const (
+	// ingressNamePrefix is prepended to the UID of a Gateway to name the
+	// Ingress it is translated into. Naming the Ingress after the UID rather
+	// than the name of the Gateway keeps the GCE resources of a Gateway apart
+	// from those of any Ingress in the same namespace.
+	ingressNamePrefix = "gw-"
+
+	// namedAddressType is the address type that references a reserved GCE
+	// static IP address by name.
+	namedAddressType = "NamedAddress"
+
+	protocolHTTP  = "HTTP"
+	protocolHTTPS = "HTTPS"
+	httpPort      = 80
+	httpsPort     = 443
+
+	// defaultBackendWeight is the weight of a backendRef without weight.
+	defaultBackendWeight = 1
+)
+
+// IsGCEGateway returns true if the given Gateway uses a GatewayClass that is
+// handled by this controller. class is the GatewayClass referenced by the
+// Gateway, nil if it does not exist. The controllerName of the GatewayClass
+// must be ControllerName, and the GatewayClass must be named after the
+// ingress class of the load balancer it provisions, e.g. "gce-internal".
+func IsGCEGateway(gw *Gateway, class *GatewayClass) bool {
+	if class == nil || class.Name != gw.Spec.GatewayClassName || class.Spec.ControllerName != ControllerName {
+		return false
+	}
+	switch class.Name {
+	case annotations.GceIngressClass, annotations.GceL7ILBIngressClass, annotations.GceL7XLBRegionalIngressClass:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToIngress returns the Ingress that the load balancer of the given Gateway
+// is provisioned for. The Ingress is not persisted, it carries the naming
+// and the load balancer type of the Gateway through the L7 pipeline.
+func ToIngress(gw *Gateway) *v1.Ingress {
+	class := gw.Spec.GatewayClassName
+	ing := &v1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: gw.Namespace,
+			Name:      ingressNamePrefix + string(gw.UID),
+			UID:       gw.UID,
+			Annotations: map[string]string{
+				annotations.IngressClassKey: class,
+				annotations.AllowHTTPKey:    fmt.Sprintf("%t", hasListener(gw, protocolHTTP)),
+			},
+			Finalizers: []string{common.FinalizerKeyV2},
+		},
+	}
+
+	for _, addr := range gw.Spec.Addresses {
+		if addr.Type == nil || *addr.Type != namedAddressType {
+			continue
+		}
+		if class == annotations.GceIngressClass {
+			ing.Annotations[annotations.GlobalStaticIPNameKey] = addr.Value
+		} else {
+			ing.Annotations[annotations.RegionalStaticIPNameKey] = addr.Value
+		}
+		break
+	}
+
+	for _, listener := range gw.Spec.Listeners {
+		if listener.Protocol != protocolHTTPS || listener.TLS == nil {
+			continue
+		}
+		var hosts []string
+		if listener.Hostname != nil {
+			hosts = []string{*listener.Hostname}
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			if !isLocalSecret(ref, gw.Namespace) {
+				continue
+			}
+			ing.Spec.TLS = append(ing.Spec.TLS, v1.IngressTLS{Hosts: hosts, SecretName: ref.Name})
+		}
+	}
+	return ing
+}
+
+// ValidateListeners returns an error if the listeners of the given Gateway
+// cannot be served by a GCE L7 load balancer.
+func ValidateListeners(gw *Gateway) error {
+	if len(gw.Spec.Listeners) == 0 {
+		return fmt.Errorf("no listeners")
+	}
+	for _, listener := range gw.Spec.Listeners {
+		switch {
+		case listener.Protocol == protocolHTTP && listener.Port == httpPort:
+		case listener.Protocol == protocolHTTPS && listener.Port == httpsPort:
+			if listener.TLS == nil || len(listener.TLS.CertificateRefs) == 0 {
+				return fmt.Errorf("listener %q has no certificateRefs", listener.Name)
+			}
+			for _, ref := range listener.TLS.CertificateRefs {
+				if !isLocalSecret(ref, gw.Namespace) {
+					return fmt.Errorf("listener %q references %s, only Secrets in namespace %s are supported", listener.Name, ref.Name, gw.Namespace)
+				}
+			}
+		default:
+			return fmt.Errorf("listener %q uses %s on port %d, only HTTP on port %d and HTTPS on port %d are supported", listener.Name, listener.Protocol, listener.Port, httpPort, httpsPort)
+		}
+	}
+	return nil
+}
+
+// AttachedRoutes returns the HTTPRoutes that reference the given Gateway as
+// a parent, sorted by creation timestamp and then by namespace and name.
+func AttachedRoutes(gw *Gateway, routes []*HTTPRoute) []*HTTPRoute {
+	var attached []*HTTPRoute
+	for _, route := range routes {
+		if IsAttached(route, gw) {
+			attached = append(attached, route)
+		}
+	}
+	sort.SliceStable(attached, func(i, j int) bool {
+		a, b := attached[i], attached[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return attached
+}
+
+// IsAttached returns true if the given HTTPRoute references the given
+// Gateway as a parent.
+func IsAttached(route *HTTPRoute, gw *Gateway) bool {
+	_, ok := parentRef(route, gw)
+	return ok
+}
+
+// parentRef returns the parentRef of the given HTTPRoute that references the
+// given Gateway.
+func parentRef(route *HTTPRoute, gw *Gateway) (ParentReference, bool) {
+	for _, ref := range route.Spec.ParentRefs {
+		if parent, ok := parentGateway(route, ref); ok && parent.Namespace == gw.Namespace && parent.Name == gw.Name {
+			return ref, true
+		}
+	}
+	return ParentReference{}, false
+}
+
+// ParentGateways returns the keys of the Gateways referenced by the
+// parentRefs of the given HTTPRoute.
+func ParentGateways(route *HTTPRoute) []types.NamespacedName {
+	var parents []types.NamespacedName
+	for _, ref := range route.Spec.ParentRefs {
+		if parent, ok := parentGateway(route, ref); ok {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// parentGateway returns the key of the Gateway referenced by the given
+// parentRef of the HTTPRoute, if it references a Gateway.
+func parentGateway(route *HTTPRoute, ref ParentReference) (types.NamespacedName, bool) {
+	if ref.Group != nil && *ref.Group != GroupName {
+		return types.NamespacedName{}, false
+	}
+	if ref.Kind != nil && *ref.Kind != GatewayKind {
+		return types.NamespacedName{}, false
+	}
+	namespace := route.Namespace
+	if ref.Namespace != nil {
+		namespace = *ref.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}, true
+}
+
+// ReferencesService returns true if a backendRef of the given HTTPRoute
+// references the given Service.
+func ReferencesService(route *HTTPRoute, svc *apiv1.Service) bool {
+	for _, id := range ServiceRefs(route) {
+		if id.Namespace == svc.Namespace && id.Name == svc.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceRefs returns the keys of the Services referenced by the backendRefs
+// of the given HTTPRoute.
+func ServiceRefs(route *HTTPRoute) []types.NamespacedName {
+	var ids []types.NamespacedName
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if isService(ref) {
+				ids = append(ids, types.NamespacedName{Namespace: backendNamespace(route, ref), Name: ref.Name})
+			}
+		}
+	}
+	return ids
+}
+
+// RouteRules translates the given HTTPRoutes into FrontendConfig route rules.
+// The route rules are ordered by the Gateway API match precedence: exact
+// paths first, then prefixes by descending length, then by the number of
+// header and query parameter matches. Ties keep the order of the routes.
+func RouteRules(routes []*HTTPRoute) ([]frontendconfigv1beta1.RouteRule, []error) {
+	var errs []error
+	var rules []frontendconfigv1beta1.RouteRule
+	for _, route := range routes {
+		hostnames := route.Spec.Hostnames
+		if len(hostnames) == 0 {
+			// A route without hostnames matches all hosts.
+			hostnames = []string{""}
+		}
+		for i, rule := range route.Spec.Rules {
+			backends, err := toRouteBackends(route, rule.BackendRefs)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %d of HTTPRoute %s/%s: %w", i, route.Namespace, route.Name, err))
+				continue
+			}
+			matches := rule.Matches
+			if len(matches) == 0 {
+				// A rule without matches matches all requests.
+				matches = []HTTPRouteMatch{{}}
+			}
+			for _, m := range matches {
+				match, err := toRouteMatch(m)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("rule %d of HTTPRoute %s/%s: %w", i, route.Namespace, route.Name, err))
+					continue
+				}
+				for _, host := range hostnames {
+					rules = append(rules, frontendconfigv1beta1.RouteRule{
+						Host:     host,
+						Match:    *match.DeepCopy(),
+						Backends: append([]frontendconfigv1beta1.RouteBackend{}, backends...),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return precedes(rules[i].Match, rules[j].Match)
+	})
+	return rules, errs
+}
+
+// precedes returns true if a request matched by both a and b must be routed
+// according to a.
+func precedes(a, b frontendconfigv1beta1.RouteMatch) bool {
+	if (a.FullPath != "") != (b.FullPath != "") {
+		return a.FullPath != ""
+	}
+	if len(a.PathPrefix) != len(b.PathPrefix) {
+		return len(a.PathPrefix) > len(b.PathPrefix)
+	}
+	if len(a.Headers) != len(b.Headers) {
+		return len(a.Headers) > len(b.Headers)
+	}
+	return len(a.QueryParameters) > len(b.QueryParameters)
+}
+
+func toRouteMatch(m HTTPRouteMatch) (frontendconfigv1beta1.RouteMatch, error) {
+	var match frontendconfigv1beta1.RouteMatch
+
+	pathType, path := PathMatchPathPrefix, "/"
+	if m.Path != nil {
+		if m.Path.Type != nil {
+			pathType = *m.Path.Type
+		}
+		if m.Path.Value != nil {
+			path = *m.Path.Value
+		}
+	}
+	switch pathType {
+	case PathMatchPathPrefix:
+		match.PathPrefix = path
+	case PathMatchExact:
+		match.FullPath = path
+	default:
+		return match, fmt.Errorf("unsupported path match type %q", pathType)
+	}
+
+	for _, header := range m.Headers {
+		headerMatch := frontendconfigv1beta1.HeaderMatch{Name: header.Name}
+		switch matchType(header.Type) {
+		case MatchExact:
+			headerMatch.ExactMatch = header.Value
+		case MatchRegularExpression:
+			headerMatch.RegexMatch = header.Value
+		default:
+			return match, fmt.Errorf("unsupported match type %q for header %q", matchType(header.Type), header.Name)
+		}
+		match.Headers = append(match.Headers, headerMatch)
+	}
+
+	for _, param := range m.QueryParams {
+		paramMatch := frontendconfigv1beta1.QueryParameterMatch{Name: param.Name}
+		switch matchType(param.Type) {
+		case MatchExact:
+			paramMatch.ExactMatch = param.Value
+		case MatchRegularExpression:
+			paramMatch.RegexMatch = param.Value
+		default:
+			return match, fmt.Errorf("unsupported match type %q for query parameter %q", matchType(param.Type), param.Name)
+		}
+		match.QueryParameters = append(match.QueryParameters, paramMatch)
+	}
+	return match, nil
+}
+
+func toRouteBackends(route *HTTPRoute, refs []HTTPBackendRef) ([]frontendconfigv1beta1.RouteBackend, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no backendRefs")
+	}
+	var backends []frontendconfigv1beta1.RouteBackend
+	for _, ref := range refs {
+		if !isService(ref) {
+			return nil, fmt.Errorf("backendRef %s is not a Service", ref.Name)
+		}
+		if backendNamespace(route, ref) != route.Namespace {
+			return nil, fmt.Errorf("backendRef %s references namespace %s, only Services in namespace %s are supported", ref.Name, *ref.Namespace, route.Namespace)
+		}
+		if ref.Port == nil {
+			return nil, fmt.Errorf("backendRef %s has no port", ref.Name)
+		}
+		weight := int32(defaultBackendWeight)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		backends = append(backends, frontendconfigv1beta1.RouteBackend{
+			ServiceName: ref.Name,
+			ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: *ref.Port},
+			Weight:      weight,
+		})
+	}
+	return backends, nil
+}
+
+func matchType(t *string) string {
+	if t == nil {
+		return MatchExact
+	}
+	return *t
+}
+
+func hasListener(gw *Gateway, protocol string) bool {
+	for _, listener := range gw.Spec.Listeners {
+		if listener.Protocol == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+func isLocalSecret(ref SecretObjectReference, namespace string) bool {
+	if ref.Group != nil && *ref.Group != "" {
+		return false
+	}
+	if ref.Kind != nil && *ref.Kind != "Secret" {
+		return false
+	}
+	return ref.Namespace == nil || *ref.Namespace == namespace
+}
+
+func isService(ref HTTPBackendRef) bool {
+	if ref.Group != nil && *ref.Group != "" {
+		return false
+	}
+	return ref.Kind == nil || *ref.Kind == ServiceKind
+}
+
+func backendNamespace(route *HTTPRoute, ref HTTPBackendRef) string {
+	if ref.Namespace != nil {
+		return *ref.Namespace
+	}
+	return route.Namespace
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+func strPtr(s string) *string { return &s }
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newGateway(class string, listeners ...Listener) *Gateway {
+	return &Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default", UID: "uid"},
+		Spec:       GatewaySpec{GatewayClassName: class, Listeners: listeners},
+	}
+}
+
+func newRoute(name string, created time.Time, parent string, rules ...HTTPRouteRule) *HTTPRoute {
+	return &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+		Spec: HTTPRouteSpec{
+			ParentRefs: []ParentReference{{Name: parent}},
+			Rules:      rules,
+		},
+	}
+}
+
+func backendRef(name string, port int32) HTTPBackendRef {
+	return HTTPBackendRef{Name: name, Port: int32Ptr(port)}
+}
+
+func TestGatewayFromUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata":   map[string]interface{}{"name": "my-gateway", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"gatewayClassName": "gce",
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+			},
+		},
+	}}
+
+	want := &Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "gateway.networking.k8s.io/v1", Kind: "Gateway"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"},
+		Spec: GatewaySpec{
+			GatewayClassName: "gce",
+			Listeners:        []Listener{{Name: "http", Port: 80, Protocol: "HTTP"}},
+		},
+	}
+	got, err := GatewayFromUnstructured(u)
+	if err != nil {
+		t.Fatalf("GatewayFromUnstructured() = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GatewayFromUnstructured() returned diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := GatewayFromUnstructured(&v1.Ingress{}); err == nil {
+		t.Errorf("GatewayFromUnstructured(Ingress) = nil, want error")
+	}
+}
+
+func TestToIngress(t *testing.T) {
+	httpListener := Listener{Name: "http", Port: 80, Protocol: "HTTP"}
+	httpsListener := Listener{
+		Name:     "https",
+		Hostname: strPtr("foo.example.com"),
+		Port:     443,
+		Protocol: "HTTPS",
+		TLS:      &GatewayTLSConfig{CertificateRefs: []SecretObjectReference{{Name: "my-cert"}}},
+	}
+
+	for _, tc := range []struct {
+		desc string
+		gw   *Gateway
+		want *v1.Ingress
+	}{
+		{
+			desc: "HTTP listener",
+			gw:   newGateway("gce", httpListener),
+			want: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gw-uid",
+					Namespace: "default",
+					UID:       "uid",
+					Annotations: map[string]string{
+						annotations.IngressClassKey: "gce",
+						annotations.AllowHTTPKey:    "true",
+					},
+					Finalizers: []string{common.FinalizerKeyV2},
+				},
+			},
+		},
+		{
+			desc: "HTTPS listener with named address",
+			gw: func() *Gateway {
+				gw := newGateway("gce-internal", httpsListener)
+				gw.Spec.Addresses = []GatewayAddress{{Type: strPtr("NamedAddress"), Value: "my-address"}}
+				return gw
+			}(),
+			want: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gw-uid",
+					Namespace: "default",
+					UID:       "uid",
+					Annotations: map[string]string{
+						annotations.IngressClassKey:         "gce-internal",
+						annotations.AllowHTTPKey:            "false",
+						annotations.RegionalStaticIPNameKey: "my-address",
+					},
+					Finalizers: []string{common.FinalizerKeyV2},
+				},
+				Spec: v1.IngressSpec{
+					TLS: []v1.IngressTLS{{Hosts: []string{"foo.example.com"}, SecretName: "my-cert"}},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := ToIngress(tc.gw)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ToIngress() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsGCEGateway(t *testing.T) {
+	newClass := func(name, controllerName string) *GatewayClass {
+		return &GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       GatewayClassSpec{ControllerName: controllerName},
+		}
+	}
+	for _, tc := range []struct {
+		desc  string
+		gw    *Gateway
+		class *GatewayClass
+		want  bool
+	}{
+		{
+			desc:  "GCE GatewayClass",
+			gw:    newGateway("gce-internal"),
+			class: newClass("gce-internal", ControllerName),
+			want:  true,
+		},
+		{
+			desc: "GatewayClass does not exist",
+			gw:   newGateway("gce"),
+		},
+		{
+			desc:  "GatewayClass of another controller",
+			gw:    newGateway("gce"),
+			class: newClass("gce", "example.com/other-controller"),
+		},
+		{
+			desc:  "GatewayClass with unknown load balancer type",
+			gw:    newGateway("my-class"),
+			class: newClass("my-class", ControllerName),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := IsGCEGateway(tc.gw, tc.class); got != tc.want {
+				t.Errorf("IsGCEGateway() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateListeners(t *testing.T) {
+	tls := &GatewayTLSConfig{CertificateRefs: []SecretObjectReference{{Name: "my-cert"}}}
+	for _, tc := range []struct {
+		desc      string
+		listeners []Listener
+		wantErr   bool
+	}{
+		{
+			desc:      "HTTP and HTTPS",
+			listeners: []Listener{{Name: "http", Port: 80, Protocol: "HTTP"}, {Name: "https", Port: 443, Protocol: "HTTPS", TLS: tls}},
+		},
+		{
+			desc:    "no listeners",
+			wantErr: true,
+		},
+		{
+			desc:      "HTTP on custom port",
+			listeners: []Listener{{Name: "http", Port: 8080, Protocol: "HTTP"}},
+			wantErr:   true,
+		},
+		{
+			desc:      "HTTPS without certificates",
+			listeners: []Listener{{Name: "https", Port: 443, Protocol: "HTTPS"}},
+			wantErr:   true,
+		},
+		{
+			desc: "HTTPS with certificate in another namespace",
+			listeners: []Listener{{Name: "https", Port: 443, Protocol: "HTTPS", TLS: &GatewayTLSConfig{
+				CertificateRefs: []SecretObjectReference{{Name: "my-cert", Namespace: strPtr("other")}},
+			}}},
+			wantErr: true,
+		},
+		{
+			desc:      "TCP",
+			listeners: []Listener{{Name: "tcp", Port: 80, Protocol: "TCP"}},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateListeners(newGateway("gce", tc.listeners...))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("ValidateListeners() = %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttachedRoutes(t *testing.T) {
+	now := time.Now()
+	gw := newGateway("gce")
+	older := newRoute("older", now.Add(-time.Hour), "my-gateway")
+	newer := newRoute("a-newer", now, "my-gateway")
+	sameTime := newRoute("b-newer", now, "my-gateway")
+	other := newRoute("other", now, "other-gateway")
+	otherNamespace := newRoute("other-namespace", now, "my-gateway")
+	otherNamespace.Namespace = "other"
+	crossNamespace := newRoute("cross-namespace", now.Add(-2*time.Hour), "my-gateway")
+	crossNamespace.Namespace = "other"
+	crossNamespace.Spec.ParentRefs[0].Namespace = strPtr("default")
+	otherKind := newRoute("other-kind", now, "my-gateway")
+	otherKind.Spec.ParentRefs[0].Kind = strPtr("Service")
+
+	got := AttachedRoutes(gw, []*HTTPRoute{sameTime, other, newer, otherNamespace, older, crossNamespace, otherKind})
+	var gotNames []string
+	for _, route := range got {
+		gotNames = append(gotNames, fmt.Sprintf("%s/%s", route.Namespace, route.Name))
+	}
+	wantNames := []string{"other/cross-namespace", "default/older", "default/a-newer", "default/b-newer"}
+	if diff := cmp.Diff(wantNames, gotNames); diff != "" {
+		t.Errorf("AttachedRoutes() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouteRules(t *testing.T) {
+	now := time.Now()
+	routeBackend := func(name string, weight int32) frontendconfigv1beta1.RouteBackend {
+		return frontendconfigv1beta1.RouteBackend{ServiceName: name, ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 80}, Weight: weight}
+	}
+
+	for _, tc := range []struct {
+		desc         string
+		routes       []*HTTPRoute
+		want         []frontendconfigv1beta1.RouteRule
+		wantErrCount int
+	}{
+		{
+			desc: "rule without matches",
+			routes: []*HTTPRoute{
+				newRoute("route", now, "my-gateway", HTTPRouteRule{BackendRefs: []HTTPBackendRef{backendRef("app", 80)}}),
+			},
+			want: []frontendconfigv1beta1.RouteRule{
+				{Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/"}, Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("app", 1)}},
+			},
+		},
+		{
+			desc: "hostnames, matches and weights",
+			routes: []*HTTPRoute{
+				func() *HTTPRoute {
+					route := newRoute("route", now, "my-gateway", HTTPRouteRule{
+						Matches: []HTTPRouteMatch{
+							{Path: &HTTPPathMatch{Type: strPtr("Exact"), Value: strPtr("/login")}},
+							{
+								Path:        &HTTPPathMatch{Value: strPtr("/api")},
+								Headers:     []HTTPHeaderMatch{{Name: "x-canary", Value: "true"}},
+								QueryParams: []HTTPQueryParamMatch{{Type: strPtr("RegularExpression"), Name: "v", Value: "2.*"}},
+							},
+						},
+						BackendRefs: []HTTPBackendRef{
+							func() HTTPBackendRef { ref := backendRef("app", 80); ref.Weight = int32Ptr(90); return ref }(),
+							func() HTTPBackendRef { ref := backendRef("canary", 80); ref.Weight = int32Ptr(10); return ref }(),
+						},
+					})
+					route.Spec.Hostnames = []string{"foo.example.com", "bar.example.com"}
+					return route
+				}(),
+			},
+			want: []frontendconfigv1beta1.RouteRule{
+				{
+					Host:     "foo.example.com",
+					Match:    frontendconfigv1beta1.RouteMatch{FullPath: "/login"},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("app", 90), routeBackend("canary", 10)},
+				},
+				{
+					Host:     "bar.example.com",
+					Match:    frontendconfigv1beta1.RouteMatch{FullPath: "/login"},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("app", 90), routeBackend("canary", 10)},
+				},
+				{
+					Host: "foo.example.com",
+					Match: frontendconfigv1beta1.RouteMatch{
+						PathPrefix:      "/api",
+						Headers:         []frontendconfigv1beta1.HeaderMatch{{Name: "x-canary", ExactMatch: "true"}},
+						QueryParameters: []frontendconfigv1beta1.QueryParameterMatch{{Name: "v", RegexMatch: "2.*"}},
+					},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("app", 90), routeBackend("canary", 10)},
+				},
+				{
+					Host: "bar.example.com",
+					Match: frontendconfigv1beta1.RouteMatch{
+						PathPrefix:      "/api",
+						Headers:         []frontendconfigv1beta1.HeaderMatch{{Name: "x-canary", ExactMatch: "true"}},
+						QueryParameters: []frontendconfigv1beta1.QueryParameterMatch{{Name: "v", RegexMatch: "2.*"}},
+					},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("app", 90), routeBackend("canary", 10)},
+				},
+			},
+		},
+		{
+			desc: "precedence across routes",
+			routes: []*HTTPRoute{
+				newRoute("first", now, "my-gateway",
+					HTTPRouteRule{BackendRefs: []HTTPBackendRef{backendRef("catch-all", 80)}},
+					HTTPRouteRule{
+						Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Value: strPtr("/api")}}},
+						BackendRefs: []HTTPBackendRef{backendRef("api", 80)},
+					},
+				),
+				newRoute("second", now, "my-gateway",
+					HTTPRouteRule{
+						Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Value: strPtr("/api")}, Headers: []HTTPHeaderMatch{{Name: "x-canary", Value: "true"}}}},
+						BackendRefs: []HTTPBackendRef{backendRef("canary", 80)},
+					},
+					HTTPRouteRule{
+						Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Value: strPtr("/api/v2")}}},
+						BackendRefs: []HTTPBackendRef{backendRef("api-v2", 80)},
+					},
+				),
+			},
+			want: []frontendconfigv1beta1.RouteRule{
+				{Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/api/v2"}, Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("api-v2", 1)}},
+				{
+					Match:    frontendconfigv1beta1.RouteMatch{PathPrefix: "/api", Headers: []frontendconfigv1beta1.HeaderMatch{{Name: "x-canary", ExactMatch: "true"}}},
+					Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("canary", 1)},
+				},
+				{Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/api"}, Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("api", 1)}},
+				{Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/"}, Backends: []frontendconfigv1beta1.RouteBackend{routeBackend("catch-all", 1)}},
+			},
+		},
+		{
+			desc: "unsupported matches and backends",
+			routes: []*HTTPRoute{
+				newRoute("route", now, "my-gateway",
+					HTTPRouteRule{
+						Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Type: strPtr("RegularExpression"), Value: strPtr("/.*")}}},
+						BackendRefs: []HTTPBackendRef{backendRef("app", 80)},
+					},
+					HTTPRouteRule{
+						Matches:     []HTTPRouteMatch{{Headers: []HTTPHeaderMatch{{Type: strPtr("Prefix"), Name: "x-canary", Value: "t"}}}},
+						BackendRefs: []HTTPBackendRef{backendRef("app", 80)},
+					},
+					HTTPRouteRule{
+						BackendRefs: []HTTPBackendRef{{Name: "app"}},
+					},
+					HTTPRouteRule{
+						BackendRefs: []HTTPBackendRef{func() HTTPBackendRef { ref := backendRef("app", 80); ref.Namespace = strPtr("other"); return ref }()},
+					},
+					HTTPRouteRule{
+						BackendRefs: []HTTPBackendRef{func() HTTPBackendRef { ref := backendRef("bucket", 80); ref.Kind = strPtr("Bucket"); return ref }()},
+					},
+					HTTPRouteRule{},
+				),
+			},
+			wantErrCount: 6,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, errs := RouteRules(tc.routes)
+			if len(errs) != tc.wantErrCount {
+				t.Errorf("RouteRules() = _, %v, want %d errors", errs, tc.wantErrCount)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RouteRules() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
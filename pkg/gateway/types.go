@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// The types below mirror the subset of the gateway.networking.k8s.io/v1 API
+// that is translated by this controller. Gateway API objects are read through
+// the dynamic client and converted into these types, so that the controller
+// does not depend on the Gateway API clientset.
+
+const (
+	// GroupName is the API group of the Gateway API.
+	GroupName = "gateway.networking.k8s.io"
+	// Version is the version of the Gateway API handled by this controller.
+	Version = "v1"
+
+	// ControllerName is the controllerName of the GatewayClasses whose
+	// Gateways are handled by this controller.
+	ControllerName = "networking.gke.io/ingress-gce"
+
+	// GatewayKind is the kind of Gateway objects.
+	GatewayKind = "Gateway"
+	// ServiceKind is the kind of the backendRefs that are supported.
+	ServiceKind = "Service"
+)
+
+var (
+	// GatewayClassGVR is the GroupVersionResource of GatewayClasses.
+	GatewayClassGVR = schema.GroupVersionResource{Group: GroupName, Version: Version, Resource: "gatewayclasses"}
+	// GatewayGVR is the GroupVersionResource of Gateways.
+	GatewayGVR = schema.GroupVersionResource{Group: GroupName, Version: Version, Resource: "gateways"}
+	// HTTPRouteGVR is the GroupVersionResource of HTTPRoutes.
+	HTTPRouteGVR = schema.GroupVersionResource{Group: GroupName, Version: Version, Resource: "httproutes"}
+)
+
+// GatewayClass describes a class of Gateways available to the user for
+// creating Gateway resources.
+type GatewayClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GatewayClassSpec `json:"spec"`
+}
+
+// GatewayClassSpec defines the desired state of a GatewayClass.
+type GatewayClassSpec struct {
+	// ControllerName is the name of the controller that manages the
+	// Gateways of the class.
+	ControllerName string `json:"controllerName"`
+}
+
+// Gateway represents an instance of a service-traffic handling
+// infrastructure by binding Listeners to a set of IP addresses.
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewaySpec   `json:"spec"`
+	Status GatewayStatus `json:"status,omitempty"`
+}
+
+// GatewaySpec defines the desired state of a Gateway.
+type GatewaySpec struct {
+	// GatewayClassName is the name of the GatewayClass used by the Gateway.
+	GatewayClassName string `json:"gatewayClassName"`
+	// Listeners are the logical endpoints that are bound on the addresses of
+	// the Gateway.
+	Listeners []Listener `json:"listeners"`
+	// Addresses requested for the Gateway.
+	Addresses []GatewayAddress `json:"addresses,omitempty"`
+}
+
+// Listener embodies the concept of a logical endpoint where a Gateway
+// accepts network connections.
+type Listener struct {
+	Name     string            `json:"name"`
+	Hostname *string           `json:"hostname,omitempty"`
+	Port     int32             `json:"port"`
+	Protocol string            `json:"protocol"`
+	TLS      *GatewayTLSConfig `json:"tls,omitempty"`
+}
+
+// GatewayTLSConfig describes the TLS configuration of a Listener.
+type GatewayTLSConfig struct {
+	Mode            *string                 `json:"mode,omitempty"`
+	CertificateRefs []SecretObjectReference `json:"certificateRefs,omitempty"`
+}
+
+// SecretObjectReference identifies an API object, a Secret by default.
+type SecretObjectReference struct {
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// GatewayAddress describes an address that can be bound to a Gateway.
+type GatewayAddress struct {
+	Type  *string `json:"type,omitempty"`
+	Value string  `json:"value"`
+}
+
+// GatewayStatus defines the observed state of a Gateway.
+type GatewayStatus struct {
+	Addresses  []GatewayStatusAddress `json:"addresses,omitempty"`
+	Conditions []metav1.Condition     `json:"conditions,omitempty"`
+}
+
+// GatewayStatusAddress describes an address that is bound to a Gateway.
+type GatewayStatusAddress struct {
+	Type  *string `json:"type,omitempty"`
+	Value string  `json:"value"`
+}
+
+// HTTPRoute provides a way to route HTTP requests.
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPRouteSpec   `json:"spec"`
+	Status HTTPRouteStatus `json:"status,omitempty"`
+}
+
+// HTTPRouteSpec defines the desired state of an HTTPRoute.
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule   `json:"rules,omitempty"`
+}
+
+// HTTPRouteStatus defines the observed state of an HTTPRoute.
+type HTTPRouteStatus struct {
+	// Parents are the statuses of the route with respect to the Gateways it
+	// attaches to. Each controller only manages its own entries.
+	Parents []RouteParentStatus `json:"parents"`
+}
+
+// RouteParentStatus describes the status of a route with respect to a
+// Gateway.
+type RouteParentStatus struct {
+	ParentRef      ParentReference    `json:"parentRef"`
+	ControllerName string             `json:"controllerName"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ParentReference identifies the Gateway an HTTPRoute attaches to.
+type ParentReference struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// HTTPRouteRule defines the conditions under which requests are forwarded to
+// the backends of the rule.
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch defines the predicate used to match requests to a rule.
+type HTTPRouteMatch struct {
+	Path        *HTTPPathMatch        `json:"path,omitempty"`
+	Headers     []HTTPHeaderMatch     `json:"headers,omitempty"`
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty"`
+}
+
+// Path match types.
+const (
+	PathMatchExact             = "Exact"
+	PathMatchPathPrefix        = "PathPrefix"
+	PathMatchRegularExpression = "RegularExpression"
+)
+
+// Header and query parameter match types.
+const (
+	MatchExact             = "Exact"
+	MatchRegularExpression = "RegularExpression"
+)
+
+// HTTPPathMatch describes how to select an HTTP route by the request path.
+type HTTPPathMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch describes how to select an HTTP route by an HTTP header.
+type HTTPHeaderMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+// HTTPQueryParamMatch describes how to select an HTTP route by a query
+// parameter.
+type HTTPQueryParamMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+// HTTPBackendRef defines how an HTTPRoute forwards an HTTP request.
+type HTTPBackendRef struct {
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace,omitempty"`
+	Port      *int32  `json:"port,omitempty"`
+	Weight    *int32  `json:"weight,omitempty"`
+}
+
+// GatewayClassFromUnstructured converts an object of the GatewayClass
+// informer into a GatewayClass. Tombstones of deleted objects are unwrapped.
+func GatewayClassFromUnstructured(obj interface{}) (*GatewayClass, error) {
+	class := &GatewayClass{}
+	if err := fromUnstructured(obj, class); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// GatewayFromUnstructured converts an object of the Gateway informer into a
+// Gateway. Tombstones of deleted objects are unwrapped.
+func GatewayFromUnstructured(obj interface{}) (*Gateway, error) {
+	gw := &Gateway{}
+	if err := fromUnstructured(obj, gw); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+// HTTPRouteFromUnstructured converts an object of the HTTPRoute informer
+// into an HTTPRoute. Tombstones of deleted objects are unwrapped.
+func HTTPRouteFromUnstructured(obj interface{}) (*HTTPRoute, error) {
+	route := &HTTPRoute{}
+	if err := fromUnstructured(obj, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
+func fromUnstructured(obj interface{}, out interface{}) error {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expected *unstructured.Unstructured, got %T", obj)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), out)
+}
@@ -151,16 +151,37 @@ func (l4hc *l4HealthChecks) EnsureHealthCheckWithDualStackFirewalls(svc *corev1.
 	if needsIPv4 {
 		hcLogger.V(3).Info("Ensuring IPv4 firewall rule for health check for service")
 		l4hc.ensureIPv4Firewall(svc, namer, hcPort, sharedHC, nodeNames, hcResult, svcNetwork, hcLogger)
+	} else if hcFwName := namer.L4HealthCheckFirewall(svc.Namespace, svc.Name, false); !sharedHC && svc.Annotations[annotations.FirewallRuleForHealthcheckKey] == hcFwName {
+		hcLogger.V(3).Info("Deleting IPv4 firewall rule for health check of service which no longer needs IPv4")
+		l4hc.deleteStaleHealthCheckFirewall(svc, hcFwName, annotations.FirewallForHealthcheckResource, hcResult, hcLogger)
 	}
 
 	if needsIPv6 {
 		hcLogger.V(3).Info("Ensuring IPv6 firewall rule for health check for service")
 		l4hc.ensureIPv6Firewall(svc, namer, hcPort, sharedHC, nodeNames, l4Type, hcResult, svcNetwork, hcLogger)
+	} else if ipv6HCFwName := namer.L4IPv6HealthCheckFirewall(svc.Namespace, svc.Name, false); !sharedHC && svc.Annotations[annotations.FirewallRuleForHealthcheckIPv6Key] == ipv6HCFwName {
+		hcLogger.V(3).Info("Deleting IPv6 firewall rule for health check of service which no longer needs IPv6")
+		l4hc.deleteStaleHealthCheckFirewall(svc, ipv6HCFwName, annotations.FirewallForHealthcheckIPv6Resource, hcResult, hcLogger)
 	}
 
 	return hcResult
 }
 
+// deleteStaleHealthCheckFirewall deletes the non-shared health check firewall
+// rule of an IP family which the service no longer uses, e.g. the IPv4 firewall
+// rule of a service which was changed to IPv6 only.
+// Shared firewall rules are left for the other services using them.
+func (l4hc *l4HealthChecks) deleteStaleHealthCheckFirewall(svc *corev1.Service, hcFwName, resource string, hcResult *EnsureHealthCheckResult, svcLogger klog.Logger) {
+	fwLogger := svcLogger.WithValues("healthcheckFirewallName", hcFwName)
+	if err := l4hc.deleteFirewall(hcFwName, svc, fwLogger); err != nil {
+		fwLogger.Error(err, "Failed to delete stale firewall rule for health check of service")
+		hcResult.GceResourceInError = resource
+		hcResult.Err = err
+		return
+	}
+	hcResult.WasFirewallUpdated = utils.ResourceUpdate
+}
+
 func (l4hc *l4HealthChecks) ensureHealthCheck(hcName string, svcName types.NamespacedName, shared bool, path string, port int32, scope meta.KeyType, l4Type utils.L4LBType, hcLogger klog.Logger) (string, utils.ResourceSyncStatus, error) {
 	start := time.Now()
 	hcLogger.V(2).Info("Ensuring healthcheck for service", "shared", shared, "path", path, "port", port, "scope", scope, "l4Type", l4Type.ToString())
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -676,6 +677,82 @@ func TestEnsureHealthCheckWithDualStackFirewalls(t *testing.T) {
 	}
 }
 
+func TestEnsureHealthCheckWithDualStackFirewallsDeletesStaleFirewall(t *testing.T) {
+	l4Namer := namer.NewL4Namer("test", namer.NewNamer("testCluster", "testFirewall", klog.TODO()))
+	testClusterValues := gce.DefaultTestClusterValues()
+
+	testCases := []struct {
+		desc         string
+		sharedHC     bool
+		needsIPv4    bool
+		needsIPv6    bool
+		staleFwName  string
+		annotation   string
+		wantFwExists bool
+	}{
+		{
+			desc:         "ipv4 firewall deleted for ipv6 only service",
+			needsIPv6:    true,
+			staleFwName:  l4Namer.L4HealthCheckFirewall("serviceNamespace", "serviceName", false),
+			annotation:   annotations.FirewallRuleForHealthcheckKey,
+			wantFwExists: false,
+		},
+		{
+			desc:         "ipv6 firewall deleted for ipv4 only service",
+			needsIPv4:    true,
+			staleFwName:  l4Namer.L4IPv6HealthCheckFirewall("serviceNamespace", "serviceName", false),
+			annotation:   annotations.FirewallRuleForHealthcheckIPv6Key,
+			wantFwExists: false,
+		},
+		{
+			desc:         "shared ipv4 firewall kept for ipv6 only service",
+			sharedHC:     true,
+			needsIPv6:    true,
+			staleFwName:  l4Namer.L4HealthCheckFirewall("serviceNamespace", "serviceName", true),
+			annotation:   annotations.FirewallRuleForHealthcheckKey,
+			wantFwExists: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "serviceName",
+					Namespace:   "serviceNamespace",
+					UID:         types.UID("1"),
+					Annotations: map[string]string{tc.annotation: tc.staleFwName},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports:               []corev1.ServicePort{{Port: 8080, Protocol: corev1.ProtocolTCP}},
+					Type:                "LoadBalancer",
+					HealthCheckNodePort: 1234,
+				},
+			}
+			fakeGCE := gce.NewFakeGCECloud(testClusterValues)
+			nodeNames := []string{"k8s-test-node"}
+			createVMInstanceWithTag(t, fakeGCE, "k8s-test")
+			defaultNetwork := network.DefaultNetwork(fakeGCE)
+			hcs := NewL4HealthChecks(fakeGCE, &record.FakeRecorder{}, klog.TODO())
+			if err := fakeGCE.CreateFirewall(&compute.Firewall{Name: tc.staleFwName, Network: testClusterValues.NetworkURL}); err != nil {
+				t.Fatalf("CreateFirewall() err=%v", err)
+			}
+
+			result := hcs.EnsureHealthCheckWithDualStackFirewalls(svc, l4Namer, tc.sharedHC, meta.Global, utils.XLB, nodeNames, tc.needsIPv4, tc.needsIPv6, *defaultNetwork, klog.TODO())
+			if result.Err != nil {
+				t.Errorf("hcs.EnsureHealthCheckWithDualStackFirewalls() err=%v", result.Err)
+			}
+			_, err := fakeGCE.GetFirewall(tc.staleFwName)
+			if utils.IgnoreHTTPNotFound(err) != nil {
+				t.Fatalf("GetFirewall() err=%v", err)
+			}
+			if gotFwExists := err == nil; gotFwExists != tc.wantFwExists {
+				t.Errorf("Firewall %s exists = %v, want %v", tc.staleFwName, gotFwExists, tc.wantFwExists)
+			}
+		})
+	}
+}
+
 func createVMInstanceWithTag(t *testing.T, fakeGCE *gce.Cloud, tag string) {
 	err := fakeGCE.Compute().Instances().Insert(context.Background(),
 		meta.ZonalKey("k8s-test-node", fakeGCE.LocalZone()),
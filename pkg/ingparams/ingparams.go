@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingparams
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/annotations"
+	apisingparams "k8s.io/ingress-gce/pkg/apis/ingparams"
+	ingparamsv1beta1 "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1"
+	"k8s.io/ingress-gce/pkg/crd"
+	ingparamslisters "k8s.io/ingress-gce/pkg/ingparams/client/listers/ingparams/v1beta1"
+)
+
+const (
+	// ControllerName is the value of spec.controller of the IngressClasses
+	// that are handled by this controller.
+	ControllerName = "networking.gke.io/ingress-gce"
+
+	// GCPIngressParamsKind is the kind that IngressClass parameters must
+	// reference to configure an Ingress handled by this controller.
+	GCPIngressParamsKind = "GCPIngressParams"
+)
+
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisingparams.GroupName,
+		GCPIngressParamsKind,
+		"GCPIngressParamsList",
+		"gcpingressparams",
+		"gcpingressparams",
+		[]*crd.Version{
+			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1.GCPIngressParams", ingparamsv1beta1.GetOpenAPIDefinitions, false),
+		},
+	)
+	return meta
+}
+
+// Resolver resolves the spec.ingressClassName of an Ingress to the
+// GCPIngressParams referenced by the parameters of the IngressClass.
+type Resolver struct {
+	ingressClassLister networkinglisters.IngressClassLister
+	paramsLister       ingparamslisters.GCPIngressParamsLister
+}
+
+// NewResolver returns a Resolver backed by the given IngressClass and
+// GCPIngressParams indexers.
+func NewResolver(ingressClassIndexer, paramsIndexer cache.Indexer) *Resolver {
+	return &Resolver{
+		ingressClassLister: networkinglisters.NewIngressClassLister(ingressClassIndexer),
+		paramsLister:       ingparamslisters.NewGCPIngressParamsLister(paramsIndexer),
+	}
+}
+
+// ParamsForIngress returns the GCPIngressParams for the given Ingress.
+// It returns nil if the Ingress does not set spec.ingressClassName or if the
+// IngressClass is not handled by this controller. An IngressClass handled by
+// this controller without parameters resolves to the default parameters,
+// which select a global external load balancer. The legacy ingress.class
+// annotation takes precedence over spec.ingressClassName.
+func (r *Resolver) ParamsForIngress(ing *v1.Ingress) (*ingparamsv1beta1.GCPIngressParams, error) {
+	if ing == nil || ing.Spec.IngressClassName == nil {
+		return nil, nil
+	}
+	if annotations.FromIngress(ing).IngressClass() != "" {
+		return nil, nil
+	}
+	ingClass, err := r.ingressClassLister.Get(*ing.Spec.IngressClassName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if ingClass.Spec.Controller != ControllerName {
+		return nil, nil
+	}
+	return r.ParamsForIngressClass(ingClass)
+}
+
+// ParamsForIngressClass returns the GCPIngressParams referenced by the
+// parameters of the given IngressClass.
+func (r *Resolver) ParamsForIngressClass(ingClass *v1.IngressClass) (*ingparamsv1beta1.GCPIngressParams, error) {
+	ref := ingClass.Spec.Parameters
+	if ref == nil {
+		return &ingparamsv1beta1.GCPIngressParams{}, nil
+	}
+	if ref.APIGroup == nil || *ref.APIGroup != apisingparams.GroupName || ref.Kind != GCPIngressParamsKind {
+		return nil, fmt.Errorf("IngressClass %s parameters must reference a %s.%s, got kind %q", ingClass.Name, GCPIngressParamsKind, apisingparams.GroupName, ref.Kind)
+	}
+	if ref.Scope != nil && *ref.Scope != v1.IngressClassParametersReferenceScopeCluster {
+		return nil, fmt.Errorf("IngressClass %s parameters must be cluster scoped, got scope %q", ingClass.Name, *ref.Scope)
+	}
+	params, err := r.paramsLister.Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s for IngressClass %s: %w", GCPIngressParamsKind, ref.Name, ingClass.Name, err)
+	}
+	return params, nil
+}
+
+// IngressClassesForParams returns the names of the IngressClasses handled by
+// this controller that reference the given GCPIngressParams.
+func (r *Resolver) IngressClassesForParams(params *ingparamsv1beta1.GCPIngressParams) []string {
+	ingClasses, err := r.ingressClassLister.List(labels.Everything())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, ingClass := range ingClasses {
+		ref := ingClass.Spec.Parameters
+		if ingClass.Spec.Controller != ControllerName || ref == nil {
+			continue
+		}
+		if ref.APIGroup != nil && *ref.APIGroup == apisingparams.GroupName && ref.Kind == GCPIngressParamsKind && ref.Name == params.Name {
+			names = append(names, ingClass.Name)
+		}
+	}
+	return names
+}
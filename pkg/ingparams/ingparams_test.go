@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingparams
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/annotations"
+	apisingparams "k8s.io/ingress-gce/pkg/apis/ingparams"
+	ingparamsv1beta1 "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1"
+)
+
+func newIngressClass(name, controller string, params *v1.IngressClassParametersReference) *v1.IngressClass {
+	return &v1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.IngressClassSpec{
+			Controller: controller,
+			Parameters: params,
+		},
+	}
+}
+
+func paramsRef(kind, name string, scope *string) *v1.IngressClassParametersReference {
+	group := apisingparams.GroupName
+	return &v1.IngressClassParametersReference{
+		APIGroup: &group,
+		Kind:     kind,
+		Name:     name,
+		Scope:    scope,
+	}
+}
+
+func newTestResolver(t *testing.T) *Resolver {
+	t.Helper()
+
+	namespaceScope := v1.IngressClassParametersReferenceScopeNamespace
+	ingClasses := []*v1.IngressClass{
+		newIngressClass("gce-internal", ControllerName, paramsRef(GCPIngressParamsKind, "internal", nil)),
+		newIngressClass("gce-regional", ControllerName, paramsRef(GCPIngressParamsKind, "regional", nil)),
+		newIngressClass("gce-internal-2", ControllerName, paramsRef(GCPIngressParamsKind, "internal", nil)),
+		newIngressClass("gce-default", ControllerName, nil),
+		newIngressClass("gce-missing", ControllerName, paramsRef(GCPIngressParamsKind, "missing", nil)),
+		newIngressClass("gce-wrong-kind", ControllerName, paramsRef("OtherParams", "internal", nil)),
+		newIngressClass("gce-namespaced", ControllerName, paramsRef(GCPIngressParamsKind, "internal", &namespaceScope)),
+		newIngressClass("other", "example.com/other-controller", paramsRef(GCPIngressParamsKind, "internal", nil)),
+	}
+	params := []*ingparamsv1beta1.GCPIngressParams{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+			Spec:       ingparamsv1beta1.GCPIngressParamsSpec{Internal: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "regional"},
+			Spec:       ingparamsv1beta1.GCPIngressParamsSpec{Regional: true},
+		},
+	}
+
+	ingClassIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ingClass := range ingClasses {
+		if err := ingClassIndexer.Add(ingClass); err != nil {
+			t.Fatalf("ingClassIndexer.Add(%s) = %v", ingClass.Name, err)
+		}
+	}
+	paramsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, p := range params {
+		if err := paramsIndexer.Add(p); err != nil {
+			t.Fatalf("paramsIndexer.Add(%s) = %v", p.Name, err)
+		}
+	}
+	return NewResolver(ingClassIndexer, paramsIndexer)
+}
+
+func TestParamsForIngress(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestResolver(t)
+
+	testCases := []struct {
+		desc         string
+		className    *string
+		annotation   string
+		wantNil      bool
+		wantInternal bool
+		wantRegional bool
+		wantErr      bool
+	}{
+		{
+			desc:    "no ingressClassName",
+			wantNil: true,
+		},
+		{
+			desc:         "internal params",
+			className:    stringPtr("gce-internal"),
+			wantInternal: true,
+		},
+		{
+			desc:         "regional params",
+			className:    stringPtr("gce-regional"),
+			wantRegional: true,
+		},
+		{
+			desc:      "no parameters defaults to global external",
+			className: stringPtr("gce-default"),
+		},
+		{
+			desc:       "legacy annotation takes precedence",
+			className:  stringPtr("gce-internal"),
+			annotation: annotations.GceIngressClass,
+			wantNil:    true,
+		},
+		{
+			desc:      "IngressClass does not exist",
+			className: stringPtr("does-not-exist"),
+			wantNil:   true,
+		},
+		{
+			desc:      "IngressClass of another controller",
+			className: stringPtr("other"),
+			wantNil:   true,
+		},
+		{
+			desc:      "params do not exist",
+			className: stringPtr("gce-missing"),
+			wantNil:   true,
+			wantErr:   true,
+		},
+		{
+			desc:      "params of the wrong kind",
+			className: stringPtr("gce-wrong-kind"),
+			wantNil:   true,
+			wantErr:   true,
+		},
+		{
+			desc:      "namespace scoped params",
+			className: stringPtr("gce-namespaced"),
+			wantNil:   true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ing := &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "default", Annotations: map[string]string{}},
+				Spec:       v1.IngressSpec{IngressClassName: tc.className},
+			}
+			if tc.annotation != "" {
+				ing.Annotations[annotations.IngressClassKey] = tc.annotation
+			}
+
+			params, err := resolver.ParamsForIngress(ing)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParamsForIngress() = %v, want error %v", err, tc.wantErr)
+			}
+			if gotNil := params == nil; gotNil != tc.wantNil {
+				t.Fatalf("ParamsForIngress() = %+v, want nil %v", params, tc.wantNil)
+			}
+			if params == nil {
+				return
+			}
+			if params.Spec.Internal != tc.wantInternal {
+				t.Errorf("params.Spec.Internal = %v, want %v", params.Spec.Internal, tc.wantInternal)
+			}
+			if params.Spec.Regional != tc.wantRegional {
+				t.Errorf("params.Spec.Regional = %v, want %v", params.Spec.Regional, tc.wantRegional)
+			}
+		})
+	}
+}
+
+func TestIngressClassesForParams(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestResolver(t)
+
+	testCases := []struct {
+		desc   string
+		params string
+		want   []string
+	}{
+		{
+			desc:   "params referenced by several IngressClasses",
+			params: "internal",
+			want:   []string{"gce-internal", "gce-internal-2", "gce-namespaced"},
+		},
+		{
+			desc:   "params referenced by one IngressClass",
+			params: "regional",
+			want:   []string{"gce-regional"},
+		},
+		{
+			desc:   "params not referenced",
+			params: "unused",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got := resolver.IngressClassesForParams(&ingparamsv1beta1.GCPIngressParams{ObjectMeta: metav1.ObjectMeta{Name: tc.params}})
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("IngressClassesForParams(%s) = %v, want %v", tc.params, got, tc.want)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
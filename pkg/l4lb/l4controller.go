@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -172,6 +173,22 @@ func NewILBController(ctx *context.ControllerContext, stopCh <-chan struct{}, lo
 			}
 		},
 	})
+	// The failover backends of services with a failover node selector depend
+	// on the labels of the nodes in each zone.
+	ctx.NodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			l4c.enqueueFailoverServices()
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldNode, curNode := old.(*v1.Node), cur.(*v1.Node)
+			if !reflect.DeepEqual(oldNode.Labels, curNode.Labels) {
+				l4c.enqueueFailoverServices()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			l4c.enqueueFailoverServices()
+		},
+	})
 	// TODO enhance this by looking at some metric from service controller to ensure it is up.
 	// We cannot use existence of a backend service or other resource, since those are on a per-service basis.
 	ctx.AddHealthCheck(l4ILBControllerName, l4c.checkHealth)
@@ -277,6 +294,12 @@ func (l4c *L4Controller) processServiceCreateOrUpdate(service *v1.Service, svcLo
 	if err != nil {
 		return &loadbalancers.L4ILBSyncResult{Error: err}
 	}
+	staticAddress, err := staticAddressIP(l4c.ctx, service, cloud.SchemeInternal)
+	if err != nil {
+		l4c.ctx.Recorder(service.Namespace).Eventf(service, v1.EventTypeWarning, "SyncLoadBalancerFailed",
+			"Error syncing load balancer: %v", err)
+		return &loadbalancers.L4ILBSyncResult{Error: err}
+	}
 	// Use the same function for both create and updates. If controller crashes and restarts,
 	// all existing services will show up as Service Adds.
 	l4ilbParams := &loadbalancers.L4ILBParams{
@@ -289,10 +312,21 @@ func (l4c *L4Controller) processServiceCreateOrUpdate(service *v1.Service, svcLo
 		EnableWeightedLB:                 l4c.ctx.EnableWeightedL4ILB,
 		DisableNodesFirewallProvisioning: l4c.ctx.DisableL4LBFirewall,
 		EnableMixedProtocol:              l4c.ctx.EnableL4MixedProtocol,
+		EnableMultipleForwardingRules:    l4c.ctx.EnableL4ILBMultipleFwdRules,
+		StaticAddress:                    staticAddress,
 	}
 	l4 := loadbalancers.NewL4Handler(l4ilbParams, svcLogger)
 	syncResult := l4.EnsureInternalLoadBalancer(utils.GetNodeNames(nodes), service)
 	// syncResult will not be nil
+	// Conditions are updated on failed syncs too, to report which features failed.
+	if syncResult.Conditions != nil {
+		if err = updateServiceConditions(l4c.ctx, service, syncResult.Conditions, svcLogger); err != nil {
+			svcLogger.Error(err, "Failed to update service conditions")
+			if syncResult.Error == nil {
+				syncResult.Error = fmt.Errorf("failed to update service conditions, err: %w", err)
+			}
+		}
+	}
 	if syncResult.Error != nil {
 		l4c.ctx.Recorder(service.Namespace).Eventf(service, v1.EventTypeWarning, "SyncLoadBalancerFailed",
 			"Error syncing load balancer: %v", syncResult.Error)
@@ -371,6 +405,7 @@ func (l4c *L4Controller) processServiceDeletion(key string, svc *v1.Service, svc
 		EnableWeightedLB:                 l4c.ctx.EnableWeightedL4ILB,
 		DisableNodesFirewallProvisioning: l4c.ctx.DisableL4LBFirewall,
 		EnableMixedProtocol:              l4c.ctx.EnableL4MixedProtocol,
+		EnableMultipleForwardingRules:    l4c.ctx.EnableL4ILBMultipleFwdRules,
 	}
 	l4 := loadbalancers.NewL4Handler(l4ilbParams, svcLogger)
 	l4c.ctx.Recorder(svc.Namespace).Eventf(svc, v1.EventTypeNormal, "DeletingLoadBalancer", "Deleting load balancer for %s", key)
@@ -415,6 +450,22 @@ func (l4c *L4Controller) processServiceDeletion(key string, svc *v1.Service, svc
 	return result
 }
 
+// enqueueFailoverServices enqueues the L4 ILB services with a failover policy,
+// whose failover backends may change with the labels of the nodes.
+func (l4c *L4Controller) enqueueFailoverServices() {
+	for _, svc := range l4c.ctx.Services().List() {
+		if _, ok := svc.Annotations[annotations.L4FailoverPolicyKey]; !ok {
+			continue
+		}
+		if needsILB, _ := annotations.WantsL4ILB(svc); !needsILB {
+			continue
+		}
+		l4c.logger.V(3).Info("Nodes changed, enqueuing service with failover policy", "serviceKey", utils.ServiceKeyFunc(svc.Namespace, svc.Name))
+		l4c.svcQueue.Enqueue(svc)
+		l4c.enqueueTracker.Track()
+	}
+}
+
 // linkNEG associates the NEG to the backendService for the given L4 ILB service.
 func (l4c *L4Controller) linkNEG(l4 *loadbalancers.L4, svcLogger klog.Logger) error {
 	// link neg to backend service
@@ -422,9 +473,23 @@ func (l4c *L4Controller) linkNEG(l4 *loadbalancers.L4, svcLogger klog.Logger) er
 	if err != nil {
 		return nil
 	}
+	// Failover is zone-granular, as the backends are the zonal NEGs: a zone
+	// is a failover backend only when all of its nodes match the selector.
+	failoverZones := sets.NewString()
+	if selector := l4.FailoverNodeSelector(); selector != nil {
+		zones, partialZones, err := l4c.zoneGetter.ListZonesMatchingSelector(zonegetter.CandidateAndUnreadyNodesFilter, selector, svcLogger)
+		if err != nil {
+			return err
+		}
+		if len(partialZones) > 0 {
+			l4c.ctx.Recorder(l4.Service.Namespace).Eventf(l4.Service, v1.EventTypeWarning, "FailoverNodeSelectorPartialZones",
+				"Failover node selector %q matches only some nodes of zones %v, which are not failover backends: failover backends are whole zones", selector.String(), partialZones)
+		}
+		failoverZones.Insert(zones...)
+	}
 	var groupKeys []backends.GroupKey
 	for _, zone := range zones {
-		groupKeys = append(groupKeys, backends.GroupKey{Zone: zone})
+		groupKeys = append(groupKeys, backends.GroupKey{Zone: zone, Failover: failoverZones.Has(zone)})
 	}
 	return l4c.NegLinker.Link(l4.ServicePort, groupKeys)
 }
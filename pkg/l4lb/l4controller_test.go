@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
@@ -35,17 +36,23 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	api_v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	cloudprovider "k8s.io/cloud-provider"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/context"
+	staticaddressfake "k8s.io/ingress-gce/pkg/staticaddress/client/clientset/versioned/fake"
+	informerstaticaddress "k8s.io/ingress-gce/pkg/staticaddress/client/informers/externalversions/staticaddress/v1beta1"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned/fake"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils/common"
@@ -839,6 +846,197 @@ func TestProcessCreateServiceWithLoadBalancerClass(t *testing.T) {
 	verifyILBServiceNotProvisioned(t, svc)
 }
 
+func TestProcessCreateServiceWithStaticAddress(t *testing.T) {
+	fakeGCE := newFakeGCE()
+	l4c := newServiceController(t, fakeGCE)
+	l4c.ctx.StaticAddressInformer = informerstaticaddress.NewStaticAddressInformer(staticaddressfake.NewSimpleClientset(), api_v1.NamespaceAll, time.Minute, utils.NewNamespaceIndexer())
+
+	newSvc := test.NewL4ILBService(false, 8080)
+	newSvc.Annotations[annotations.StaticAddressKey] = "my-ip"
+	addILBService(l4c, newSvc)
+	addNEGAndSvcNegL4Controller(l4c, newSvc)
+
+	// The Service is not provisioned while the StaticAddress does not exist.
+	key := getKeyForSvc(newSvc, t)
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync newly added service %s, err %v", newSvc.Name, err)
+	}
+	svc, err := l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	if len(svc.Status.LoadBalancer.Ingress) != 0 {
+		t.Errorf("Expected no LoadBalancer IP before the StaticAddress exists, got %v", svc.Status.LoadBalancer.Ingress)
+	}
+
+	const addressName, ip = "k8s1-ip-static", "10.1.2.3"
+	if err := fakeGCE.ReserveRegionAddress(&compute.Address{Name: addressName, Address: ip, AddressType: string(cloud.SchemeInternal)}, fakeGCE.Region()); err != nil {
+		t.Fatalf("ReserveRegionAddress(%s) = %v", addressName, err)
+	}
+	l4c.ctx.StaticAddressInformer.GetIndexer().Add(&staticaddressv1beta1.StaticAddress{
+		ObjectMeta: v1.ObjectMeta{Namespace: newSvc.Namespace, Name: "my-ip"},
+		Spec:       staticaddressv1beta1.StaticAddressSpec{AddressType: string(cloud.SchemeInternal)},
+		Status:     staticaddressv1beta1.StaticAddressStatus{AddressName: addressName, Address: ip},
+	})
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync service %s, err %v", newSvc.Name, err)
+	}
+	svc, err = l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	verifyILBServiceProvisioned(t, svc)
+	if got := svc.Status.LoadBalancer.Ingress[0].IP; got != ip {
+		t.Errorf("Got LoadBalancer IP %s, want %s from the StaticAddress", got, ip)
+	}
+
+	// The StaticAddress is not released when the Service is deleted.
+	svc.DeletionTimestamp = &v1.Time{}
+	updateILBService(l4c, svc)
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync deleted service %s, err %v", key, err)
+	}
+	if _, err := fakeGCE.GetRegionAddress(addressName, fakeGCE.Region()); err != nil {
+		t.Errorf("GetRegionAddress(%s) = %v, want the address to be kept", addressName, err)
+	}
+}
+
+func TestProcessCreateServiceWithForwardingRuleConditions(t *testing.T) {
+	l4c := newServiceController(t, newFakeGCE())
+
+	newSvc := test.NewL4ILBService(false, 8080)
+	newSvc.Annotations[gce.ServiceAnnotationILBAllowGlobalAccess] = "true"
+	newSvc.Annotations[annotations.ServiceDirectoryRegistrationKey] = `{"namespace": "sd-ns", "service": "sd-svc"}`
+	newSvc.Annotations[annotations.MirroringCollectorKey] = "true"
+	addILBService(l4c, newSvc)
+	addNEGAndSvcNegL4Controller(l4c, newSvc)
+
+	key := getKeyForSvc(newSvc, t)
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync newly added service %s, err %v", newSvc.Name, err)
+	}
+	svc, err := l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	verifyILBServiceProvisioned(t, svc)
+	for _, conditionType := range loadbalancers.ForwardingRuleConditionTypes {
+		condition := apimeta.FindStatusCondition(svc.Status.Conditions, conditionType)
+		if condition == nil {
+			t.Errorf("Condition %s not found in %+v", conditionType, svc.Status.Conditions)
+			continue
+		}
+		if condition.Status != v1.ConditionTrue || condition.Reason != loadbalancers.ConditionReasonApplied {
+			t.Errorf("Got condition %+v, want status %s and reason %s", condition, v1.ConditionTrue, loadbalancers.ConditionReasonApplied)
+		}
+	}
+
+	// Conditions of features that are no longer requested are removed.
+	delete(svc.Annotations, annotations.MirroringCollectorKey)
+	updateILBService(l4c, svc)
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync updated service %s, err %v", svc.Name, err)
+	}
+	svc, err = l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	if condition := apimeta.FindStatusCondition(svc.Status.Conditions, loadbalancers.MirroringCollectorConditionType); condition != nil {
+		t.Errorf("Got condition %+v, want it removed", condition)
+	}
+	if !apimeta.IsStatusConditionTrue(svc.Status.Conditions, loadbalancers.ServiceDirectoryRegistrationConditionType) {
+		t.Errorf("Condition %s is not true in %+v", loadbalancers.ServiceDirectoryRegistrationConditionType, svc.Status.Conditions)
+	}
+}
+
+func TestProcessCreateServiceWithInvalidServiceDirectoryRegistration(t *testing.T) {
+	l4c := newServiceController(t, newFakeGCE())
+
+	newSvc := test.NewL4ILBService(false, 8080)
+	newSvc.Annotations[annotations.ServiceDirectoryRegistrationKey] = `{"namespace": "sd-ns"}`
+	addILBService(l4c, newSvc)
+	addNEGAndSvcNegL4Controller(l4c, newSvc)
+
+	syncResult := l4c.processServiceCreateOrUpdate(newSvc, klog.TODO())
+	if syncResult == nil || !utils.IsUserError(syncResult.Error) {
+		t.Fatalf("processServiceCreateOrUpdate() = %+v, want a user error", syncResult)
+	}
+}
+
+func TestProcessCreateServiceWithFailoverPolicy(t *testing.T) {
+	l4c := newServiceController(t, newFakeGCE())
+
+	nodes, err := l4c.ctx.NodeInformer.GetIndexer().ByIndex(cache.NamespaceIndex, "")
+	if err != nil || len(nodes) == 0 {
+		t.Fatalf("Failed to list nodes, got %v, err: %v", nodes, err)
+	}
+	for _, obj := range nodes {
+		node := obj.(*api_v1.Node).DeepCopy()
+		node.Labels["role"] = "standby"
+		l4c.ctx.NodeInformer.GetIndexer().Update(node)
+	}
+
+	linker := &recordingLinker{}
+	l4c.NegLinker = linker
+
+	newSvc := test.NewL4ILBService(false, 8080)
+	newSvc.Annotations[annotations.L4FailoverPolicyKey] = `{"failoverNodeSelector": "role=standby", "failoverRatio": 0.5, "dropTrafficIfUnhealthy": true}`
+	addILBService(l4c, newSvc)
+	addNEGAndSvcNegL4Controller(l4c, newSvc)
+
+	key := getKeyForSvc(newSvc, t)
+	if err := l4c.sync(key, klog.TODO()); err != nil {
+		t.Errorf("Failed to sync newly added service %s, err %v", newSvc.Name, err)
+	}
+	svc, err := l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	verifyILBServiceProvisioned(t, svc)
+
+	bsName := l4c.namer.L4Backend(svc.Namespace, svc.Name)
+	bs, err := composite.GetBackendService(l4c.ctx.Cloud, meta.RegionalKey(bsName, l4c.ctx.Cloud.Region()), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("Failed to get backend service %s, err: %v", bsName, err)
+	}
+	wantPolicy := &composite.BackendServiceFailoverPolicy{FailoverRatio: 0.5, DropTrafficIfUnhealthy: true}
+	if diff := cmp.Diff(wantPolicy, bs.FailoverPolicy); diff != "" {
+		t.Errorf("Got unexpected failover policy, diff -want +got\n%v", diff)
+	}
+	wantGroups := []backends.GroupKey{{Zone: testGCEZone, Failover: true}}
+	if diff := cmp.Diff(wantGroups, linker.groups); diff != "" {
+		t.Errorf("Got unexpected linked groups, diff -want +got\n%v", diff)
+	}
+}
+
+func TestEnqueueFailoverServices(t *testing.T) {
+	l4c := newServiceController(t, newFakeGCE())
+
+	failoverSvc := test.NewL4ILBService(false, 8080)
+	failoverSvc.Annotations[annotations.L4FailoverPolicyKey] = `{"failoverNodeSelector": "role=standby"}`
+	addILBService(l4c, failoverSvc)
+	svc := test.NewL4ILBService(false, 8081)
+	svc.Name = "svc-without-failover"
+	addILBService(l4c, svc)
+
+	// Services with a failover policy are enqueued when the labels of the
+	// nodes change, as their failover zones may change.
+	l4c.enqueueFailoverServices()
+	if got := l4c.svcQueue.Len(); got != 1 {
+		t.Errorf("enqueueFailoverServices() enqueued %d services, want 1", got)
+	}
+}
+
+// recordingLinker records the groups it was asked to link.
+type recordingLinker struct {
+	groups []backends.GroupKey
+}
+
+func (l *recordingLinker) Link(_ utils.ServicePort, groups []backends.GroupKey) error {
+	l.groups = groups
+	return nil
+}
+
 func newServiceController(t *testing.T, fakeGCE *gce.Cloud) *L4Controller {
 	kubeClient := fake.NewSimpleClientset()
 	svcNegClient := svcnegclient.NewSimpleClientset()
@@ -852,7 +1050,7 @@ func newServiceController(t *testing.T, fakeGCE *gce.Cloud) *L4Controller {
 		ResyncPeriod: 1 * time.Minute,
 		NumL4Workers: 5,
 	}
-	ctx := context.NewControllerContext(kubeClient, nil, nil, nil, svcNegClient, nil, nil, nil, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
+	ctx := context.NewControllerContext(kubeClient, nil, nil, nil, svcNegClient, nil, nil, nil, context.ExtensionClients{}, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
 	ctx.ZoneGetter = zonegetter.NewFakeZoneGetter(ctx.NodeInformer, zonegetter.FakeNodeTopologyInformer(), defaultTestSubnetURL, false)
 	// Add some nodes so that NEG linker kicks in during ILB creation.
 	nodes, err := test.CreateAndInsertNodes(ctx.Cloud, []string{"instance-1"}, vals.ZoneName)
@@ -20,15 +20,19 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/cloud-provider/service/helpers"
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/context"
 	l4metrics "k8s.io/ingress-gce/pkg/l4lb/metrics"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/ingress-gce/pkg/staticaddress"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/ingress-gce/pkg/utils/patch"
@@ -108,12 +112,48 @@ func updateServiceStatus(ctx *context.ControllerContext, svc *v1.Service, newSta
 	return patch.PatchServiceLoadBalancerStatus(ctx.KubeClient.CoreV1(), svc, *newStatus)
 }
 
+// updateServiceConditions sets the given forwarding rule conditions on the
+// service, removes the ones that are not part of conditions and patches the
+// service if needed. Conditions of other types are left untouched.
+func updateServiceConditions(ctx *context.ControllerContext, svc *v1.Service, conditions []metav1.Condition, svcLogger klog.Logger) error {
+	newConditions := make([]metav1.Condition, len(svc.Status.Conditions))
+	copy(newConditions, svc.Status.Conditions)
+	for _, conditionType := range loadbalancers.ForwardingRuleConditionTypes {
+		if apimeta.FindStatusCondition(conditions, conditionType) == nil {
+			apimeta.RemoveStatusCondition(&newConditions, conditionType)
+		}
+	}
+	for _, condition := range conditions {
+		apimeta.SetStatusCondition(&newConditions, condition)
+	}
+	if reflect.DeepEqual(svc.Status.Conditions, newConditions) || (len(svc.Status.Conditions) == 0 && len(newConditions) == 0) {
+		return nil
+	}
+	svcLogger.V(2).Info("Patching service conditions", "conditions", fmt.Sprintf("%+v", conditions))
+	return patch.PatchServiceConditions(ctx.KubeClient.CoreV1(), svc, newConditions)
+}
+
 // isHealthCheckDeleted checks if given health check exists in GCE
 func isHealthCheckDeleted(cloud *gce.Cloud, hcName string, logger klog.Logger) bool {
 	_, err := composite.GetHealthCheck(cloud, meta.GlobalKey(hcName), meta.VersionGA, logger)
 	return utils.IsNotFoundError(err)
 }
 
+// staticAddressIP returns the IP address of the StaticAddress referenced by
+// the given Service, or an empty string if it does not reference one or
+// static addresses are not enabled.
+func staticAddressIP(ctx *context.ControllerContext, svc *v1.Service, addrType cloud.LbScheme) (string, error) {
+	name := annotations.FromService(svc).StaticAddress()
+	if name == "" || ctx.StaticAddressInformer == nil {
+		return "", nil
+	}
+	sa, err := staticaddress.AddressFor(ctx.StaticAddressInformer.GetIndexer(), svc.Namespace, name, false, addrType)
+	if err != nil {
+		return "", err
+	}
+	return sa.Status.Address, nil
+}
+
 func skipUserError(err error, svcLogger klog.Logger) error {
 	if utils.IsUserError(err) {
 		svcLogger.Info("Sync failed with user-caused error", "err", err)
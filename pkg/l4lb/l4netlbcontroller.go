@@ -537,6 +537,13 @@ func (lc *L4NetLBController) syncInternal(service *v1.Service, svcLogger klog.Lo
 
 	usesNegBackends := lc.shouldUseNEGBackends(service)
 
+	staticAddress, err := staticAddressIP(lc.ctx, service, cloud.SchemeExternal)
+	if err != nil {
+		lc.ctx.Recorder(service.Namespace).Eventf(service, v1.EventTypeWarning, "SyncExternalLoadBalancerFailed",
+			"Error ensuring Resource for L4 External LoadBalancer, err: %v", err)
+		return &loadbalancers.L4NetLBSyncResult{Error: err}
+	}
+
 	l4NetLBParams := &loadbalancers.L4NetLBParams{
 		Service:                          service,
 		Cloud:                            lc.ctx.Cloud,
@@ -548,6 +555,7 @@ func (lc *L4NetLBController) syncInternal(service *v1.Service, svcLogger klog.Lo
 		EnableWeightedLB:                 lc.ctx.EnableWeightedL4NetLB,
 		DisableNodesFirewallProvisioning: lc.ctx.DisableL4LBFirewall,
 		UseNEGs:                          usesNegBackends,
+		StaticAddress:                    staticAddress,
 	}
 	l4netlb := loadbalancers.NewL4NetLB(l4NetLBParams, svcLogger)
 
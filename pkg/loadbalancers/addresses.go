@@ -80,9 +80,8 @@ func (l7 *L7) checkStaticIP() (err error) {
 }
 
 func (l7 *L7) newStaticAddress(name string) *composite.Address {
-	isInternal := utils.IsGCEL7ILBIngress(&l7.ingress)
 	address := &composite.Address{Name: name, Address: l7.fw.IPAddress, Version: meta.VersionGA}
-	if isInternal {
+	if l7.isL7ILB {
 		// Used for L7 ILB
 		address.AddressType = "INTERNAL"
 	}
@@ -22,7 +22,6 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/networking/v1"
-	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 )
 
@@ -55,10 +54,7 @@ func TestNewStaticAddress(t *testing.T) {
 			l7 := &L7{
 				ingress: v1.Ingress{Spec: v1.IngressSpec{}},
 				fw:      &composite.ForwardingRule{IPAddress: tc.ip},
-			}
-
-			if tc.isInternal {
-				l7.ingress.Annotations = map[string]string{annotations.IngressClassKey: "gce-internal"}
+				isL7ILB: tc.isInternal,
 			}
 
 			result := l7.newStaticAddress(tc.name)
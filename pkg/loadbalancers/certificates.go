@@ -20,7 +20,11 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -28,9 +32,7 @@ import (
 const SslCertificateMissing = "SslCertificateMissing"
 
 func (l7 *L7) checkSSLCert() error {
-	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
-	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(l7.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
+	tr := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
 	env := &translator.Env{Region: l7.cloud.Region(), Project: l7.cloud.ProjectID()}
 	translatorCerts := tr.ToCompositeSSLCertificates(env, l7.runtimeInfo.TLSName, l7.runtimeInfo.TLS, l7.Versions().SslCertificate)
 
@@ -58,6 +60,27 @@ func (l7 *L7) checkSSLCert() error {
 	return nil
 }
 
+// checkCertificateMapSSLCerts prepares the ssl certificates of a target https
+// proxy that serves a certificate map. The proxy does not serve ssl
+// certificates, so the ssl certificates created for the Ingress are detached
+// and cleaned up. Pre-shared certificates, including the Google-managed
+// certificates of ManagedCertificate resources, are detached but never deleted.
+func (l7 *L7) checkCertificateMapSSLCerts() error {
+	existingSecretsSslCerts, err := l7.getIngressManagedSslCerts()
+	if err != nil {
+		return err
+	}
+	l7.oldSSLCerts = existingSecretsSslCerts
+	l7.sslCerts = nil
+
+	ingAnnotations := annotations.FromIngress(l7.runtimeInfo.Ingress)
+	if l7.runtimeInfo.TLSName != "" || len(l7.runtimeInfo.TLS) > 0 || ingAnnotations.ManagedCertificates() != "" {
+		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeWarning, events.SyncIngress,
+			"Certificate map %q is used, the pre-shared, managed and Secret certificates of the Ingress are not served", l7.runtimeInfo.CertificateMap)
+	}
+	return nil
+}
+
 // createSslCertificates creates SslCertificates based on kubernetes secrets in Ingress configuration.
 func (l7 *L7) createSslCertificates(existingCerts, translatorCerts []*composite.SslCertificate) ([]*composite.SslCertificate, error) {
 	var result []*composite.SslCertificate
@@ -200,6 +223,23 @@ func (l7 *L7) deleteOldSSLCerts() {
 	if len(l7.oldSSLCerts) == 0 {
 		return
 	}
+	// Certificates that the target https proxy still references, for instance
+	// because updating the proxy failed, are retained. They are cleaned up by a
+	// later sync, once the proxy no longer uses them.
+	linksInUse, err := l7.getSslCertLinkInUse()
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		l7.logger.Error(err, "Failed to get the ssl certificates in use, skipping old cert cleanup")
+		return
+	}
+	inUse := sets.New[string]()
+	for _, link := range linksInUse {
+		name, err := utils.KeyName(link)
+		if err != nil {
+			l7.logger.Error(err, "Cannot get cert name, skipping old cert cleanup", "certLink", link)
+			return
+		}
+		inUse.Insert(name)
+	}
 	certsMap := getMapFromCertList(l7.sslCerts)
 	for _, cert := range l7.oldSSLCerts {
 		if !l7.namer.IsCertNameForLB(cert.Name) && !l7.namer.IsLegacySSLCert(cert.Name) {
@@ -210,6 +250,10 @@ func (l7 *L7) deleteOldSSLCerts() {
 			// cert found in current map
 			continue
 		}
+		if inUse.Has(cert.Name) {
+			l7.logger.V(3).Info("Retaining old SSL Certificate still used by the target proxy", "certName", cert.Name)
+			continue
+		}
 		l7.logger.V(3).Info("Cleaning up old SSL Certificate", "certName", cert.Name)
 		key, _ := l7.CreateKey(cert.Name)
 		if certErr := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificate(l7.cloud, key, l7.Versions().SslCertificate, l7.logger)); certErr != nil {
@@ -95,11 +95,25 @@ func (r *ResourceVersions) merge(other *ResourceVersions) *ResourceVersions {
 }
 
 // featuresFromIngress returns the features enabled by an ingress
-func featuresFromIngress(ing *v1.Ingress) []string {
+func featuresFromIngress(ing *v1.Ingress, classifier utils.IngressClassifier) ([]string, error) {
+	isL7ILB, err := classifier.IsL7ILB(ing)
+	if err != nil {
+		return nil, err
+	}
+	isL7XLBRegional, err := classifier.IsL7XLBRegional(ing)
+	if err != nil {
+		return nil, err
+	}
+	return featuresForLoadBalancer(isL7ILB, isL7XLBRegional), nil
+}
+
+// featuresForLoadBalancer returns the features enabled by the given type of
+// load balancer
+func featuresForLoadBalancer(isL7ILB, isL7XLBRegional bool) []string {
 	var result []string
-	if utils.IsGCEL7ILBIngress(ing) {
+	if isL7ILB {
 		result = append(result, FeatureL7ILB)
-	} else if utils.IsGCEL7XLBRegionalIngress(ing) {
+	} else if isL7XLBRegional {
 		result = append(result, FeatureL7XLBRegional)
 	}
 	return result
@@ -154,11 +168,22 @@ func scopeFromFeatures(features []string) meta.KeyType {
 
 // TODO: (shance) refactor scope to be per-resource
 // ScopeFromIngress returns the required scope of features for an Ingress
-func ScopeFromIngress(ing *v1.Ingress) meta.KeyType {
-	return scopeFromFeatures(featuresFromIngress(ing))
+func ScopeFromIngress(ing *v1.Ingress, classifier utils.IngressClassifier) (meta.KeyType, error) {
+	features, err := featuresFromIngress(ing, classifier)
+	if err != nil {
+		return "", err
+	}
+	return scopeFromFeatures(features), nil
+}
+
+// ScopeForLoadBalancer returns the required scope of features for the given
+// type of load balancer
+func ScopeForLoadBalancer(isL7ILB, isL7XLBRegional bool) meta.KeyType {
+	return scopeFromFeatures(featuresForLoadBalancer(isL7ILB, isL7XLBRegional))
 }
 
-// VersionsFromIngress returns a ResourceVersions struct containing all of the resources per version
-func VersionsFromIngress(ing *v1.Ingress) *ResourceVersions {
-	return versionsFromFeatures(featuresFromIngress(ing))
+// VersionsForLoadBalancer returns a ResourceVersions struct containing all of
+// the resources per version for the given type of load balancer
+func VersionsForLoadBalancer(isL7ILB, isL7XLBRegional bool) *ResourceVersions {
+	return versionsFromFeatures(featuresForLoadBalancer(isL7ILB, isL7XLBRegional))
 }
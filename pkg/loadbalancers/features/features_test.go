@@ -23,6 +23,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	networkingv1 "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/utils"
 )
 
 const (
@@ -101,7 +102,10 @@ func TestScopeFromIngress(t *testing.T) {
 	scopeToFeatures = fakeScopeToFeatures
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			result := ScopeFromIngress(&tc.ing)
+			result, err := ScopeFromIngress(&tc.ing, utils.IngressClassifier{})
+			if err != nil {
+				t.Fatalf("ScopeFromIngress() = %v", err)
+			}
 
 			if result != tc.scope {
 				t.Fatalf("want scope %s, got %s", tc.scope, result)
@@ -41,6 +41,7 @@ import (
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
+	utilnet "k8s.io/utils/net"
 )
 
 const (
@@ -100,9 +101,7 @@ func (l7 *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		return nil, err
 	}
 
-	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
-	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(&l7.ingress)
-	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
+	tr := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
 	env := &translator.Env{VIP: ip, Network: l7.cloud.NetworkURL(), Subnetwork: l7.cloud.SubnetworkURL()}
 	fr := tr.ToCompositeForwardingRule(env, protocol, version, proxyLink, description, l7.runtimeInfo.StaticIPSubnet)
 
@@ -264,7 +263,21 @@ func (l4 *L4) ensureIPv4ForwardingRule(bsLink string, options gce.ILBOptions, ex
 		AllowGlobalAccess:   options.AllowGlobalAccess,
 		Description:         frDesc,
 	}
+	if l4.serviceDirectoryRegistration != nil {
+		newFwdRule.ServiceDirectoryRegistrations = []*composite.ForwardingRuleServiceDirectoryRegistration{
+			{
+				Namespace: l4.serviceDirectoryRegistration.Namespace,
+				Service:   l4.serviceDirectoryRegistration.Service,
+			},
+		}
+	}
+	newFwdRule.IsMirroringCollector = l4.mirroringCollector
+	return l4.applyIPv4ForwardingRule(existingFwdRule, newFwdRule, frLogger)
+}
 
+// applyIPv4ForwardingRule creates newFwdRule if existingFwdRule is nil, or patches or re-creates existingFwdRule if it
+// differs from newFwdRule, and returns the resulting forwarding rule.
+func (l4 *L4) applyIPv4ForwardingRule(existingFwdRule, newFwdRule *composite.ForwardingRule, frLogger klog.Logger) (*composite.ForwardingRule, utils.ResourceSyncStatus, error) {
 	if existingFwdRule != nil {
 		equal, err := forwardingrules.EqualIPv4(existingFwdRule, newFwdRule)
 		if err != nil {
@@ -291,7 +304,7 @@ func (l4 *L4) ensureIPv4ForwardingRule(bsLink string, options gce.ILBOptions, ex
 			}
 		}
 	} else {
-		if err = l4.createFwdRule(newFwdRule, frLogger); err != nil {
+		if err := l4.createFwdRule(newFwdRule, frLogger); err != nil {
 			return nil, utils.ResourceUpdate, err
 		}
 		l4.recorder.Eventf(l4.Service, corev1.EventTypeNormal, events.SyncIngress, "ForwardingRule %s created", newFwdRule.Name)
@@ -302,7 +315,7 @@ func (l4 *L4) ensureIPv4ForwardingRule(bsLink string, options gce.ILBOptions, ex
 		return nil, utils.ResourceUpdate, err
 	}
 	if readFwdRule == nil {
-		return nil, utils.ResourceUpdate, fmt.Errorf("Forwarding Rule %s not found", frName)
+		return nil, utils.ResourceUpdate, fmt.Errorf("Forwarding Rule %s not found", newFwdRule.Name)
 	}
 	return readFwdRule, utils.ResourceUpdate, nil
 }
@@ -353,7 +366,7 @@ func (l4netlb *L4NetLB) ensureIPv4ForwardingRule(bsLink string) (*composite.Forw
 
 	// Determine IP which will be used for this LB. If no forwarding rule has been established
 	// or specified in the Service spec, then requestedIP = "".
-	ipToUse, err := ipv4AddrToUse(l4netlb.cloud, l4netlb.recorder, l4netlb.Service, existingFwdRule, "")
+	ipToUse, err := ipv4AddrToUse(l4netlb.cloud, l4netlb.recorder, l4netlb.Service, l4netlb.staticAddress, existingFwdRule, "")
 	if err != nil {
 		frLogger.Error(err, "ipv4AddrToUse for service returned error")
 		return nil, address.IPAddrUndefined, utils.ResourceResync, err
@@ -500,11 +513,15 @@ func (l4netlb *L4NetLB) tearDownResourcesWithWrongNetworkTier(existingFwdRule *c
 // ipv4AddrToUse determines which IPv4 address needs to be used in the ForwardingRule,
 // address evaluated in the following order:
 //
-//  1. Use static addresses annotation "networking.gke.io/load-balancer-ip-addresses".
-//  2. Use .Spec.LoadBalancerIP (old field, was deprecated).
-//  3. Use existing forwarding rule IP. If subnetwork was changed (or no existing IP),
+//  1. Use the address of the StaticAddress referenced by the "networking.gke.io/static-address" annotation.
+//  2. Use static addresses annotation "networking.gke.io/load-balancer-ip-addresses".
+//  3. Use .Spec.LoadBalancerIP (old field, was deprecated), if it is an IPv4 address.
+//  4. Use existing forwarding rule IP. If subnetwork was changed (or no existing IP),
 //     reset the IP (by returning empty string).
-func ipv4AddrToUse(cloud *gce.Cloud, recorder record.EventRecorder, svc *v1.Service, fwdRule *composite.ForwardingRule, requestedSubnet string) (string, error) {
+func ipv4AddrToUse(cloud *gce.Cloud, recorder record.EventRecorder, svc *v1.Service, staticAddress string, fwdRule *composite.ForwardingRule, requestedSubnet string) (string, error) {
+	if staticAddress != "" {
+		return staticAddress, nil
+	}
 	// Get value from new annotation which support both IPv4 and IPv6
 	ipv4FromAnnotation, err := annotations.FromService(svc).IPv4AddressAnnotation(cloud)
 	if err != nil {
@@ -517,7 +534,7 @@ func ipv4AddrToUse(cloud *gce.Cloud, recorder record.EventRecorder, svc *v1.Serv
 		return ipv4FromAnnotation, nil
 		// if no value from annotation (for example, annotation has only IPv6 addresses) -- continue
 	}
-	if svc.Spec.LoadBalancerIP != "" {
+	if svc.Spec.LoadBalancerIP != "" && !utilnet.IsIPv6String(svc.Spec.LoadBalancerIP) {
 		return svc.Spec.LoadBalancerIP, nil
 	}
 	if fwdRule == nil {
@@ -35,6 +35,7 @@ import (
 	"k8s.io/ingress-gce/pkg/forwardingrules"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 )
 
 const (
@@ -300,11 +301,12 @@ func (l4netlb *L4NetLB) deleteChangedIPv6ForwardingRule(existingFwdRule *composi
 	return nil
 }
 
-// ipv6AddrToUse determines which IPv4 address needs to be used in the ForwardingRule,
+// ipv6AddrToUse determines which IPv6 address needs to be used in the ForwardingRule,
 // address evaluated in the following order:
 //
 //  1. Use static addresses annotation "networking.gke.io/load-balancer-ip-addresses".
-//  2. Use existing forwarding rule IP. If subnetwork was changed (or no existing IP),
+//  2. Use .Spec.LoadBalancerIP (old field, was deprecated), if it is an IPv6 address.
+//  3. Use existing forwarding rule IP. If subnetwork was changed (or no existing IP),
 //     reset the IP (by returning empty string).
 func ipv6AddressToUse(cloud *gce.Cloud, svc *corev1.Service, ipv6FwdRule *composite.ForwardingRule, requestedSubnet string, logger klog.Logger) (string, error) {
 	// Get value from new annotation which support both IPv4 and IPv6
@@ -319,6 +321,10 @@ func ipv6AddressToUse(cloud *gce.Cloud, svc *corev1.Service, ipv6FwdRule *compos
 		logger.V(2).Info("ipv6AddressToUse: using IPv6 Address from annotation", "address", addr)
 		return addr, nil
 	}
+	if utilnet.IsIPv6String(svc.Spec.LoadBalancerIP) {
+		logger.V(2).Info("ipv6AddressToUse: using IPv6 Address from .Spec.LoadBalancerIP", "address", svc.Spec.LoadBalancerIP)
+		return svc.Spec.LoadBalancerIP, nil
+	}
 	if ipv6FwdRule == nil {
 		logger.V(2).Info("ipv6AddressToUse: use any IPv6 Address")
 		return "", nil
@@ -215,3 +215,52 @@ func ipV6ForwardingRuleDescription(t *testing.T, namespace, name string) string
 	}
 	return description
 }
+
+func TestIPv6AddressToUse(t *testing.T) {
+	t.Parallel()
+
+	subnetworkURL := "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-central1/subnetworks/default"
+	existingFwdRule := &composite.ForwardingRule{IPAddress: "2001:db8::2/96", Subnetwork: subnetworkURL}
+	testCases := []struct {
+		desc           string
+		loadBalancerIP string
+		fwdRule        *composite.ForwardingRule
+		wantAddress    string
+	}{
+		{
+			desc:           "ipv6 loadBalancerIP",
+			loadBalancerIP: "2001:db8::1",
+			fwdRule:        existingFwdRule,
+			wantAddress:    "2001:db8::1",
+		},
+		{
+			desc:           "ipv4 loadBalancerIP is ignored",
+			loadBalancerIP: "10.1.2.3",
+			wantAddress:    "",
+		},
+		{
+			desc:        "existing forwarding rule address",
+			fwdRule:     existingFwdRule,
+			wantAddress: "2001:db8::2",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-svc", Namespace: "test-ns"},
+				Spec:       corev1.ServiceSpec{LoadBalancerIP: tc.loadBalancerIP},
+			}
+			address, err := ipv6AddressToUse(fakeGCE, svc, tc.fwdRule, subnetworkURL, klog.TODO())
+			if err != nil {
+				t.Fatalf("ipv6AddressToUse() returned error %v", err)
+			}
+			if address != tc.wantAddress {
+				t.Errorf("ipv6AddressToUse() = %q, want %q", address, tc.wantAddress)
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider-gcp/providers/gce"
@@ -71,6 +73,11 @@ type L4 struct {
 	networkResolver                  network.Resolver
 	enableWeightedLB                 bool
 	enableMixedProtocol              bool
+	enableMultipleForwardingRules    bool
+	staticAddress                    string
+	serviceDirectoryRegistration     *annotations.ServiceDirectoryRegistration
+	mirroringCollector               bool
+	failoverPolicy                   *annotations.FailoverPolicy
 	disableNodesFirewallProvisioning bool
 	svcLogger                        klog.Logger
 }
@@ -87,6 +94,9 @@ type L4ILBSyncResult struct {
 	SyncType           string
 	StartTime          time.Time
 	ResourceUpdates    ResourceUpdates
+	// Conditions report the optional forwarding rule features requested on
+	// the Service. Nil if the sync did not get to the forwarding rule.
+	Conditions []metav1.Condition
 }
 
 func NewL4ILBSyncResult(syncType string, startTime time.Time, svc *corev1.Service, isMultinetService bool, isWeightedLBPodsPerNode bool) *L4ILBSyncResult {
@@ -111,6 +121,10 @@ type L4ILBParams struct {
 	EnableWeightedLB                 bool
 	DisableNodesFirewallProvisioning bool
 	EnableMixedProtocol              bool
+	EnableMultipleForwardingRules    bool
+	// StaticAddress is the IP address of the StaticAddress referenced by the
+	// Service, if any.
+	StaticAddress string
 }
 
 // NewL4Handler creates a new L4Handler for the given L4 service.
@@ -130,6 +144,8 @@ func NewL4Handler(params *L4ILBParams, logger klog.Logger) *L4 {
 		networkResolver:                  params.NetworkResolver,
 		enableWeightedLB:                 params.EnableWeightedLB,
 		enableMixedProtocol:              params.EnableMixedProtocol,
+		enableMultipleForwardingRules:    params.EnableMultipleForwardingRules,
+		staticAddress:                    params.StaticAddress,
 		disableNodesFirewallProvisioning: params.DisableNodesFirewallProvisioning,
 		svcLogger:                        logger,
 	}
@@ -147,6 +163,18 @@ func (l4 *L4) CreateKey(name string) (*meta.Key, error) {
 	return composite.CreateKey(l4.cloud, name, l4.scope)
 }
 
+// FailoverNodeSelector returns the selector for the nodes of the failover
+// backends, or nil if the Service has no failover policy. It is only set
+// after EnsureInternalLoadBalancer.
+func (l4 *L4) FailoverNodeSelector() labels.Selector {
+	if l4.failoverPolicy == nil {
+		return nil
+	}
+	// The selector is validated when parsing the annotation.
+	selector, _ := l4.failoverPolicy.NodeSelector()
+	return selector
+}
+
 // getILBOptions fetches the optional features requested on the given ILB service.
 func (l4 *L4) getILBOptions() gce.ILBOptions {
 	if l4.cloud.IsLegacyNetwork() {
@@ -234,9 +262,11 @@ func (l4 *L4) deleteIPv4ResourcesOnDelete(result *L4ILBSyncResult) {
 // IPv4 Specific resources:
 // - IPv4 Forwarding Rule
 // - IPv4 Address
+// - Additional IPv4 Forwarding Rules and their Addresses
 // - IPv4 Firewall
 // This function does not delete Backend Service and Health Check, because they are shared between IPv4 and IPv6.
-// IPv4 Firewall Rule for Health Check also will not be deleted here, and will be left till the Service Deletion.
+// IPv4 Firewall Rule for Health Check also will not be deleted here, it is deleted when ensuring health checks if it is not shared,
+// otherwise it will be left till the Service Deletion.
 func (l4 *L4) deleteIPv4ResourcesAnnotationBased(result *L4ILBSyncResult, shouldIgnoreAnnotations bool) {
 	hasFwdRuleAnnotation := l4.hasAnnotation(annotations.TCPForwardingRuleKey) ||
 		l4.hasAnnotation(annotations.UDPForwardingRuleKey) ||
@@ -250,6 +280,15 @@ func (l4 *L4) deleteIPv4ResourcesAnnotationBased(result *L4ILBSyncResult, should
 		}
 	}
 
+	if shouldIgnoreAnnotations || l4.hasAnnotation(annotations.AdditionalForwardingRulesKey) {
+		err := l4.deleteAdditionalForwardingRules(nil, shouldIgnoreAnnotations)
+		if err != nil {
+			l4.svcLogger.Error(err, "Failed to delete additional forwarding rules for internal loadbalancer service")
+			result.Error = err
+			result.GCEResourceInError = annotations.ForwardingRuleResource
+		}
+	}
+
 	// Deleting non-existent address do not print error audit logs, and we don't store address in annotations
 	// that's why we can delete it without checking annotation
 	err := l4.deleteIPv4Address()
@@ -325,12 +364,16 @@ func (l4 *L4) deleteFirewall(name string, fwLogger klog.Logger) error {
 // This appends the protocol to the forwarding rule name, which will help supporting multiple protocols in the same ILB
 // service.
 func (l4 *L4) GetFRName() string {
+	return l4.getFRNameWithProtocol(l4.getFRProtocol())
+}
+
+// getFRProtocol returns the protocol of the IPv4 forwarding rules of the ILB service.
+func (l4 *L4) getFRProtocol() string {
 	ports := l4.Service.Spec.Ports
-	protocol := string(utils.GetProtocol(ports))
 	if l4.enableMixedProtocol {
-		protocol = forwardingrules.GetILBProtocol(ports)
+		return forwardingrules.GetILBProtocol(ports)
 	}
-	return l4.getFRNameWithProtocol(protocol)
+	return string(utils.GetProtocol(ports))
 }
 
 func (l4 *L4) getFRNameWithProtocol(protocol string) string {
@@ -398,6 +441,21 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 	}
 	l4.svcLogger.V(2).Info("subnetworkURL for service", "subnetworkURL", subnetworkURL)
 
+	if !l4.cloud.IsLegacyNetwork() {
+		svcAnnotations := annotations.FromService(l4.Service)
+		l4.serviceDirectoryRegistration, err = svcAnnotations.ServiceDirectoryRegistration()
+		if err != nil {
+			result.Error = utils.NewUserError(err)
+			return result
+		}
+		l4.mirroringCollector = svcAnnotations.IsMirroringCollector()
+	}
+	l4.failoverPolicy, err = annotations.FromService(l4.Service).FailoverPolicy()
+	if err != nil {
+		result.Error = utils.NewUserError(err)
+		return result
+	}
+
 	bsName := l4.namer.L4Backend(l4.Service.Namespace, l4.Service.Name)
 	// TODO(cheungdavid): Create backend logger that contains backendName,
 	// backendVersion, and backendScope before passing to backendPool.Get().
@@ -410,9 +468,12 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 	// Reserve existing IP address before making any changes
 	var existingIPv4FR *composite.ForwardingRule
 	var ipv4AddressToUse string
+	var additionalFRs []*additionalForwardingRule
+	// Without dual-stack handling, the IP families of the Service are
+	// ignored, so IPv6-only Services get an IPv4 load balancer.
 	if !l4.enableDualStack || utils.NeedsIPv4(l4.Service) {
 		existingIPv4FR, err = l4.getOldIPv4ForwardingRule(existingBS)
-		ipv4AddressToUse, err = ipv4AddrToUse(l4.cloud, l4.recorder, l4.Service, existingIPv4FR, subnetworkURL)
+		ipv4AddressToUse, err = ipv4AddrToUse(l4.cloud, l4.recorder, l4.Service, l4.staticAddress, existingIPv4FR, subnetworkURL)
 		if err != nil {
 			result.Error = fmt.Errorf("EnsureInternalLoadBalancer error: ipv4AddrToUse returned error: %w", err)
 			return result
@@ -439,6 +500,21 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 				}
 			}()
 		}
+
+		additionalFRs, err = l4.getAdditionalForwardingRules(subnetworkURL)
+		if err != nil {
+			result.GCEResourceInError = annotations.ForwardingRuleResource
+			result.Error = fmt.Errorf("EnsureInternalLoadBalancer error: getAdditionalForwardingRules returned error: %w", err)
+			return result
+		}
+		if !l4.cloud.IsLegacyNetwork() {
+			releaseAdditionalAddresses, err := l4.holdAdditionalAddresses(additionalFRs, subnetworkURL)
+			if err != nil {
+				result.Error = fmt.Errorf("EnsureInternalLoadBalancer error: %w", err)
+				return result
+			}
+			defer releaseAdditionalAddresses()
+		}
 	}
 
 	// Reserve existing IPv6 address before making any changes
@@ -491,6 +567,7 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 				l4.svcLogger.Error(err, "Failed to delete forwarding rule", "forwardingRuleName", existingIPv4FR.Name)
 			}
 		}
+		l4.deleteExistingAdditionalForwardingRules(additionalFRs)
 
 		if l4.enableDualStack && existingIPv6FR != nil {
 			// Delete ipv6 forwarding rule if it exists
@@ -515,6 +592,13 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 		ConnectionTrackingPolicy: noConnectionTrackingPolicy,
 		LocalityLbPolicy:         localityLbPolicy,
 	}
+	if l4.failoverPolicy != nil {
+		backendParams.FailoverPolicy = &composite.BackendServiceFailoverPolicy{
+			DisableConnectionDrainOnFailover: l4.failoverPolicy.DisableConnectionDrainOnFailover,
+			DropTrafficIfUnhealthy:           l4.failoverPolicy.DropTrafficIfUnhealthy,
+			FailoverRatio:                    l4.failoverPolicy.FailoverRatio,
+		}
+	}
 	bs, bsSyncStatus, err := l4.backendPool.EnsureL4BackendService(backendParams, l4.svcLogger)
 	result.ResourceUpdates.SetBackendService(bsSyncStatus)
 	if err != nil {
@@ -531,9 +615,9 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 	result.Annotations[annotations.BackendServiceKey] = bsName
 
 	if l4.enableDualStack {
-		l4.ensureDualStackResources(result, nodeNames, options, bs, existingIPv4FR, existingIPv6FR, subnetworkURL, ipv4AddressToUse, ipv6AddrToUse)
+		l4.ensureDualStackResources(result, nodeNames, options, bs, existingIPv4FR, existingIPv6FR, additionalFRs, subnetworkURL, ipv4AddressToUse, ipv6AddrToUse)
 	} else {
-		l4.ensureIPv4Resources(result, nodeNames, options, bs, existingIPv4FR, subnetworkURL, ipv4AddressToUse)
+		l4.ensureIPv4Resources(result, nodeNames, options, bs, existingIPv4FR, additionalFRs, subnetworkURL, ipv4AddressToUse)
 	}
 	if result.Error != nil {
 		return result
@@ -595,9 +679,9 @@ func (l4 *L4) provideIPv4HealthChecks(nodeNames []string, result *L4ILBSyncResul
 	return hcResult.HCLink
 }
 
-func (l4 *L4) ensureDualStackResources(result *L4ILBSyncResult, nodeNames []string, options gce.ILBOptions, bs *composite.BackendService, existingIPv4FwdRule, existingIPv6FwdRule *composite.ForwardingRule, subnetworkURL, ipv4AddressToUse, ipv6AddressToUse string) {
+func (l4 *L4) ensureDualStackResources(result *L4ILBSyncResult, nodeNames []string, options gce.ILBOptions, bs *composite.BackendService, existingIPv4FwdRule, existingIPv6FwdRule *composite.ForwardingRule, additionalFRs []*additionalForwardingRule, subnetworkURL, ipv4AddressToUse, ipv6AddressToUse string) {
 	if utils.NeedsIPv4(l4.Service) {
-		l4.ensureIPv4Resources(result, nodeNames, options, bs, existingIPv4FwdRule, subnetworkURL, ipv4AddressToUse)
+		l4.ensureIPv4Resources(result, nodeNames, options, bs, existingIPv4FwdRule, additionalFRs, subnetworkURL, ipv4AddressToUse)
 	} else {
 		l4.deleteIPv4ResourcesOnSync(result)
 	}
@@ -610,10 +694,12 @@ func (l4 *L4) ensureDualStackResources(result *L4ILBSyncResult, nodeNames []stri
 
 // ensureIPv4Resources creates resources specific to IPv4 L4 Load Balancers:
 // - IPv4 Forwarding Rule
+// - Additional IPv4 Forwarding Rules
 // - IPv4 Firewall
-func (l4 *L4) ensureIPv4Resources(result *L4ILBSyncResult, nodeNames []string, options gce.ILBOptions, bs *composite.BackendService, existingFR *composite.ForwardingRule, subnetworkURL, ipToUse string) {
+func (l4 *L4) ensureIPv4Resources(result *L4ILBSyncResult, nodeNames []string, options gce.ILBOptions, bs *composite.BackendService, existingFR *composite.ForwardingRule, additionalFRs []*additionalForwardingRule, subnetworkURL, ipToUse string) {
 	fr, fwdRuleSyncStatus, err := l4.ensureIPv4ForwardingRule(bs.SelfLink, options, existingFR, subnetworkURL, ipToUse)
 	result.ResourceUpdates.SetForwardingRule(fwdRuleSyncStatus)
+	result.Conditions = l4.forwardingRuleConditions(options, fr, err)
 	if err != nil {
 		l4.svcLogger.Error(err, "ensureIPv4Resources: Failed to ensure forwarding rule for L4 ILB Service")
 		result.GCEResourceInError = annotations.ForwardingRuleResource
@@ -630,16 +716,23 @@ func (l4 *L4) ensureIPv4Resources(result *L4ILBSyncResult, nodeNames []string, o
 		result.Annotations[annotations.L3ForwardingRuleKey] = fr.Name
 	}
 
-	l4.ensureIPv4NodesFirewall(nodeNames, fr.IPAddress, result)
+	additionalIPs := l4.ensureAdditionalForwardingRules(result, bs.SelfLink, options, additionalFRs, subnetworkURL)
+	if result.Error != nil {
+		return
+	}
+
+	l4.ensureIPv4NodesFirewall(nodeNames, fr.IPAddress, result, additionalIPs...)
 	if result.Error != nil {
 		l4.svcLogger.Error(err, "ensureIPv4Resources: Failed to ensure nodes firewall for L4 ILB Service")
 		return
 	}
 
-	result.Status = utils.AddIPToLBStatus(result.Status, fr.IPAddress)
+	result.Status = utils.AddIPToLBStatus(result.Status, append([]string{fr.IPAddress}, additionalIPs...)...)
 }
 
-func (l4 *L4) ensureIPv4NodesFirewall(nodeNames []string, ipAddress string, result *L4ILBSyncResult) {
+// ensureIPv4NodesFirewall ensures the firewall rule allowing the traffic to the nodes for ipAddress and the
+// additionalIPAddresses of the additional forwarding rules.
+func (l4 *L4) ensureIPv4NodesFirewall(nodeNames []string, ipAddress string, result *L4ILBSyncResult, additionalIPAddresses ...string) {
 	// DisableL4LBFirewall flag disables L4 FW enforcment to remove conflicts with firewall policies
 	if l4.disableNodesFirewallProvisioning {
 		l4.svcLogger.Info("Skipped ensuring IPv4 nodes firewall for L4 ILB Service to enable compatibility with firewall policies. " +
@@ -679,7 +772,7 @@ func (l4 *L4) ensureIPv4NodesFirewall(nodeNames []string, ipAddress string, resu
 	nodesFWRParams := firewalls.FirewallParams{
 		Allowed:           allowed,
 		SourceRanges:      ipv4SourceRanges,
-		DestinationRanges: []string{ipAddress},
+		DestinationRanges: append([]string{ipAddress}, additionalIPAddresses...),
 		Name:              firewallName,
 		NodeNames:         nodeNames,
 		L4Type:            utils.ILB,
@@ -41,6 +41,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	servicehelper "k8s.io/cloud-provider/service/helpers"
@@ -1148,6 +1149,138 @@ func TestEnsureInternalLoadBalancerEnableGlobalAccess(t *testing.T) {
 	assertILBResourcesDeleted(t, l4)
 }
 
+func TestEnsureInternalLoadBalancerForwardingRuleFeatures(t *testing.T) {
+	t.Parallel()
+
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Annotations[annotations.ServiceDirectoryRegistrationKey] = `{"namespace": "sd-ns", "service": "sd-svc"}`
+	svc.Annotations[annotations.MirroringCollectorKey] = "true"
+	namer := namer_util.NewL4Namer(kubeSystemUID, nil)
+	l4ilbParams := &L4ILBParams{
+		Service:         svc,
+		Cloud:           fakeGCE,
+		Namer:           namer,
+		Recorder:        record.NewFakeRecorder(100),
+		NetworkResolver: network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+	}
+	l4 := NewL4Handler(l4ilbParams, klog.TODO())
+	l4.healthChecks = healthchecksl4.Fake(fakeGCE, l4ilbParams.Recorder)
+
+	if _, err := test.CreateAndInsertNodes(l4.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Errorf("Unexpected error when adding nodes %v", err)
+	}
+	key, err := composite.CreateKey(l4.cloud, l4.GetFRName(), meta.Regional)
+	if err != nil {
+		t.Errorf("Unexpected error when creating key - %v", err)
+	}
+
+	for _, sdService := range []string{"sd-svc", "sd-svc-2"} {
+		svc.Annotations[annotations.ServiceDirectoryRegistrationKey] = fmt.Sprintf(`{"namespace": "sd-ns", "service": %q}`, sdService)
+		result := l4.EnsureInternalLoadBalancer(nodeNames, svc)
+		if result.Error != nil {
+			t.Fatalf("Failed to ensure loadBalancer, err %v", result.Error)
+		}
+		fwdRule, err := composite.GetForwardingRule(l4.cloud, key, meta.VersionGA, klog.TODO())
+		if err != nil {
+			t.Fatalf("Unexpected error when looking up forwarding rule - %v", err)
+		}
+		if !fwdRule.IsMirroringCollector {
+			t.Errorf("Unexpected false value for IsMirroringCollector")
+		}
+		wantRegistrations := []*composite.ForwardingRuleServiceDirectoryRegistration{{Namespace: "sd-ns", Service: sdService}}
+		if diff := cmp.Diff(wantRegistrations, fwdRule.ServiceDirectoryRegistrations); diff != "" {
+			t.Errorf("Got unexpected ServiceDirectoryRegistrations, diff -want +got\n%v", diff)
+		}
+		var gotConditionTypes []string
+		for _, condition := range result.Conditions {
+			gotConditionTypes = append(gotConditionTypes, condition.Type)
+			if condition.Status != metav1.ConditionTrue || condition.Reason != ConditionReasonApplied {
+				t.Errorf("Got condition %+v, want status %s and reason %s", condition, metav1.ConditionTrue, ConditionReasonApplied)
+			}
+		}
+		wantConditionTypes := []string{ServiceDirectoryRegistrationConditionType, MirroringCollectorConditionType}
+		if diff := cmp.Diff(wantConditionTypes, gotConditionTypes); diff != "" {
+			t.Errorf("Got unexpected condition types, diff -want +got\n%v", diff)
+		}
+	}
+
+	svc.Annotations[annotations.ServiceDirectoryRegistrationKey] = "invalid"
+	result := l4.EnsureInternalLoadBalancer(nodeNames, svc)
+	if !utils.IsUserError(result.Error) {
+		t.Errorf("EnsureInternalLoadBalancer() returned error %v, want a user error", result.Error)
+	}
+}
+
+func TestForwardingRuleConditions(t *testing.T) {
+	t.Parallel()
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Generation = 3
+	l4 := &L4{
+		Service:                      svc,
+		serviceDirectoryRegistration: &annotations.ServiceDirectoryRegistration{Namespace: "sd-ns", Service: "sd-svc"},
+	}
+	options := gce.ILBOptions{AllowGlobalAccess: true}
+	fr := &composite.ForwardingRule{Name: "fr", AllowGlobalAccess: true}
+	syncErr := fmt.Errorf("quota exceeded")
+
+	for _, tc := range []struct {
+		desc       string
+		fr         *composite.ForwardingRule
+		err        error
+		wantReason map[string]string
+	}{
+		{
+			desc: "sync failed",
+			err:  syncErr,
+			wantReason: map[string]string{
+				GlobalAccessConditionType:                 ConditionReasonSyncFailed,
+				ServiceDirectoryRegistrationConditionType: ConditionReasonSyncFailed,
+			},
+		},
+		{
+			desc: "partially applied",
+			fr:   fr,
+			wantReason: map[string]string{
+				GlobalAccessConditionType:                 ConditionReasonApplied,
+				ServiceDirectoryRegistrationConditionType: ConditionReasonNotApplied,
+			},
+		},
+		{
+			desc: "no forwarding rule",
+			wantReason: map[string]string{
+				GlobalAccessConditionType:                 ConditionReasonNotApplied,
+				ServiceDirectoryRegistrationConditionType: ConditionReasonNotApplied,
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			conditions := l4.forwardingRuleConditions(options, tc.fr, tc.err)
+			gotReason := map[string]string{}
+			for _, condition := range conditions {
+				gotReason[condition.Type] = condition.Reason
+				wantStatus := metav1.ConditionFalse
+				if condition.Reason == ConditionReasonApplied {
+					wantStatus = metav1.ConditionTrue
+				}
+				if condition.Status != wantStatus {
+					t.Errorf("Got status %s for condition %s, want %s", condition.Status, condition.Type, wantStatus)
+				}
+				if condition.ObservedGeneration != svc.Generation {
+					t.Errorf("Got ObservedGeneration %d for condition %s, want %d", condition.ObservedGeneration, condition.Type, svc.Generation)
+				}
+			}
+			if diff := cmp.Diff(tc.wantReason, gotReason); diff != "" {
+				t.Errorf("Got unexpected condition reasons, diff -want +got\n%v", diff)
+			}
+		})
+	}
+}
+
 func TestEnsureInternalLoadBalancerCustomSubnet(t *testing.T) {
 	t.Parallel()
 	nodeNames := []string{"test-node-1"}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+const (
+	// GlobalAccessConditionType reports whether global access was applied to
+	// the forwarding rule of an L4 ILB Service.
+	GlobalAccessConditionType = "networking.gke.io/GlobalAccess"
+	// ServiceDirectoryRegistrationConditionType reports whether the forwarding
+	// rule of an L4 ILB Service was registered in Service Directory.
+	ServiceDirectoryRegistrationConditionType = "networking.gke.io/ServiceDirectoryRegistration"
+	// MirroringCollectorConditionType reports whether the forwarding rule of
+	// an L4 ILB Service was made a Packet Mirroring collector.
+	MirroringCollectorConditionType = "networking.gke.io/MirroringCollector"
+
+	// ConditionReasonApplied is used when the forwarding rule reflects the
+	// requested feature.
+	ConditionReasonApplied = "Applied"
+	// ConditionReasonNotApplied is used when the forwarding rule does not
+	// reflect the requested feature, e.g. because the Service has no IPv4
+	// forwarding rule.
+	ConditionReasonNotApplied = "NotApplied"
+	// ConditionReasonSyncFailed is used when syncing the forwarding rule
+	// failed.
+	ConditionReasonSyncFailed = "SyncFailed"
+)
+
+// ForwardingRuleConditionTypes are the Service condition types managed by the
+// L4 ILB controller. Conditions of these types that are not part of a sync
+// result are removed from the Service.
+var ForwardingRuleConditionTypes = []string{
+	GlobalAccessConditionType,
+	ServiceDirectoryRegistrationConditionType,
+	MirroringCollectorConditionType,
+}
+
+// forwardingRuleFeature is an optional feature of an L4 ILB forwarding rule.
+type forwardingRuleFeature struct {
+	conditionType string
+	requested     bool
+	applied       func(fr *composite.ForwardingRule) bool
+}
+
+// forwardingRuleConditions returns a condition for every optional feature
+// requested on the Service, based on the synced forwarding rule fr and the
+// error syncErr returned when syncing it. fr can be nil if the sync failed or
+// the Service has no such forwarding rule. The result is never nil, so that
+// conditions of features that are no longer requested get removed.
+func (l4 *L4) forwardingRuleConditions(options gce.ILBOptions, fr *composite.ForwardingRule, syncErr error) []metav1.Condition {
+	features := []forwardingRuleFeature{
+		{
+			conditionType: GlobalAccessConditionType,
+			requested:     options.AllowGlobalAccess,
+			applied: func(fr *composite.ForwardingRule) bool {
+				return fr.AllowGlobalAccess
+			},
+		},
+		{
+			conditionType: ServiceDirectoryRegistrationConditionType,
+			requested:     l4.serviceDirectoryRegistration != nil,
+			applied: func(fr *composite.ForwardingRule) bool {
+				for _, r := range fr.ServiceDirectoryRegistrations {
+					if r.Namespace == l4.serviceDirectoryRegistration.Namespace && r.Service == l4.serviceDirectoryRegistration.Service {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		{
+			conditionType: MirroringCollectorConditionType,
+			requested:     l4.mirroringCollector,
+			applied: func(fr *composite.ForwardingRule) bool {
+				return fr.IsMirroringCollector
+			},
+		},
+	}
+
+	conditions := []metav1.Condition{}
+	for _, f := range features {
+		if !f.requested {
+			continue
+		}
+		condition := metav1.Condition{
+			Type:               f.conditionType,
+			ObservedGeneration: l4.Service.Generation,
+		}
+		switch {
+		case syncErr != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = ConditionReasonSyncFailed
+			condition.Message = syncErr.Error()
+		case fr != nil && f.applied(fr):
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = ConditionReasonApplied
+			condition.Message = fmt.Sprintf("Applied to forwarding rule %s", fr.Name)
+		case fr != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = ConditionReasonNotApplied
+			condition.Message = fmt.Sprintf("Not applied to forwarding rule %s", fr.Name)
+		default:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = ConditionReasonNotApplied
+			condition.Message = "Service has no forwarding rule"
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/address"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/forwardingrules"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// additionalForwardingRule is an additional IPv4 forwarding rule of an L4 ILB
+// Service, requested with the annotations.ILBForwardingRulesKey annotation.
+// All additional forwarding rules point at the backend service of the
+// Service, each with its own VIP.
+type additionalForwardingRule struct {
+	annotations.ILBForwardingRule
+	// frName is the name of the GCE forwarding rule.
+	frName string
+	// existing is the existing GCE forwarding rule of the rule, which may
+	// have a different name if the protocol of the Service changed.
+	existing *composite.ForwardingRule
+	// ipToUse is the IP address of the forwarding rule, or empty to use an
+	// ephemeral address.
+	ipToUse string
+}
+
+// getAdditionalFRName returns the name of the additional forwarding rule with
+// the given rule name.
+func (l4 *L4) getAdditionalFRName(ruleName string) string {
+	return l4.namer.L4AdditionalForwardingRule(l4.Service.Namespace, l4.Service.Name, l4.getFRProtocol(), ruleName)
+}
+
+// servicePortNumbers returns the port numbers of the Service.
+func (l4 *L4) servicePortNumbers() []int32 {
+	var ports []int32
+	for _, port := range l4.Service.Spec.Ports {
+		ports = append(ports, port.Port)
+	}
+	return ports
+}
+
+// getAdditionalForwardingRules returns the additional forwarding rules
+// requested for the Service, along with their existing forwarding rule and the
+// IP address to use. It returns no rules if multiple forwarding rules are not
+// enabled.
+func (l4 *L4) getAdditionalForwardingRules(subnetworkURL string) ([]*additionalForwardingRule, error) {
+	if !l4.enableMultipleForwardingRules {
+		return nil, nil
+	}
+	rules, _, err := annotations.FromService(l4.Service).ILBForwardingRules(l4.servicePortNumbers())
+	if err != nil {
+		return nil, utils.NewUserError(err)
+	}
+	recordedFRNames, err := annotations.FromService(l4.Service).AdditionalForwardingRules()
+	if err != nil {
+		l4.svcLogger.Error(err, "Failed to parse recorded additional forwarding rules, ignoring")
+	}
+
+	var result []*additionalForwardingRule
+	for _, rule := range rules {
+		fr := &additionalForwardingRule{
+			ILBForwardingRule: rule,
+			frName:            l4.getAdditionalFRName(rule.Name),
+		}
+		fr.existing, err = l4.forwardingRules.Get(fr.frName)
+		if err != nil {
+			return nil, err
+		}
+		if recordedName, ok := recordedFRNames[rule.Name]; fr.existing == nil && ok && recordedName != fr.frName {
+			// The forwarding rule name depends on the protocol, get the
+			// forwarding rule of the previous protocol.
+			fr.existing, err = l4.forwardingRules.Get(recordedName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case rule.Address != "":
+			addr, err := l4.cloud.GetRegionAddress(rule.Address, l4.cloud.Region())
+			if err != nil {
+				if utils.IsNotFoundError(err) {
+					return nil, utils.NewUserError(fmt.Errorf("address %q of forwarding rule %q not found", rule.Address, rule.Name))
+				}
+				return nil, err
+			}
+			fr.ipToUse = addr.Address
+		case fr.existing != nil && fr.existing.Subnetwork == subnetworkURL:
+			fr.ipToUse = fr.existing.IPAddress
+		}
+		result = append(result, fr)
+	}
+	return result, nil
+}
+
+// holdAdditionalAddresses reserves the IP addresses of the additional
+// forwarding rules before making any changes, and returns a function which
+// releases the reservations.
+func (l4 *L4) holdAdditionalAddresses(additionalFRs []*additionalForwardingRule, subnetworkURL string) (func(), error) {
+	var addrMgrs []*address.Manager
+	release := func() {
+		for _, addrMgr := range addrMgrs {
+			if err := addrMgr.ReleaseAddress(); err != nil {
+				l4.svcLogger.Error(err, "EnsureInternalLoadBalancer: failed to release IPv4 address reservation of additional forwarding rule, possibly causing an orphan")
+			}
+		}
+	}
+	nm := l4.NamespacedName.String()
+	for _, fr := range additionalFRs {
+		// ILB can be created only in Premium Tier
+		addrMgr := address.NewManager(l4.cloud, nm, l4.cloud.Region(), subnetworkURL, fr.frName, fr.ipToUse, cloud.SchemeInternal, cloud.NetworkTierPremium, address.IPv4Version, l4.svcLogger)
+		ip, _, err := addrMgr.HoldAddress()
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("addrMgr.HoldAddress() for additional forwarding rule %s returned error %w", fr.frName, err)
+		}
+		addrMgrs = append(addrMgrs, addrMgr)
+		fr.ipToUse = ip
+	}
+	return release, nil
+}
+
+// ensureAdditionalForwardingRules creates or updates the additional
+// forwarding rules, records them in the result annotations, and deletes the
+// additional forwarding rules which are no longer requested. It returns the
+// IP addresses of the additional forwarding rules.
+func (l4 *L4) ensureAdditionalForwardingRules(result *L4ILBSyncResult, bsLink string, options gce.ILBOptions, additionalFRs []*additionalForwardingRule, subnetworkURL string) []string {
+	var ips []string
+	frNames := make(map[string]string)
+	for _, fr := range additionalFRs {
+		readFwdRule, fwdRuleSyncStatus, err := l4.ensureAdditionalForwardingRule(bsLink, options, fr, subnetworkURL)
+		result.ResourceUpdates.SetForwardingRule(fwdRuleSyncStatus)
+		if err != nil {
+			l4.svcLogger.Error(err, "Failed to ensure additional forwarding rule for L4 ILB Service", "forwardingRuleName", fr.frName)
+			result.GCEResourceInError = annotations.ForwardingRuleResource
+			result.Error = err
+			return nil
+		}
+		frNames[fr.Name] = readFwdRule.Name
+		ips = append(ips, readFwdRule.IPAddress)
+	}
+
+	if err := l4.deleteAdditionalForwardingRules(frNames, false); err != nil {
+		l4.svcLogger.Error(err, "Failed to delete additional forwarding rules which are no longer requested for L4 ILB Service")
+		result.GCEResourceInError = annotations.ForwardingRuleResource
+		result.Error = err
+		return nil
+	}
+	if len(frNames) > 0 {
+		frNamesJSON, err := json.Marshal(frNames)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to marshal additional forwarding rule names: %w", err)
+			return nil
+		}
+		result.Annotations[annotations.AdditionalForwardingRulesKey] = string(frNamesJSON)
+	}
+	return ips
+}
+
+// ensureAdditionalForwardingRule creates or updates an additional forwarding
+// rule. It forwards the ports of the rule, or the same ports as the main
+// forwarding rule if it does not specify any.
+func (l4 *L4) ensureAdditionalForwardingRule(bsLink string, options gce.ILBOptions, fr *additionalForwardingRule, subnetworkURL string) (*composite.ForwardingRule, utils.ResourceSyncStatus, error) {
+	start := time.Now()
+	frLogger := l4.svcLogger.WithValues("forwardingRuleName", fr.frName, "rule", fr.Name)
+	frLogger.V(2).Info("Ensuring additional internal forwarding rule for L4 ILB Service", "backendServiceLink", bsLink)
+	defer func() {
+		frLogger.V(2).Info("Finished ensuring additional internal forwarding rule for L4 ILB Service", "timeTaken", time.Since(start))
+	}()
+
+	version := meta.VersionGA
+	protocol := l4.getFRProtocol()
+	ports := utils.GetPorts(l4.Service.Spec.Ports)
+	if len(fr.Ports) > 0 {
+		ports = nil
+		for _, port := range fr.Ports {
+			ports = append(ports, strconv.Itoa(int(port)))
+		}
+	}
+	allPorts := fr.AllPorts || protocol == forwardingrules.ProtocolL3 || len(ports) > maxForwardedPorts
+	if allPorts {
+		ports = nil
+	}
+
+	frDesc, err := utils.MakeL4LBServiceDescription(utils.ServiceKeyFunc(l4.Service.Namespace, l4.Service.Name), fr.ipToUse,
+		version, false, utils.ILB)
+	if err != nil {
+		return nil, utils.ResourceResync, fmt.Errorf("Failed to compute description for forwarding rule %s, err: %w", fr.frName,
+			err)
+	}
+	newFwdRule := &composite.ForwardingRule{
+		Name:                fr.frName,
+		IPAddress:           fr.ipToUse,
+		Ports:               ports,
+		AllPorts:            allPorts,
+		IPProtocol:          protocol,
+		LoadBalancingScheme: string(cloud.SchemeInternal),
+		Subnetwork:          subnetworkURL,
+		Network:             l4.network.NetworkURL,
+		NetworkTier:         cloud.NetworkTierDefault.ToGCEValue(),
+		Version:             version,
+		BackendService:      bsLink,
+		AllowGlobalAccess:   options.AllowGlobalAccess,
+		Description:         frDesc,
+	}
+	return l4.applyIPv4ForwardingRule(fr.existing, newFwdRule, frLogger)
+}
+
+// deleteExistingAdditionalForwardingRules deletes the existing additional
+// forwarding rules. This is needed before changing the protocol of the
+// backend service.
+func (l4 *L4) deleteExistingAdditionalForwardingRules(additionalFRs []*additionalForwardingRule) {
+	for _, fr := range additionalFRs {
+		if fr.existing == nil {
+			continue
+		}
+		if err := l4.forwardingRules.Delete(fr.existing.Name); err != nil {
+			l4.svcLogger.Error(err, "Failed to delete additional forwarding rule", "forwardingRuleName", fr.existing.Name)
+		}
+	}
+}
+
+// deleteAdditionalForwardingRules deletes the additional forwarding rules
+// recorded in the Service annotations, and their addresses, except the ones
+// in keep. If includeRequested is true, the additional forwarding rules
+// requested in the Service annotations are also deleted, as the recorded
+// names may have been removed.
+func (l4 *L4) deleteAdditionalForwardingRules(keep map[string]string, includeRequested bool) error {
+	toDelete := make(map[string]bool)
+	recordedFRNames, err := annotations.FromService(l4.Service).AdditionalForwardingRules()
+	if err != nil {
+		l4.svcLogger.Error(err, "Failed to parse recorded additional forwarding rules, ignoring")
+	}
+	for _, frName := range recordedFRNames {
+		toDelete[frName] = true
+	}
+	if includeRequested {
+		rules, _, err := annotations.FromService(l4.Service).ILBForwardingRules(l4.servicePortNumbers())
+		if err != nil {
+			l4.svcLogger.Error(err, "Failed to parse requested additional forwarding rules, ignoring")
+		}
+		for _, rule := range rules {
+			toDelete[l4.getAdditionalFRName(rule.Name)] = true
+		}
+	}
+	for _, frName := range keep {
+		delete(toDelete, frName)
+	}
+
+	var frNames []string
+	for frName := range toDelete {
+		frNames = append(frNames, frName)
+	}
+	sort.Strings(frNames)
+	for _, frName := range frNames {
+		l4.svcLogger.Info("Deleting additional IPv4 forwarding rule for L4 ILB Service", "forwardingRuleName", frName)
+		if err := l4.forwardingRules.Delete(frName); err != nil {
+			return err
+		}
+		if err := address.EnsureDeleted(l4.cloud, frName, l4.cloud.Region()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
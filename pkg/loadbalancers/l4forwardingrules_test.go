@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/healthchecksl4"
+	"k8s.io/ingress-gce/pkg/network"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureInternalLoadBalancerAdditionalForwardingRules(t *testing.T) {
+	// The nodes firewall has destination ranges only with pinhole enabled.
+	oldEnablePinhole := flags.F.EnablePinhole
+	flags.F.EnablePinhole = true
+	defer func() { flags.F.EnablePinhole = oldEnablePinhole }()
+
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Spec.Ports = append(svc.Spec.Ports, v1.ServicePort{Name: "testport2", Port: 8443, Protocol: "TCP"})
+	svc.Annotations[annotations.ILBForwardingRulesKey] = `[{"name":"all","allPorts":true,"address":"all-vip"},{"name":"https","ports":[8443],"address":"https-vip"}]`
+	for name, ip := range map[string]string{"all-vip": "10.1.2.3", "https-vip": "10.1.2.4"} {
+		addr := &compute.Address{Name: name, Address: ip, AddressType: string(cloud.SchemeInternal)}
+		if err := fakeGCE.ReserveRegionAddress(addr, fakeGCE.Region()); err != nil {
+			t.Fatalf("Failed to reserve address %s, err %v", name, err)
+		}
+	}
+	namer := namer_util.NewL4Namer(kubeSystemUID, nil)
+	l4ilbParams := &L4ILBParams{
+		Service:                       svc,
+		Cloud:                         fakeGCE,
+		Namer:                         namer,
+		Recorder:                      record.NewFakeRecorder(100),
+		NetworkResolver:               network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+		EnableMultipleForwardingRules: true,
+	}
+	l4 := NewL4Handler(l4ilbParams, klog.TODO())
+	l4.healthChecks = healthchecksl4.Fake(fakeGCE, l4ilbParams.Recorder)
+	if _, err := test.CreateAndInsertNodes(l4.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Fatalf("Unexpected error when adding nodes %v", err)
+	}
+
+	result := l4.EnsureInternalLoadBalancer(nodeNames, svc)
+	if result.Error != nil {
+		t.Fatalf("Failed to ensure loadBalancer, err %v", result.Error)
+	}
+	if len(result.Status.Ingress) != 3 {
+		t.Fatalf("Got loadBalancer status %+v, want 3 ingress IPs", result.Status)
+	}
+	ips := make(map[string]bool)
+	for _, ingress := range result.Status.Ingress {
+		ips[ingress.IP] = true
+	}
+	if len(ips) != 3 {
+		t.Errorf("Got loadBalancer status %+v, want 3 distinct ingress IPs", result.Status)
+	}
+
+	bsName := l4.namer.L4Backend(svc.Namespace, svc.Name)
+	bs, err := composite.GetBackendService(l4.cloud, meta.RegionalKey(bsName, l4.cloud.Region()), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("Failed to lookup backend service, err %v", err)
+	}
+	allFRName := l4.getAdditionalFRName("all")
+	httpsFRName := l4.getAdditionalFRName("https")
+	for _, tc := range []struct {
+		frName       string
+		wantAllPorts bool
+		wantPorts    []string
+	}{
+		{frName: l4.GetFRName(), wantPorts: []string{"8080", "8443"}},
+		{frName: allFRName, wantAllPorts: true},
+		{frName: httpsFRName, wantPorts: []string{"8443"}},
+	} {
+		fr, err := l4.forwardingRules.Get(tc.frName)
+		if err != nil || fr == nil {
+			t.Fatalf("Failed to lookup forwarding rule %s, err %v", tc.frName, err)
+		}
+		if fr.BackendService != bs.SelfLink {
+			t.Errorf("Forwarding rule %s points at backend service %s, want %s", tc.frName, fr.BackendService, bs.SelfLink)
+		}
+		if fr.AllPorts != tc.wantAllPorts || !utils.EqualStringSets(fr.Ports, tc.wantPorts) {
+			t.Errorf("Forwarding rule %s has AllPorts %v and ports %v, want %v and %v", tc.frName, fr.AllPorts, fr.Ports, tc.wantAllPorts, tc.wantPorts)
+		}
+		if !ips[fr.IPAddress] {
+			t.Errorf("Forwarding rule %s IP %s is not in loadBalancer status %+v", tc.frName, fr.IPAddress, result.Status)
+		}
+	}
+
+	wantFRNames := map[string]string{"all": allFRName, "https": httpsFRName}
+	var gotFRNames map[string]string
+	if err := json.Unmarshal([]byte(result.Annotations[annotations.AdditionalForwardingRulesKey]), &gotFRNames); err != nil {
+		t.Fatalf("Failed to parse annotation %s, err %v", annotations.AdditionalForwardingRulesKey, err)
+	}
+	if len(gotFRNames) != len(wantFRNames) || gotFRNames["all"] != allFRName || gotFRNames["https"] != httpsFRName {
+		t.Errorf("Got additional forwarding rules annotation %v, want %v", gotFRNames, wantFRNames)
+	}
+
+	firewall, err := l4.cloud.GetFirewall(l4.namer.L4Firewall(svc.Namespace, svc.Name))
+	if err != nil {
+		t.Fatalf("Failed to lookup nodes firewall, err %v", err)
+	}
+	if len(firewall.DestinationRanges) != 3 {
+		t.Errorf("Got nodes firewall destination ranges %v, want the 3 ingress IPs", firewall.DestinationRanges)
+	}
+	for _, destination := range firewall.DestinationRanges {
+		if !ips[destination] {
+			t.Errorf("Nodes firewall destination %s is not in loadBalancer status %+v", destination, result.Status)
+		}
+	}
+
+	// Removing a rule deletes its forwarding rule on the next sync.
+	svc.Annotations[annotations.AdditionalForwardingRulesKey] = result.Annotations[annotations.AdditionalForwardingRulesKey]
+	svc.Annotations[annotations.ILBForwardingRulesKey] = `[{"name":"all","allPorts":true,"address":"all-vip"}]`
+	result = l4.EnsureInternalLoadBalancer(nodeNames, svc)
+	if result.Error != nil {
+		t.Fatalf("Failed to ensure loadBalancer, err %v", result.Error)
+	}
+	if len(result.Status.Ingress) != 2 {
+		t.Errorf("Got loadBalancer status %+v, want 2 ingress IPs", result.Status)
+	}
+	if err := verifyForwardingRuleNotExists(l4.cloud, httpsFRName); err != nil {
+		t.Errorf("verifyForwardingRuleNotExists(%s) returned error %v", httpsFRName, err)
+	}
+	if fr, err := l4.forwardingRules.Get(allFRName); err != nil || fr == nil {
+		t.Errorf("Failed to lookup forwarding rule %s, err %v", allFRName, err)
+	}
+
+	// Deleting the Service deletes the additional forwarding rules, even
+	// without the annotation recording them.
+	delete(svc.Annotations, annotations.AdditionalForwardingRulesKey)
+	result = l4.EnsureInternalLoadBalancerDeleted(svc)
+	if result.Error != nil {
+		t.Fatalf("Failed to delete loadBalancer, err %v", result.Error)
+	}
+	assertILBResourcesDeleted(t, l4)
+	for _, frName := range []string{allFRName, httpsFRName} {
+		if err := verifyForwardingRuleNotExists(l4.cloud, frName); err != nil {
+			t.Errorf("verifyForwardingRuleNotExists(%s) returned error %v", frName, err)
+		}
+		if err := verifyAddressNotExists(l4.cloud, frName); err != nil {
+			t.Errorf("verifyAddressNotExists(%s) returned error %v", frName, err)
+		}
+	}
+}
+
+func TestEnsureInternalLoadBalancerAdditionalForwardingRulesInvalid(t *testing.T) {
+	t.Parallel()
+
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Annotations[annotations.ILBForwardingRulesKey] = `[{"name":"https","ports":[8443]}]`
+	l4ilbParams := &L4ILBParams{
+		Service:                       svc,
+		Cloud:                         fakeGCE,
+		Namer:                         namer_util.NewL4Namer(kubeSystemUID, nil),
+		Recorder:                      record.NewFakeRecorder(100),
+		NetworkResolver:               network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+		EnableMultipleForwardingRules: true,
+	}
+	l4 := NewL4Handler(l4ilbParams, klog.TODO())
+	l4.healthChecks = healthchecksl4.Fake(fakeGCE, l4ilbParams.Recorder)
+	if _, err := test.CreateAndInsertNodes(l4.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Fatalf("Unexpected error when adding nodes %v", err)
+	}
+
+	result := l4.EnsureInternalLoadBalancer(nodeNames, svc)
+	if !utils.IsUserError(result.Error) {
+		t.Errorf("EnsureInternalLoadBalancer() returned error %v, want a user error", result.Error)
+	}
+}
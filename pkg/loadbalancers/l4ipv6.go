@@ -24,6 +24,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/address"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/firewalls"
@@ -37,6 +38,10 @@ import (
 func (l4 *L4) ensureIPv6Resources(syncResult *L4ILBSyncResult, nodeNames []string, options gce.ILBOptions, bsLink string, existingIPv6FwdRule *composite.ForwardingRule, ipv6AddressToUse string) {
 	ipv6fr, fwdRuleSyncStatus, err := l4.ensureIPv6ForwardingRule(bsLink, options, existingIPv6FwdRule, ipv6AddressToUse)
 	syncResult.ResourceUpdates.SetForwardingRule(fwdRuleSyncStatus)
+	if !utils.NeedsIPv4(l4.Service) {
+		// IPv6-only Services report the optional features of their IPv6 forwarding rule.
+		syncResult.Conditions = l4.forwardingRuleConditions(options, ipv6fr, err)
+	}
 	if err != nil {
 		l4.svcLogger.Error(err, "ensureIPv6Resources: Failed to ensure ipv6 forwarding rule")
 		syncResult.GCEResourceInError = annotations.ForwardingRuleIPv6Resource
@@ -87,9 +92,11 @@ func (l4 *L4) deleteIPv6ResourcesOnDelete(syncResult *L4ILBSyncResult) {
 // if resource exists in Service annotation, if shouldIgnoreAnnotations not set to true
 // IPv6 Specific resources:
 // - IPv6 Forwarding Rule
+// - IPv6 Address
 // - IPv6 Firewall
 // This function does not delete Backend Service and Health Check, because they are shared between IPv4 and IPv6.
-// IPv6 Firewall Rule for Health Check also will not be deleted here, and will be left till the Service Deletion.
+// IPv6 Firewall Rule for Health Check also will not be deleted here, it is deleted when ensuring health checks if it is not shared,
+// otherwise it will be left till the Service Deletion.
 func (l4 *L4) deleteIPv6ResourcesAnnotationBased(syncResult *L4ILBSyncResult, shouldCheckAnnotations bool) {
 	if !shouldCheckAnnotations || l4.hasAnnotation(annotations.TCPForwardingRuleIPv6Key) || l4.hasAnnotation(annotations.UDPForwardingRuleIPv6Key) {
 		err := l4.deleteIPv6ForwardingRule()
@@ -98,6 +105,15 @@ func (l4 *L4) deleteIPv6ResourcesAnnotationBased(syncResult *L4ILBSyncResult, sh
 			syncResult.Error = err
 			syncResult.GCEResourceInError = annotations.ForwardingRuleIPv6Resource
 		}
+
+		// The address is reserved only while the forwarding rule is created,
+		// but it could be leaked if releasing it failed.
+		err = l4.deleteIPv6Address()
+		if err != nil {
+			l4.svcLogger.Error(err, "Failed to delete ipv6 address for internal loadbalancer service")
+			syncResult.Error = err
+			syncResult.GCEResourceInError = annotations.AddressIPv6Resource
+		}
 	}
 
 	if !shouldCheckAnnotations || l4.hasAnnotation(annotations.FirewallRuleIPv6Key) {
@@ -185,6 +201,18 @@ func (l4 *L4) deleteIPv6ForwardingRule() error {
 	return l4.forwardingRules.Delete(ipv6FrName)
 }
 
+func (l4 *L4) deleteIPv6Address() error {
+	addressName := l4.getIPv6FRName()
+
+	start := time.Now()
+	l4.svcLogger.V(2).Info("Deleting IPv6 address for L4 ILB Service", "addressName", addressName)
+	defer func() {
+		l4.svcLogger.V(2).Info("Finished deleting IPv6 address for L4 ILB Service", "addressName", addressName, "timeTaken", time.Since(start))
+	}()
+
+	return address.EnsureDeleted(l4.cloud, addressName, l4.cloud.Region())
+}
+
 func (l4 *L4) deleteIPv6NodesFirewall() error {
 	ipv6FirewallName := l4.namer.L4IPv6Firewall(l4.Service.Namespace, l4.Service.Name)
 
@@ -77,6 +77,7 @@ type L4NetLB struct {
 	disableNodesFirewallProvisioning bool
 	svcLogger                        klog.Logger
 	useNEGs                          bool
+	staticAddress                    string
 }
 
 // L4NetLBSyncResult contains information about the outcome of an L4 NetLB sync. It stores the list of resource name annotations,
@@ -130,6 +131,9 @@ type L4NetLBParams struct {
 	EnableMixedProtocol              bool
 	DisableNodesFirewallProvisioning bool
 	UseNEGs                          bool
+	// StaticAddress is the IP address of the StaticAddress referenced by the
+	// Service, if any.
+	StaticAddress string
 }
 
 // NewL4NetLB creates a new Handler for the given L4NetLB service.
@@ -152,6 +156,7 @@ func NewL4NetLB(params *L4NetLBParams, logger klog.Logger) *L4NetLB {
 		enableMixedProtocol:              params.EnableMixedProtocol,
 		disableNodesFirewallProvisioning: params.DisableNodesFirewallProvisioning,
 		useNEGs:                          params.UseNEGs,
+		staticAddress:                    params.StaticAddress,
 		svcLogger:                        logger,
 	}
 	return l4netlb
@@ -264,6 +269,8 @@ func (l4netlb *L4NetLB) EnsureFrontend(nodeNames []string, svc *corev1.Service)
 		return result
 	}
 
+	// Without dual-stack handling, the IP families of the Service are
+	// ignored, so IPv6-only Services get an IPv4 load balancer.
 	if l4netlb.enableDualStack {
 		l4netlb.ensureDualStackResources(result, nodeNames, bsLink)
 	} else {
@@ -516,9 +523,11 @@ func (l4netlb *L4NetLB) deleteIPv4ResourcesOnDelete(result *L4NetLBSyncResult) {
 // - IPv4 Address
 // - IPv4 Firewall
 // This function does not delete Backend Service and Health Check, because they are shared between IPv4 and IPv6.
-// IPv4 Firewall Rule for Health Check also will not be deleted here, and will be left till the Service Deletion.
+// IPv4 Firewall Rule for Health Check also will not be deleted here, it is deleted when ensuring health checks if it is not shared,
+// otherwise it will be left till the Service Deletion.
 func (l4netlb *L4NetLB) deleteIPv4ResourcesAnnotationBased(result *L4NetLBSyncResult, shouldIgnoreAnnotations bool) {
-	if shouldIgnoreAnnotations || l4netlb.hasAnnotation(annotations.TCPForwardingRuleKey) || l4netlb.hasAnnotation(annotations.UDPForwardingRuleKey) {
+	hasFwdRuleAnnotation := l4netlb.hasAnnotation(annotations.TCPForwardingRuleKey) || l4netlb.hasAnnotation(annotations.UDPForwardingRuleKey)
+	if shouldIgnoreAnnotations || hasFwdRuleAnnotation {
 		err := l4netlb.deleteIPv4ForwardingRule()
 		if err != nil {
 			l4netlb.svcLogger.Error(err, "Failed to delete forwarding rule for NetLB RBS service")
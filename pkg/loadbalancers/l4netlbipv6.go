@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	compute "google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/ingress-gce/pkg/address"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/firewalls"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -90,12 +91,17 @@ func (l4netlb *L4NetLB) deleteIPv6ResourcesOnDelete(syncResult *L4NetLBSyncResul
 // if resource exists in Service annotation, if shouldIgnoreAnnotations not set to true
 // IPv6 Specific resources:
 // - IPv6 Forwarding Rule
+// - IPv6 Address
 // - IPv6 Firewall
 // This function does not delete Backend Service and Health Check, because they are shared between IPv4 and IPv6.
-// IPv6 Firewall Rule for Health Check also will not be deleted here, and will be left till the Service Deletion.
+// IPv6 Firewall Rule for Health Check also will not be deleted here, it is deleted when ensuring health checks if it is not shared,
+// otherwise it will be left till the Service Deletion.
 func (l4netlb *L4NetLB) deleteIPv6ResourcesAnnotationBased(syncResult *L4NetLBSyncResult, shouldIgnoreAnnotations bool) {
 	if shouldIgnoreAnnotations || l4netlb.hasAnnotation(annotations.TCPForwardingRuleIPv6Key) || l4netlb.hasAnnotation(annotations.UDPForwardingRuleIPv6Key) {
 		l4netlb.deleteIPv6ForwardingRule(syncResult)
+		// The address is reserved only while the forwarding rule is created,
+		// but it could be leaked if releasing it failed.
+		l4netlb.deleteIPv6Address(syncResult)
 	}
 
 	if shouldIgnoreAnnotations || l4netlb.hasAnnotation(annotations.FirewallRuleIPv6Key) {
@@ -177,6 +183,23 @@ func (l4netlb *L4NetLB) deleteIPv6ForwardingRule(syncResult *L4NetLBSyncResult)
 	}
 }
 
+func (l4netlb *L4NetLB) deleteIPv6Address(syncResult *L4NetLBSyncResult) {
+	addressName := l4netlb.ipv6FRName()
+
+	start := time.Now()
+	l4netlb.svcLogger.V(2).Info("Deleting IPv6 external static address for L4 NetLB Service", "addressName", addressName)
+	defer func() {
+		l4netlb.svcLogger.V(2).Info("Finished deleting IPv6 external static address for L4 NetLB Service", "addressName", addressName, "timeTaken", time.Since(start))
+	}()
+
+	err := address.EnsureDeleted(l4netlb.cloud, addressName, l4netlb.cloud.Region())
+	if err != nil {
+		l4netlb.svcLogger.Error(err, "Failed to delete ipv6 address for external loadbalancer service")
+		syncResult.Error = err
+		syncResult.GCEResourceInError = annotations.AddressIPv6Resource
+	}
+}
+
 func (l4netlb *L4NetLB) deleteIPv6NodesFirewall(syncResult *L4NetLBSyncResult) {
 	ipv6FirewallName := l4netlb.namer.L4IPv6Firewall(l4netlb.Service.Namespace, l4netlb.Service.Name)
 
@@ -30,6 +30,7 @@ var L4ResourceAnnotationKeys = []string{
 	annotations.TCPForwardingRuleKey,
 	annotations.UDPForwardingRuleKey,
 	annotations.L3ForwardingRuleKey,
+	annotations.AdditionalForwardingRulesKey,
 	annotations.HealthcheckKey,
 	annotations.FirewallRuleKey,
 	annotations.FirewallRuleForHealthcheckKey,
@@ -25,6 +25,7 @@ import (
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/translator"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
@@ -51,6 +52,9 @@ type L7RuntimeInfo struct {
 	TLS []*translator.TLSCerts
 	// TLSName is the name of the preshared cert to use. Multiple certs can be specified as a comma-separated string
 	TLSName string
+	// CertificateMap is the name of the Certificate Manager certificate map to use.
+	// If specified, the certificates of the map are served instead of TLS and TLSName.
+	CertificateMap string
 	// Ingress is the processed Ingress API object.
 	Ingress *v1.Ingress
 	// AllowHTTP will not setup :80, if TLS is nil and AllowHTTP is set,
@@ -76,6 +80,9 @@ type L7 struct {
 	ingress v1.Ingress
 	// cloud is an interface to manage loadbalancers in the GCE cloud.
 	cloud *gce.Cloud
+	// rateLimiter throttles the compute API calls that the cloud provider
+	// does not wrap.
+	rateLimiter cloud.RateLimiter
 	// um is the UrlMap associated with this L7.
 	um *composite.UrlMap
 	// rum is the Http Redirect only UrlMap associated with this L7.
@@ -103,6 +110,11 @@ type L7 struct {
 	recorder record.EventRecorder
 	// resource type stores the KeyType of the resources in the loadbalancer (e.g. Regional)
 	scope meta.KeyType
+	// isL7ILB is true if the loadbalancer is an internal HTTP(S) loadbalancer.
+	isL7ILB bool
+	// isL7XLBRegional is true if the loadbalancer is a regional external
+	// HTTP(S) loadbalancer.
+	isL7XLBRegional bool
 
 	logger klog.Logger
 }
@@ -116,7 +128,7 @@ func (l7 *L7) String() string {
 
 // Versions returns the struct listing the versions for every resource
 func (l7 *L7) Versions() *features.ResourceVersions {
-	return features.VersionsFromIngress(&l7.ingress)
+	return features.VersionsForLoadBalancer(l7.isL7ILB, l7.isL7XLBRegional)
 }
 
 // CreateKey creates a meta.Key for use with composite types
@@ -140,14 +152,14 @@ func (l7 *L7) UrlMap() *composite.UrlMap {
 }
 
 func (l7 *L7) edgeHop() error {
-	sslConfigured := l7.runtimeInfo.TLS != nil || l7.runtimeInfo.TLSName != ""
+	sslConfigured := l7.runtimeInfo.TLS != nil || l7.runtimeInfo.TLSName != "" || l7.runtimeInfo.CertificateMap != ""
 	// Return an error if user configuration species that both HTTP & HTTPS are not to be configured.
 	if !l7.runtimeInfo.AllowHTTP && !sslConfigured {
 		return errAllProtocolsDisabled
 	}
 
 	// Check for invalid L7-ILB HTTPS config before attempting sync
-	if utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress) && sslConfigured && l7.runtimeInfo.AllowHTTP && l7.runtimeInfo.StaticIPName == "" {
+	if l7.isL7ILB && sslConfigured && l7.runtimeInfo.AllowHTTP && l7.runtimeInfo.StaticIPName == "" {
 		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeWarning, "WillNotConfigureFrontend", "gce-internal Ingress class must be configured with a static-ip to use both HTTP and HTTPS served on the same IP. Please configure a static-ip with Purpose=SHARED_LOADBALANCER_VIP and attach it to the ingress with the kubernetes.io/ingress.regional-static-ip-name annotation.")
 		return fmt.Errorf("error invalid internal ingress https config")
 	}
@@ -160,6 +172,9 @@ func (l7 *L7) edgeHop() error {
 		if err := l7.ensureRedirectURLMap(); err != nil {
 			return fmt.Errorf("ensureRedirectUrlMap() = %v", err)
 		}
+		if err := l7.ensureEdgeSecurityPolicy(); err != nil {
+			return fmt.Errorf("ensureEdgeSecurityPolicy() = %v", err)
+		}
 	}
 
 	if l7.runtimeInfo.AllowHTTP {
@@ -167,7 +182,7 @@ func (l7 *L7) edgeHop() error {
 			return err
 		}
 	} else if flags.F.EnableDeleteUnusedFrontends && requireDeleteFrontend(l7.ingress, namer.HTTPProtocol, l7.logger) {
-		if err := l7.deleteHttp(features.VersionsFromIngress(&l7.ingress)); err != nil {
+		if err := l7.deleteHttp(l7.Versions()); err != nil {
 			return err
 		}
 		l7.logger.V(2).Info("Successfully deleted unused HTTP frontend resources for load-balancer", "l7", l7)
@@ -185,7 +200,7 @@ func (l7 *L7) edgeHop() error {
 			return err
 		}
 	} else if flags.F.EnableDeleteUnusedFrontends && requireDeleteFrontend(l7.ingress, namer.HTTPSProtocol, l7.logger) {
-		if err := l7.deleteHttps(features.VersionsFromIngress(&l7.ingress)); err != nil {
+		if err := l7.deleteHttps(l7.Versions()); err != nil {
 			return err
 		}
 		l7.logger.V(2).Info("Successfully deleted unused HTTPS frontend resources for load-balancer", "l7", l7)
@@ -205,7 +220,11 @@ func (l7 *L7) edgeHopHttp() error {
 
 func (l7 *L7) edgeHopHttps() error {
 	defer l7.deleteOldSSLCerts()
-	if err := l7.checkSSLCert(); err != nil {
+	if l7.runtimeInfo.CertificateMap == "" {
+		if err := l7.checkSSLCert(); err != nil {
+			return err
+		}
+	} else if err := l7.checkCertificateMapSSLCerts(); err != nil {
 		return err
 	}
 
@@ -319,6 +338,8 @@ func (l7 *L7) deleteHttps(versions *features.ResourceVersions) error {
 	}
 	// Delete ingress managed ssl certificates those created from a secret,
 	// not referencing a pre-created GCE cert or managed certificates.
+	// The certificate map of the target https proxy is not managed by the
+	// controller and is never deleted.
 	return l7.deleteSSLCertificates(secretsSslCerts, versions)
 }
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/networking/v1"
 	"k8s.io/cloud-provider-gcp/providers/gce"
@@ -36,39 +37,68 @@ import (
 // L7s implements LoadBalancerPool.
 type L7s struct {
 	cloud *gce.Cloud
+	// rateLimiter throttles the compute API calls that the cloud provider
+	// does not wrap.
+	rateLimiter cloud.RateLimiter
 	// v1NamerHelper is an interface for helper functions for v1 frontend naming scheme.
 	v1NamerHelper    namer_util.V1FrontendNamer
 	recorderProducer events.RecorderProducer
 	// namerFactory creates frontend naming policy for ingress/ load balancer.
 	namerFactory namer_util.IngressFrontendNamerFactory
+	// classifier determines the type of load balancer requested by an ingress.
+	classifier utils.IngressClassifier
 
 	logger klog.Logger
 }
 
 // NewLoadBalancerPool returns a new loadbalancer pool.
-// - cloud: implements LoadBalancers. Used to sync L7 loadbalancer resources
-//
-//	with the cloud.
-func NewLoadBalancerPool(cloud *gce.Cloud, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory, logger klog.Logger) LoadBalancerPool {
+//   - cloud: implements LoadBalancers. Used to sync L7 loadbalancer resources
+//     with the cloud.
+//   - rateLimiter: throttles the compute API calls that the cloud provider
+//     does not wrap. It may be nil.
+//   - classifier: determines the type of load balancer requested by an
+//     ingress.
+func NewLoadBalancerPool(gceCloud *gce.Cloud, rateLimiter cloud.RateLimiter, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory, classifier utils.IngressClassifier, logger klog.Logger) LoadBalancerPool {
 	return &L7s{
-		cloud:            cloud,
+		cloud:            gceCloud,
+		rateLimiter:      rateLimiter,
 		v1NamerHelper:    v1NamerHelper,
 		recorderProducer: recorderProducer,
 		namerFactory:     namerFactory,
+		classifier:       classifier,
 		logger:           logger.WithName("L7Pool"),
 	}
 }
 
+// loadBalancerType returns whether the given ingress requests an internal or
+// a regional external load balancer.
+func (l7s *L7s) loadBalancerType(ing *v1.Ingress) (isL7ILB bool, isL7XLBRegional bool, err error) {
+	if isL7ILB, err = l7s.classifier.IsL7ILB(ing); err != nil {
+		return false, false, err
+	}
+	if isL7XLBRegional, err = l7s.classifier.IsL7XLBRegional(ing); err != nil {
+		return false, false, err
+	}
+	return isL7ILB, isL7XLBRegional, nil
+}
+
 // Ensure implements LoadBalancerPool.
 func (l7s *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
+	isL7ILB, isL7XLBRegional, err := l7s.loadBalancerType(ri.Ingress)
+	if err != nil {
+		return nil, err
+	}
 	lb := &L7{
-		runtimeInfo: ri,
-		cloud:       l7s.cloud,
-		namer:       l7s.namerFactory.Namer(ri.Ingress),
-		recorder:    l7s.recorderProducer.Recorder(ri.Ingress.Namespace),
-		scope:       features.ScopeFromIngress(ri.Ingress),
-		ingress:     *ri.Ingress,
-		logger:      l7s.logger,
+		runtimeInfo:     ri,
+		cloud:           l7s.cloud,
+		rateLimiter:     l7s.rateLimiter,
+		namer:           l7s.namerFactory.Namer(ri.Ingress),
+		recorder:        l7s.recorderProducer.Recorder(ri.Ingress.Namespace),
+		scope:           features.ScopeForLoadBalancer(isL7ILB, isL7XLBRegional),
+		isL7ILB:         isL7ILB,
+		isL7XLBRegional: isL7XLBRegional,
+		ingress:         *ri.Ingress,
+		logger:          l7s.logger,
 	}
 
 	if !lb.namer.IsValidLoadBalancer() {
@@ -126,7 +156,11 @@ func (l7s *L7s) list(key *meta.Key, version meta.Version) ([]*composite.UrlMap,
 func (l7s *L7s) GCv2(ing *v1.Ingress, scope meta.KeyType) error {
 	ingKey := common.NamespacedName(ing)
 	l7s.logger.V(2).Info("GCv2", "key", ingKey)
-	if err := l7s.delete(l7s.namerFactory.Namer(ing), features.VersionsFromIngress(ing), scope); err != nil {
+	isL7ILB, isL7XLBRegional, err := l7s.loadBalancerType(ing)
+	if err != nil {
+		return err
+	}
+	if err := l7s.delete(l7s.namerFactory.Namer(ing), features.VersionsForLoadBalancer(isL7ILB, isL7XLBRegional), scope); err != nil {
 		return err
 	}
 	l7s.logger.V(2).Info("GCv2 ok", "key", ingKey)
@@ -142,9 +176,13 @@ func (l7s *L7s) FrontendScopeChangeGC(ing *v1.Ingress, ingLogger klog.Logger) (*
 		return nil, nil
 	}
 
+	isL7ILB, isL7XLBRegional, err := l7s.loadBalancerType(ing)
+	if err != nil {
+		return nil, err
+	}
 	namer := l7s.namerFactory.Namer(ing)
 	urlMapName := namer.UrlMap()
-	currentScope := features.ScopeFromIngress(ing)
+	currentScope := features.ScopeForLoadBalancer(isL7ILB, isL7XLBRegional)
 
 	for _, scope := range []meta.KeyType{meta.Global, meta.Regional} {
 		if scope != currentScope {
@@ -154,7 +192,7 @@ func (l7s *L7s) FrontendScopeChangeGC(ing *v1.Ingress, ingLogger klog.Logger) (*
 			}
 
 			// Look for existing LBs with the same name but of a different scope
-			_, err = composite.GetUrlMap(l7s.cloud, key, features.VersionsFromIngress(ing).UrlMap, l7s.logger)
+			_, err = composite.GetUrlMap(l7s.cloud, key, features.VersionsForLoadBalancer(isL7ILB, isL7XLBRegional).UrlMap, l7s.logger)
 			if err == nil {
 				l7s.logger.V(2).Info("GC'ing ing for scope", "ing", ing, "scope", scope)
 				return &scope, nil
@@ -175,8 +213,12 @@ func (l7s *L7s) DidRegionalClassChange(ing *v1.Ingress, ingLogger klog.Logger) (
 		return false, nil
 	}
 
+	isL7ILB, isL7XLBRegional, err := l7s.loadBalancerType(ing)
+	if err != nil {
+		return false, err
+	}
 	namer := l7s.namerFactory.Namer(ing)
-	currentLBScheme := lbSchemeForIngress(ing)
+	currentLBScheme := lbScheme(isL7ILB, isL7XLBRegional)
 	ingLogger.WithName("DidRegionalClassChange")
 	ingLogger.Info("Checking ingress for class name change")
 
@@ -189,7 +231,7 @@ func (l7s *L7s) DidRegionalClassChange(ing *v1.Ingress, ingLogger klog.Logger) (
 		}
 		ingLogger.Info("Checking for existence of forwarding rule with different LoadBalancingScheme", "frKey", key)
 
-		fr, err := composite.GetForwardingRule(l7s.cloud, key, features.VersionsFromIngress(ing).ForwardingRule, ingLogger)
+		fr, err := composite.GetForwardingRule(l7s.cloud, key, features.VersionsForLoadBalancer(isL7ILB, isL7XLBRegional).ForwardingRule, ingLogger)
 		if err == nil && fr.LoadBalancingScheme != currentLBScheme {
 			ingLogger.Info("ingress needs GC for changed lb scheme", "ingress", ing, "schemeToClean", fr.LoadBalancingScheme)
 			return true, nil
@@ -201,10 +243,10 @@ func (l7s *L7s) DidRegionalClassChange(ing *v1.Ingress, ingLogger klog.Logger) (
 	return false, nil
 }
 
-func lbSchemeForIngress(ing *v1.Ingress) string {
-	if utils.IsGCEL7XLBRegionalIngress(ing) {
+func lbScheme(isL7ILB, isL7XLBRegional bool) string {
+	if isL7XLBRegional {
 		return "EXTERNAL_MANAGED"
-	} else if utils.IsGCEL7ILBIngress(ing) {
+	} else if isL7ILB {
 		return "INTERNAL_MANAGED"
 	} else {
 		return "EXTERNAL"
@@ -248,7 +290,10 @@ func (l7s *L7s) GCv1(names []string) error {
 	return nil
 }
 
-// gc is a helper for GCv1.
+// gc is a helper for GCv1. Backend buckets referenced by the url maps are
+// owned by their BackendBucket CRs, so deleting the url map only releases
+// them. The backend bucket controller deletes them once their CR is deleted,
+// or, if the CR no longer exists, in its periodic garbage collection.
 // TODO(shance): get versions from description
 func (l7s *L7s) gc(urlMaps []*composite.UrlMap, knownLoadBalancers map[namer_util.LoadBalancerName]bool, versions *features.ResourceVersions) []error {
 	var errors []error
@@ -287,7 +332,12 @@ func (l7s *L7s) Shutdown(ings []*v1.Ingress) error {
 		return namer_util.FrontendNamingScheme(ing, l7s.logger) == namer_util.V2NamingScheme
 	}).AsList()
 	for _, ing := range v2Ings {
-		if err := l7s.GCv2(ing, features.ScopeFromIngress(ing)); err != nil {
+		scope, err := features.ScopeFromIngress(ing, l7s.classifier)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := l7s.GCv2(ing, scope); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -300,12 +350,16 @@ func (l7s *L7s) Shutdown(ings []*v1.Ingress) error {
 
 // HasUrlMap implements LoadBalancerPool.
 func (l7s *L7s) HasUrlMap(ing *v1.Ingress) (bool, error) {
+	isL7ILB, isL7XLBRegional, err := l7s.loadBalancerType(ing)
+	if err != nil {
+		return false, err
+	}
 	namer := l7s.namerFactory.Namer(ing)
-	key, err := composite.CreateKey(l7s.cloud, namer.UrlMap(), features.ScopeFromIngress(ing))
+	key, err := composite.CreateKey(l7s.cloud, namer.UrlMap(), features.ScopeForLoadBalancer(isL7ILB, isL7XLBRegional))
 	if err != nil {
 		return false, err
 	}
-	if _, err := composite.GetUrlMap(l7s.cloud, key, features.VersionsFromIngress(ing).UrlMap, l7s.logger); err != nil {
+	if _, err := composite.GetUrlMap(l7s.cloud, key, features.VersionsForLoadBalancer(isL7ILB, isL7XLBRegional).UrlMap, l7s.logger); err != nil {
 		if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
 			return false, nil
 		}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
@@ -527,7 +528,11 @@ func TestV2GC(t *testing.T) {
 				createFakeLoadbalancer(cloud, feNamerFactory.Namer(ing), versions, defaultScope)
 			}
 
-			err := l7sPool.GCv2(tc.ingressToDelete, features.ScopeFromIngress(tc.ingressToDelete))
+			scope, err := features.ScopeFromIngress(tc.ingressToDelete, utils.IngressClassifier{})
+			if err != nil {
+				t.Fatalf("ScopeFromIngress() = %v", err)
+			}
+			err = l7sPool.GCv2(tc.ingressToDelete, scope)
 			if err != nil {
 				t.Errorf("l7sPool.GC(%q) = %v, want nil for case %q", common.NamespacedName(tc.ingressToDelete), err, tc.desc)
 			}
@@ -606,7 +611,11 @@ func TestDoNotLeakV2LB(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			feNamer := feNamerFactory.Namer(tc.ing)
 			createFakeLoadbalancer(l7sPool.cloud, feNamer, versions, defaultScope)
-			err := l7sPool.GCv2(tc.ing, features.ScopeFromIngress(tc.ing))
+			scope, err := features.ScopeFromIngress(tc.ing, utils.IngressClassifier{})
+			if err != nil {
+				t.Fatalf("ScopeFromIngress() = %v", err)
+			}
+			err = l7sPool.GCv2(tc.ing, scope)
 			if err != nil {
 				t.Errorf("l7sPool.GC(%q) = %v, want nil for case %q", common.NamespacedName(tc.ing), err, tc.desc)
 			}
@@ -824,7 +833,7 @@ func newTestLoadBalancerPool() LoadBalancerPool {
 	namer := namer_util.NewNamer(testClusterName, "fw1", klog.TODO())
 	fakeGCECloud := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
 	ctx := &context.ControllerContext{}
-	return NewLoadBalancerPool(fakeGCECloud, namer, ctx, namer_util.NewFrontendNamerFactory(namer, kubeSystemUID, klog.TODO()), klog.TODO())
+	return NewLoadBalancerPool(fakeGCECloud, nil, namer, ctx, namer_util.NewFrontendNamerFactory(namer, kubeSystemUID, klog.TODO()), utils.IngressClassifier{}, klog.TODO())
 }
 
 func createFakeLoadbalancer(cloud *gce.Cloud, namer namer_util.IngressFrontendNamer, versions *features.ResourceVersions, scope meta.KeyType) {
@@ -18,8 +18,11 @@ package loadbalancers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"path"
 	"strconv"
 	"strings"
 	"testing"
@@ -32,6 +35,7 @@ import (
 	"google.golang.org/api/googleapi"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
@@ -120,6 +124,17 @@ func newTestJig(t *testing.T) *testJig {
 		tps.SslPolicy = ref.SslPolicy
 		return nil
 	}
+	mockGCE.MockTargetHttpsProxies.SetCertificateMapHook = func(ctx context.Context, key *meta.Key, request *compute.TargetHttpsProxiesSetCertificateMapRequest, proxies *cloud.MockTargetHttpsProxies, _ ...cloud.Option) error {
+		tp, err := proxies.Get(ctx, key)
+		if err != nil {
+			return &googleapi.Error{
+				Code:    http.StatusNotFound,
+				Message: fmt.Sprintf("Key: %s was not found in TargetHttpsProxies", key.String()),
+			}
+		}
+		tp.CertificateMap = request.CertificateMap
+		return nil
+	}
 	mockGCE.MockGlobalForwardingRules.InsertHook = InsertGlobalForwardingRuleHook
 
 	ing := newIngress()
@@ -149,7 +164,7 @@ func newIngress() *networkingv1.Ingress {
 }
 
 func newFakeLoadBalancerPool(cloud *gce.Cloud, t *testing.T, namer *namer_util.Namer) L7s {
-	return L7s{cloud, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), klog.TODO()}
+	return L7s{cloud, nil, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), utils.IngressClassifier{}, klog.TODO()}
 }
 
 func newILBIngress() *networkingv1.Ingress {
@@ -651,7 +666,6 @@ func TestMaxCertsUpload(t *testing.T) {
 	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
 	var tlsCerts []*translator.TLSCerts
 	expectCerts := make(map[string]string)
-	expectCertsExtra := make(map[string]string)
 	ing := newIngress()
 	feNamer := namer_util.NewFrontendNamerFactory(j.namer, "", klog.TODO()).Namer(ing)
 
@@ -682,15 +696,13 @@ func TestMaxCertsUpload(t *testing.T) {
 	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
 	// Set cert count less than cert creation limit but more than target proxy limit
 	lbInfo.TLS = lbInfo.TLS[:TargetProxyCertLimit+1]
-	for _, cert := range lbInfo.TLS {
-		expectCertsExtra[feNamer.SSLCertName(cert.CertHash)] = cert.Cert
-	}
 	_, err = j.pool.Ensure(lbInfo)
 	if err == nil {
 		t.Fatalf("Assigning more than %d certs should have errored out", TargetProxyCertLimit)
 	}
-	// load balancer will contain the extra cert, but target proxy will not
-	verifyCertAndProxyLink(expectCertsExtra, expectCerts, j, t)
+	// The target proxy was not updated, the certs it still references must
+	// not be deleted.
+	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
 	// Removing the extra cert from ingress spec should delete it
 	lbInfo.TLS = lbInfo.TLS[:TargetProxyCertLimit]
 	_, err = j.pool.Ensure(lbInfo)
@@ -1184,6 +1196,286 @@ func TestFrontendConfigRedirects(t *testing.T) {
 	}
 }
 
+func TestFrontendConfigCustomErrorResponsePolicy(t *testing.T) {
+	flags.F.EnableFrontendConfig = true
+	defer func() { flags.F.EnableFrontendConfig = false }()
+
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			CustomErrorResponsePolicy: &frontendconfigv1beta1.CustomErrorResponsePolicy{
+				ErrorBackendBucket: "errors",
+				ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+			},
+		}},
+	}
+
+	l7, err := j.pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+
+	um, err := composite.GetUrlMap(j.fakeGCE, meta.GlobalKey(l7.um.Name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetUrlMap(%q) = %v, want nil", l7.um.Name, err)
+	}
+	policy := um.DefaultCustomErrorResponsePolicy
+	if policy == nil || policy.ErrorService != "global/backendBuckets/errors" || len(policy.ErrorResponseRules) != 1 {
+		t.Fatalf("url map custom error response policy = %+v, want policy with error service global/backendBuckets/errors", policy)
+	}
+
+	// Removing the policy from the FrontendConfig removes it from the url map.
+	lbInfo.FrontendConfig = &frontendconfigv1beta1.FrontendConfig{}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	um, err = composite.GetUrlMap(j.fakeGCE, meta.GlobalKey(l7.um.Name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetUrlMap(%q) = %v, want nil", l7.um.Name, err)
+	}
+	if um.DefaultCustomErrorResponsePolicy != nil {
+		t.Errorf("url map custom error response policy = %+v, want nil", um.DefaultCustomErrorResponsePolicy)
+	}
+
+	// Custom error responses are not supported for internal Ingresses.
+	lbInfo.Ingress = newILBIngress()
+	lbInfo.FrontendConfig.Spec.CustomErrorResponsePolicy = &frontendconfigv1beta1.CustomErrorResponsePolicy{
+		ErrorBackendBucket: "errors",
+		ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+	}
+	if _, err := j.pool.Ensure(lbInfo); err == nil || !strings.Contains(err.Error(), "custom error response") {
+		t.Errorf("j.pool.Ensure(%v) = %v, want custom error response policy error for internal Ingress", lbInfo, err)
+	}
+}
+
+func TestCertificateMap(t *testing.T) {
+	t.Parallel()
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP:      false,
+		CertificateMap: "my-map",
+		UrlMap:         gceUrlMap,
+		Ingress:        newIngress(),
+	}
+
+	l7, err := j.pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	if l7.tps == nil {
+		t.Fatalf("Expected https proxy for certificate map %q", lbInfo.CertificateMap)
+	}
+	tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", l7.tps.Name, err)
+	}
+	if path.Base(tps.CertificateMap) != "my-map" || len(tps.SslCertificates) != 0 {
+		t.Errorf("https proxy certificate map = %q, ssl certificates = %v, want certificate map my-map and no ssl certificates", tps.CertificateMap, tps.SslCertificates)
+	}
+
+	// Changing the certificate map updates the https proxy.
+	lbInfo.CertificateMap = "other-map"
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	tps, err = composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", l7.tps.Name, err)
+	}
+	if path.Base(tps.CertificateMap) != "other-map" {
+		t.Errorf("https proxy certificate map = %q, want other-map", tps.CertificateMap)
+	}
+
+	// Certificate maps are not supported for internal Ingresses.
+	lbInfo.Ingress = newILBIngress()
+	if _, err := j.pool.Ensure(lbInfo); err == nil || !strings.Contains(err.Error(), "certificate map") {
+		t.Errorf("j.pool.Ensure(%v) = %v, want certificate map error for internal Ingress", lbInfo, err)
+	}
+}
+
+func TestCertificateMapReplacesSslCerts(t *testing.T) {
+	t.Parallel()
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	ing := newIngress()
+	feNamer := namer_util.NewFrontendNamerFactory(j.namer, "", klog.TODO()).Namer(ing)
+	cert := createCert("key", "cert", "name")
+	expectCerts := map[string]string{feNamer.SSLCertName(cert.CertHash): cert.Cert}
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: false,
+		TLS:       []*translator.TLSCerts{cert},
+		UrlMap:    gceUrlMap,
+		Ingress:   ing,
+	}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
+
+	// Switching to a certificate map detaches and deletes the secret certs.
+	lbInfo.CertificateMap = "my-map"
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	verifyCertAndProxyLink(map[string]string{}, map[string]string{}, j, t)
+
+	// Removing the certificate map attaches the secret certs again.
+	lbInfo.CertificateMap = ""
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
+	key, err := composite.CreateKey(j.fakeGCE, j.feNamer.TargetProxy(namer_util.HTTPSProtocol), defaultScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, key, defaultVersion, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", key.Name, err)
+	}
+	if tps.CertificateMap != "" {
+		t.Errorf("https proxy certificate map = %q, want none", tps.CertificateMap)
+	}
+}
+
+func TestEnsureEdgeSecurityPolicy(t *testing.T) {
+	t.Parallel()
+	j := newTestJig(t)
+
+	be := &composite.BackendService{
+		Name:               "k8s-be-30000--uid1",
+		EdgeSecurityPolicy: "https://www.googleapis.com/compute/v1/projects/p/global/securityPolicies/edge-policy",
+	}
+	if err := composite.CreateBackendService(j.fakeGCE, meta.GlobalKey(be.Name), be, klog.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	um := &composite.UrlMap{Name: "k8s-um-lb-name", DefaultService: "global/backendServices/" + be.Name}
+
+	testCases := []struct {
+		desc    string
+		fc      *frontendconfigv1beta1.FrontendConfig
+		scope   meta.KeyType
+		wantErr bool
+	}{
+		{
+			desc:  "Empty frontendconfig",
+			fc:    nil,
+			scope: meta.Global,
+		},
+		{
+			desc:  "frontendconfig with no edge security policy",
+			fc:    &frontendconfigv1beta1.FrontendConfig{},
+			scope: meta.Global,
+		},
+		{
+			desc:  "edge security policy already attached",
+			fc:    &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{EdgeSecurityPolicy: utils.NewStringPointer("edge-policy")}},
+			scope: meta.Global,
+		},
+		{
+			desc:    "regional load balancer",
+			fc:      &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{EdgeSecurityPolicy: utils.NewStringPointer("edge-policy")}},
+			scope:   meta.Regional,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		l7 := L7{runtimeInfo: &L7RuntimeInfo{FrontendConfig: tc.fc}, cloud: j.fakeGCE, scope: tc.scope, um: um, logger: klog.TODO()}
+		if err := l7.ensureEdgeSecurityPolicy(); (err != nil) != tc.wantErr {
+			t.Errorf("desc: %q, l7.ensureEdgeSecurityPolicy() = %v, want err? %v", tc.desc, err, tc.wantErr)
+		}
+	}
+}
+
+func TestEnsureEdgeSecurityPolicyUpdate(t *testing.T) {
+	t.Parallel()
+	j := newTestJig(t)
+
+	var setCalls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/test-project/global/backendServices/k8s-be-30000--uid1/setEdgeSecurityPolicy", func(w http.ResponseWriter, r *http.Request) {
+		ref := &compute.SecurityPolicyReference{}
+		if err := json.NewDecoder(r.Body).Decode(ref); err != nil {
+			t.Errorf("Failed to decode setEdgeSecurityPolicy request: %v", err)
+		}
+		setCalls = append(setCalls, ref.SecurityPolicy)
+		json.NewEncoder(w).Encode(&compute.Operation{Name: "op-set"})
+	})
+	mux.HandleFunc("/projects/test-project/global/operations/op-set/wait", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&compute.Operation{Name: "op-set", Status: "DONE"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	j.fakeGCE.ComputeServices().GA.BasePath = server.URL + "/"
+
+	be := &composite.BackendService{
+		Name:               "k8s-be-30000--uid1",
+		EdgeSecurityPolicy: "https://www.googleapis.com/compute/v1/projects/p/global/securityPolicies/old-policy",
+	}
+	if err := composite.CreateBackendService(j.fakeGCE, meta.GlobalKey(be.Name), be, klog.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	um := &composite.UrlMap{Name: "k8s-um-lb-name", DefaultService: "global/backendServices/" + be.Name}
+	ing := newIngress()
+
+	for _, tc := range []struct {
+		desc     string
+		policy   string
+		wantLink string
+	}{
+		{
+			desc:     "policy changed",
+			policy:   "new-policy",
+			wantLink: cloud.SelfLink(meta.VersionGA, "test-project", "securityPolicies", meta.GlobalKey("new-policy")),
+		},
+		{
+			desc:     "policy detached",
+			policy:   "",
+			wantLink: "",
+		},
+	} {
+		setCalls = nil
+		rl := &countingRateLimiter{}
+		fc := &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{EdgeSecurityPolicy: utils.NewStringPointer(tc.policy)}}
+		l7 := L7{runtimeInfo: &L7RuntimeInfo{FrontendConfig: fc, Ingress: ing}, cloud: j.fakeGCE, rateLimiter: rl, scope: meta.Global, um: um, recorder: &record.FakeRecorder{}, logger: klog.TODO()}
+		if err := l7.ensureEdgeSecurityPolicy(); err != nil {
+			t.Fatalf("desc: %q, l7.ensureEdgeSecurityPolicy() = %v, want nil", tc.desc, err)
+		}
+		if diff := cmp.Diff([]string{tc.wantLink}, setCalls); diff != "" {
+			t.Errorf("desc: %q, unexpected setEdgeSecurityPolicy calls (-want +got):\n%s", tc.desc, diff)
+		}
+		if rl.accepted != len(setCalls) {
+			t.Errorf("desc: %q, rate limiter accepted %d calls, want %d", tc.desc, rl.accepted, len(setCalls))
+		}
+	}
+}
+
+// countingRateLimiter is a cloud.RateLimiter that counts accepted calls.
+type countingRateLimiter struct {
+	accepted int
+}
+
+func (rl *countingRateLimiter) Accept(context.Context, *cloud.RateLimitKey) error {
+	rl.accepted++
+	return nil
+}
+
+func (rl *countingRateLimiter) Observe(context.Context, error, *cloud.RateLimitKey) {}
+
 func TestEnsureSslPolicy(t *testing.T) {
 	t.Parallel()
 	j := newTestJig(t)
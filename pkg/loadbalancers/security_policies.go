@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// ensureEdgeSecurityPolicy ensures that the edge security policy described in
+// the frontendconfig is attached to every backend service of the url map.
+func (l7 *L7) ensureEdgeSecurityPolicy() error {
+	// It is too dangerous to detach an edge security policy that may have been
+	// configured via the UI or gcloud directly rather than via Kubernetes.
+	// Treat nil edge security policy -> ignored
+	// Treat empty string edge security policy name -> detached
+	feConfig := l7.runtimeInfo.FrontendConfig
+	if feConfig == nil || feConfig.Spec.EdgeSecurityPolicy == nil {
+		return nil
+	}
+	if l7.scope != meta.Global {
+		return fmt.Errorf("error: cannot attach edge security policies with %s load balancers", l7.scope)
+	}
+
+	beNames, err := getBackendNames(l7.um)
+	if err != nil {
+		return err
+	}
+	desiredPolicyName := *feConfig.Spec.EdgeSecurityPolicy
+	for _, beName := range beNames {
+		be, err := composite.GetBackendService(l7.cloud, meta.GlobalKey(beName), meta.VersionGA, l7.logger)
+		if err != nil {
+			return err
+		}
+		// The scope is not part of the fetched resource.
+		be.Scope = meta.Global
+		var existingPolicyName string
+		if be.EdgeSecurityPolicy != "" {
+			if existingPolicyName, err = utils.KeyName(be.EdgeSecurityPolicy); err != nil {
+				return fmt.Errorf("failed to parse existing edge security policy %q: %v", be.EdgeSecurityPolicy, err)
+			}
+		}
+		if existingPolicyName == desiredPolicyName {
+			continue
+		}
+
+		l7.logger.V(2).Info("Setting edge security policy of backend service", "backendName", beName, "existingPolicyName", existingPolicyName, "desiredPolicyName", desiredPolicyName)
+		if err := composite.SetEdgeSecurityPolicy(l7.cloud, l7.rateLimiter, be, desiredPolicyName, l7.logger); err != nil {
+			return fmt.Errorf("failed to set edge security policy from %q to %q for backend service %s: %v", existingPolicyName, desiredPolicyName, beName, err)
+		}
+		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "BackendService %q edge security policy updated", beName)
+	}
+	return nil
+}
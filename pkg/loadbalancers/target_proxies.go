@@ -17,6 +17,8 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"path"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cloud-provider-gcp/providers/gce"
@@ -57,9 +59,7 @@ func (l7 *L7) checkProxy() (err error) {
 		return err
 	}
 
-	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
-	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(l7.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
+	tr := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
 
 	description, err := l7.description()
 	if err != nil {
@@ -109,13 +109,11 @@ func (l7 *L7) checkProxy() (err error) {
 }
 
 func (l7 *L7) checkHttpsProxy() (err error) {
-	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
-	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(l7.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
-	env := &translator.Env{FrontendConfig: l7.runtimeInfo.FrontendConfig, Region: l7.cloud.Region()}
+	tr := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
+	env := &translator.Env{FrontendConfig: l7.runtimeInfo.FrontendConfig, Region: l7.cloud.Region(), Project: l7.cloud.ProjectID(), CertificateMap: l7.runtimeInfo.CertificateMap}
 
-	if len(l7.sslCerts) == 0 {
-		l7.logger.V(2).Info("No SSL certificates for load-balancer, will not create HTTPS Proxy.", "l7", l7)
+	if len(l7.sslCerts) == 0 && l7.runtimeInfo.CertificateMap == "" {
+		l7.logger.V(2).Info("No SSL certificates or certificate map for load-balancer, will not create HTTPS Proxy.", "l7", l7)
 		return nil
 	}
 
@@ -173,21 +171,24 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
 	}
 
-	if !l7.compareCerts(currentProxy.SslCertificates) {
-		l7.logger.V(2).Info("Https Proxy has the wrong ssl certs, overwriting",
-			"proxyName", currentProxy.Name, "newCerts", toCertNames(l7.sslCerts), "existingCerts", currentProxy.SslCertificates)
-		var sslCertURLs []string
-		for _, cert := range l7.sslCerts {
-			sslCertURLs = append(sslCertURLs, cert.SelfLink)
+	// The proxy must always serve either ssl certificates or a certificate
+	// map. A certificate map is attached before the ssl certificates are
+	// detached, and ssl certificates are attached before the certificate map
+	// is removed.
+	if proxy.CertificateMap != "" {
+		if err := l7.ensureCertificateMap(currentProxy, proxy.CertificateMap); err != nil {
+			return err
 		}
-		key, err := l7.CreateKey(currentProxy.Name)
-		if err != nil {
+		if err := l7.ensureSslCertificates(currentProxy); err != nil {
 			return err
 		}
-		if err := composite.SetSslCertificateForTargetHttpsProxy(l7.cloud, key, currentProxy, sslCertURLs, l7.logger); err != nil {
+	} else {
+		if err := l7.ensureSslCertificates(currentProxy); err != nil {
+			return err
+		}
+		if err := l7.ensureCertificateMap(currentProxy, proxy.CertificateMap); err != nil {
 			return err
 		}
-		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certs updated", key.Name)
 	}
 
 	if flags.F.EnableFrontendConfig && sslPolicySet {
@@ -200,6 +201,57 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 	return nil
 }
 
+// ensureSslCertificates ensures that the proxy serves the ssl certificates of
+// the L7.
+func (l7 *L7) ensureSslCertificates(currentProxy *composite.TargetHttpsProxy) error {
+	if l7.compareCerts(currentProxy.SslCertificates) {
+		return nil
+	}
+	l7.logger.V(2).Info("Https Proxy has the wrong ssl certs, overwriting",
+		"proxyName", currentProxy.Name, "newCerts", toCertNames(l7.sslCerts), "existingCerts", currentProxy.SslCertificates)
+	var sslCertURLs []string
+	for _, cert := range l7.sslCerts {
+		sslCertURLs = append(sslCertURLs, cert.SelfLink)
+	}
+	key, err := l7.CreateKey(currentProxy.Name)
+	if err != nil {
+		return err
+	}
+	if err := composite.SetSslCertificateForTargetHttpsProxy(l7.cloud, key, currentProxy, sslCertURLs, l7.logger); err != nil {
+		return err
+	}
+	l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certs updated", key.Name)
+	return nil
+}
+
+// ensureCertificateMap ensures that the proxy serves the given certificate
+// map. An empty link removes the certificate map.
+func (l7 *L7) ensureCertificateMap(currentProxy *composite.TargetHttpsProxy, certificateMapLink string) error {
+	if certificateMapsEqual(currentProxy.CertificateMap, certificateMapLink) {
+		return nil
+	}
+	l7.logger.V(2).Info("Https Proxy has the wrong certificate map, overwriting",
+		"proxyName", currentProxy.Name, "newCertificateMap", certificateMapLink, "existingCertificateMap", currentProxy.CertificateMap)
+	key, err := l7.CreateKey(currentProxy.Name)
+	if err != nil {
+		return err
+	}
+	if err := composite.SetCertificateMapForTargetHttpsProxy(l7.cloud, key, currentProxy, certificateMapLink, l7.logger); err != nil {
+		return err
+	}
+	l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certificate map updated", key.Name)
+	return nil
+}
+
+// certificateMapsEqual compares two certificate map links by the name of the
+// certificate map, since the project may either be a project ID or a number.
+func certificateMapsEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return path.Base(a) == path.Base(b)
+}
+
 func (l7 *L7) getSslCertLinkInUse() ([]string, error) {
 	proxyName := l7.namer.TargetProxy(namer.HTTPSProtocol)
 	key, err := l7.CreateKey(proxyName)
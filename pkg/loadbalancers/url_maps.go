@@ -18,12 +18,16 @@ package loadbalancers
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -45,6 +49,19 @@ func (l7 *L7) ensureComputeURLMap() error {
 	expectedMap := translator.ToCompositeURLMap(l7.runtimeInfo.UrlMap, l7.namer, key)
 	key.Name = expectedMap.Name
 
+	if flags.F.EnableFrontendConfig {
+		if feConfig := l7.runtimeInfo.FrontendConfig; feConfig != nil {
+			if err := frontendconfig.ValidateCustomErrorResponsePolicy(feConfig.Spec.CustomErrorResponsePolicy); err != nil {
+				return err
+			}
+		}
+		t := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
+		env := &translator.Env{FrontendConfig: l7.runtimeInfo.FrontendConfig, Ing: &l7.ingress}
+		if expectedMap.DefaultCustomErrorResponsePolicy, err = t.ToCustomErrorResponsePolicy(env, l7.runtimeInfo.UrlMap); err != nil {
+			return err
+		}
+	}
+
 	expectedMap.Version = l7.Versions().UrlMap
 	currentMap, err := composite.GetUrlMap(l7.cloud, key, expectedMap.Version, l7.logger)
 	if utils.IgnoreHTTPNotFound(err) != nil {
@@ -84,17 +101,15 @@ func (l7 *L7) ensureComputeURLMap() error {
 
 func (l7 *L7) ensureRedirectURLMap() error {
 	feConfig := l7.runtimeInfo.FrontendConfig
-	isL7ILB := utils.IsGCEL7ILBIngress(&l7.ingress)
-	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(&l7.ingress)
 
-	t := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
+	t := translator.NewTranslator(l7.isL7ILB, l7.isL7XLBRegional, l7.namer)
 	env := &translator.Env{FrontendConfig: feConfig, Ing: &l7.ingress}
 
 	name, namerSupported := l7.namer.RedirectUrlMap()
 	expectedMap := t.ToRedirectUrlMap(env, l7.Versions().UrlMap)
 
 	// Cannot enable for internal ingress
-	if expectedMap != nil && isL7ILB {
+	if expectedMap != nil && l7.isL7ILB {
 		return fmt.Errorf("error: cannot enable HTTPS Redirects with L7 ILB")
 	}
 
@@ -158,40 +173,61 @@ func compareRedirectUrlMaps(a, b *composite.UrlMap) bool {
 	return false
 }
 
-// getBackendNames returns the names of backends in this L7 urlmap.
+// getBackendNames returns the names of backend services in this L7 urlmap.
+// Backend buckets referenced by the urlmap are not included.
 func getBackendNames(computeURLMap *composite.UrlMap) ([]string, error) {
 	beNames := sets.NewString()
+	// The default Service recorded in the urlMap is a link to the backend.
+	// Note that this can either be user specified, or the L7 controller's
+	// global default. It is replaced by a default route action when the
+	// traffic of the default backend is split.
+	services := routeServices(computeURLMap.DefaultService, computeURLMap.DefaultRouteAction)
 	for _, pathMatcher := range computeURLMap.PathMatchers {
-		name, err := utils.KeyName(pathMatcher.DefaultService)
+		services = append(services, routeServices(pathMatcher.DefaultService, pathMatcher.DefaultRouteAction)...)
+		for _, pathRule := range pathMatcher.PathRules {
+			services = append(services, routeServices(pathRule.Service, pathRule.RouteAction)...)
+		}
+		for _, routeRule := range pathMatcher.RouteRules {
+			services = append(services, routeServices(routeRule.Service, routeRule.RouteAction)...)
+		}
+	}
+	for _, service := range services {
+		id, err := cloud.ParseResourceURL(service)
 		if err != nil {
 			return nil, err
 		}
-		beNames.Insert(name)
-
-		for _, pathRule := range pathMatcher.PathRules {
-			name, err = utils.KeyName(pathRule.Service)
-			if err != nil {
-				return nil, err
-			}
-			beNames.Insert(name)
+		// Backend buckets are not backend services.
+		if id.Resource == "backendBuckets" {
+			continue
 		}
+		beNames.Insert(id.Key.Name)
 	}
-	// The default Service recorded in the urlMap is a link to the backend.
-	// Note that this can either be user specified, or the L7 controller's
-	// global default.
-	name, err := utils.KeyName(computeURLMap.DefaultService)
-	if err != nil {
-		return nil, err
-	}
-	beNames.Insert(name)
 	return beNames.List(), nil
 }
 
+// routeServices returns the backend services a path or route rule sends
+// traffic to.
+func routeServices(service string, routeAction *composite.HttpRouteAction) []string {
+	var services []string
+	if service != "" {
+		services = append(services, service)
+	}
+	if routeAction != nil {
+		for _, wbs := range routeAction.WeightedBackendServices {
+			services = append(services, wbs.BackendService)
+		}
+	}
+	return services
+}
+
 // mapsEqual compares the structure of two compute.UrlMaps.
 // The service strings are parsed and compared as resource paths (such as
 // "global/backendServices/my-service") to ignore variables: endpoint, version, and project.
 func mapsEqual(a, b *composite.UrlMap) bool {
-	if !utils.EqualResourcePaths(a.DefaultService, b.DefaultService) {
+	if !servicesEqual(a.DefaultService, b.DefaultService) || !weightedBackendServicesEqual(a.DefaultRouteAction, b.DefaultRouteAction) {
+		return false
+	}
+	if !customErrorResponsePoliciesEqual(a.DefaultCustomErrorResponsePolicy, b.DefaultCustomErrorResponsePolicy) {
 		return false
 	}
 	if len(a.HostRules) != len(b.HostRules) {
@@ -221,7 +257,7 @@ func mapsEqual(a, b *composite.UrlMap) bool {
 	for i := range a.PathMatchers {
 		a := a.PathMatchers[i]
 		b := b.PathMatchers[i]
-		if !utils.EqualResourcePaths(a.DefaultService, b.DefaultService) {
+		if !servicesEqual(a.DefaultService, b.DefaultService) || !weightedBackendServicesEqual(a.DefaultRouteAction, b.DefaultRouteAction) {
 			return false
 		}
 		if a.Description != b.Description {
@@ -244,10 +280,150 @@ func mapsEqual(a, b *composite.UrlMap) bool {
 					return false
 				}
 			}
-			if !utils.EqualResourcePaths(a.Service, b.Service) {
+			if !servicesEqual(a.Service, b.Service) {
+				return false
+			}
+			if !weightedBackendServicesEqual(a.RouteAction, b.RouteAction) {
+				return false
+			}
+		}
+		if len(a.RouteRules) != len(b.RouteRules) {
+			return false
+		}
+		for i := range a.RouteRules {
+			if !routeRulesEqual(a.RouteRules[i], b.RouteRules[i]) {
 				return false
 			}
 		}
 	}
 	return true
 }
+
+// routeRulesEqual compares the fields of two route rules that are managed by
+// the controller. Backend services are compared as resource paths.
+func routeRulesEqual(a, b *composite.HttpRouteRule) bool {
+	if a.Priority != b.Priority {
+		return false
+	}
+	if !servicesEqual(a.Service, b.Service) {
+		return false
+	}
+	if len(a.MatchRules) != len(b.MatchRules) {
+		return false
+	}
+	for i := range a.MatchRules {
+		if !routeRuleMatchesEqual(a.MatchRules[i], b.MatchRules[i]) {
+			return false
+		}
+	}
+
+	aAction, bAction := a.RouteAction, b.RouteAction
+	if aAction == nil {
+		aAction = &composite.HttpRouteAction{}
+	}
+	if bAction == nil {
+		bAction = &composite.HttpRouteAction{}
+	}
+	aRewrite, bRewrite := aAction.UrlRewrite, bAction.UrlRewrite
+	if aRewrite == nil {
+		aRewrite = &composite.UrlRewrite{}
+	}
+	if bRewrite == nil {
+		bRewrite = &composite.UrlRewrite{}
+	}
+	if aRewrite.PathPrefixRewrite != bRewrite.PathPrefixRewrite || aRewrite.HostRewrite != bRewrite.HostRewrite {
+		return false
+	}
+	return weightedBackendServicesEqual(aAction, bAction)
+}
+
+// servicesEqual compares two backend service links that may be unset.
+func servicesEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return utils.EqualResourcePaths(a, b)
+}
+
+// weightedBackendServicesEqual compares the weighted backend services of two
+// route actions that may be nil.
+func weightedBackendServicesEqual(a, b *composite.HttpRouteAction) bool {
+	var aServices, bServices []*composite.WeightedBackendService
+	if a != nil {
+		aServices = a.WeightedBackendServices
+	}
+	if b != nil {
+		bServices = b.WeightedBackendServices
+	}
+	if len(aServices) != len(bServices) {
+		return false
+	}
+	for i := range aServices {
+		a := aServices[i]
+		b := bServices[i]
+		if a.Weight != b.Weight || !utils.EqualResourcePaths(a.BackendService, b.BackendService) {
+			return false
+		}
+	}
+	return true
+}
+
+// customErrorResponsePoliciesEqual compares two custom error response
+// policies that may be nil. Error services are compared as resource paths.
+func customErrorResponsePoliciesEqual(a, b *composite.CustomErrorResponsePolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !servicesEqual(a.ErrorService, b.ErrorService) {
+		return false
+	}
+	if len(a.ErrorResponseRules) != len(b.ErrorResponseRules) {
+		return false
+	}
+	for i := range a.ErrorResponseRules {
+		a := a.ErrorResponseRules[i]
+		b := b.ErrorResponseRules[i]
+		if a.Path != b.Path || a.OverrideResponseCode != b.OverrideResponseCode {
+			return false
+		}
+		if !slices.Equal(a.MatchResponseCodes, b.MatchResponseCodes) {
+			return false
+		}
+	}
+	return true
+}
+
+func routeRuleMatchesEqual(a, b *composite.HttpRouteRuleMatch) bool {
+	if a.PrefixMatch != b.PrefixMatch || a.FullPathMatch != b.FullPathMatch {
+		return false
+	}
+	if len(a.HeaderMatches) != len(b.HeaderMatches) {
+		return false
+	}
+	for i := range a.HeaderMatches {
+		a := a.HeaderMatches[i]
+		b := b.HeaderMatches[i]
+		if a.HeaderName != b.HeaderName ||
+			a.ExactMatch != b.ExactMatch ||
+			a.PrefixMatch != b.PrefixMatch ||
+			a.RegexMatch != b.RegexMatch ||
+			a.PresentMatch != b.PresentMatch ||
+			a.InvertMatch != b.InvertMatch {
+			return false
+		}
+	}
+	if len(a.QueryParameterMatches) != len(b.QueryParameterMatches) {
+		return false
+	}
+	for i := range a.QueryParameterMatches {
+		a := a.QueryParameterMatches[i]
+		b := b.QueryParameterMatches[i]
+		if a.Name != b.Name ||
+			a.ExactMatch != b.ExactMatch ||
+			a.RegexMatch != b.RegexMatch ||
+			a.PresentMatch != b.PresentMatch {
+			return false
+		}
+	}
+	return true
+}
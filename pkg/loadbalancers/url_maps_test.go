@@ -41,6 +41,128 @@ func TestComputeURLMapEquals(t *testing.T) {
 	}
 }
 
+func TestComputeURLMapEqualsRouteRules(t *testing.T) {
+	t.Parallel()
+
+	m := testCompositeURLMapWithRouteRules()
+	// Test equality, ignoring the version of the backend service links.
+	same := testCompositeURLMapWithRouteRules()
+	same.PathMatchers[0].RouteRules[0].Service = "https://www.googleapis.com/compute/v1/projects/p/global/backendServices/k8s-be-32000--uid1"
+	if !mapsEqual(m, same) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", m, same)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		modify func(*composite.UrlMap)
+	}{
+		{
+			desc: "different header match",
+			modify: func(m *composite.UrlMap) {
+				m.PathMatchers[0].RouteRules[0].MatchRules[0].HeaderMatches[0].ExactMatch = "false"
+			},
+		},
+		{
+			desc: "different url rewrite",
+			modify: func(m *composite.UrlMap) {
+				m.PathMatchers[0].RouteRules[0].RouteAction.UrlRewrite.PathPrefixRewrite = "/v2/"
+			},
+		},
+		{
+			desc: "different weight",
+			modify: func(m *composite.UrlMap) {
+				m.PathMatchers[0].RouteRules[1].RouteAction.WeightedBackendServices[1].Weight = 20
+			},
+		},
+		{
+			desc: "path rule split between backends",
+			modify: func(m *composite.UrlMap) {
+				m.PathMatchers[0].PathRules = []*composite.PathRule{
+					{
+						Paths: []string{"/web"},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 100},
+							},
+						},
+					},
+				}
+			},
+		},
+		{
+			desc: "missing route rule",
+			modify: func(m *composite.UrlMap) {
+				m.PathMatchers[0].RouteRules = m.PathMatchers[0].RouteRules[:1]
+			},
+		},
+	} {
+		diff := testCompositeURLMapWithRouteRules()
+		tc.modify(diff)
+		if mapsEqual(m, diff) {
+			t.Errorf("%s: mapsEqual(%+v, %+v) = true, want false", tc.desc, m, diff)
+		}
+	}
+}
+
+func TestComputeURLMapEqualsCustomErrorResponsePolicy(t *testing.T) {
+	t.Parallel()
+
+	withPolicy := func() *composite.UrlMap {
+		m := testCompositeURLMap()
+		m.DefaultCustomErrorResponsePolicy = &composite.CustomErrorResponsePolicy{
+			ErrorService: "global/backendBuckets/errors",
+			ErrorResponseRules: []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{
+				{MatchResponseCodes: []string{"4xx", "503"}, Path: "/errors/default.html"},
+			},
+		}
+		return m
+	}
+
+	m := withPolicy()
+	// Test equality, ignoring the version of the error service link.
+	same := withPolicy()
+	same.DefaultCustomErrorResponsePolicy.ErrorService = "https://www.googleapis.com/compute/v1/projects/p/global/backendBuckets/errors"
+	if !mapsEqual(m, same) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", m, same)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		modify func(*composite.UrlMap)
+	}{
+		{
+			desc: "missing policy",
+			modify: func(m *composite.UrlMap) {
+				m.DefaultCustomErrorResponsePolicy = nil
+			},
+		},
+		{
+			desc: "different error service",
+			modify: func(m *composite.UrlMap) {
+				m.DefaultCustomErrorResponsePolicy.ErrorService = "global/backendBuckets/other-errors"
+			},
+		},
+		{
+			desc: "different response codes",
+			modify: func(m *composite.UrlMap) {
+				m.DefaultCustomErrorResponsePolicy.ErrorResponseRules[0].MatchResponseCodes = []string{"4xx"}
+			},
+		},
+		{
+			desc: "different override response code",
+			modify: func(m *composite.UrlMap) {
+				m.DefaultCustomErrorResponsePolicy.ErrorResponseRules[0].OverrideResponseCode = 200
+			},
+		},
+	} {
+		diff := withPolicy()
+		tc.modify(diff)
+		if mapsEqual(m, diff) {
+			t.Errorf("%s: mapsEqual(%+v, %+v) = true, want false", tc.desc, m, diff)
+		}
+	}
+}
+
 func testCompositeURLMap() *composite.UrlMap {
 	return &composite.UrlMap{
 		Name:           "k8s-um-lb-name",
@@ -88,6 +210,50 @@ func testCompositeURLMap() *composite.UrlMap {
 	}
 }
 
+func testCompositeURLMapWithRouteRules() *composite.UrlMap {
+	return &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"foo.bar.com"},
+				PathMatcher: "host2d50cf9711f59181be6a5e5658e42c21",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host2d50cf9711f59181be6a5e5658e42c21",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority: 0,
+						MatchRules: []*composite.HttpRouteRuleMatch{
+							{
+								PrefixMatch:   "/api/",
+								HeaderMatches: []*composite.HttpHeaderMatch{{HeaderName: "x-canary", ExactMatch: "true"}},
+							},
+						},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+						RouteAction: &composite.HttpRouteAction{
+							UrlRewrite: &composite.UrlRewrite{PathPrefixRewrite: "/"},
+						},
+					},
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 90},
+								{BackendService: "global/backendServices/k8s-be-32500--uid1", Weight: 10},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func TestGetBackendNames(t *testing.T) {
 	t.Parallel()
 
@@ -113,6 +279,95 @@ func TestGetBackendNames(t *testing.T) {
 			},
 			wantNames: []string{"service-A", "service-B", "service-C"},
 		},
+		"UrlMap with RouteRules": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendServices/service-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultService: "global/backendServices/service-A",
+						RouteRules: []*composite.HttpRouteRule{
+							{
+								Service: "global/backendServices/service-B",
+							},
+							{
+								RouteAction: &composite.HttpRouteAction{
+									WeightedBackendServices: []*composite.WeightedBackendService{
+										{BackendService: "global/backendServices/service-C", Weight: 50},
+										{BackendService: "global/backendServices/service-D", Weight: 50},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B", "service-C", "service-D"},
+		},
+		"Backend buckets are skipped": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendServices/service-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultService: "global/backendServices/service-A",
+						PathRules: []*composite.PathRule{
+							{
+								Paths:   []string{"/static/*"},
+								Service: "global/backendBuckets/bucket-A",
+							},
+							{
+								Paths:   []string{"/web"},
+								Service: "global/backendServices/service-B",
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B"},
+		},
+		"Default backend bucket is skipped": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendBuckets/bucket-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultService: "global/backendBuckets/bucket-A",
+						PathRules: []*composite.PathRule{
+							{
+								Paths:   []string{"/web"},
+								Service: "global/backendServices/service-A",
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A"},
+		},
+		"Default route action": {
+			urlMap: &composite.UrlMap{
+				DefaultRouteAction: &composite.HttpRouteAction{
+					WeightedBackendServices: []*composite.WeightedBackendService{
+						{BackendService: "global/backendServices/service-A", Weight: 90},
+						{BackendService: "global/backendServices/service-B", Weight: 10},
+					},
+				},
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultRouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/service-A", Weight: 90},
+								{BackendService: "global/backendServices/service-B", Weight: 10},
+							},
+						},
+						PathRules: []*composite.PathRule{
+							{
+								Paths:   []string{"/web"},
+								Service: "global/backendServices/service-C",
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B", "service-C"},
+		},
 		"Invalid DefaultService": {
 			urlMap: &composite.UrlMap{
 				DefaultService: "/global/backendServices/service-A",
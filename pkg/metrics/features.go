@@ -100,8 +100,10 @@ const (
 	transparentHealthChecks = feature("TransparentHC")
 
 	// FrontendConfig Features
-	sslPolicy      = feature("SSLPolicy")
-	httpsRedirects = feature("HTTPSRedirects")
+	sslPolicy            = feature("SSLPolicy")
+	httpsRedirects       = feature("HTTPSRedirects")
+	customErrorResponses = feature("CustomErrorResponses")
+	edgeSecurityPolicy   = feature("EdgeSecurityPolicy")
 
 	l4ILBService      = feature("L4ILBService")
 	l4ILBGlobalAccess = feature("L4ILBGlobalAccess")
@@ -251,6 +253,12 @@ func featuresForIngress(ing *v1.Ingress, fc *frontendconfigv1beta1.FrontendConfi
 		if fc.Spec.RedirectToHttps != nil && fc.Spec.RedirectToHttps.Enabled {
 			features = append(features, httpsRedirects)
 		}
+		if fc.Spec.CustomErrorResponsePolicy != nil {
+			features = append(features, customErrorResponses)
+		}
+		if fc.Spec.EdgeSecurityPolicy != nil && *fc.Spec.EdgeSecurityPolicy != "" {
+			features = append(features, edgeSecurityPolicy)
+		}
 	}
 
 	logger.V(4).Info("Features for ingress", "ingressKey", ingKey, "ingressFeatures", features)
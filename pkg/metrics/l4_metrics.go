@@ -32,6 +32,12 @@ const (
 	l4LabelStrongSessionAffinity = "strong_session_affinity"
 	l4LabelWeightedLBPodsPerNode = "weighted_lb_pods_per_node"
 	l4LabelBackendType           = "backend_type"
+	l4LabelIPStack               = "ip_stack"
+
+	// ipStackIPv4, ipStackIPv6 and ipStackDualStack are the values of the ip_stack label.
+	ipStackIPv4      = "IPv4"
+	ipStackIPv6      = "IPv6"
+	ipStackDualStack = "DualStack"
 )
 
 var (
@@ -40,7 +46,7 @@ var (
 			Name: "l4_ilbs_count",
 			Help: "Metric containing the number of ILBs that can be filtered by feature labels and status",
 		},
-		[]string{l4LabelStatus, l4LabelMultinet, l4LabelWeightedLBPodsPerNode},
+		[]string{l4LabelStatus, l4LabelMultinet, l4LabelWeightedLBPodsPerNode, l4LabelIPStack},
 	)
 
 	l4NetLBCount = prometheus.NewGaugeVec(
@@ -48,7 +54,7 @@ var (
 			Name: "l4_netlbs_count",
 			Help: "Metric containing the number of NetLBs that can be filtered by feature labels and status",
 		},
-		[]string{l4LabelStatus, l4LabelMultinet, l4LabelStrongSessionAffinity, l4LabelWeightedLBPodsPerNode, l4LabelBackendType},
+		[]string{l4LabelStatus, l4LabelMultinet, l4LabelStrongSessionAffinity, l4LabelWeightedLBPodsPerNode, l4LabelBackendType, l4LabelIPStack},
 	)
 )
 
@@ -138,6 +144,20 @@ func ipFamiliesToString(ipFamilies []corev1.IPFamily) string {
 	return strings.Join(ipFamiliesStrings, ",")
 }
 
+// ipStack returns the IP stack of a service with the given comma separated
+// ipFamilies, which distinguishes IPv6 only services from IPv4 and DualStack
+// services. Services without ipFamilies are IPv4.
+func ipStack(ipFamilies string) string {
+	switch {
+	case strings.Contains(ipFamilies, ","):
+		return ipStackDualStack
+	case ipFamilies == string(corev1.IPv6Protocol):
+		return ipStackIPv6
+	default:
+		return ipStackIPv4
+	}
+}
+
 func (im *ControllerMetrics) exportL4ILBsMetrics() {
 	im.Lock()
 	defer im.Unlock()
@@ -148,6 +168,7 @@ func (im *ControllerMetrics) exportL4ILBsMetrics() {
 			l4LabelStatus:                string(getStatusConsideringPersistentError(&svcState)),
 			l4LabelMultinet:              strconv.FormatBool(svcState.Multinetwork),
 			l4LabelWeightedLBPodsPerNode: strconv.FormatBool(svcState.WeightedLBPodsPerNode),
+			l4LabelIPStack:               ipStack(svcState.IPFamilies),
 		}).Inc()
 	}
 	im.logger.V(3).Info("L4 ILB usage metrics exported")
@@ -165,6 +186,7 @@ func (im *ControllerMetrics) exportL4NetLBsMetrics() {
 			l4LabelStrongSessionAffinity: strconv.FormatBool(svcState.StrongSessionAffinity),
 			l4LabelWeightedLBPodsPerNode: strconv.FormatBool(svcState.WeightedLBPodsPerNode),
 			l4LabelBackendType:           string(svcState.BackendType),
+			l4LabelIPStack:               ipStack(svcState.IPFamilies),
 		}).Inc()
 	}
 	im.logger.V(3).Info("L4 NetLB usage metrics exported")
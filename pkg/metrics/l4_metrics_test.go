@@ -85,6 +85,23 @@ func TestExportILBMetric(t *testing.T) {
 		FirstSyncErrorTime: &notExceedingPersistentErrorThresholdTime,
 	})
 
+	newMetrics.SetL4ILBService("svc-ipv6-only", L4ServiceState{
+		L4FeaturesServiceLabels: L4FeaturesServiceLabels{Multinetwork: false, WeightedLBPodsPerNode: false},
+		L4DualStackServiceLabels: L4DualStackServiceLabels{
+			IPFamilies:     "IPv6",
+			IPFamilyPolicy: "SingleStack",
+		},
+		Status: StatusSuccess,
+	})
+	newMetrics.SetL4ILBService("svc-dual-stack", L4ServiceState{
+		L4FeaturesServiceLabels: L4FeaturesServiceLabels{Multinetwork: false, WeightedLBPodsPerNode: false},
+		L4DualStackServiceLabels: L4DualStackServiceLabels{
+			IPFamilies:     "IPv6,IPv4",
+			IPFamilyPolicy: "RequireDualStack",
+		},
+		Status: StatusSuccess,
+	})
+
 	newMetrics.exportL4ILBsMetrics()
 
 	verifyL4ILBMetric(t, 1, StatusSuccess, isMultinetwork, isWeightedLBPodsPerNode)
@@ -93,10 +110,16 @@ func TestExportILBMetric(t *testing.T) {
 	verifyL4ILBMetric(t, 1, StatusUserError, notMultinetwork, notWeightedLBPodsPerNode)
 	verifyL4ILBMetric(t, 2, StatusError, notMultinetwork, notWeightedLBPodsPerNode)
 	verifyL4ILBMetric(t, 1, StatusPersistentError, notMultinetwork, notWeightedLBPodsPerNode)
+	verifyL4ILBMetricWithIPStack(t, 1, StatusSuccess, notMultinetwork, notWeightedLBPodsPerNode, ipStackIPv6)
+	verifyL4ILBMetricWithIPStack(t, 1, StatusSuccess, notMultinetwork, notWeightedLBPodsPerNode, ipStackDualStack)
 }
 
 func verifyL4ILBMetric(t *testing.T, expectedCount int, status L4ServiceStatus, multinet string, weightedLBPodsPerNode string) {
-	countFloat := testutil.ToFloat64(l4ILBCount.With(prometheus.Labels{l4LabelStatus: string(status), l4LabelMultinet: multinet, l4LabelWeightedLBPodsPerNode: weightedLBPodsPerNode}))
+	verifyL4ILBMetricWithIPStack(t, expectedCount, status, multinet, weightedLBPodsPerNode, ipStackIPv4)
+}
+
+func verifyL4ILBMetricWithIPStack(t *testing.T, expectedCount int, status L4ServiceStatus, multinet string, weightedLBPodsPerNode string, ipStack string) {
+	countFloat := testutil.ToFloat64(l4ILBCount.With(prometheus.Labels{l4LabelStatus: string(status), l4LabelMultinet: multinet, l4LabelWeightedLBPodsPerNode: weightedLBPodsPerNode, l4LabelIPStack: ipStack}))
 	actualCount := int(math.Round(countFloat))
 	if expectedCount != actualCount {
 		t.Errorf("expected value %d but got %d", expectedCount, actualCount)
@@ -163,6 +186,15 @@ func TestExportNetLBMetric(t *testing.T) {
 		FirstSyncErrorTime: &notExceedingPersistentErrorThresholdTime,
 	})
 
+	newMetrics.SetL4NetLBService("svc-ipv6-only", L4ServiceState{
+		L4FeaturesServiceLabels: L4FeaturesServiceLabels{Multinetwork: false, StrongSessionAffinity: false, WeightedLBPodsPerNode: false, BackendType: L4BackendTypeInstanceGroup},
+		L4DualStackServiceLabels: L4DualStackServiceLabels{
+			IPFamilies:     "IPv6",
+			IPFamilyPolicy: "SingleStack",
+		},
+		Status: StatusSuccess,
+	})
+
 	newMetrics.exportL4NetLBsMetrics()
 
 	verifyL4NetLBMetric(t, 2, StatusSuccess, isMultinetwork, disabledStrongSessionAffinity, notWeightedLBPodsPerNode, L4BackendTypeNEG)
@@ -172,12 +204,17 @@ func TestExportNetLBMetric(t *testing.T) {
 	verifyL4NetLBMetric(t, 1, StatusUserError, notMultinetwork, enabledStrongSessionAffinity, notWeightedLBPodsPerNode, L4BackendTypeInstanceGroup)
 	verifyL4NetLBMetric(t, 2, StatusError, notMultinetwork, disabledStrongSessionAffinity, notWeightedLBPodsPerNode, L4BackendTypeInstanceGroup)
 	verifyL4NetLBMetric(t, 1, StatusPersistentError, notMultinetwork, disabledStrongSessionAffinity, notWeightedLBPodsPerNode, L4BackendTypeInstanceGroup)
+	verifyL4NetLBMetricWithIPStack(t, 1, StatusSuccess, notMultinetwork, disabledStrongSessionAffinity, notWeightedLBPodsPerNode, L4BackendTypeInstanceGroup, ipStackIPv6)
 }
 
 func verifyL4NetLBMetric(t *testing.T, expectedCount int, status L4ServiceStatus, multinet string, strongSessionAffinity string, weightedLBPodsPerNode string, backendType L4BackendType) {
-	countFloat := testutil.ToFloat64(l4NetLBCount.With(prometheus.Labels{l4LabelStatus: string(status), l4LabelMultinet: multinet, l4LabelStrongSessionAffinity: strongSessionAffinity, l4LabelWeightedLBPodsPerNode: weightedLBPodsPerNode, l4LabelBackendType: string(backendType)}))
+	verifyL4NetLBMetricWithIPStack(t, expectedCount, status, multinet, strongSessionAffinity, weightedLBPodsPerNode, backendType, ipStackIPv4)
+}
+
+func verifyL4NetLBMetricWithIPStack(t *testing.T, expectedCount int, status L4ServiceStatus, multinet string, strongSessionAffinity string, weightedLBPodsPerNode string, backendType L4BackendType, ipStack string) {
+	countFloat := testutil.ToFloat64(l4NetLBCount.With(prometheus.Labels{l4LabelStatus: string(status), l4LabelMultinet: multinet, l4LabelStrongSessionAffinity: strongSessionAffinity, l4LabelWeightedLBPodsPerNode: weightedLBPodsPerNode, l4LabelBackendType: string(backendType), l4LabelIPStack: ipStack}))
 	actualCount := int(math.Round(countFloat))
 	if expectedCount != actualCount {
-		t.Errorf("expected value %d but got %d for status: %q, multinet: %q, ssa: %q, backendType: %q", expectedCount, actualCount, status, multinet, strongSessionAffinity, backendType)
+		t.Errorf("expected value %d but got %d for status: %q, multinet: %q, ssa: %q, backendType: %q, ipStack: %q", expectedCount, actualCount, status, multinet, strongSessionAffinity, backendType, ipStack)
 	}
 }
@@ -921,6 +921,39 @@ var (
 			[]utils.ServicePort{testServicePorts[7]},
 			[]feature{servicePort, regionalExternalServicePort, neg},
 		},
+		{
+			"custom error responses and edge security policy",
+			&v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      "ingress23",
+				},
+				Spec: v1.IngressSpec{
+					DefaultBackend: &v1.IngressBackend{
+						Service: &v1.IngressServiceBackend{
+							Name: "dummy-service",
+							Port: v1.ServiceBackendPort{
+								Number: int32(80),
+							},
+						},
+					},
+					Rules: []v1.IngressRule{},
+				},
+			},
+			&frontendconfigv1beta1.FrontendConfig{
+				Spec: frontendconfigv1beta1.FrontendConfigSpec{
+					CustomErrorResponsePolicy: &frontendconfigv1beta1.CustomErrorResponsePolicy{
+						ErrorBackendBucket: "errors",
+						ErrorResponseRules: []frontendconfigv1beta1.CustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+					},
+					EdgeSecurityPolicy: utils.NewStringPointer("edge-policy"),
+				},
+			},
+			[]feature{ingress, externalIngress, httpEnabled,
+				customErrorResponses, edgeSecurityPolicy},
+			[]utils.ServicePort{},
+			nil,
+		},
 	}
 )
 
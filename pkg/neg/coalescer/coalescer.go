@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coalescer
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/klog/v2"
+)
+
+type opType string
+
+const (
+	attachOp opType = "Attach"
+	detachOp opType = "Detach"
+)
+
+// negKey identifies the queue of the pending operations on a NEG.
+type negKey struct {
+	name string
+	zone string
+}
+
+// target identifies the API call on the NEG an operation can be merged into.
+type target struct {
+	op      opType
+	version meta.Version
+}
+
+// operation is an attach or detach operation submitted by a syncer.
+type operation struct {
+	target
+	endpoints []*composite.NetworkEndpoint
+	logger    klog.Logger
+	// done receives the error of the API call which included the endpoints.
+	done chan error
+	// dispatched is true once the operation is included in an API call.
+	dispatched bool
+}
+
+// Coalescer bounds the number of concurrent attach and detach calls to the NEG
+// API across all syncers, and merges the operations pending for the same NEG
+// into fuller batches while they wait.
+// The NEG API updates a single NEG per call, and each NEG is synced by a single
+// syncer, so the merged operations are the batches a syncer issued for a NEG
+// before the previous ones ran, e.g. during a node drain. Operations on
+// different NEGs only share the concurrency budget.
+type Coalescer struct {
+	cloud negtypes.NetworkEndpointGroupCloud
+	// maxBatchSize is the maximum number of endpoints in an API call.
+	maxBatchSize int
+	// budget holds a token for each API call in progress.
+	budget chan struct{}
+
+	lock sync.Mutex
+	// queues contains the pending operations of each NEG.
+	queues map[negKey][]*operation
+
+	logger klog.Logger
+}
+
+// NewCoalescer returns a Coalescer which makes at most maxConcurrency attach
+// and detach calls with at most maxBatchSize endpoints each at a time.
+func NewCoalescer(cloud negtypes.NetworkEndpointGroupCloud, maxBatchSize, maxConcurrency int, logger klog.Logger) *Coalescer {
+	return &Coalescer{
+		cloud:        cloud,
+		maxBatchSize: maxBatchSize,
+		budget:       make(chan struct{}, maxConcurrency),
+		queues:       make(map[negKey][]*operation),
+		logger:       logger.WithName("Coalescer"),
+	}
+}
+
+// AttachNetworkEndpoints attaches the endpoints to the NEG, along with the
+// endpoints of the other attach operations pending for the NEG. It blocks
+// until the API call which includes the endpoints completes and returns its
+// error.
+func (c *Coalescer) AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
+	return c.do(negKey{name: name, zone: zone}, target{op: attachOp, version: version}, endpoints, logger)
+}
+
+// DetachNetworkEndpoints detaches the endpoints from the NEG, along with the
+// endpoints of the other detach operations pending for the NEG. It blocks
+// until the API call which includes the endpoints completes and returns its
+// error.
+func (c *Coalescer) DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
+	return c.do(negKey{name: name, zone: zone}, target{op: detachOp, version: version}, endpoints, logger)
+}
+
+// do queues the operation, and waits for the concurrency budget to run it
+// unless it was merged into the API call of another operation meanwhile.
+func (c *Coalescer) do(key negKey, t target, endpoints []*composite.NetworkEndpoint, logger klog.Logger) error {
+	op := &operation{
+		target:    t,
+		endpoints: endpoints,
+		logger:    logger,
+		done:      make(chan error, 1),
+	}
+	c.lock.Lock()
+	c.queues[key] = append(c.queues[key], op)
+	c.lock.Unlock()
+
+	c.budget <- struct{}{}
+	batch := c.takeBatch(key, op)
+	if len(batch) == 0 {
+		// The operation is part of the API call of another operation.
+		<-c.budget
+		return <-op.done
+	}
+	err := c.call(key, batch)
+	<-c.budget
+	for _, o := range batch {
+		o.done <- err
+	}
+	return <-op.done
+}
+
+// takeBatch removes the operation from the queue of its NEG along with the
+// operations with the same target that fit in the batch, oldest first. It
+// returns nil if the operation has already been dispatched.
+func (c *Coalescer) takeBatch(key negKey, op *operation) []*operation {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if op.dispatched {
+		return nil
+	}
+
+	batch := []*operation{op}
+	size := len(op.endpoints)
+	op.dispatched = true
+	var remaining []*operation
+	for _, o := range c.queues[key] {
+		if o.dispatched {
+			continue
+		}
+		if o.target == op.target && size+len(o.endpoints) <= c.maxBatchSize {
+			batch = append(batch, o)
+			size += len(o.endpoints)
+			o.dispatched = true
+			continue
+		}
+		remaining = append(remaining, o)
+	}
+	if len(remaining) == 0 {
+		delete(c.queues, key)
+	} else {
+		c.queues[key] = remaining
+	}
+	return batch
+}
+
+// call makes the API call for the endpoints of the batch of operations.
+func (c *Coalescer) call(key negKey, batch []*operation) error {
+	t := batch[0].target
+	var endpoints []*composite.NetworkEndpoint
+	for _, o := range batch {
+		endpoints = append(endpoints, o.endpoints...)
+	}
+	if len(batch) > 1 {
+		c.logger.V(2).Info("Merged network endpoint operations", "operation", t.op, "neg", key.name, "zone", key.zone, "operationCount", len(batch), "endpointCount", len(endpoints))
+	}
+
+	logger := batch[0].logger
+	if t.op == attachOp {
+		return c.cloud.AttachNetworkEndpoints(key.name, key.zone, endpoints, t.version, logger)
+	}
+	return c.cloud.DetachNetworkEndpoints(key.name, key.zone, endpoints, t.version, logger)
+}
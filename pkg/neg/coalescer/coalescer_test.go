@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coalescer
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	testZone    = "zone1"
+	testNegName = "neg1"
+)
+
+// countingCloud counts the attach and detach calls made to the fake NEG cloud,
+// the maximum number of concurrent calls, and fails the calls for failNEG.
+type countingCloud struct {
+	negtypes.NetworkEndpointGroupCloud
+
+	lock        sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+	failNEG     string
+}
+
+func (c *countingCloud) begin(name string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls++
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	if name == c.failNEG {
+		return fmt.Errorf("failed to update NEG %s", name)
+	}
+	return nil
+}
+
+func (c *countingCloud) end() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.inFlight--
+}
+
+func (c *countingCloud) AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
+	defer c.end()
+	if err := c.begin(name); err != nil {
+		return err
+	}
+	// Give other calls a chance to run concurrently.
+	time.Sleep(time.Millisecond)
+	return c.NetworkEndpointGroupCloud.AttachNetworkEndpoints(name, zone, endpoints, version, logger)
+}
+
+func (c *countingCloud) DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
+	defer c.end()
+	if err := c.begin(name); err != nil {
+		return err
+	}
+	time.Sleep(time.Millisecond)
+	return c.NetworkEndpointGroupCloud.DetachNetworkEndpoints(name, zone, endpoints, version, logger)
+}
+
+func newCountingCloud() *countingCloud {
+	return &countingCloud{NetworkEndpointGroupCloud: negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")}
+}
+
+func testEndpoints(prefix string, count int) []*composite.NetworkEndpoint {
+	var ret []*composite.NetworkEndpoint
+	for i := 0; i < count; i++ {
+		ret = append(ret, &composite.NetworkEndpoint{IpAddress: fmt.Sprintf("%s.%d", prefix, i), Instance: "instance1", Port: 80})
+	}
+	return ret
+}
+
+// queueOperations submits the operations with the concurrency budget taken,
+// and releases it once all of them are queued. It returns the errors of the
+// operations once they are done.
+func queueOperations(t *testing.T, c *Coalescer, ops []func() error) []error {
+	t.Helper()
+	for i := 0; i < cap(c.budget); i++ {
+		c.budget <- struct{}{}
+	}
+
+	errs := make([]error, len(ops))
+	var wg sync.WaitGroup
+	for i, op := range ops {
+		wg.Add(1)
+		go func(i int, op func() error) {
+			defer wg.Done()
+			errs[i] = op()
+		}(i, op)
+	}
+
+	if err := waitForQueuedOperations(c, len(ops)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < cap(c.budget); i++ {
+		<-c.budget
+	}
+	wg.Wait()
+	return errs
+}
+
+func waitForQueuedOperations(c *Coalescer, count int) error {
+	for i := 0; i < 1000; i++ {
+		c.lock.Lock()
+		queued := 0
+		for _, ops := range c.queues {
+			queued += len(ops)
+		}
+		c.lock.Unlock()
+		if queued == count {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for %d operations to be queued", count)
+}
+
+func TestCoalescerMergesPendingOperations(t *testing.T) {
+	t.Parallel()
+	cloud := newCountingCloud()
+	c := NewCoalescer(cloud, 10, 1, klog.TODO())
+
+	var ops []func() error
+	var want []*composite.NetworkEndpoint
+	for i := 0; i < 5; i++ {
+		endpoints := testEndpoints(fmt.Sprintf("10.0.%d", i), 2)
+		want = append(want, endpoints...)
+		ops = append(ops, func() error {
+			return c.AttachNetworkEndpoints(testNegName, testZone, endpoints, meta.VersionGA, klog.TODO())
+		})
+	}
+	for i, err := range queueOperations(t, c, ops) {
+		if err != nil {
+			t.Errorf("operation %d returned error %v, want nil", i, err)
+		}
+	}
+
+	if cloud.calls != 1 {
+		t.Errorf("got %d attach calls, want 1 call for all the pending operations", cloud.calls)
+	}
+	got, err := cloud.ListNetworkEndpoints(testNegName, testZone, false, meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("ListNetworkEndpoints() = %v, want nil", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d endpoints in the NEG, want %d", len(got), len(want))
+	}
+	if len(c.queues) != 0 {
+		t.Errorf("got %d queues left, want 0", len(c.queues))
+	}
+}
+
+func TestCoalescerBatchBoundaries(t *testing.T) {
+	t.Parallel()
+	cloud := newCountingCloud()
+	c := NewCoalescer(cloud, 4, 1, klog.TODO())
+
+	attach := func(negName, zone string, endpoints []*composite.NetworkEndpoint) func() error {
+		return func() error {
+			return c.AttachNetworkEndpoints(negName, zone, endpoints, meta.VersionGA, klog.TODO())
+		}
+	}
+	detach := func(negName string, endpoints []*composite.NetworkEndpoint) func() error {
+		return func() error {
+			return c.DetachNetworkEndpoints(negName, testZone, endpoints, meta.VersionGA, klog.TODO())
+		}
+	}
+	ops := []func() error{
+		// These fill one batch of 4 endpoints.
+		attach(testNegName, testZone, testEndpoints("10.0.1", 2)),
+		attach(testNegName, testZone, testEndpoints("10.0.2", 2)),
+		// This does not fit in the batch.
+		attach(testNegName, testZone, testEndpoints("10.0.3", 1)),
+		// These target other NEGs, zones or operations.
+		attach("neg2", testZone, testEndpoints("10.0.4", 1)),
+		attach(testNegName, "zone2", testEndpoints("10.0.5", 1)),
+		detach(testNegName, testEndpoints("10.0.6", 1)),
+	}
+	for i, err := range queueOperations(t, c, ops) {
+		if err != nil {
+			t.Errorf("operation %d returned error %v, want nil", i, err)
+		}
+	}
+
+	if cloud.calls != 5 {
+		t.Errorf("got %d calls, want 5", cloud.calls)
+	}
+}
+
+func TestCoalescerReturnsErrorToAllMergedOperations(t *testing.T) {
+	t.Parallel()
+	cloud := newCountingCloud()
+	cloud.failNEG = testNegName
+	c := NewCoalescer(cloud, 10, 1, klog.TODO())
+
+	ops := []func() error{
+		func() error {
+			return c.DetachNetworkEndpoints(testNegName, testZone, testEndpoints("10.0.1", 1), meta.VersionGA, klog.TODO())
+		},
+		func() error {
+			return c.DetachNetworkEndpoints(testNegName, testZone, testEndpoints("10.0.2", 1), meta.VersionGA, klog.TODO())
+		},
+		func() error {
+			return c.DetachNetworkEndpoints("neg2", testZone, testEndpoints("10.0.3", 1), meta.VersionGA, klog.TODO())
+		},
+	}
+	errs := queueOperations(t, c, ops)
+	for i, wantErr := range []bool{true, true, false} {
+		if gotErr := errs[i] != nil; gotErr != wantErr {
+			t.Errorf("operation %d returned error %v, want error: %v", i, errs[i], wantErr)
+		}
+	}
+}
+
+func TestCoalescerConcurrencyBudget(t *testing.T) {
+	t.Parallel()
+	cloud := newCountingCloud()
+	maxConcurrency := 3
+	c := NewCoalescer(cloud, 10, maxConcurrency, klog.TODO())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			negName := fmt.Sprintf("neg-%d", i)
+			if err := c.AttachNetworkEndpoints(negName, testZone, testEndpoints("10.0.1", 1), meta.VersionGA, klog.TODO()); err != nil {
+				t.Errorf("AttachNetworkEndpoints(%s) = %v, want nil", negName, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cloud.maxInFlight > maxConcurrency {
+		t.Errorf("got %d concurrent calls, want at most %d", cloud.maxInFlight, maxConcurrency)
+	}
+	if cloud.calls != 20 {
+		t.Errorf("got %d calls, want 20 calls for the different NEGs", cloud.calls)
+	}
+}
@@ -18,6 +18,7 @@ package neg
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	nodetopologyv1 "github.com/GoogleCloudPlatform/gke-networking-api/apis/nodetopology/v1"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/cloud-provider/service/helpers"
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	svcnegv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/controller/translator"
 	"k8s.io/ingress-gce/pkg/flags"
@@ -43,6 +45,7 @@ import (
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	syncMetrics "k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
+	"k8s.io/ingress-gce/pkg/neg/sharding"
 	"k8s.io/ingress-gce/pkg/neg/syncers/labels"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/network"
@@ -72,11 +75,16 @@ type Controller struct {
 	zoneGetter      *zonegetter.ZoneGetter
 	networkResolver network.Resolver
 
-	hasSynced                   func() bool
-	ingressLister               cache.Indexer
-	serviceLister               cache.Indexer
-	client                      kubernetes.Interface
-	defaultBackendService       utils.ServicePort
+	hasSynced     func() bool
+	ingressLister cache.Indexer
+	// frontendConfigLister is nil if the FrontendConfig CRD is not enabled.
+	frontendConfigLister  cache.Indexer
+	serviceLister         cache.Indexer
+	client                kubernetes.Interface
+	defaultBackendService utils.ServicePort
+	// ingressClassifier determines whether an Ingress is processed by the
+	// ingress controller and the type of its load balancer.
+	ingressClassifier           utils.IngressClassifier
 	enableASM                   bool
 	asmServiceNEGSkipNamespaces []string
 
@@ -115,6 +123,17 @@ type Controller struct {
 	// runL4ForNetLB indicates if the controller can create NEGs for L4 NetLB services.
 	runL4ForNetLB bool
 
+	// negNameTemplate generates the names of the exposed NEGs of Services
+	// without a custom name or a NEG name template. It is nil if the names
+	// are generated by the namer.
+	negNameTemplate *namer.NEGNameTemplate
+	// clusterName is the name of the cluster in NEG name templates.
+	clusterName string
+
+	// shards assigns the Services to the replicas of the NEG controller. It
+	// is nil if the controller is not sharded and processes all Services.
+	shards *sharding.Coordinator
+
 	stopCh <-chan struct{}
 	logger klog.Logger
 }
@@ -126,6 +145,7 @@ func NewController(
 	eventRecorderClient kubernetes.Interface,
 	kubeSystemUID types.UID,
 	ingressInformer cache.SharedIndexInformer,
+	frontendConfigInformer cache.SharedIndexInformer,
 	serviceInformer cache.SharedIndexInformer,
 	podInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
@@ -137,6 +157,7 @@ func NewController(
 	hasSynced func() bool,
 	l4Namer namer.L4ResourcesNamer,
 	defaultBackendService utils.ServicePort,
+	ingressClassifier utils.IngressClassifier,
 	cloud negtypes.NetworkEndpointGroupCloud,
 	zoneGetter *zonegetter.ZoneGetter,
 	namer negtypes.NetworkEndpointGroupNamer,
@@ -149,10 +170,11 @@ func NewController(
 	enableDualStackNEG bool,
 	enableAsm bool,
 	asmServiceNEGSkipNamespaces []string,
-	lpConfig labels.PodLabelPropagationConfig,
+	lpConfig labels.PodLabelPropagationConfigSource,
 	enableMultiNetworking bool,
 	enableIngressRegionalExternal bool,
 	runL4ForNetLB bool,
+	shards *sharding.Coordinator,
 	stopCh <-chan struct{},
 	logger klog.Logger,
 ) *Controller {
@@ -237,6 +259,7 @@ func NewController(
 		namer:                          namer,
 		l4Namer:                        l4Namer,
 		defaultBackendService:          defaultBackendService,
+		ingressClassifier:              ingressClassifier,
 		hasSynced:                      hasSynced,
 		ingressLister:                  ingressInformer.GetIndexer(),
 		serviceLister:                  serviceInformer.GetIndexer(),
@@ -251,9 +274,23 @@ func NewController(
 		enableIngressRegionalExternal:  enableIngressRegionalExternal,
 		enableMultiSubnetClusterPhase1: enableMultiSubnetClusterPhase1,
 		runL4ForNetLB:                  runL4ForNetLB,
+		negNameTemplate:                negNameTemplateFromFlags(logger),
+		clusterName:                    flags.F.GKEClusterName,
+		shards:                         shards,
 		stopCh:                         stopCh,
 		logger:                         logger,
 	}
+	if frontendConfigInformer != nil {
+		negController.frontendConfigLister = frontendConfigInformer.GetIndexer()
+	}
+	if shards != nil {
+		manager.shards = shards
+		manager.shardGCGracePeriod = gcPeriod
+		shards.AddHandler(sharding.Handler{
+			OnAcquire: negController.enqueueShardServices,
+			OnRelease: func(int) { manager.StopUnownedSyncers() },
+		})
+	}
 	if enableMultiSubnetClusterPhase1 {
 		negController.nodeTopologyQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "neg_node_topology_queue")
 	}
@@ -261,7 +298,7 @@ func NewController(
 	ingressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			addIng := obj.(*v1.Ingress)
-			if !utils.IsGLBCIngress(addIng) {
+			if !negController.isGLBCIngress(addIng) {
 				logger.V(4).Info("Ignoring add for ingress based on annotation", "ingress", klog.KObj(addIng), "annotation", annotations.IngressClassKey)
 				return
 			}
@@ -269,7 +306,7 @@ func NewController(
 		},
 		DeleteFunc: func(obj interface{}) {
 			delIng := obj.(*v1.Ingress)
-			if !utils.IsGLBCIngress(delIng) {
+			if !negController.isGLBCIngress(delIng) {
 				logger.V(4).Info("Ignoring delete for ingress based on annotation", "ingress", klog.KObj(delIng), "annotation", annotations.IngressClassKey)
 				return
 			}
@@ -280,17 +317,32 @@ func NewController(
 			curIng := cur.(*v1.Ingress)
 			// Check if ingress class changed and previous class was a GCE ingress
 			// Ingress class change may require cleanup so enqueue related services
-			if !utils.IsGLBCIngress(curIng) && !utils.IsGLBCIngress(oldIng) {
+			if !negController.isGLBCIngress(curIng) && !negController.isGLBCIngress(oldIng) {
 				logger.V(4).Info("Ignoring update for ingress based on annotation", "ingress", klog.KObj(curIng), "annotation", annotations.IngressClassKey)
 				return
 			}
-			keys := gatherIngressServiceKeys(oldIng)
-			keys = keys.Union(gatherIngressServiceKeys(curIng))
+			keys := gatherIngressServiceKeys(oldIng, negController.frontendConfigForIngress(oldIng))
+			keys = keys.Union(gatherIngressServiceKeys(curIng, negController.frontendConfigForIngress(curIng)))
 			for _, key := range keys.List() {
 				negController.enqueueService(cache.ExplicitKey(key))
 			}
 		},
 	})
+	if frontendConfigInformer != nil {
+		frontendConfigInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: negController.enqueueFrontendConfigServices,
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				negController.enqueueFrontendConfigServices(obj)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				negController.enqueueFrontendConfigServices(old)
+				negController.enqueueFrontendConfigServices(cur)
+			},
+		})
+	}
 	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pod := obj.(*apiv1.Pod)
@@ -403,6 +455,11 @@ func (c *Controller) Run() {
 	}()
 	go c.reflector.Run(c.stopCh)
 	go c.syncerMetrics.Run(c.stopCh)
+	if c.shards != nil {
+		// Start acquiring shards once the informers are synced, so that all
+		// the Services of the acquired shards are enqueued.
+		go c.shards.Run(c.stopCh)
+	}
 	<-c.stopCh
 }
 
@@ -519,6 +576,12 @@ func (c *Controller) processService(key string) error {
 		c.manager.StopSyncer(namespace, name)
 		return nil
 	}
+	if c.shards != nil && !c.shards.Owns(namespace, name) {
+		c.logger.V(3).Info("Skipping service owned by another replica", "service", key, "shard", c.shards.Shard(namespace, name))
+		c.syncerMetrics.DeleteNegService(key)
+		c.manager.StopSyncer(namespace, name)
+		return nil
+	}
 	service := obj.(*apiv1.Service)
 	if service == nil {
 		return fmt.Errorf("cannot convert to Service (%T)", obj)
@@ -559,6 +622,12 @@ func (c *Controller) processService(key string) error {
 	if err := c.mergeVmIpNEGsPortInfo(service, types.NamespacedName{Namespace: namespace, Name: name}, svcPortInfoMap, &negUsage, networkInfo); err != nil {
 		return err
 	}
+	if err := setExternalNEGsPortInfo(service, svcPortInfoMap); err != nil {
+		return err
+	}
+	if flags.F.EnableNEGTopologyAwareHints {
+		setTopologyAwareNEGsPortInfo(service, svcPortInfoMap)
+	}
 	if len(svcPortInfoMap) != 0 {
 		c.logger.V(2).Info("Syncing service", "service", key)
 		// TODO(cheungdavid): Remove this validation when single stack ipv6 endpoint is supported
@@ -622,8 +691,8 @@ func (c *Controller) mergeIngressPortInfo(service *apiv1.Service, name types.Nam
 	// handle NEGs used by ingress
 	if negAnnotation != nil && negAnnotation.NEGEnabledForIngress() {
 		// Only service ports referenced by ingress are synced for NEG
-		ings := getIngressServicesFromStore(c.ingressLister, service)
-		ingressSvcPortTuples := gatherPortMappingUsedByIngress(ings, service, c.logger)
+		ings := getIngressServicesFromStore(c.ingressLister, service, c.isGLBCIngress, c.frontendConfigForIngress)
+		ingressSvcPortTuples := gatherPortMappingUsedByIngress(ings, service, c.isGLBCIngress, c.frontendConfigForIngress, c.logger)
 		ingressPortInfoMap := negtypes.NewPortInfoMap(name.Namespace, name.Name, ingressSvcPortTuples, c.namer, true, nil, networkInfo)
 		if err := portInfoMap.Merge(ingressPortInfoMap); err != nil {
 			return fmt.Errorf("failed to merge service ports referenced by ingress (%v): %w", ingressPortInfoMap, err)
@@ -664,7 +733,18 @@ func (c *Controller) mergeStandaloneNEGsPortInfo(service *apiv1.Service, name ty
 		}
 		negUsage.CustomNamedNeg = len(customNames)
 
-		if err := portInfoMap.Merge(negtypes.NewPortInfoMap(name.Namespace, name.Name, exposedNegSvcPort, c.namer, true, customNames, networkInfo)); err != nil {
+		if err := c.addTemplatedNEGNames(name, negAnnotation, exposedNegSvcPort, customNames); err != nil {
+			return err
+		}
+
+		exposedPortInfoMap := negtypes.NewPortInfoMap(name.Namespace, name.Name, exposedNegSvcPort, c.namer, true, customNames, networkInfo)
+		if negAnnotation.AdoptExisting {
+			for key, portInfo := range exposedPortInfoMap {
+				portInfo.AdoptExistingNEG = true
+				exposedPortInfoMap[key] = portInfo
+			}
+		}
+		if err := portInfoMap.Merge(exposedPortInfoMap); err != nil {
 			return fmt.Errorf("failed to merge service ports exposed as standalone NEGs (%v) into ingress referenced service ports (%v): %w", exposedNegSvcPort, portInfoMap, err)
 		}
 	}
@@ -672,6 +752,54 @@ func (c *Controller) mergeStandaloneNEGsPortInfo(service *apiv1.Service, name ty
 	return nil
 }
 
+// addTemplatedNEGNames adds the NEG names generated from the NEG name template
+// of the Service, or else of the controller, for the exposed ports without a
+// custom name. The NEG name template of the controller does not apply to
+// Services with Ingress enabled, as Ingress uses generated NEG names.
+func (c *Controller) addTemplatedNEGNames(name types.NamespacedName, negAnnotation *annotations.NegAnnotation, exposedNegSvcPort negtypes.SvcPortTupleSet, customNames map[negtypes.SvcPortTuple]string) error {
+	template := c.negNameTemplate
+	if negAnnotation.NameTemplate != "" {
+		if negAnnotation.NEGEnabledForIngress() {
+			return fmt.Errorf("configuration for negs in service (%s) is invalid, neg name template cannot be used with ingress enabled", name.String())
+		}
+		var err error
+		if template, err = namer.ParseNEGNameTemplate(negAnnotation.NameTemplate); err != nil {
+			return fmt.Errorf("configuration for negs in service (%s) is invalid: %w", name.String(), err)
+		}
+	} else if negAnnotation.NEGEnabledForIngress() {
+		return nil
+	}
+	if template == nil {
+		return nil
+	}
+
+	for svcPortTuple := range exposedNegSvcPort {
+		if _, ok := customNames[svcPortTuple]; ok {
+			continue
+		}
+		negName, err := template.Name(c.clusterName, name.Namespace, name.Name, svcPortTuple.Port)
+		if err != nil {
+			return fmt.Errorf("failed to generate the name of the neg for port %d of service (%s): %w", svcPortTuple.Port, name.String(), err)
+		}
+		customNames[svcPortTuple] = negName
+	}
+	return nil
+}
+
+// negNameTemplateFromFlags returns the NEG name template of the controller, or
+// nil if there is none. The template is validated by flags.Validate.
+func negNameTemplateFromFlags(logger klog.Logger) *namer.NEGNameTemplate {
+	if flags.F.NEGNameTemplate == "" {
+		return nil
+	}
+	template, err := namer.ParseNEGNameTemplate(flags.F.NEGNameTemplate)
+	if err != nil {
+		logger.Error(err, "Ignoring invalid NEG name template", "template", flags.F.NEGNameTemplate)
+		return nil
+	}
+	return template
+}
+
 // mergeVmIpNEGsPortInfo merges the PortInfo for ILB, multinet NetLB and NetLB V3 (variant with NEG default) services using GCE_VM_IP NEGs into portInfoMap
 func (c *Controller) mergeVmIpNEGsPortInfo(service *apiv1.Service, name types.NamespacedName, portInfoMap negtypes.PortInfoMap, negUsage *metricscollector.NegServiceState, networkInfo *network.NetworkInfo) error {
 	wantsILB, _ := annotations.WantsL4ILB(service)
@@ -704,7 +832,75 @@ func (c *Controller) mergeVmIpNEGsPortInfo(service *apiv1.Service, name types.Na
 		l4LBType = negtypes.L4ExternalLB
 	}
 
-	return portInfoMap.Merge(negtypes.NewPortInfoMapForVMIPNEG(name.Namespace, name.Name, c.l4Namer, onlyLocal, networkInfo, l4LBType))
+	vmIpPortInfoMap := negtypes.NewPortInfoMapForVMIPNEG(name.Namespace, name.Name, c.l4Namer, onlyLocal, networkInfo, l4LBType)
+	// With externalTrafficPolicy Local, nodes are only in the NEG while they
+	// run pods of the service, so the attachment of a node to the NEG can gate
+	// the readiness of the pods scheduled on it. Its health cannot, as the
+	// node only passes the health check once it has a ready pod.
+	if flags.F.EnableL4NEGReadinessGate && onlyLocal {
+		for key, portInfo := range vmIpPortInfoMap {
+			portInfo.ReadinessGate = true
+			vmIpPortInfoMap[key] = portInfo
+		}
+	}
+	return portInfoMap.Merge(vmIpPortInfoMap)
+}
+
+// setExternalNEGsPortInfo updates the PortInfo of the L7 NEGs of a Service
+// whose endpoints are outside of the cluster, so that they are materialized as
+// internet or hybrid NEGs instead of GCE_VM_IP_PORT NEGs.
+// - ExternalName services use INTERNET_FQDN_PORT NEGs.
+// - services without a selector opt in with the NEG endpoint type annotation.
+func setExternalNEGsPortInfo(service *apiv1.Service, portInfoMap negtypes.PortInfoMap) error {
+	negType, err := negtypes.ExternalNEGType(service)
+	if err != nil || negType == "" {
+		return err
+	}
+	for key, portInfo := range portInfoMap {
+		// VM_IP NEGs have an empty port tuple and always target nodes.
+		if portInfo.PortTuple.Empty() {
+			continue
+		}
+		portInfo.NetworkEndpointType = negType
+		portInfo.EpCalculatorMode = negtypes.ExternalMode
+		// There are no pods behind the NEG to gate on.
+		portInfo.ReadinessGate = false
+		portInfoMap[key] = portInfo
+	}
+	return nil
+}
+
+// setTopologyAwareNEGsPortInfo updates the PortInfo of the GCE_VM_IP_PORT NEGs
+// of a Service that uses topology-aware routing, so that their endpoints follow
+// the topology hints of the EndpointSlices.
+func setTopologyAwareNEGsPortInfo(service *apiv1.Service, portInfoMap negtypes.PortInfoMap) {
+	if !usesTopologyAwareRouting(service) {
+		return
+	}
+	for key, portInfo := range portInfoMap {
+		// VM_IP NEGs have an empty port tuple and external NEGs have their own
+		// calculator.
+		if portInfo.PortTuple.Empty() || portInfo.NetworkEndpointType != "" {
+			continue
+		}
+		portInfo.EpCalculatorMode = negtypes.L7TopologyAwareMode
+		portInfoMap[key] = portInfo
+	}
+}
+
+// usesTopologyAwareRouting returns true if the EndpointSlices of the service
+// carry topology hints, either because of the PreferClose traffic
+// distribution or the Auto topology mode annotation.
+func usesTopologyAwareRouting(service *apiv1.Service) bool {
+	if service.Spec.TrafficDistribution != nil && *service.Spec.TrafficDistribution == apiv1.ServiceTrafficDistributionPreferClose {
+		return true
+	}
+	for _, key := range []string{apiv1.AnnotationTopologyMode, apiv1.DeprecatedAnnotationTopologyAwareHints} {
+		if value, ok := service.Annotations[key]; ok {
+			return strings.EqualFold(value, "auto")
+		}
+	}
+	return false
 }
 
 // netLBServiceNeedsNEG determines if NEGs need to be created for L4 NetLB.
@@ -735,10 +931,13 @@ func (c *Controller) mergeDefaultBackendServicePortInfoMap(key string, service *
 		return nil
 	}
 
-	scanIngress := func(qualify func(*v1.Ingress) bool) error {
+	// Ingresses whose IngressClass parameters cannot be resolved qualify, so
+	// that the NEGs of the default backend are not removed.
+	scanIngress := func(qualify func(*v1.Ingress) (bool, error)) error {
 		for _, m := range c.ingressLister.List() {
 			ing := *m.(*v1.Ingress)
-			if qualify(&ing) && ing.Spec.DefaultBackend == nil {
+			qualified, err := qualify(&ing)
+			if (qualified || err != nil) && ing.Spec.DefaultBackend == nil {
 				svcPortTupleSet := make(negtypes.SvcPortTupleSet)
 				svcPortTupleSet.Insert(negtypes.SvcPortTuple{
 					Name:       c.defaultBackendService.ID.Port.Name,
@@ -753,12 +952,12 @@ func (c *Controller) mergeDefaultBackendServicePortInfoMap(key string, service *
 	}
 
 	// ILB always has neg enabled, regardless of neg annotation.
-	if err := scanIngress(utils.IsGCEL7ILBIngress); err != nil {
+	if err := scanIngress(c.ingressClassifier.IsL7ILB); err != nil {
 		return err
 	}
 	if c.enableIngressRegionalExternal {
 		// Regional XLB always has neg enabled, regardless of annotation.
-		if err := scanIngress(utils.IsGCEL7XLBRegionalIngress); err != nil {
+		if err := scanIngress(c.ingressClassifier.IsL7XLBRegional); err != nil {
 			return err
 		}
 	}
@@ -774,7 +973,7 @@ func (c *Controller) mergeDefaultBackendServicePortInfoMap(key string, service *
 	if negAnnotation.Ingress == false {
 		return nil
 	}
-	return scanIngress(utils.IsGCEIngress)
+	return scanIngress(c.ingressClassifier.IsGCEIngress)
 }
 
 // getCSMPortInfoMap returns the PortInfoMap used when ASM is enabled. The controller will create NEGs for every port of the service
@@ -908,9 +1107,23 @@ func (c *Controller) enqueueService(obj interface{}) {
 	c.serviceQueue.Add(key)
 }
 
+// enqueueShardServices enqueues the services of a shard acquired by this
+// replica.
+func (c *Controller) enqueueShardServices(shard int) {
+	for _, key := range c.serviceLister.ListKeys() {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		if c.shards.Shard(namespace, name) == shard {
+			c.serviceQueue.Add(key)
+		}
+	}
+}
+
 func (c *Controller) enqueueIngressServices(ing *v1.Ingress) {
 	// enqueue services referenced by ingress
-	keys := gatherIngressServiceKeys(ing)
+	keys := gatherIngressServiceKeys(ing, c.frontendConfigForIngress(ing))
 	for key := range keys {
 		c.enqueueService(cache.ExplicitKey(key))
 	}
@@ -932,6 +1145,14 @@ func (c *Controller) enqueueNodeTopology(obj interface{}) {
 	c.nodeTopologyQueue.Add(key)
 }
 
+// isGLBCIngress returns true if the given Ingress is processed by the ingress
+// controller. Ingresses whose IngressClass parameters cannot be resolved are
+// considered processed, so that their NEGs are not removed.
+func (c *Controller) isGLBCIngress(ing *v1.Ingress) bool {
+	isGLBCIngress, err := c.ingressClassifier.IsGLBCIngress(ing)
+	return isGLBCIngress || err != nil
+}
+
 func (c *Controller) gc() {
 	if err := c.manager.GC(); err != nil {
 		c.logger.Error(err, "NEG controller garbage collection failed")
@@ -939,13 +1160,45 @@ func (c *Controller) gc() {
 	}
 }
 
+// enqueueFrontendConfigServices enqueues the services referenced by the route
+// rules of the given FrontendConfig so that NEGs are created for them.
+func (c *Controller) enqueueFrontendConfigServices(obj interface{}) {
+	feConfig, ok := obj.(*frontendconfigv1beta1.FrontendConfig)
+	if !ok {
+		return
+	}
+	for _, rule := range feConfig.Spec.RouteRules {
+		for _, backend := range rule.Backends {
+			c.enqueueService(cache.ExplicitKey(utils.ServiceKeyFunc(feConfig.Namespace, backend.ServiceName)))
+		}
+	}
+}
+
+// frontendConfigForIngress returns the FrontendConfig referenced by the
+// ingress, or nil if there is none.
+func (c *Controller) frontendConfigForIngress(ing *v1.Ingress) *frontendconfigv1beta1.FrontendConfig {
+	if c.frontendConfigLister == nil || ing == nil {
+		return nil
+	}
+	name := annotations.FromIngress(ing).FrontendConfig()
+	if name == "" {
+		return nil
+	}
+	obj, exists, err := c.frontendConfigLister.GetByKey(utils.ServiceKeyFunc(ing.Namespace, name))
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*frontendconfigv1beta1.FrontendConfig)
+}
+
 // gatherPortMappingUsedByIngress returns a map containing port:targetport
-// of all service ports of the service that are referenced by ingresses
-func gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service, logger klog.Logger) negtypes.SvcPortTupleSet {
+// of all service ports of the service that are referenced by ingresses,
+// including the route rules of the FrontendConfig returned by feConfigFor.
+func gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service, isGLBCIngress func(*v1.Ingress) bool, feConfigFor func(*v1.Ingress) *frontendconfigv1beta1.FrontendConfig, logger klog.Logger) negtypes.SvcPortTupleSet {
 	ingressSvcPortTuples := make(negtypes.SvcPortTupleSet)
 	for _, ing := range ings {
-		if utils.IsGLBCIngress(&ing) {
-			utils.TraverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
+		if isGLBCIngress(&ing) {
+			utils.TraverseIngressBackends(&ing, feConfigFor(&ing), func(id utils.ServicePortID) bool {
 				if id.Service.Name == svc.Name && id.Service.Namespace == svc.Namespace {
 					servicePort := translator.ServicePort(*svc, id.Port)
 					if servicePort == nil {
@@ -966,27 +1219,28 @@ func gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service, logge
 }
 
 // gatherIngressServiceKeys returns all service key (formatted as namespace/name) referenced in the ingress
-func gatherIngressServiceKeys(ing *v1.Ingress) sets.String {
+// and in the route rules of its FrontendConfig, if any.
+func gatherIngressServiceKeys(ing *v1.Ingress, feConfig *frontendconfigv1beta1.FrontendConfig) sets.String {
 	set := sets.NewString()
 	if ing == nil {
 		return set
 	}
-	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+	utils.TraverseIngressBackends(ing, feConfig, func(id utils.ServicePortID) bool {
 		set.Insert(utils.ServiceKeyFunc(id.Service.Namespace, id.Service.Name))
 		return false
 	})
 	return set
 }
 
-func getIngressServicesFromStore(store cache.Store, svc *apiv1.Service) (ings []v1.Ingress) {
+func getIngressServicesFromStore(store cache.Store, svc *apiv1.Service, isGLBCIngress func(*v1.Ingress) bool, feConfigFor func(*v1.Ingress) *frontendconfigv1beta1.FrontendConfig) (ings []v1.Ingress) {
 	for _, m := range store.List() {
 		ing := *m.(*v1.Ingress)
 		if ing.Namespace != svc.Namespace {
 			continue
 		}
 
-		if utils.IsGLBCIngress(&ing) {
-			utils.TraverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
+		if isGLBCIngress(&ing) {
+			utils.TraverseIngressBackends(&ing, feConfigFor(&ing), func(id utils.ServicePortID) bool {
 				if id.Service.Name == svc.Name {
 					ings = append(ings, ing)
 					return true
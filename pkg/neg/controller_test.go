@@ -41,6 +41,8 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/syncers/labels"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
@@ -48,6 +50,7 @@ import (
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/ingress-gce/pkg/utils/zonegetter"
 	"k8s.io/klog/v2"
 )
@@ -132,6 +135,7 @@ func newTestControllerWithParamsAndContext(kubeClient kubernetes.Interface, test
 		kubeClient,
 		testContext.KubeSystemUID,
 		testContext.IngressInformer,
+		nil,
 		testContext.ServiceInformer,
 		testContext.PodInformer,
 		testContext.NodeInformer,
@@ -143,6 +147,7 @@ func newTestControllerWithParamsAndContext(kubeClient kubernetes.Interface, test
 		func() bool { return true },
 		testContext.L4Namer,
 		defaultBackend,
+		utils.IngressClassifier{},
 		negtypes.NewAdapter(testContext.Cloud),
 		zoneGetter,
 		testContext.NegNamer,
@@ -160,6 +165,7 @@ func newTestControllerWithParamsAndContext(kubeClient kubernetes.Interface, test
 		true,
 		false,
 		false,
+		nil, // shards
 		make(<-chan struct{}),
 		klog.TODO(),
 	)
@@ -710,7 +716,7 @@ func TestGatherPortMappingUsedByIngress(t *testing.T) {
 	for _, tc := range testCases {
 		controller := newTestController(fake.NewSimpleClientset())
 		defer controller.stop()
-		portTupleSet := gatherPortMappingUsedByIngress(tc.ings, newTestService(controller, true, []int32{}), klog.TODO())
+		portTupleSet := gatherPortMappingUsedByIngress(tc.ings, newTestService(controller, true, []int32{}), controller.isGLBCIngress, controller.frontendConfigForIngress, klog.TODO())
 		if len(portTupleSet) != len(tc.expect) {
 			t.Errorf("For test case %q, expect %d ports, but got %d.", tc.desc, len(tc.expect), len(portTupleSet))
 		}
@@ -732,6 +738,51 @@ func TestGatherPortMappingUsedByIngress(t *testing.T) {
 	}
 }
 
+func TestFrontendConfigRouteRuleServices(t *testing.T) {
+	t.Parallel()
+	controller := newTestController(fake.NewSimpleClientset())
+	defer controller.stop()
+	controller.frontendConfigLister = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	routeSvcName := "route-svc"
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "fc", Namespace: testServiceNamespace},
+		Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			RouteRules: []frontendconfigv1beta1.RouteRule{{
+				Backends: []frontendconfigv1beta1.RouteBackend{{
+					ServiceName: routeSvcName,
+					ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 80},
+				}},
+			}},
+		},
+	}
+	if err := controller.frontendConfigLister.Add(feConfig); err != nil {
+		t.Fatalf("Failed to add FrontendConfig: %v", err)
+	}
+	ing := newTestIngress(testServiceName)
+	routeSvcKey := utils.ServiceKeyFunc(testServiceNamespace, routeSvcName)
+
+	if keys := gatherIngressServiceKeys(ing, controller.frontendConfigForIngress(ing)); keys.Has(routeSvcKey) {
+		t.Errorf("gatherIngressServiceKeys() = %v, want no %q without FrontendConfig annotation", keys.List(), routeSvcKey)
+	}
+
+	ing.Annotations = map[string]string{annotations.FrontendConfigKey: feConfig.Name}
+	keys := gatherIngressServiceKeys(ing, controller.frontendConfigForIngress(ing))
+	for _, want := range []string{routeSvcKey, utils.ServiceKeyFunc(testServiceNamespace, testServiceName)} {
+		if !keys.Has(want) {
+			t.Errorf("gatherIngressServiceKeys() = %v, want %q", keys.List(), want)
+		}
+	}
+
+	controller.enqueueFrontendConfigServices(feConfig)
+	if got := controller.serviceQueue.Len(); got != 1 {
+		t.Fatalf("serviceQueue.Len() = %d, want 1", got)
+	}
+	if item, _ := controller.serviceQueue.Get(); item != routeSvcKey {
+		t.Errorf("serviceQueue.Get() = %v, want %q", item, routeSvcKey)
+	}
+}
+
 func TestSyncNegAnnotation(t *testing.T) {
 	t.Parallel()
 	// TODO: test that c.serviceLister.Update is called whenever the annotation
@@ -1328,6 +1379,276 @@ func TestMergeVmIpNEGsPortInfo(t *testing.T) {
 	}
 }
 
+func TestMergeVmIpNEGsPortInfoReadinessGate(t *testing.T) {
+	prevFlag := flags.F.EnableL4NEGReadinessGate
+	defer func() { flags.F.EnableL4NEGReadinessGate = prevFlag }()
+	flags.F.EnableL4NEGReadinessGate = true
+
+	controller := newTestController(fake.NewSimpleClientset())
+	controller.runL4ForILB = true
+
+	for _, onlyLocal := range []bool{true, false} {
+		svc := newTestILBService(controller, onlyLocal, 80)
+		svc.Finalizers = append(svc.Finalizers, common.ILBFinalizerV2)
+		portInfoMap := make(negtypes.PortInfoMap)
+		negUsage := metricscollector.NegServiceState{}
+		if err := controller.mergeVmIpNEGsPortInfo(svc, types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}, portInfoMap, &negUsage, defaultNetwork); err != nil {
+			t.Fatalf("mergeVmIpNEGsPortInfo() = %v, want nil", err)
+		}
+		if len(portInfoMap) != 1 {
+			t.Fatalf("mergeVmIpNEGsPortInfo() created %d port infos, want 1", len(portInfoMap))
+		}
+		for _, portInfo := range portInfoMap {
+			// Only nodes of Local mode services are in the NEG because of their pods.
+			if portInfo.ReadinessGate != onlyLocal {
+				t.Errorf("For onlyLocal=%v, got ReadinessGate=%v, want %v", onlyLocal, portInfo.ReadinessGate, onlyLocal)
+			}
+		}
+	}
+}
+
+func TestMergeStandaloneNEGsPortInfoNameTemplate(t *testing.T) {
+	controller := newTestController(fake.NewSimpleClientset())
+	controller.clusterName = "cluster1"
+
+	controllerTemplate, err := namer.ParseNEGNameTemplate("{cluster}-{namespace}-{service}-{port}")
+	if err != nil {
+		t.Fatalf("ParseNEGNameTemplate() = %v, want nil", err)
+	}
+
+	testCases := []struct {
+		desc               string
+		negAnnotation      string
+		controllerTemplate *namer.NEGNameTemplate
+		wantNegNames       map[int32]string
+		wantAdopt          bool
+		wantErr            bool
+	}{
+		{
+			desc:          "no template",
+			negAnnotation: `{"exposed_ports":{"80":{}}}`,
+			wantNegNames:  map[int32]string{80: controller.namer.NEG("ns1", "svc1", 80)},
+		},
+		{
+			desc:               "controller template",
+			negAnnotation:      `{"exposed_ports":{"80":{},"443":{"name":"custom-neg"}}}`,
+			controllerTemplate: controllerTemplate,
+			wantNegNames:       map[int32]string{80: "cluster1-ns1-svc1-80", 443: "custom-neg"},
+		},
+		{
+			desc:               "service template overrides controller template",
+			negAnnotation:      `{"exposed_ports":{"80":{},"443":{}},"name_template":"neg-{namespace}-{service}-{port}"}`,
+			controllerTemplate: controllerTemplate,
+			wantNegNames:       map[int32]string{80: "neg-ns1-svc1-80", 443: "neg-ns1-svc1-443"},
+		},
+		{
+			desc:               "controller template does not apply with ingress enabled",
+			negAnnotation:      `{"ingress":true,"exposed_ports":{"80":{}}}`,
+			controllerTemplate: controllerTemplate,
+			wantNegNames:       map[int32]string{80: controller.namer.NEG("ns1", "svc1", 80)},
+		},
+		{
+			desc:          "service template with ingress enabled",
+			negAnnotation: `{"ingress":true,"exposed_ports":{"80":{}},"name_template":"neg-{namespace}-{service}-{port}"}`,
+			wantErr:       true,
+		},
+		{
+			desc:          "invalid service template",
+			negAnnotation: `{"exposed_ports":{"80":{}},"name_template":"neg-{name}"}`,
+			wantErr:       true,
+		},
+		{
+			desc:          "service template without the namespace",
+			negAnnotation: `{"exposed_ports":{"80":{}},"name_template":"neg-{service}-{port}"}`,
+			wantErr:       true,
+		},
+		{
+			desc:          "adopt existing NEGs",
+			negAnnotation: `{"exposed_ports":{"80":{"name":"terraform-neg"}},"adopt_existing":true}`,
+			wantNegNames:  map[int32]string{80: "terraform-neg"},
+			wantAdopt:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			controller.negNameTemplate = tc.controllerTemplate
+			svc := &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "svc1",
+					Namespace:   "ns1",
+					Annotations: map[string]string{annotations.NEGAnnotationKey: tc.negAnnotation},
+				},
+				Spec: apiv1.ServiceSpec{Ports: servicePorts()},
+			}
+			portInfoMap := make(negtypes.PortInfoMap)
+			negUsage := metricscollector.NegServiceState{}
+			err := controller.mergeStandaloneNEGsPortInfo(svc, types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}, portInfoMap, &negUsage, defaultNetwork)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("mergeStandaloneNEGsPortInfo() = %v, want error: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(portInfoMap) != len(tc.wantNegNames) {
+				t.Fatalf("mergeStandaloneNEGsPortInfo() created %d port infos, want %d", len(portInfoMap), len(tc.wantNegNames))
+			}
+			for port, wantNegName := range tc.wantNegNames {
+				portInfo := portInfoMap[negtypes.PortInfoMapKey{ServicePort: port}]
+				if portInfo.NegName != wantNegName {
+					t.Errorf("For port %d, got NEG name %q, want %q", port, portInfo.NegName, wantNegName)
+				}
+				if portInfo.AdoptExistingNEG != tc.wantAdopt {
+					t.Errorf("For port %d, got AdoptExistingNEG=%v, want %v", port, portInfo.AdoptExistingNEG, tc.wantAdopt)
+				}
+			}
+		})
+	}
+}
+
+func TestSetExternalNEGsPortInfo(t *testing.T) {
+	svcPortTuple := negtypes.SvcPortTuple{Name: "https", Port: 443, TargetPort: "443"}
+	newPortInfoMap := func() negtypes.PortInfoMap {
+		return negtypes.PortInfoMap{
+			negtypes.PortInfoMapKey{ServicePort: 443}: negtypes.PortInfo{PortTuple: svcPortTuple, NegName: "neg-443", ReadinessGate: true},
+		}
+	}
+	newService := func(svcType apiv1.ServiceType, selector, svcAnnotations map[string]string) *apiv1.Service {
+		return &apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: testServiceName, Namespace: testServiceNamespace, Annotations: svcAnnotations},
+			Spec:       apiv1.ServiceSpec{Type: svcType, Selector: selector},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		svc      *apiv1.Service
+		wantType negtypes.NetworkEndpointType
+		wantErr  bool
+	}{
+		{
+			desc: "service with selector",
+			svc:  newService(apiv1.ServiceTypeClusterIP, map[string]string{"app": "foo"}, nil),
+		},
+		{
+			desc:     "ExternalName service",
+			svc:      newService(apiv1.ServiceTypeExternalName, nil, nil),
+			wantType: negtypes.InternetFQDNEndpointType,
+		},
+		{
+			desc: "selector-less service without annotation",
+			svc:  newService(apiv1.ServiceTypeClusterIP, nil, nil),
+		},
+		{
+			desc:     "selector-less service with internet annotation",
+			svc:      newService(apiv1.ServiceTypeClusterIP, nil, map[string]string{annotations.NEGEndpointTypeKey: annotations.NEGEndpointTypeInternet}),
+			wantType: negtypes.InternetIPEndpointType,
+		},
+		{
+			desc:     "selector-less service with hybrid annotation",
+			svc:      newService(apiv1.ServiceTypeClusterIP, nil, map[string]string{annotations.NEGEndpointTypeKey: annotations.NEGEndpointTypeHybrid}),
+			wantType: negtypes.NonGCPPrivateEndpointType,
+		},
+		{
+			desc:    "service with selector and annotation",
+			svc:     newService(apiv1.ServiceTypeClusterIP, map[string]string{"app": "foo"}, map[string]string{annotations.NEGEndpointTypeKey: annotations.NEGEndpointTypeHybrid}),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid annotation",
+			svc:     newService(apiv1.ServiceTypeClusterIP, nil, map[string]string{annotations.NEGEndpointTypeKey: "foo"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			portInfoMap := newPortInfoMap()
+			err := setExternalNEGsPortInfo(tc.svc, portInfoMap)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("setExternalNEGsPortInfo() = %v, want error: %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			wantPortInfoMap := newPortInfoMap()
+			if tc.wantType != "" {
+				wantPortInfoMap[negtypes.PortInfoMapKey{ServicePort: 443}] = negtypes.PortInfo{
+					PortTuple:           svcPortTuple,
+					NegName:             "neg-443",
+					EpCalculatorMode:    negtypes.ExternalMode,
+					NetworkEndpointType: tc.wantType,
+				}
+			}
+			if !reflect.DeepEqual(wantPortInfoMap, portInfoMap) {
+				t.Errorf("Wrong services PortInfoMap, got %+v, want %+v", portInfoMap, wantPortInfoMap)
+			}
+		})
+	}
+}
+
+func TestSetTopologyAwareNEGsPortInfo(t *testing.T) {
+	svcPortTuple := negtypes.SvcPortTuple{Name: "http", Port: 80, TargetPort: "8080"}
+	preferClose := apiv1.ServiceTrafficDistributionPreferClose
+	newPortInfoMap := func() negtypes.PortInfoMap {
+		return negtypes.PortInfoMap{
+			negtypes.PortInfoMapKey{ServicePort: 80}: negtypes.PortInfo{PortTuple: svcPortTuple, NegName: "neg-80"},
+			negtypes.PortInfoMapKey{ServicePort: 0}:  negtypes.PortInfo{NegName: "neg-vm-ip", EpCalculatorMode: negtypes.L4ClusterMode},
+		}
+	}
+
+	testCases := []struct {
+		desc                string
+		trafficDistribution *string
+		annotations         map[string]string
+		wantTopologyAware   bool
+	}{
+		{
+			desc: "service without topology-aware routing",
+		},
+		{
+			desc:                "PreferClose traffic distribution",
+			trafficDistribution: &preferClose,
+			wantTopologyAware:   true,
+		},
+		{
+			desc:              "Auto topology mode",
+			annotations:       map[string]string{apiv1.AnnotationTopologyMode: "Auto"},
+			wantTopologyAware: true,
+		},
+		{
+			desc:              "deprecated topology-aware hints annotation",
+			annotations:       map[string]string{apiv1.DeprecatedAnnotationTopologyAwareHints: "auto"},
+			wantTopologyAware: true,
+		},
+		{
+			desc:        "Disabled topology mode",
+			annotations: map[string]string{apiv1.AnnotationTopologyMode: "Disabled"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: testServiceName, Namespace: testServiceNamespace, Annotations: tc.annotations},
+				Spec:       apiv1.ServiceSpec{TrafficDistribution: tc.trafficDistribution},
+			}
+			portInfoMap := newPortInfoMap()
+			setTopologyAwareNEGsPortInfo(svc, portInfoMap)
+
+			wantPortInfoMap := newPortInfoMap()
+			if tc.wantTopologyAware {
+				portInfo := wantPortInfoMap[negtypes.PortInfoMapKey{ServicePort: 80}]
+				portInfo.EpCalculatorMode = negtypes.L7TopologyAwareMode
+				wantPortInfoMap[negtypes.PortInfoMapKey{ServicePort: 80}] = portInfo
+			}
+			if !reflect.DeepEqual(wantPortInfoMap, portInfoMap) {
+				t.Errorf("Wrong services PortInfoMap, got %+v, want %+v", portInfoMap, wantPortInfoMap)
+			}
+		})
+	}
+}
+
 func TestEnableNegCRD(t *testing.T) {
 	t.Parallel()
 
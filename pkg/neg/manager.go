@@ -33,13 +33,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/neg/coalescer"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
+	"k8s.io/ingress-gce/pkg/neg/sharding"
 	negsyncer "k8s.io/ingress-gce/pkg/neg/syncers"
 	podlabels "k8s.io/ingress-gce/pkg/neg/syncers/labels"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
@@ -50,6 +54,7 @@ import (
 	"k8s.io/ingress-gce/pkg/utils/patch"
 	"k8s.io/ingress-gce/pkg/utils/zonegetter"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	utilpointer "k8s.io/utils/pointer"
 )
 
@@ -69,6 +74,9 @@ type syncerManager struct {
 	recorder   record.EventRecorder
 	cloud      negtypes.NetworkEndpointGroupCloud
 	zoneGetter *zonegetter.ZoneGetter
+	// coalescer merges and bounds the attach and detach calls of all the
+	// syncers. It is nil if the syncers make their own calls.
+	coalescer *coalescer.Coalescer
 
 	nodeLister          cache.Indexer
 	podLister           cache.Indexer
@@ -109,6 +117,22 @@ type syncerManager struct {
 	// NEG Delete API.
 	numGCWorkers int
 
+	// gcRetentionPeriod is the period for which unused NEGs are kept in the
+	// TO_BE_DELETED state before they are garbage collected.
+	gcRetentionPeriod time.Duration
+	// gcDryRun indicates that garbage collection only emits events and
+	// metrics for unused NEGs, without deleting them.
+	gcDryRun bool
+	// gcCandidateTimes contains the time at which each unused NEG was first
+	// found, when it cannot be recorded in a NEG CR. In dry-run mode, it is
+	// also used to report each unused NEG only once. It is only accessed by
+	// the NEG garbage collection, and is protected by gcCandidateMu as NEG CRs
+	// are processed by concurrent workers.
+	gcCandidateTimes map[string]time.Time
+	gcCandidateMu    sync.Mutex
+
+	clock clock.Clock
+
 	logger klog.Logger
 
 	// zone maps keep track of the last set of zones the neg controller has seen
@@ -116,7 +140,15 @@ type syncerManager struct {
 	vmIpPortZoneMap map[string]struct{}
 
 	// lpConfig configures the pod label to be propagated to NEG endpoints.
-	lpConfig podlabels.PodLabelPropagationConfig
+	lpConfig podlabels.PodLabelPropagationConfigSource
+
+	// shards assigns the Services to the replicas of the NEG controller. It
+	// is nil if the controller is not sharded and manages all Services.
+	shards *sharding.Coordinator
+	// shardGCGracePeriod is the period after acquiring a shard before the
+	// NEGs of its Services are garbage collected, so that all its Services
+	// are processed first.
+	shardGCGracePeriod time.Duration
 }
 
 func newSyncerManager(namer negtypes.NetworkEndpointGroupNamer,
@@ -135,18 +167,24 @@ func newSyncerManager(namer negtypes.NetworkEndpointGroupNamer,
 	enableNonGcpMode bool,
 	enableDualStackNEG bool,
 	numGCWorkers int,
-	lpConfig podlabels.PodLabelPropagationConfig,
+	lpConfig podlabels.PodLabelPropagationConfigSource,
 	logger klog.Logger) *syncerManager {
 
 	var vmIpPortZoneMap map[string]struct{}
 	updateZoneMap(&vmIpPortZoneMap, negtypes.NodeFilterForNetworkEndpointType(negtypes.VmIpPortEndpointType), zoneGetter, logger)
 
+	var endpointCoalescer *coalescer.Coalescer
+	if flags.F.NEGMaxConcurrentEndpointOperations > 0 {
+		endpointCoalescer = coalescer.NewCoalescer(cloud, negsyncer.MAX_NETWORK_ENDPOINTS_PER_BATCH, flags.F.NEGMaxConcurrentEndpointOperations, logger)
+	}
+
 	return &syncerManager{
 		namer:               namer,
 		l4Namer:             l4Namer,
 		recorder:            recorder,
 		cloud:               cloud,
 		zoneGetter:          zoneGetter,
+		coalescer:           endpointCoalescer,
 		nodeLister:          nodeLister,
 		podLister:           podLister,
 		serviceLister:       serviceLister,
@@ -160,6 +198,10 @@ func newSyncerManager(namer negtypes.NetworkEndpointGroupNamer,
 		enableNonGcpMode:    enableNonGcpMode,
 		enableDualStackNEG:  enableDualStackNEG,
 		numGCWorkers:        numGCWorkers,
+		gcRetentionPeriod:   flags.F.NegGCRetentionPeriod,
+		gcDryRun:            flags.F.NegGCDryRun,
+		gcCandidateTimes:    make(map[string]time.Time),
+		clock:               clock.RealClock{},
 		logger:              logger,
 		vmIpPortZoneMap:     vmIpPortZoneMap,
 		lpConfig:            lpConfig,
@@ -171,6 +213,13 @@ func newSyncerManager(namer negtypes.NetworkEndpointGroupNamer,
 func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts negtypes.PortInfoMap) (int, int, error) {
 	manager.mu.Lock()
 	defer manager.mu.Unlock()
+	// The shard of the service may have been released since the service was
+	// processed. Its syncers are stopped by StopUnownedSyncers under
+	// manager.mu, so they must not be started again.
+	if manager.shards != nil && !manager.shards.Owns(namespace, name) {
+		manager.logger.V(3).Info("Skipping syncers of service owned by another replica", "service", klog.KRef(namespace, name))
+		return 0, 0, nil
+	}
 	start := time.Now()
 	key := getServiceKey(namespace, name)
 	currentPorts, ok := manager.svcPortMap[key]
@@ -241,6 +290,7 @@ func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts neg
 				syncerKey,
 				manager.recorder,
 				manager.cloud,
+				manager.coalescer,
 				manager.zoneGetter,
 				manager.podLister,
 				manager.serviceLister,
@@ -253,6 +303,7 @@ func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts neg
 				manager.svcNegClient,
 				manager.syncerMetrics,
 				syncerKey.NegType == negtypes.VmIpPortEndpointType && !manager.namer.IsNEG(portInfo.NegName),
+				portInfo.AdoptExistingNEG,
 				manager.logger,
 				manager.lpConfig,
 				manager.enableDualStackNEG,
@@ -281,15 +332,80 @@ func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts neg
 func (manager *syncerManager) StopSyncer(namespace, name string) {
 	manager.mu.Lock()
 	defer manager.mu.Unlock()
-	key := getServiceKey(namespace, name)
+	manager.stopServiceSyncers(getServiceKey(namespace, name))
+}
+
+// stopServiceSyncers stops the syncers of the service, and returns them.
+// manager.mu must be held.
+func (manager *syncerManager) stopServiceSyncers(key serviceKey) []negtypes.NegSyncer {
+	var stopped []negtypes.NegSyncer
 	if ports, ok := manager.svcPortMap[key]; ok {
 		for svcPort, portInfo := range ports {
-			if syncer, ok := manager.syncerMap[manager.getSyncerKey(namespace, name, svcPort, portInfo)]; ok {
+			if syncer, ok := manager.syncerMap[manager.getSyncerKey(key.namespace, key.name, svcPort, portInfo)]; ok {
 				syncer.Stop()
+				stopped = append(stopped, syncer)
 			}
 		}
 		delete(manager.svcPortMap, key)
 	}
+	return stopped
+}
+
+// StopUnownedSyncers stops the syncers of the services which are not owned by
+// this replica of the NEG controller, and waits for them to finish shutting
+// down, so that the replica acquiring the services does not sync the same
+// NEGs. The shards of the services are only released once it returns.
+func (manager *syncerManager) StopUnownedSyncers() {
+	if manager.shards == nil {
+		return
+	}
+	var stopped []negtypes.NegSyncer
+	func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+		for key := range manager.svcPortMap {
+			if !manager.shards.Owns(key.namespace, key.name) {
+				manager.logger.V(2).Info("Stopping syncers of service owned by another replica", "service", key.Key())
+				stopped = append(stopped, manager.stopServiceSyncers(key)...)
+			}
+		}
+	}()
+	wait.PollImmediateInfinite(100*time.Millisecond, func() (bool, error) {
+		for _, syncer := range stopped {
+			if syncer.IsShuttingDown() {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// NegsManagedElsewhere returns true if NEGs managed by another replica of the
+// NEG controller may contain the pods with the labels in the namespace, as a
+// service selecting them is owned by another replica and has NEGs.
+func (manager *syncerManager) NegsManagedElsewhere(namespace string, podLabels map[string]string) bool {
+	if manager.shards == nil {
+		return false
+	}
+	services, err := manager.serviceLister.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		manager.logger.Error(err, "Failed to list services", "namespace", namespace)
+		// Assume that other replicas may manage NEGs of the pods.
+		return true
+	}
+	for _, obj := range services {
+		service := obj.(*v1.Service)
+		if service.Spec.Selector == nil || manager.shards.Owns(service.Namespace, service.Name) {
+			continue
+		}
+		if _, ok := service.Annotations[annotations.NEGStatusKey]; !ok {
+			continue
+		}
+		if labels.Set(service.Spec.Selector).AsSelectorPreValidated().Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+	return false
 }
 
 // Sync signals all syncers related to the service to sync.
@@ -331,6 +447,9 @@ func (manager *syncerManager) SyncNodes() {
 			manager.logger.V(1).Info("SyncNodes: Triggering sync", "negSyncerKey", key.String(), "negSyncerType", key.NegType)
 			syncer.Sync()
 
+		case negtypes.InternetFQDNEndpointType, negtypes.InternetIPEndpointType:
+			manager.logger.V(1).Info("SyncNodes: Not triggering sync since global NEGs do not depend on nodes", "negSyncerKey", key.String(), "negSyncerType", key.NegType)
+
 		case negtypes.VmIpPortEndpointType, negtypes.NonGCPPrivateEndpointType:
 			if isVmIpPortZoneChange {
 				manager.logger.V(1).Info("SyncNodes: Triggering sync because of zone change", "negSyncerKey", key.String(), "negSyncerType", key.NegType)
@@ -502,6 +621,12 @@ func (manager *syncerManager) garbageCollectSyncer() {
 }
 
 func (manager *syncerManager) garbageCollectNEG() error {
+	if manager.shards != nil {
+		// The services of the NEGs are only known from NEG CRs, so NEGs
+		// owned by other replicas cannot be told apart without them.
+		manager.logger.Info("Skipping NEG garbage collection as NEG CRs are required with sharding")
+		return nil
+	}
 	// Retrieve aggregated NEG list from cloud
 	// Compare against svcPortMap and Remove unintended NEGs by best effort
 	negList, err := manager.cloud.AggregatedListNetworkEndpointGroup(meta.VersionGA, manager.logger)
@@ -534,6 +659,8 @@ func (manager *syncerManager) garbageCollectNEG() error {
 		}
 	}()
 
+	deleteCandidates = manager.filterGCCandidatesWithoutCRD(deleteCandidates)
+
 	// This section includes a potential race condition between deleting neg here and users adds the neg annotation.
 	// The worst outcome of the race condition is that neg is deleted in the end but user actually specifies a neg.
 	// This would be resolved (sync neg) when the next endpoint update or resync arrives.
@@ -548,6 +675,72 @@ func (manager *syncerManager) garbageCollectNEG() error {
 	return nil
 }
 
+// filterGCCandidatesWithoutCRD applies the NEG garbage collection policy to
+// the unused NEGs found without NEG CRs, and returns the ones which can be
+// deleted now. The time at which the NEGs were first found unused is only kept
+// in memory, and the opt-out annotation is not supported as the Services of
+// the NEGs are not known.
+func (manager *syncerManager) filterGCCandidatesWithoutCRD(deleteCandidates map[string][]string) map[string][]string {
+	now := manager.clock.Now()
+	manager.forgetGCCandidates(func(name string) bool {
+		_, ok := deleteCandidates[name]
+		return ok
+	})
+	ret := make(map[string][]string)
+	for name, zones := range deleteCandidates {
+		candidateTime, ok := manager.gcCandidateTime(name, now)
+		if manager.gcDryRun {
+			if !ok {
+				manager.logger.Info("NEG garbage collection is in dry-run mode, skipping deletion of unused NEG", "negName", name, "zones", zones)
+			}
+			metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDryRun)
+			continue
+		}
+		if manager.gcRetentionPeriod > 0 {
+			if !ok {
+				manager.logger.V(2).Info("Scheduled deletion of unused NEG", "negName", name, "retentionPeriod", manager.gcRetentionPeriod)
+				metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionScheduled)
+			}
+			if now.Before(candidateTime.Add(manager.gcRetentionPeriod)) {
+				if ok {
+					metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionRetained)
+				}
+				continue
+			}
+		}
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDeleted)
+		ret[name] = zones
+	}
+	return ret
+}
+
+// gcCandidateTime returns the time at which the unused NEG with the given name
+// was first found, and whether it had been found before. The NEG is recorded
+// as found at now if it was not.
+func (manager *syncerManager) gcCandidateTime(name string, now time.Time) (time.Time, bool) {
+	manager.gcCandidateMu.Lock()
+	defer manager.gcCandidateMu.Unlock()
+	candidateTime, ok := manager.gcCandidateTimes[name]
+	if !ok {
+		candidateTime = now
+		manager.gcCandidateTimes[name] = candidateTime
+	}
+	return candidateTime, ok
+}
+
+// forgetGCCandidates forgets the unused NEGs for which isCandidate returns
+// false, so that they are scheduled for deletion again if they become unused
+// later.
+func (manager *syncerManager) forgetGCCandidates(isCandidate func(name string) bool) {
+	manager.gcCandidateMu.Lock()
+	defer manager.gcCandidateMu.Unlock()
+	for name := range manager.gcCandidateTimes {
+		if !isCandidate(name) {
+			delete(manager.gcCandidateTimes, name)
+		}
+	}
+}
+
 // garbageCollectNEGWithCRD uses the NEG CRs and the svcPortMap to determine which NEGs
 // need to be garbage collected. Neg CRs that do not have a configuration in the svcPortMap will deleted
 // along with all corresponding NEGs in the CR's list of NetworkEndpointGroups. If NEG deletion fails in
@@ -557,9 +750,22 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 	negCRs := manager.svcNegLister.List()
 	for _, obj := range negCRs {
 		neg := obj.(*negv1beta1.ServiceNetworkEndpointGroup)
+		// NEG CRs of other controllers, such as the ones that report
+		// serverless NEGs, are not garbage collected here.
+		if managedBy, ok := neg.Labels[negtypes.NegCRManagedByKey]; ok && managedBy != negtypes.NegCRControllerValue {
+			continue
+		}
 		deletionCandidates[neg.Name] = neg
 	}
 
+	if manager.shards != nil {
+		for name, neg := range deletionCandidates {
+			if !manager.ownsNegCRForGC(neg) {
+				delete(deletionCandidates, name)
+			}
+		}
+	}
+
 	func() {
 		manager.mu.Lock()
 		defer manager.mu.Unlock()
@@ -578,6 +784,12 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 		}
 	}()
 
+	// NEG CRs which are no longer deletion candidates are forgotten.
+	manager.forgetGCCandidates(func(name string) bool {
+		_, ok := deletionCandidates[name]
+		return ok
+	})
+
 	// This section includes a potential race condition between deleting neg here and users adds the neg annotation.
 	// The worst outcome of the race condition is that neg is deleted in the end but user actually specifies a neg.
 	// This would be resolved (sync neg) when the next endpoint update or resync arrives.
@@ -621,11 +833,30 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 	return utilerrors.NewAggregate(errList)
 }
 
+// ownsNegCRForGC returns true if this replica of the NEG controller owns the
+// service of the NEG CR since at least shardGCGracePeriod, so that its unused
+// NEGs can be garbage collected.
+func (manager *syncerManager) ownsNegCRForGC(svcNegCR *negv1beta1.ServiceNetworkEndpointGroup) bool {
+	serviceName, ok := svcNegCR.Labels[negtypes.NegCRServiceNameKey]
+	if !ok {
+		return false
+	}
+	ownedSince, ok := manager.shards.OwnedSince(svcNegCR.Namespace, serviceName)
+	return ok && manager.clock.Since(ownedSince) >= manager.shardGCGracePeriod
+}
+
 // processNEGDeletionCandidate attempts to delete `svcNegCR` and all NEGs
 // associated with it. In case when `svcNegCR` does not have ample information
 // about the zones associated with this NEG, it will attempt to delete the NEG
 // from all zones specified through the `zones` slice.
 func (manager *syncerManager) processNEGDeletionCandidate(svcNegCR *negv1beta1.ServiceNetworkEndpointGroup, zones []string) []error {
+	if deleteNow, err := manager.gcPolicyAllowsDeletion(svcNegCR); !deleteNow {
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+
 	manager.logger.V(2).Info("Count of NEGs referenced by SvcNegCR", "svcneg", klog.KObj(svcNegCR), "count", len(svcNegCR.Status.NetworkEndpointGroups))
 	var errList []error
 	shouldDeleteNegCR := true
@@ -706,6 +937,90 @@ func (manager *syncerManager) processNEGDeletionCandidate(svcNegCR *negv1beta1.S
 	return errList
 }
 
+// gcPolicyAllowsDeletion applies the NEG garbage collection policy to a NEG
+// CR which is a deletion candidate, and returns true if the CR and its NEGs
+// can be deleted now. Otherwise, it only emits events and metrics, and records
+// when the NEGs were scheduled for deletion if there is a retention period.
+func (manager *syncerManager) gcPolicyAllowsDeletion(svcNegCR *negv1beta1.ServiceNetworkEndpointGroup) (bool, error) {
+	serviceName := svcNegCR.GetLabels()[negtypes.NegCRServiceNameKey]
+	if manager.serviceRetainsNEGs(svcNegCR.Namespace, serviceName) {
+		manager.recorder.Eventf(svcNegCR, v1.EventTypeNormal, negtypes.NegGCSkipped, "Skipping deletion of unused NEGs as Service %s has annotation %s: %s", serviceName, annotations.NEGGCPolicyKey, annotations.NEGGCPolicyRetain)
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionOptedOut)
+		return false, nil
+	}
+	now := manager.clock.Now()
+	if manager.gcDryRun {
+		// The event is only emitted when the NEG CR is first found unused,
+		// rather than on every garbage collection.
+		if _, ok := manager.gcCandidateTime(svcNegCR.Name, now); !ok {
+			manager.recorder.Eventf(svcNegCR, v1.EventTypeNormal, negtypes.NegGCSkipped, "Skipping deletion of unused NEGs as NEG garbage collection is in dry-run mode")
+		}
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDryRun)
+		return false, nil
+	}
+	if manager.gcRetentionPeriod <= 0 {
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDeleted)
+		return true, nil
+	}
+	// NEG CRs without NEG references, whose NEGs are deleted by zone, do not
+	// have a state to record the scheduled deletion in, so the time at which
+	// they were first found unused is kept in memory.
+	if len(svcNegCR.Status.NetworkEndpointGroups) == 0 {
+		candidateTime, ok := manager.gcCandidateTime(svcNegCR.Name, now)
+		if !ok {
+			manager.recorder.Eventf(svcNegCR, v1.EventTypeNormal, negtypes.NegGCScheduled, "Scheduled deletion of unused NEGs after %v", manager.gcRetentionPeriod)
+			metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionScheduled)
+			return false, nil
+		}
+		if now.Before(candidateTime.Add(manager.gcRetentionPeriod)) {
+			manager.logger.V(2).Info("Retaining unused NEGs until the end of the retention period", "svcneg", klog.KObj(svcNegCR), "deletionTime", candidateTime.Add(manager.gcRetentionPeriod))
+			metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionRetained)
+			return false, nil
+		}
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDeleted)
+		return true, nil
+	}
+
+	var deletionTime time.Time
+	updatedCR := svcNegCR.DeepCopy()
+	for i := range updatedCR.Status.NetworkEndpointGroups {
+		negRef := &updatedCR.Status.NetworkEndpointGroups[i]
+		if negRef.State != negv1beta1.ToBeDeletedState || negRef.ToBeDeletedTime == nil {
+			negRef.State = negv1beta1.ToBeDeletedState
+			negRef.ToBeDeletedTime = &metav1.Time{Time: now}
+		}
+		if t := negRef.ToBeDeletedTime.Add(manager.gcRetentionPeriod); t.After(deletionTime) {
+			deletionTime = t
+		}
+	}
+
+	if !reflect.DeepEqual(svcNegCR.Status, updatedCR.Status) {
+		if _, err := patchNegStatus(manager.svcNegClient, *svcNegCR, *updatedCR); err != nil {
+			return false, fmt.Errorf("failed to schedule deletion of NEGs in neg cr %s/%s: %w", svcNegCR.Namespace, svcNegCR.Name, err)
+		}
+		manager.recorder.Eventf(svcNegCR, v1.EventTypeNormal, negtypes.NegGCScheduled, "Scheduled deletion of unused NEGs after %v", manager.gcRetentionPeriod)
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionScheduled)
+		return false, nil
+	}
+	if now.Before(deletionTime) {
+		manager.logger.V(2).Info("Retaining unused NEGs until the end of the retention period", "svcneg", klog.KObj(svcNegCR), "deletionTime", deletionTime)
+		metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionRetained)
+		return false, nil
+	}
+	metrics.PublishNegGCDecisionMetrics(metrics.GCDecisionDeleted)
+	return true, nil
+}
+
+// serviceRetainsNEGs returns true if the Service exists and opts its NEGs out
+// of garbage collection.
+func (manager *syncerManager) serviceRetainsNEGs(namespace, name string) bool {
+	obj, exists, err := manager.serviceLister.GetByKey(getServiceKey(namespace, name).Key())
+	if err != nil || !exists {
+		return false
+	}
+	return annotations.FromService(obj.(*v1.Service)).RetainNEGs()
+}
+
 // deleteNegOrReportErr will attempt to delete the specified NEG resource in the
 // cloud. Successful deletion is indicated by returning `true` and a failure
 // would return `false`. In addition, if the deletion failed, the error will be
@@ -919,6 +1234,13 @@ func (manager *syncerManager) getSyncerKey(namespace, name string, servicePortKe
 		networkEndpointType = negtypes.VmIpEndpointType
 		calculatorMode = portInfo.EpCalculatorMode
 	}
+	if portInfo.NetworkEndpointType != "" {
+		networkEndpointType = portInfo.NetworkEndpointType
+		calculatorMode = portInfo.EpCalculatorMode
+	}
+	if portInfo.EpCalculatorMode == negtypes.L7TopologyAwareMode && networkEndpointType == negtypes.VmIpPortEndpointType {
+		calculatorMode = portInfo.EpCalculatorMode
+	}
 
 	return negtypes.NegSyncerKey{
 		Namespace:        namespace,
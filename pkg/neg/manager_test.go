@@ -43,8 +43,10 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
+	"k8s.io/ingress-gce/pkg/neg/sharding"
 	"k8s.io/ingress-gce/pkg/neg/syncers/labels"
 	"k8s.io/ingress-gce/pkg/neg/types"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
@@ -53,6 +55,7 @@ import (
 	"k8s.io/ingress-gce/pkg/utils/common"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/ingress-gce/pkg/utils/zonegetter"
+	clocktesting "k8s.io/utils/clock/testing"
 )
 
 const (
@@ -414,6 +417,25 @@ func TestEnsureSyncersWithMissingSyncer(t *testing.T) {
 	manager.StopSyncer(namespace, name)
 }
 
+func TestEnsureSyncersOfUnownedService(t *testing.T) {
+	manager, _, _ := NewTestSyncerManager(fake.NewSimpleClientset())
+	// The coordinator does not run, so that this replica owns no shard.
+	manager.shards = sharding.NewCoordinator(fake.NewSimpleClientset().CoordinationV1(), sharding.Config{NumShards: 4}, klog.TODO())
+
+	svcPort := int32(3000)
+	portInfo := types.PortInfo{PortTuple: negtypes.SvcPortTuple{Port: svcPort, TargetPort: "80"}, NegName: manager.namer.NEG(testServiceNamespace, testServiceName, svcPort)}
+	portMap := types.PortInfoMap{negtypes.PortInfoMapKey{ServicePort: svcPort}: portInfo}
+	manager.serviceLister.Add(&v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: testServiceNamespace, Name: testServiceName}})
+
+	successfulSyncers, errorSyncers, err := manager.EnsureSyncers(testServiceNamespace, testServiceName, portMap)
+	if err != nil {
+		t.Fatalf("Failed to ensure syncer: %v", err)
+	}
+	if successfulSyncers != 0 || errorSyncers != 0 || len(manager.syncerMap) != 0 {
+		t.Errorf("EnsureSyncers() started %d syncers for a service owned by another replica, want none", len(manager.syncerMap))
+	}
+}
+
 func TestGarbageCollectionNEG(t *testing.T) {
 	t.Parallel()
 	kubeClient := fake.NewSimpleClientset()
@@ -1490,6 +1512,279 @@ func TestGarbageCollectionNegCrdEnabled(t *testing.T) {
 	}
 }
 
+func TestGarbageCollectionSkipsNegCrsOfOtherControllers(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _ := NewTestSyncerManager(fake.NewSimpleClientset())
+	svcNegClient := manager.svcNegClient
+
+	cr := &negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testServiceNamespace,
+			Name:      "serverless-neg",
+			Labels:    map[string]string{negtypes.NegCRManagedByKey: negtypes.NegCRServerlessNEGControllerValue},
+		},
+		Status: negv1beta1.ServiceNetworkEndpointGroupStatus{
+			NetworkEndpointGroups: []negv1beta1.NegObjectReference{{
+				Id:                  "123",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/mock-project/regions/us-central1/networkEndpointGroups/serverless-neg",
+				NetworkEndpointType: negv1beta1.ServerlessEndpointType,
+			}},
+		},
+	}
+	if _, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(cr.Namespace).Create(context2.TODO(), cr, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create neg cr: %v", err)
+	}
+	populateSvcNegCache(t, manager, svcNegClient, testServiceNamespace)
+
+	if err := manager.GC(); err != nil {
+		t.Fatalf("failed to GC: %v", err)
+	}
+	if _, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(cr.Namespace).Get(context2.TODO(), cr.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("NEG CR of the serverless NEG controller was garbage collected: %v", err)
+	}
+}
+
+func TestGarbageCollectionNegCrdPolicy(t *testing.T) {
+	t.Parallel()
+
+	port80 := int32(80)
+	zones := []string{negtypes.TestZone1, negtypes.TestZone2}
+	retentionPeriod := time.Hour
+
+	testCases := []struct {
+		desc            string
+		svcAnnotations  map[string]string
+		dryRun          bool
+		retentionPeriod time.Duration
+		// noNegRefs indicates that the NEG CR does not reference its NEGs,
+		// which are then deleted by zone.
+		noNegRefs bool
+		// expectScheduled indicates that the first GC marks the NEGs as
+		// TO_BE_DELETED instead of deleting them.
+		expectScheduled bool
+		// expectNegGC indicates whether the NEGs are deleted once the
+		// retention period, if any, has passed.
+		expectNegGC bool
+	}{
+		{
+			desc:        "no policy",
+			expectNegGC: true,
+		},
+		{
+			desc:           "service opts out of NEG GC",
+			svcAnnotations: map[string]string{annotations.NEGGCPolicyKey: annotations.NEGGCPolicyRetain},
+			expectNegGC:    false,
+		},
+		{
+			desc:           "service has an unknown NEG GC policy",
+			svcAnnotations: map[string]string{annotations.NEGGCPolicyKey: "Unknown"},
+			expectNegGC:    true,
+		},
+		{
+			desc:        "dry run",
+			dryRun:      true,
+			expectNegGC: false,
+		},
+		{
+			desc:            "retention period",
+			retentionPeriod: retentionPeriod,
+			expectScheduled: true,
+			expectNegGC:     true,
+		},
+		{
+			desc:            "retention period without NEG references",
+			retentionPeriod: retentionPeriod,
+			noNegRefs:       true,
+			expectScheduled: true,
+			expectNegGC:     true,
+		},
+		{
+			desc:            "service opts out of NEG GC with retention period",
+			svcAnnotations:  map[string]string{annotations.NEGGCPolicyKey: annotations.NEGGCPolicyRetain},
+			retentionPeriod: retentionPeriod,
+			expectNegGC:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			manager, _, _ := NewTestSyncerManager(fake.NewSimpleClientset())
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			manager.clock = fakeClock
+			manager.gcDryRun = tc.dryRun
+			manager.gcRetentionPeriod = tc.retentionPeriod
+			svcNegClient := manager.svcNegClient
+
+			svc := &v1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   testServiceNamespace,
+					Name:        testServiceName,
+					Annotations: tc.svcAnnotations,
+				},
+			}
+			svc.SetUID("svc-uid")
+			manager.serviceLister.Add(svc)
+
+			negName := manager.namer.NEG(testServiceNamespace, testServiceName, port80)
+			negDesc := utils.NegDescription{
+				ClusterUID:  KubeSystemUID,
+				Namespace:   testServiceNamespace,
+				ServiceName: testServiceName,
+				Port:        fmt.Sprint(port80),
+			}
+			for _, zone := range zones {
+				manager.cloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{
+					Version:             meta.VersionGA,
+					Name:                negName,
+					NetworkEndpointType: string(negtypes.VmIpPortEndpointType),
+					Description:         negDesc.String(),
+				}, zone, klog.TODO())
+			}
+
+			gcPortInfo := negtypes.PortInfo{PortTuple: negtypes.SvcPortTuple{Port: port80}, NegName: negName}
+			cr := createNegCR(svc, serviceKey{namespace: testServiceNamespace, name: testServiceName}, gcPortInfo)
+			if !tc.noNegRefs {
+				cr.Status.NetworkEndpointGroups = getNegObjectRefs(t, manager.cloud, zones, negName, meta.VersionGA)
+			}
+			if _, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(cr.Namespace).Create(context2.TODO(), &cr, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("failed to create neg cr: %v", err)
+			}
+			populateSvcNegCache(t, manager, svcNegClient, testServiceNamespace)
+
+			if err := manager.GC(); err != nil {
+				t.Fatalf("failed to GC: %v", err)
+			}
+
+			if tc.dryRun {
+				// Dry-run events are only emitted when the NEGs are first
+				// found unused.
+				if err := manager.GC(); err != nil {
+					t.Fatalf("failed to GC: %v", err)
+				}
+				recorder := manager.recorder.(*record.FakeRecorder)
+				if got := len(recorder.Events); got != 1 {
+					t.Errorf("expected 1 dry-run event after 2 garbage collections, but got %d", got)
+				}
+			}
+
+			if tc.expectScheduled {
+				checkNegsExist(t, manager.cloud, negName, len(zones))
+				crs := getNegCRs(t, svcNegClient, testServiceNamespace)
+				if len(crs) != 1 {
+					t.Fatalf("expected 1 neg cr, but found %d", len(crs))
+				}
+				for _, ref := range crs[0].Status.NetworkEndpointGroups {
+					if ref.State != negv1beta1.ToBeDeletedState {
+						t.Errorf("expected NEG %s to be in state %s, but got %s", ref.SelfLink, negv1beta1.ToBeDeletedState, ref.State)
+					}
+					if ref.ToBeDeletedTime == nil || !ref.ToBeDeletedTime.Time.Equal(fakeClock.Now().Truncate(time.Second)) {
+						t.Errorf("expected NEG %s to be scheduled for deletion at %v, but got %v", ref.SelfLink, fakeClock.Now(), ref.ToBeDeletedTime)
+					}
+				}
+
+				// NEGs are retained until the end of the retention period.
+				rebuildSvcNegCache(t, manager, svcNegClient, testServiceNamespace)
+				fakeClock.Step(tc.retentionPeriod / 2)
+				if err := manager.GC(); err != nil {
+					t.Fatalf("failed to GC: %v", err)
+				}
+				checkNegsExist(t, manager.cloud, negName, len(zones))
+
+				rebuildSvcNegCache(t, manager, svcNegClient, testServiceNamespace)
+				fakeClock.Step(tc.retentionPeriod)
+				if err := manager.GC(); err != nil {
+					t.Fatalf("failed to GC: %v", err)
+				}
+			}
+
+			expectedNegCount := len(zones)
+			if tc.expectNegGC {
+				expectedNegCount = 0
+			}
+			checkNegsExist(t, manager.cloud, negName, expectedNegCount)
+
+			crDeleted := checkForNegCRDeletion(getNegCRs(t, svcNegClient, testServiceNamespace), negName)
+			if crDeleted != tc.expectNegGC {
+				t.Errorf("expected neg cr deleted to be %v, but got %v", tc.expectNegGC, crDeleted)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectionNEGPolicyWithoutCRD(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc            string
+		dryRun          bool
+		retentionPeriod time.Duration
+	}{
+		{
+			desc: "no policy",
+		},
+		{
+			desc:   "dry run",
+			dryRun: true,
+		},
+		{
+			desc:            "retention period",
+			retentionPeriod: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			manager, _, _ := NewTestSyncerManager(fake.NewSimpleClientset())
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			manager.clock = fakeClock
+			manager.gcDryRun = tc.dryRun
+			manager.gcRetentionPeriod = tc.retentionPeriod
+
+			candidates := map[string][]string{"neg1": {negtypes.TestZone1}}
+			got := manager.filterGCCandidatesWithoutCRD(candidates)
+			switch {
+			case tc.dryRun, tc.retentionPeriod > 0:
+				if len(got) != 0 {
+					t.Errorf("filterGCCandidatesWithoutCRD() = %v, want no NEGs to delete", got)
+				}
+			default:
+				if !reflect.DeepEqual(got, candidates) {
+					t.Errorf("filterGCCandidatesWithoutCRD() = %v, want %v", got, candidates)
+				}
+			}
+			if tc.retentionPeriod <= 0 {
+				return
+			}
+
+			fakeClock.Step(tc.retentionPeriod / 2)
+			if got := manager.filterGCCandidatesWithoutCRD(candidates); len(got) != 0 {
+				t.Errorf("filterGCCandidatesWithoutCRD() = %v during the retention period, want no NEGs to delete", got)
+			}
+			fakeClock.Step(tc.retentionPeriod)
+			if got := manager.filterGCCandidatesWithoutCRD(candidates); !reflect.DeepEqual(got, candidates) {
+				t.Errorf("filterGCCandidatesWithoutCRD() = %v after the retention period, want %v", got, candidates)
+			}
+
+			// NEGs which are used again restart the retention period.
+			manager.filterGCCandidatesWithoutCRD(map[string][]string{})
+			if got := manager.filterGCCandidatesWithoutCRD(candidates); len(got) != 0 {
+				t.Errorf("filterGCCandidatesWithoutCRD() = %v for NEGs which were used again, want no NEGs to delete", got)
+			}
+		})
+	}
+}
+
 func TestSyncNodesConditions(t *testing.T) {
 	testcases := []struct {
 		desc          string
@@ -1783,6 +2078,18 @@ func checkForNegDeletions(negs map[*meta.Key]*composite.NetworkEndpointGroup, ne
 	return foundNegs
 }
 
+// checkNegsExist checks that the NEG exists in the expected number of zones.
+func checkNegsExist(t *testing.T, cloud negtypes.NetworkEndpointGroupCloud, negName string, expectedCount int) {
+	t.Helper()
+	negs, err := cloud.AggregatedListNetworkEndpointGroup(meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("failed getting negs from cloud: %s", err)
+	}
+	if count := checkForNegDeletions(negs, negName); count != expectedCount {
+		t.Errorf("expected %d negs in the cloud, but found %d", expectedCount, count)
+	}
+}
+
 // checkForNegCRDeletion verifies that either no cr with name `negName` exists or a cr withe name `negName` has its deletion timestamp set
 func checkForNegCRDeletion(negs []negv1beta1.ServiceNetworkEndpointGroup, negName string) bool {
 	for _, neg := range negs {
@@ -33,6 +33,13 @@ const (
 	GCProcess   = "GC"
 	SyncProcess = "Sync"
 
+	// Decisions of the NEG garbage collection policy for unused NEGs.
+	GCDecisionDeleted   = "deleted"
+	GCDecisionScheduled = "scheduled"
+	GCDecisionRetained  = "retained"
+	GCDecisionDryRun    = "dry_run"
+	GCDecisionOptedOut  = "opted_out"
+
 	NotInDegradedEndpoints  = "not_in_degraded_endpoints"
 	OnlyInDegradedEndpoints = "only_in_degraded_endpoints"
 
@@ -211,6 +218,17 @@ var (
 		[]string{"error_type"},
 	)
 
+	// NegGCDecisionCount tracks the decisions of the NEG garbage collection
+	// policy for the unused NEGs found by NEG garbage collection.
+	NegGCDecisionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: negControllerSubsystem,
+			Name:      "gc_decision_count",
+			Help:      "Number of decisions of the NEG garbage collection policy for unused NEGs",
+		},
+		[]string{"decision"},
+	)
+
 	// GCERequestCount tracks the number of GCE requests the neg controller sends to the NEG API
 	GCERequestCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -273,6 +291,7 @@ func RegisterMetrics() {
 		prometheus.MustRegister(AnnotationSize)
 		prometheus.MustRegister(DegradeModeCorrectness)
 		prometheus.MustRegister(NegControllerErrorCount)
+		prometheus.MustRegister(NegGCDecisionCount)
 		prometheus.MustRegister(GCERequestCount)
 		prometheus.MustRegister(GCERequestLatency)
 		prometheus.MustRegister(K8sRequestCount)
@@ -330,6 +349,12 @@ func PublishNegControllerErrorCountMetrics(err error, isIgnored bool) {
 	NegControllerErrorCount.WithLabelValues(getErrorLabel(err, isIgnored)).Inc()
 }
 
+// PublishNegGCDecisionMetrics publishes a decision of the NEG garbage
+// collection policy.
+func PublishNegGCDecisionMetrics(decision string) {
+	NegGCDecisionCount.WithLabelValues(decision).Inc()
+}
+
 // PublishLabelPropagationError publishes error occured during label propagation.
 func PublishLabelPropagationError(errType string) {
 	LabelPropagationError.WithLabelValues(errType).Inc()
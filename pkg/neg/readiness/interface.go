@@ -33,7 +33,15 @@ type Reflector interface {
 	// negName is the name of the network endpoint group (NEG) in the zone (e.g. k8s1-1234567-namespace-name-80-1234567)
 	// zone is the corresponding zone of the NEG resource (e.g. us-central1-b)
 	// endpointMap contains mapping from all network endpoints to pods which have been added into the NEG
+	// For GCE_VM_IP NEGs, it maps the endpoints of the pods scheduled on the nodes which have been added into the NEG,
+	// and the pods are ready once their node is listed in the NEG.
 	CommitPods(syncerKey negtypes.NegSyncerKey, negName string, zone string, endpointMap negtypes.EndpointPodMap)
+	// NegHealth returns the health status of the endpoints in a NEG at the last
+	// time the reflector polled it, and false if it has not polled the NEG.
+	NegHealth(syncerKey negtypes.NegSyncerKey, negName string, zone string) (NegHealth, bool)
+	// ForgetSyncer stops polling the NEGs committed with the given syncer key
+	// and drops their health status. It is called once the syncer has stopped.
+	ForgetSyncer(syncerKey negtypes.NegSyncerKey)
 }
 
 // NegLookup defines an interface for looking up pod membership.
@@ -42,6 +50,9 @@ type NegLookup interface {
 	ReadinessGateEnabledNegs(namespace string, labels map[string]string) []string
 	// ReadinessGateEnabled returns true if the NEG requires readiness feedback
 	ReadinessGateEnabled(syncerKey negtypes.NegSyncerKey) bool
+	// NegsManagedElsewhere returns true if NEGs managed by another replica of the NEG controller may contain
+	// the pods with the input namespace and labels.
+	NegsManagedElsewhere(namespace string, labels map[string]string) bool
 }
 
 type NoopReflector struct{}
@@ -51,3 +62,9 @@ func (*NoopReflector) Run(<-chan struct{}) {}
 func (*NoopReflector) SyncPod(*v1.Pod) {}
 
 func (*NoopReflector) CommitPods(negtypes.NegSyncerKey, string, string, negtypes.EndpointPodMap) {}
+
+func (*NoopReflector) NegHealth(negtypes.NegSyncerKey, string, string) (NegHealth, bool) {
+	return NegHealth{}, false
+}
+
+func (*NoopReflector) ForgetSyncer(negtypes.NegSyncerKey) {}
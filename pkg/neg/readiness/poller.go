@@ -65,22 +65,43 @@ type podStatusPatcher interface {
 	// neg is the key of the NEG resource
 	// backendService is the key of the BackendService resource.
 	syncPod(podKey string, neg, backendService *meta.Key) error
+	// syncAttachedPod syncs the NEG readiness gate condition of the given pod,
+	// whose node is in the GCE_VM_IP NEG with the given key.
+	syncAttachedPod(podKey string, neg *meta.Key) error
 }
 
 // pollTarget is the target for polling
 type pollTarget struct {
-	// endpointMap maps network endpoint to namespaced name of pod
+	// endpointMap maps network endpoint to namespaced name of pod.
+	// For GCE_VM_IP NEGs, the network endpoints carry the IPs of the pods and
+	// the name of the node they are scheduled on.
 	endpointMap negtypes.EndpointPodMap
 	// polling indicates if the NEG is being polled
 	polling bool
 }
 
+// NegHealth is the health status of the endpoints of a NEG at the last poll.
+type NegHealth struct {
+	// PollTime is the time at which the health status was polled.
+	PollTime time.Time
+	// Healthy is the number of endpoints healthy for any backend service.
+	Healthy int
+	// Unhealthy is the number of health checked endpoints that are not
+	// healthy for any backend service.
+	Unhealthy int
+	// Unknown is the number of endpoints without health status.
+	Unknown int
+}
+
 // poller tracks the negs and corresponding targets needed to be polled.
 type poller struct {
 	lock sync.Mutex
 	// pollMap contains negs and corresponding targets needed to be polled.
 	// all operations(read, write) to the pollMap are lock protected.
 	pollMap map[negMeta]*pollTarget
+	// healthMap contains the health status of the negs at the last poll.
+	// It is lock protected as the pollMap.
+	healthMap map[negMeta]NegHealth
 
 	podLister cache.Indexer
 	lookup    NegLookup
@@ -98,6 +119,7 @@ type poller struct {
 func NewPoller(podLister cache.Indexer, lookup NegLookup, patcher podStatusPatcher, negCloud negtypes.NetworkEndpointGroupCloud, enableDualStackNEG bool, logger klog.Logger) *poller {
 	return &poller{
 		pollMap:            make(map[negMeta]*pollTarget),
+		healthMap:          make(map[negMeta]NegHealth),
 		podLister:          podLister,
 		lookup:             lookup,
 		patcher:            patcher,
@@ -112,9 +134,38 @@ func NewPoller(podLister cache.Indexer, lookup NegLookup, patcher podStatusPatch
 func (p *poller) RegisterNegEndpoints(key negMeta, endpointMap negtypes.EndpointPodMap) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	if len(endpointMap) == 0 {
+		delete(p.healthMap, key)
+	}
 	p.registerNegEndpoints(key, endpointMap)
 }
 
+// Health returns the health status of the NEG at the last poll, and whether
+// the NEG has been polled.
+func (p *poller) Health(key negMeta) (NegHealth, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	health, ok := p.healthMap[key]
+	return health, ok
+}
+
+// ForgetSyncer removes the NEGs registered with the given syncer key from the
+// pollMap and the healthMap.
+func (p *poller) ForgetSyncer(syncerKey negtypes.NegSyncerKey) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for key := range p.pollMap {
+		if key.SyncerKey == syncerKey {
+			delete(p.pollMap, key)
+		}
+	}
+	for key := range p.healthMap {
+		if key.SyncerKey == syncerKey {
+			delete(p.healthMap, key)
+		}
+	}
+}
+
 // registerNegEndpoints registered the endpoints that needed to be poll for the NEG
 // It returns false if there is no endpoints needed to be polled, returns true if otherwise.
 // Assumes p.lock is held when calling this method.
@@ -212,6 +263,7 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 		// patchCount is the count of the pod got patched
 		patchCount    int
 		unhealthyPods []types.NamespacedName
+		health        = NegHealth{PollTime: p.clock.Now()}
 	)
 
 	for _, healthStatus := range healthStatuses {
@@ -225,7 +277,17 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 			continue
 		}
 
-		healthChecked = healthChecked || hasSupportedHealthStatus(healthStatus)
+		supported := hasSupportedHealthStatus(healthStatus)
+		healthChecked = healthChecked || supported
+		bsKey := getHealthyBackendService(healthStatus, p.enableDualStackNEG, p.logger)
+		switch {
+		case bsKey != nil:
+			health.Healthy++
+		case supported:
+			health.Unhealthy++
+		default:
+			health.Unknown++
+		}
 
 		ne := negtypes.NetworkEndpoint{
 			IP:   healthStatus.NetworkEndpoint.IpAddress,
@@ -236,24 +298,34 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 			ne.IPv6 = healthStatus.NetworkEndpoint.Ipv6Address
 		}
 
-		podName, ok := p.getPod(key, ne)
-		if !ok {
-			// The pod is not in interest. Skip
+		if key.SyncerKey.NegType == negtypes.VmIpEndpointType {
+			// The health check of a node only passes once the node has a
+			// ready endpoint of the Service, so waiting for it would keep
+			// the pods on the node unready forever. The pods are ready
+			// once their node is in the NEG instead.
+			for _, podName := range p.getPods(key, ne) {
+				if err := p.patcher.syncAttachedPod(keyFunc(podName.Namespace, podName.Name), meta.ZonalKey(key.Name, key.Zone)); err != nil {
+					errList = append(errList, err)
+					continue
+				}
+				patchCount++
+			}
 			continue
 		}
 
-		bsKey := getHealthyBackendService(healthStatus, p.enableDualStackNEG, p.logger)
-		if bsKey == nil {
-			unhealthyPods = append(unhealthyPods, podName)
-			continue
-		}
+		for _, podName := range p.getPods(key, ne) {
+			if bsKey == nil {
+				unhealthyPods = append(unhealthyPods, podName)
+				continue
+			}
 
-		err := p.patcher.syncPod(keyFunc(podName.Namespace, podName.Name), meta.ZonalKey(key.Name, key.Zone), bsKey)
-		if err != nil {
-			errList = append(errList, err)
-			continue
+			err := p.patcher.syncPod(keyFunc(podName.Namespace, podName.Name), meta.ZonalKey(key.Name, key.Zone), bsKey)
+			if err != nil {
+				errList = append(errList, err)
+				continue
+			}
+			patchCount++
 		}
-		patchCount++
 	}
 
 	// if the NEG is not health checked, signal the patcher to mark the unhealthy pods to be Ready.
@@ -270,6 +342,8 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 		}
 	}
 
+	p.healthMap[key] = health
+
 	retry := false
 	if target, ok := p.pollMap[key]; ok {
 		if patchCount < len(target.endpointMap) {
@@ -325,15 +399,28 @@ func hasSupportedHealthStatus(healthStatus *composite.NetworkEndpointWithHealthS
 	return false
 }
 
-// getPod returns the namespaced name of a pod corresponds to an endpoint and whether the pod is registered
+// getPods returns the namespaced names of the pods corresponding to an endpoint.
+// The endpoints of GCE_VM_IP NEGs are nodes, so the pods registered for the NEG
+// that are scheduled on the node of the endpoint are returned.
 // Assumes p.lock is held when calling this method.
-func (p *poller) getPod(key negMeta, endpoint negtypes.NetworkEndpoint) (namespacedName types.NamespacedName, exists bool) {
+func (p *poller) getPods(key negMeta, endpoint negtypes.NetworkEndpoint) []types.NamespacedName {
 	t, ok := p.pollMap[key]
 	if !ok {
-		return types.NamespacedName{}, false
+		return nil
+	}
+	if key.SyncerKey.NegType == negtypes.VmIpEndpointType {
+		var ret []types.NamespacedName
+		for podEndpoint, podName := range t.endpointMap {
+			if podEndpoint.Node == endpoint.Node {
+				ret = append(ret, podName)
+			}
+		}
+		return ret
 	}
-	ret, ok := t.endpointMap[endpoint]
-	return ret, ok
+	if podName, ok := t.endpointMap[endpoint]; ok {
+		return []types.NamespacedName{podName}
+	}
+	return nil
 }
 
 // markPolling returns true if the NEG is successfully marked as polling
@@ -53,6 +53,10 @@ func (p *testPatcher) syncPod(pod string, negKey, bsKey *meta.Key) error {
 	return nil
 }
 
+func (p *testPatcher) syncAttachedPod(pod string, negKey *meta.Key) error {
+	return p.syncPod(pod, negKey, nil)
+}
+
 func (p *testPatcher) Eval(t *testing.T, pod string, negKey, bsKey *meta.Key) {
 	if p.lastPod != pod {
 		t.Errorf("got pod=%q; want=%q", p.lastPod, pod)
@@ -715,3 +719,158 @@ func TestProcessHealthStatus_dualStackNEGs(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessHealthStatusRecordsNegHealth(t *testing.T) {
+	t.Parallel()
+	backendServiceURL := "https://www.googleapis.com/compute/v1/projects/foo/global/backendServices/bsName1"
+	poller := newFakePoller()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	poller.clock = fakeClock
+	key := negMeta{SyncerKey: negtypes.NegSyncerKey{}, Name: "negName", Zone: "zone1"}
+
+	if _, ok := poller.Health(key); ok {
+		t.Fatalf("Health(%v) returned a health status before the NEG was polled", key)
+	}
+
+	res := []*composite.NetworkEndpointWithHealthStatus{
+		{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.1"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: backendServiceURL},
+				HealthState:    healthyState,
+			}},
+		},
+		{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.2"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: backendServiceURL},
+				HealthState:    "UNHEALTHY",
+			}},
+		},
+		{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.3"},
+		},
+	}
+	if _, err := poller.processHealthStatus(key, res); err != nil {
+		t.Fatalf("processHealthStatus() = %v, want nil", err)
+	}
+
+	want := NegHealth{PollTime: fakeClock.Now(), Healthy: 1, Unhealthy: 1, Unknown: 1}
+	got, ok := poller.Health(key)
+	if !ok {
+		t.Fatalf("Health(%v) returned no health status after the NEG was polled", key)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Health(%v) returned unexpected diff (-want +got):\n%s", key, diff)
+	}
+
+	// Registering no endpoints for the NEG forgets its health status.
+	poller.RegisterNegEndpoints(key, negtypes.EndpointPodMap{})
+	if _, ok := poller.Health(key); ok {
+		t.Errorf("Health(%v) returned a health status after the NEG endpoints were removed", key)
+	}
+}
+
+func TestPollerForgetSyncer(t *testing.T) {
+	t.Parallel()
+	poller := newFakePoller()
+	syncerKey := negtypes.NegSyncerKey{Namespace: "ns", Name: "svc", NegName: "neg1"}
+	otherSyncerKey := negtypes.NegSyncerKey{Namespace: "ns", Name: "svc", NegName: "neg2"}
+	keys := []negMeta{
+		{SyncerKey: syncerKey, Name: "neg1", Zone: "zone1"},
+		{SyncerKey: syncerKey, Name: "neg1", Zone: "zone2"},
+		{SyncerKey: otherSyncerKey, Name: "neg2", Zone: "zone1"},
+	}
+	for _, key := range keys {
+		poller.pollMap[key] = &pollTarget{endpointMap: negtypes.EndpointPodMap{}}
+		poller.healthMap[key] = NegHealth{Healthy: 1}
+	}
+
+	poller.ForgetSyncer(syncerKey)
+
+	for _, key := range keys {
+		wantExists := key.SyncerKey == otherSyncerKey
+		if _, ok := poller.pollMap[key]; ok != wantExists {
+			t.Errorf("pollMap contains %v = %v, want %v", key, ok, wantExists)
+		}
+		if _, ok := poller.Health(key); ok != wantExists {
+			t.Errorf("Health(%v) returned a health status = %v, want %v", key, ok, wantExists)
+		}
+	}
+}
+
+// podRecordingPatcher records the BackendService each pod was synced with,
+// and the NEG each pod of an attached node was synced with.
+type podRecordingPatcher struct {
+	pods     map[string]*meta.Key
+	attached map[string]*meta.Key
+}
+
+func (p *podRecordingPatcher) syncPod(pod string, negKey, bsKey *meta.Key) error {
+	p.pods[pod] = bsKey
+	return nil
+}
+
+func (p *podRecordingPatcher) syncAttachedPod(pod string, negKey *meta.Key) error {
+	p.attached[pod] = negKey
+	return nil
+}
+
+func TestProcessHealthStatus_vmIpNEGs(t *testing.T) {
+	t.Parallel()
+	backendServiceURL := "https://www.googleapis.com/compute/v1/projects/foo/global/backendServices/bsName1"
+	poller := newFakePoller()
+	patcher := &podRecordingPatcher{pods: make(map[string]*meta.Key), attached: make(map[string]*meta.Key)}
+	poller.patcher = patcher
+	key := negMeta{SyncerKey: negtypes.NegSyncerKey{NegType: negtypes.VmIpEndpointType}, Name: "negName", Zone: "zone1"}
+
+	// Pod endpoints of a GCE_VM_IP NEG carry the pod IPs and the node name.
+	poller.pollMap[key] = &pollTarget{endpointMap: negtypes.EndpointPodMap{
+		{IP: "10.100.1.1", Node: "node1"}: {Namespace: "ns", Name: "pod1"},
+		{IP: "10.100.1.2", Node: "node1"}: {Namespace: "ns", Name: "pod2"},
+		{IP: "10.100.2.1", Node: "node2"}: {Namespace: "ns", Name: "pod3"},
+		{IP: "10.100.3.1", Node: "node3"}: {Namespace: "ns", Name: "pod4"},
+	}}
+
+	res := []*composite.NetworkEndpointWithHealthStatus{
+		{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.1", Instance: "node1"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: backendServiceURL},
+				HealthState:    healthyState,
+			}},
+		},
+		{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.2", Instance: "node2"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: backendServiceURL},
+				HealthState:    "UNHEALTHY",
+			}},
+		},
+	}
+	retry, err := poller.processHealthStatus(key, res)
+	if err != nil {
+		t.Fatalf("processHealthStatus() = %v, want nil", err)
+	}
+	if !retry {
+		t.Errorf("processHealthStatus() returned retry = false, want true as not all pods were patched")
+	}
+
+	// The pods of the nodes in the NEG are ready whatever the health of the
+	// node, which depends on the readiness of the pods.
+	negKey := meta.ZonalKey("negName", "zone1")
+	want := map[string]*meta.Key{
+		keyFunc("ns", "pod1"): negKey,
+		keyFunc("ns", "pod2"): negKey,
+		keyFunc("ns", "pod3"): negKey,
+	}
+	if diff := cmp.Diff(want, patcher.attached); diff != "" {
+		t.Errorf("processHealthStatus() synced unexpected pods of attached nodes (-want +got):\n%s", diff)
+	}
+	if len(patcher.pods) != 0 {
+		t.Errorf("processHealthStatus() synced pods %v based on the health of their node, want none", patcher.pods)
+	}
+	if got, _ := poller.Health(key); got.Healthy != 1 || got.Unhealthy != 1 {
+		t.Errorf("Health(%v) = %+v, want 1 healthy and 1 unhealthy endpoint", key, got)
+	}
+}
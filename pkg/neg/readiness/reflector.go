@@ -47,6 +47,8 @@ const (
 	negReadyTimedOutReason = "LoadBalancerNegTimeout"
 	// negReadyUnhealthCheckedReason is the pod condition reason when pod is in a NEG without associated health checking
 	negReadyUnhealthCheckedReason = "LoadBalancerNegWithoutHealthCheck"
+	// negReadyNodeAttachedReason is the pod condition reason when the node of the pod is in a GCE_VM_IP NEG
+	negReadyNodeAttachedReason = "LoadBalancerNegNodeAttached"
 	// negNotReadyReason is the pod condition reason when pod is not healthy in NEG
 	negNotReadyReason = "LoadBalancerNegNotReady"
 	// unreadyTimeout is the timeout for health status feedback for pod readiness. If load balancer health
@@ -153,6 +155,29 @@ func (r *readinessReflector) handleErr(err error, key interface{}) {
 // syncPod process pod and patch the NEG readiness condition if needed
 // if neg and backendService is specified, it means pod is Healthy in the NEG attached to backendService.
 func (r *readinessReflector) syncPod(podKey string, neg, backendService *meta.Key) (err error) {
+	r.logger.V(3).Info("Syncing pod", "pod", podKey, "neg", neg, "backendService", backendService)
+	return r.syncPodCondition(podKey, func(pod *v1.Pod) v1.PodCondition {
+		return r.getExpectedNegCondition(pod, neg, backendService)
+	})
+}
+
+// syncAttachedPod marks the NEG readiness condition of the pod True, as its
+// node is in the given GCE_VM_IP NEG.
+func (r *readinessReflector) syncAttachedPod(podKey string, neg *meta.Key) error {
+	r.logger.V(3).Info("Syncing pod of attached node", "pod", podKey, "neg", neg)
+	return r.syncPodCondition(podKey, func(*v1.Pod) v1.PodCondition {
+		return v1.PodCondition{
+			Type:    shared.NegReadinessGate,
+			Status:  v1.ConditionTrue,
+			Reason:  negReadyNodeAttachedReason,
+			Message: fmt.Sprintf("The node of the pod is in NEG %q. Marking condition %q to True.", neg.String(), shared.NegReadinessGate),
+		}
+	})
+}
+
+// syncPodCondition patches the NEG readiness condition of the pod to the one
+// returned by expectedCondition, if the pod needs to be processed.
+func (r *readinessReflector) syncPodCondition(podKey string, expectedCondition func(pod *v1.Pod) v1.PodCondition) (err error) {
 	// podUpdateLock to ensure there is no race in pod status update
 	r.podUpdateLock.Lock()
 	defer r.podUpdateLock.Unlock()
@@ -176,9 +201,7 @@ func (r *readinessReflector) syncPod(podKey string, neg, backendService *meta.Ke
 		return nil
 	}
 
-	r.logger.V(3).Info("Syncing pod", "pod", podKey, "neg", neg, "backendService", backendService)
-	expectedCondition := r.getExpectedNegCondition(pod, neg, backendService)
-	return r.ensurePodNegCondition(pod, expectedCondition)
+	return r.ensurePodNegCondition(pod, expectedCondition(pod))
 }
 
 // getExpectedCondition returns the expected NEG readiness condition for the given pod
@@ -204,7 +227,7 @@ func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg, backendSe
 
 	negs := r.lookup.ReadinessGateEnabledNegs(pod.Namespace, pod.Labels)
 	// mark pod as ready if it belongs to no NEGs
-	if len(negs) == 0 {
+	if len(negs) == 0 && !r.lookup.NegsManagedElsewhere(pod.Namespace, pod.Labels) {
 		expectedCondition.Status = v1.ConditionTrue
 		expectedCondition.Reason = negReadyReason
 		expectedCondition.Message = fmt.Sprintf("Pod does not belong to any NEG. Marking condition %q to True.", shared.NegReadinessGate)
@@ -220,6 +243,13 @@ func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg, backendSe
 		return expectedCondition
 	}
 
+	// the replica of the NEG controller managing the NEGs which may contain the pod updates its condition.
+	if len(negs) == 0 {
+		expectedCondition.Reason = negNotReadyReason
+		expectedCondition.Message = "Waiting for the NEG controller replica managing the NEGs of the pod's services."
+		return expectedCondition
+	}
+
 	if r.enableMultiSubnetCluster {
 		if pod.Spec.NodeName == "" {
 			r.logger.Error(nil, "Unable to determine the pod's node name.", "podNamespace", pod.Namespace, "podName", pod.Name)
@@ -277,6 +307,20 @@ func (r *readinessReflector) CommitPods(syncerKey negtypes.NegSyncerKey, negName
 	r.poll()
 }
 
+// NegHealth returns the health status of the endpoints in a NEG at the last poll
+func (r *readinessReflector) NegHealth(syncerKey negtypes.NegSyncerKey, negName string, zone string) (NegHealth, bool) {
+	return r.poller.Health(negMeta{
+		SyncerKey: syncerKey,
+		Name:      negName,
+		Zone:      zone,
+	})
+}
+
+// ForgetSyncer stops polling the NEGs of a stopped syncer and drops their health status
+func (r *readinessReflector) ForgetSyncer(syncerKey negtypes.NegSyncerKey) {
+	r.poller.ForgetSyncer(syncerKey)
+}
+
 // poll spins off go routines to poll NEGs
 func (r *readinessReflector) poll() {
 	r.pollerLock.Lock()
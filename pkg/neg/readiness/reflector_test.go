@@ -47,6 +47,7 @@ const (
 type fakeLookUp struct {
 	readinessGateEnabled     bool
 	readinessGateEnabledNegs []string
+	negsManagedElsewhere     bool
 }
 
 func (f *fakeLookUp) ReadinessGateEnabledNegs(namespace string, labels map[string]string) []string {
@@ -58,6 +59,10 @@ func (f *fakeLookUp) ReadinessGateEnabled(syncerKey negtypes.NegSyncerKey) bool
 	return f.readinessGateEnabled
 }
 
+func (f *fakeLookUp) NegsManagedElsewhere(namespace string, labels map[string]string) bool {
+	return f.negsManagedElsewhere
+}
+
 func newTestReadinessReflector(testContext *negtypes.TestContext, enableMultiSubnetCluster bool) *readinessReflector {
 	fakeZoneGetter := zonegetter.NewFakeZoneGetter(testContext.NodeInformer, testContext.NodeTopologyInformer, defaultTestSubnetURL, enableMultiSubnetCluster)
 	reflector := NewReadinessReflector(
@@ -380,6 +385,44 @@ func TestSyncPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "need to update pod: there is no Negs associated but NEGs managed by another replica may contain the pod",
+			mutateState: func(testlookUp *fakeLookUp) {
+				pod := generatePod(testServiceNamespace, "pod8", true, false, false)
+				pod.CreationTimestamp = now
+				podLister.Add(pod)
+				client.CoreV1().Pods(testServiceNamespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				testlookUp.readinessGateEnabledNegs = []string{}
+				testlookUp.negsManagedElsewhere = true
+			},
+			inputKey:     keyFunc(testServiceNamespace, "pod8"),
+			inputNeg:     nil,
+			expectExists: true,
+			expectPod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testServiceNamespace,
+					Name:      "pod8",
+					Labels: map[string]string{
+						utils.LabelNodeSubnet: defaultTestSubnet,
+					},
+				},
+				Spec: v1.PodSpec{
+					NodeName: nodeName,
+					ReadinessGates: []v1.PodReadinessGate{
+						{ConditionType: shared.NegReadinessGate},
+					},
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:    shared.NegReadinessGate,
+							Reason:  negNotReadyReason,
+							Message: "Waiting for the NEG controller replica managing the NEGs of the pod's services.",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -407,6 +450,36 @@ func TestSyncPod(t *testing.T) {
 	}
 }
 
+func TestSyncAttachedPod(t *testing.T) {
+	t.Parallel()
+	fakeContext := negtypes.NewTestContext()
+	testReadinessReflector := newTestReadinessReflector(fakeContext, false)
+	testlookUp := testReadinessReflector.lookup.(*fakeLookUp)
+	// The pod waits for a GCE_VM_IP NEG.
+	testlookUp.readinessGateEnabledNegs = []string{"neg1"}
+
+	pod := generatePod(testServiceNamespace, "pod1", true, true, false)
+	fakeContext.PodInformer.GetIndexer().Add(pod)
+	fakeContext.KubeClient.CoreV1().Pods(testServiceNamespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+
+	negKey := meta.ZonalKey("neg1", "zone1")
+	if err := testReadinessReflector.syncAttachedPod(keyFunc(testServiceNamespace, "pod1"), negKey); err != nil {
+		t.Fatalf("syncAttachedPod() = %v, want nil", err)
+	}
+
+	got, err := fakeContext.KubeClient.CoreV1().Pods(testServiceNamespace).Get(context.TODO(), "pod1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(pod1) = %v, want nil", err)
+	}
+	condition, ok := NegReadinessConditionStatus(got)
+	if !ok {
+		t.Fatalf("Pod has no %s condition", shared.NegReadinessGate)
+	}
+	if condition.Status != v1.ConditionTrue || condition.Reason != negReadyNodeAttachedReason {
+		t.Errorf("Got condition %+v, want status %s with reason %s", condition, v1.ConditionTrue, negReadyNodeAttachedReason)
+	}
+}
+
 func TestSyncPodMultipleSubnets(t *testing.T) {
 	t.Parallel()
 	fakeContext := negtypes.NewTestContext()
@@ -0,0 +1,468 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationclient "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// LeaseRoleLabelKey is the label of the Leases used for sharding, set to
+	// leaseRoleShard or leaseRoleMember.
+	LeaseRoleLabelKey = "networking.gke.io/neg-sharding-role"
+	// LeaseShardLabelKey is the label with the shard of a shard Lease.
+	LeaseShardLabelKey = "networking.gke.io/neg-shard"
+
+	// leaseRoleShard is the role of the Leases held by the owner of each
+	// shard.
+	leaseRoleShard = "shard"
+	// leaseRoleMember is the role of the Leases each replica renews to be
+	// assigned shards.
+	leaseRoleMember = "member"
+)
+
+// Config contains the configuration of a Coordinator.
+type Config struct {
+	// Namespace is the namespace of the Leases.
+	Namespace string
+	// LeasePrefix is the prefix of the names of the Leases.
+	LeasePrefix string
+	// Identity is the unique identity of the replica.
+	Identity string
+	// NumShards is the number of shards the Services are assigned to.
+	NumShards int
+	// LeaseDuration is the duration after which a Lease which was not
+	// renewed can be taken by another replica.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration after which the replica stops owning a
+	// shard whose Lease it could not renew. It must be shorter than
+	// LeaseDuration, so that the replica stops the work on the Services of
+	// the shard before another replica can acquire it.
+	RenewDeadline time.Duration
+	// RetryPeriod is the period at which the Leases are renewed, and shards
+	// are acquired and released. It is also the timeout of each call to the
+	// API server.
+	RetryPeriod time.Duration
+}
+
+// Handler is notified when the replica acquires or releases a shard.
+type Handler struct {
+	// OnAcquire is called after the replica acquires the shard.
+	OnAcquire func(shard int)
+	// OnRelease is called after the replica stops owning the shard, and
+	// before its Lease is released for other replicas to acquire it. It must
+	// stop all the work on the Services of the shard before returning.
+	OnRelease func(shard int)
+}
+
+// Coordinator assigns the Services to the replicas of the NEG controller.
+// Services are assigned to shards by consistent hashing of their key. Each
+// shard is owned by the replica holding its Lease. Replicas renew a member
+// Lease to be part of the live replicas, and each shard is assigned to one of
+// them by rendezvous hashing, so that all replicas agree on the assignment
+// and only the shards of the added or removed replicas move. A replica
+// releases the shards which are no longer assigned to it, and acquires the
+// shards assigned to it once their Lease is released or expired.
+type Coordinator struct {
+	client coordinationclient.LeasesGetter
+	config Config
+	ring   *Ring
+	clock  clock.Clock
+
+	mu sync.RWMutex
+	// owned maps the shards owned by the replica to the time at which they
+	// were acquired.
+	owned map[int]time.Time
+	// renewed maps the shards owned by the replica to the time at which
+	// their Lease was last renewed. It is only accessed by sync.
+	renewed map[int]time.Time
+
+	handlers []Handler
+
+	logger klog.Logger
+}
+
+// NewCoordinator returns a Coordinator which manages its Leases with client.
+func NewCoordinator(client coordinationclient.LeasesGetter, config Config, logger klog.Logger) *Coordinator {
+	return &Coordinator{
+		client:  client,
+		config:  config,
+		ring:    NewRing(config.NumShards),
+		clock:   clock.RealClock{},
+		owned:   make(map[int]time.Time),
+		renewed: make(map[int]time.Time),
+		logger:  logger.WithName("ShardCoordinator").WithValues("identity", config.Identity),
+	}
+}
+
+// AddHandler registers a Handler. Handlers must be added before Run.
+func (c *Coordinator) AddHandler(handler Handler) {
+	c.handlers = append(c.handlers, handler)
+}
+
+// Run acquires, renews and releases the shards until stopCh is closed, and
+// then releases all the shards of the replica.
+func (c *Coordinator) Run(stopCh <-chan struct{}) {
+	c.logger.Info("Starting shard coordinator", "numShards", c.ring.NumShards())
+	wait.Until(c.sync, c.config.RetryPeriod, stopCh)
+	c.releaseAll()
+}
+
+// Owns returns true if the replica owns the shard of the Service.
+func (c *Coordinator) Owns(namespace, name string) bool {
+	_, ok := c.OwnedSince(namespace, name)
+	return ok
+}
+
+// OwnedSince returns the time at which the replica acquired the shard of the
+// Service, and false if it does not own it.
+func (c *Coordinator) OwnedSince(namespace, name string) (time.Time, bool) {
+	shard := c.ring.Shard(ServiceKey(namespace, name))
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	acquired, ok := c.owned[shard]
+	return acquired, ok
+}
+
+// Shard returns the shard of the Service.
+func (c *Coordinator) Shard(namespace, name string) int {
+	return c.ring.Shard(ServiceKey(namespace, name))
+}
+
+// OwnedShards returns the shards owned by the replica in increasing order.
+func (c *Coordinator) OwnedShards() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	shards := make([]int, 0, len(c.owned))
+	for shard := range c.owned {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+// sync renews the member Lease of the replica, and acquires, renews and
+// releases the shard Leases according to the live replicas.
+func (c *Coordinator) sync() {
+	now := c.clock.Now()
+	if err := c.renewMember(now); err != nil {
+		c.logger.Error(err, "Failed to renew member lease")
+	}
+
+	ctx, cancel := c.callContext()
+	leaseList, err := c.client.Leases(c.config.Namespace).List(ctx, metav1.ListOptions{LabelSelector: LeaseRoleLabelKey})
+	cancel()
+	if err != nil {
+		c.logger.Error(err, "Failed to list leases")
+		c.dropExpired(now)
+		return
+	}
+
+	members := []string{c.config.Identity}
+	shardLeases := make(map[int]*coordinationv1.Lease)
+	for i := range leaseList.Items {
+		lease := &leaseList.Items[i]
+		if !c.ownsLeaseName(lease.Name) {
+			continue
+		}
+		switch lease.Labels[LeaseRoleLabelKey] {
+		case leaseRoleMember:
+			if holder := holderIdentity(lease); holder != "" && holder != c.config.Identity && !c.expired(lease, now) {
+				members = append(members, holder)
+			}
+		case leaseRoleShard:
+			shard, err := strconv.Atoi(lease.Labels[LeaseShardLabelKey])
+			if err != nil || shard < 0 || shard >= c.ring.NumShards() {
+				continue
+			}
+			shardLeases[shard] = lease
+		}
+	}
+
+	for shard := 0; shard < c.ring.NumShards(); shard++ {
+		lease := shardLeases[shard]
+		assigned := assignedMember(shard, members) == c.config.Identity
+		heldBySelf := lease != nil && holderIdentity(lease) == c.config.Identity
+		_, owned := c.renewed[shard]
+		switch {
+		case owned && lease != nil && !heldBySelf:
+			c.logger.Info("Lost shard lease to another replica", "shard", shard, "holder", holderIdentity(lease))
+			c.drop(shard)
+		case owned && !assigned:
+			c.release(shard, lease)
+		case owned && lease != nil:
+			c.renew(shard, lease, now)
+		case assigned:
+			c.acquire(shard, lease, now)
+		case heldBySelf:
+			// The replica stopped owning the shard as it could not renew
+			// the lease in time, release it for the assigned replica.
+			c.releaseLease(lease)
+		}
+	}
+	c.dropExpired(now)
+}
+
+// renewMember creates or renews the member Lease of the replica.
+func (c *Coordinator) renewMember(now time.Time) error {
+	name := c.memberLeaseName()
+	lease, err := c.getLease(name)
+	if errors.IsNotFound(err) {
+		return c.createLease(c.newLease(name, map[string]string{LeaseRoleLabelKey: leaseRoleMember}, now))
+	}
+	if err != nil {
+		return err
+	}
+	lease = lease.DeepCopy()
+	c.setHolder(lease, now)
+	return c.updateLease(lease)
+}
+
+// acquire creates or takes the Lease of the shard if it is free, and notifies
+// the handlers on success.
+func (c *Coordinator) acquire(shard int, lease *coordinationv1.Lease, now time.Time) {
+	var err error
+	if lease == nil {
+		labels := map[string]string{LeaseRoleLabelKey: leaseRoleShard, LeaseShardLabelKey: strconv.Itoa(shard)}
+		err = c.createLease(c.newLease(c.shardLeaseName(shard), labels, now))
+	} else {
+		holder := holderIdentity(lease)
+		if holder != "" && holder != c.config.Identity && !c.expired(lease, now) {
+			// Wait for the previous owner to release the shard.
+			return
+		}
+		lease = lease.DeepCopy()
+		if holder != c.config.Identity {
+			transitions := int32(1)
+			if lease.Spec.LeaseTransitions != nil {
+				transitions += *lease.Spec.LeaseTransitions
+			}
+			lease.Spec.LeaseTransitions = &transitions
+			lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+		}
+		c.setHolder(lease, now)
+		err = c.updateLease(lease)
+	}
+	if err != nil {
+		c.logger.Error(err, "Failed to acquire shard lease", "shard", shard)
+		return
+	}
+
+	c.logger.Info("Acquired shard", "shard", shard)
+	c.mu.Lock()
+	c.owned[shard] = now
+	c.mu.Unlock()
+	c.renewed[shard] = now
+	for _, handler := range c.handlers {
+		if handler.OnAcquire != nil {
+			handler.OnAcquire(shard)
+		}
+	}
+}
+
+// renew renews the Lease of a shard owned by the replica.
+func (c *Coordinator) renew(shard int, lease *coordinationv1.Lease, now time.Time) {
+	lease = lease.DeepCopy()
+	c.setHolder(lease, now)
+	if err := c.updateLease(lease); err != nil {
+		c.logger.Error(err, "Failed to renew shard lease", "shard", shard)
+		return
+	}
+	c.renewed[shard] = now
+}
+
+// release hands off a shard owned by the replica: the handlers stop the work
+// on the Services of the shard before its Lease is released.
+func (c *Coordinator) release(shard int, lease *coordinationv1.Lease) {
+	c.logger.Info("Releasing shard assigned to another replica", "shard", shard)
+	c.drop(shard)
+	if lease != nil {
+		c.releaseLease(lease)
+	}
+}
+
+// releaseLease clears the holder of the Lease so that the assigned replica
+// can acquire it without waiting for it to expire.
+func (c *Coordinator) releaseLease(lease *coordinationv1.Lease) {
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.RenewTime = nil
+	if err := c.updateLease(lease); err != nil {
+		c.logger.Error(err, "Failed to release lease", "lease", klog.KObj(lease))
+	}
+}
+
+// drop stops owning the shard and notifies the handlers.
+func (c *Coordinator) drop(shard int) {
+	c.mu.Lock()
+	delete(c.owned, shard)
+	c.mu.Unlock()
+	delete(c.renewed, shard)
+	for _, handler := range c.handlers {
+		if handler.OnRelease != nil {
+			handler.OnRelease(shard)
+		}
+	}
+}
+
+// dropExpired stops owning the shards whose Lease could not be renewed
+// within RenewDeadline, so that the work on their Services is stopped before
+// the Lease expires and other replicas may acquire them.
+func (c *Coordinator) dropExpired(now time.Time) {
+	for shard, renewed := range c.renewed {
+		if !now.Before(renewed.Add(c.config.RenewDeadline)) {
+			c.logger.Info("Shard lease could not be renewed before the renew deadline", "shard", shard, "renewDeadline", c.config.RenewDeadline)
+			c.drop(shard)
+		}
+	}
+}
+
+// releaseAll releases all the shards owned by the replica, and deletes its
+// member Lease, so that other replicas take over without waiting for the
+// Leases to expire.
+func (c *Coordinator) releaseAll() {
+	for shard := range c.renewed {
+		lease, err := c.getLease(c.shardLeaseName(shard))
+		if err != nil {
+			c.logger.Error(err, "Failed to get shard lease", "shard", shard)
+			lease = nil
+		} else if holderIdentity(lease) != c.config.Identity {
+			lease = nil
+		}
+		c.release(shard, lease)
+	}
+	ctx, cancel := c.callContext()
+	defer cancel()
+	if err := c.client.Leases(c.config.Namespace).Delete(ctx, c.memberLeaseName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		c.logger.Error(err, "Failed to delete member lease")
+	}
+}
+
+// callContext returns the context of a call to the API server, which times
+// out after RetryPeriod so that a slow call does not hold up the renewal of
+// the other Leases.
+func (c *Coordinator) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.config.RetryPeriod)
+}
+
+func (c *Coordinator) getLease(name string) (*coordinationv1.Lease, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.client.Leases(c.config.Namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *Coordinator) createLease(lease *coordinationv1.Lease) error {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	_, err := c.client.Leases(c.config.Namespace).Create(ctx, lease, metav1.CreateOptions{})
+	return err
+}
+
+func (c *Coordinator) updateLease(lease *coordinationv1.Lease) error {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	_, err := c.client.Leases(c.config.Namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Coordinator) newLease(name string, labels map[string]string, now time.Time) *coordinationv1.Lease {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.config.Namespace,
+			Labels:    labels,
+		},
+		Spec: coordinationv1.LeaseSpec{AcquireTime: &metav1.MicroTime{Time: now}},
+	}
+	c.setHolder(lease, now)
+	return lease
+}
+
+func (c *Coordinator) setHolder(lease *coordinationv1.Lease, now time.Time) {
+	identity := c.config.Identity
+	durationSeconds := int32(c.config.LeaseDuration / time.Second)
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+}
+
+// expired returns true if the Lease was not renewed within its duration.
+func (c *Coordinator) expired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+	duration := c.config.LeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return !now.Before(lease.Spec.RenewTime.Add(duration))
+}
+
+func (c *Coordinator) shardLeaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", c.config.LeasePrefix, shard)
+}
+
+// memberLeaseName returns the name of the member Lease of the replica, which
+// is derived from a hash of its identity as identities may not be valid
+// object names.
+func (c *Coordinator) memberLeaseName() string {
+	h := fnv.New32a()
+	h.Write([]byte(c.config.Identity))
+	return fmt.Sprintf("%s-member-%08x", c.config.LeasePrefix, h.Sum32())
+}
+
+// ownsLeaseName returns true if the Lease belongs to the Coordinators with
+// the same LeasePrefix.
+func (c *Coordinator) ownsLeaseName(name string) bool {
+	return strings.HasPrefix(name, c.config.LeasePrefix+"-")
+}
+
+func holderIdentity(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+// assignedMember returns the replica the shard is assigned to by rendezvous
+// hashing: the replica with the highest hash combined with the shard.
+func assignedMember(shard int, members []string) string {
+	var assigned string
+	var maxHash uint64
+	for _, member := range members {
+		h := hash(fmt.Sprintf("%s/%d", member, shard))
+		if assigned == "" || h > maxHash || (h == maxHash && member < assigned) {
+			assigned, maxHash = member, h
+		}
+	}
+	return assigned
+}
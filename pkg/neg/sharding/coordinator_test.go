@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+const (
+	testNamespace     = "kube-system"
+	testLeasePrefix   = "ingress-gce-neg-lock"
+	testNumShards     = 16
+	testLeaseDuration = 15 * time.Second
+	testRenewDeadline = 10 * time.Second
+	testRetryPeriod   = 2 * time.Second
+)
+
+// testCluster runs Coordinators of several replicas on a fake cluster with
+// many Services.
+type testCluster struct {
+	t        *testing.T
+	client   *fake.Clientset
+	clock    *clocktesting.FakeClock
+	services []types.NamespacedName
+	replicas map[string]*Coordinator
+	// running contains the replicas which sync their Leases.
+	running map[string]bool
+	// events counts the acquired and released shards of each replica.
+	acquired map[string]int
+	released map[string]int
+}
+
+func newTestCluster(t *testing.T, numServices int) *testCluster {
+	services := make([]types.NamespacedName, numServices)
+	for i := range services {
+		services[i] = types.NamespacedName{Namespace: fmt.Sprintf("ns-%d", i%20), Name: fmt.Sprintf("svc-%d", i)}
+	}
+	return &testCluster{
+		t:        t,
+		client:   fake.NewSimpleClientset(),
+		clock:    clocktesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		services: services,
+		replicas: make(map[string]*Coordinator),
+		running:  make(map[string]bool),
+		acquired: make(map[string]int),
+		released: make(map[string]int),
+	}
+}
+
+func (tc *testCluster) addReplica(identity string) *Coordinator {
+	c := NewCoordinator(tc.client.CoordinationV1(), Config{
+		Namespace:     testNamespace,
+		LeasePrefix:   testLeasePrefix,
+		Identity:      identity,
+		NumShards:     testNumShards,
+		LeaseDuration: testLeaseDuration,
+		RenewDeadline: testRenewDeadline,
+		RetryPeriod:   testRetryPeriod,
+	}, klog.TODO())
+	c.clock = tc.clock
+	c.AddHandler(Handler{
+		OnAcquire: func(int) { tc.acquired[identity]++ },
+		OnRelease: func(int) {
+			tc.released[identity]++
+			// Handoff must not overlap: the released shard must no longer
+			// be owned when the handlers are called.
+			tc.checkAtMostOnce()
+		},
+	})
+	tc.replicas[identity] = c
+	tc.running[identity] = true
+	return c
+}
+
+// syncRound syncs each running replica once, and checks after each sync that
+// no Service is owned by several replicas.
+func (tc *testCluster) syncRound() {
+	for identity, c := range tc.replicas {
+		if tc.running[identity] {
+			c.sync()
+			tc.checkAtMostOnce()
+		}
+	}
+	tc.clock.Step(testRetryPeriod)
+}
+
+// converge syncs the replicas until each shard is owned by the running
+// replica it is assigned to, and fails the test if it takes longer than
+// maxRounds rounds.
+func (tc *testCluster) converge(maxRounds int) {
+	tc.t.Helper()
+	for i := 0; i < maxRounds; i++ {
+		tc.syncRound()
+		if tc.balanced() {
+			return
+		}
+	}
+	tc.t.Fatalf("Shards are not owned by their assigned replicas after %d rounds, %d services are not owned", maxRounds, tc.unowned())
+}
+
+// balanced returns true if each shard is owned by the running replica it is
+// assigned to, and by no other replica.
+func (tc *testCluster) balanced() bool {
+	var members []string
+	for identity := range tc.replicas {
+		if tc.running[identity] {
+			members = append(members, identity)
+		}
+	}
+	for shard := 0; shard < testNumShards; shard++ {
+		assigned := assignedMember(shard, members)
+		for _, identity := range members {
+			_, owned := tc.replicas[identity].owned[shard]
+			if owned != (identity == assigned) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (tc *testCluster) owners(service types.NamespacedName) []string {
+	var owners []string
+	for identity, c := range tc.replicas {
+		if tc.running[identity] && c.Owns(service.Namespace, service.Name) {
+			owners = append(owners, identity)
+		}
+	}
+	return owners
+}
+
+func (tc *testCluster) checkAtMostOnce() {
+	tc.t.Helper()
+	for _, service := range tc.services {
+		if owners := tc.owners(service); len(owners) > 1 {
+			tc.t.Fatalf("Service %s is owned by several replicas: %v", service, owners)
+		}
+	}
+}
+
+func (tc *testCluster) unowned() int {
+	count := 0
+	for _, service := range tc.services {
+		if len(tc.owners(service)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// checkOwnedOnce checks that every Service is owned exactly once, and that
+// every running replica owns shards.
+func (tc *testCluster) checkOwnedOnce() {
+	tc.t.Helper()
+	for _, service := range tc.services {
+		if owners := tc.owners(service); len(owners) != 1 {
+			tc.t.Errorf("Service %s is owned by %v, want exactly one replica", service, owners)
+		}
+	}
+	for identity, c := range tc.replicas {
+		if tc.running[identity] && len(c.OwnedShards()) == 0 {
+			tc.t.Errorf("Replica %s owns no shards", identity)
+		}
+	}
+}
+
+func TestCoordinatorOwnsEveryServiceOnce(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestCluster(t, 5000)
+	for i := 0; i < 3; i++ {
+		tc.addReplica(fmt.Sprintf("replica-%d", i))
+	}
+	tc.converge(5)
+	tc.checkOwnedOnce()
+
+	// A new replica takes over shards released by the other replicas.
+	tc.addReplica("replica-3")
+	tc.converge(5)
+	tc.checkOwnedOnce()
+	if tc.acquired["replica-3"] == 0 {
+		t.Errorf("replica-3 did not acquire any shard")
+	}
+
+	// A replica shutting down releases its shards cleanly, so that other
+	// replicas take them over without waiting for the Leases to expire.
+	shutdown := tc.replicas["replica-0"]
+	shards := len(shutdown.OwnedShards())
+	releasedBefore := tc.released["replica-0"]
+	shutdown.releaseAll()
+	tc.running["replica-0"] = false
+	if got := tc.released["replica-0"] - releasedBefore; got != shards {
+		t.Errorf("replica-0 released %d shards on shutdown, want %d", got, shards)
+	}
+	tc.converge(3)
+	tc.checkOwnedOnce()
+
+	// The shards of a crashed replica are taken over once their Leases
+	// expire.
+	tc.running["replica-1"] = false
+	rounds := int(testLeaseDuration/testRetryPeriod) + 3
+	tc.converge(rounds)
+	tc.checkOwnedOnce()
+}
+
+func TestCoordinatorDropsExpiredShards(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestCluster(t, 100)
+	c := tc.addReplica("replica-0")
+	tc.converge(2)
+	if got := len(c.OwnedShards()); got != testNumShards {
+		t.Fatalf("Single replica owns %d shards, want %d", got, testNumShards)
+	}
+
+	// The replica stops owning its shards if it cannot renew their Leases
+	// before the renew deadline, which is before they expire. The clock has
+	// already moved by testRetryPeriod since the last renewal.
+	tc.clock.Step(testRenewDeadline - testRetryPeriod - time.Second)
+	c.dropExpired(tc.clock.Now())
+	if got := len(c.OwnedShards()); got != testNumShards {
+		t.Errorf("Replica owns %d shards before the renew deadline, want %d", got, testNumShards)
+	}
+	tc.clock.Step(time.Second)
+	c.dropExpired(tc.clock.Now())
+	if got := len(c.OwnedShards()); got != 0 {
+		t.Errorf("Replica owns %d shards after the renew deadline, want 0", got)
+	}
+	if tc.released["replica-0"] != testNumShards {
+		t.Errorf("Replica released %d shards, want %d", tc.released["replica-0"], testNumShards)
+	}
+
+	// It acquires them again on the next sync.
+	tc.converge(1)
+	tc.checkOwnedOnce()
+}
+
+func TestCoordinatorHandoff(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestCluster(t, 100)
+	old := tc.addReplica("replica-0")
+	tc.converge(2)
+
+	// The Lease of a released shard is only released once the handlers of
+	// the previous owner have stopped the work on its Services.
+	old.AddHandler(Handler{
+		OnRelease: func(shard int) {
+			lease, err := tc.client.CoordinationV1().Leases(testNamespace).Get(context.TODO(), old.shardLeaseName(shard), metav1.GetOptions{})
+			if err != nil {
+				t.Errorf("Failed to get lease of shard %d: %v", shard, err)
+				return
+			}
+			if holder := holderIdentity(lease); holder != "replica-0" {
+				t.Errorf("Lease of shard %d is held by %q while replica-0 releases it, want replica-0", shard, holder)
+			}
+		},
+	})
+	cur := tc.addReplica("replica-1")
+	tc.converge(5)
+	tc.checkOwnedOnce()
+	if tc.released["replica-0"] == 0 {
+		t.Fatalf("replica-0 did not release any shard to replica-1")
+	}
+
+	// A replica which cannot reach the API server stops owning its shards
+	// at the renew deadline, before another replica can acquire them once
+	// their Leases expire.
+	shards := cur.OwnedShards()
+	for elapsed := time.Duration(0); elapsed <= testLeaseDuration+2*testRetryPeriod; elapsed += testRetryPeriod {
+		tc.clock.Step(testRetryPeriod)
+		old.sync()
+		for _, service := range tc.services {
+			if old.Owns(service.Namespace, service.Name) && cur.Owns(service.Namespace, service.Name) {
+				t.Fatalf("Service %s is owned by both replicas after %v", service, elapsed+testRetryPeriod)
+			}
+		}
+		cur.dropExpired(tc.clock.Now())
+	}
+	for _, shard := range shards {
+		if _, ok := old.owned[shard]; !ok {
+			t.Errorf("replica-0 did not take over shard %d of unreachable replica-1", shard)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// virtualNodesPerShard is the number of points of each shard on the ring.
+// More points spread the Services more evenly across the shards.
+const virtualNodesPerShard = 128
+
+// Ring assigns Service keys to shards by consistent hashing, so that changing
+// the number of shards only moves the Services of the added or removed
+// shards.
+type Ring struct {
+	numShards int
+	points    []ringPoint
+}
+
+type ringPoint struct {
+	hash  uint64
+	shard int
+}
+
+// NewRing returns a Ring with numShards shards, and at least one.
+func NewRing(numShards int) *Ring {
+	if numShards < 1 {
+		numShards = 1
+	}
+	points := make([]ringPoint, 0, numShards*virtualNodesPerShard)
+	for shard := 0; shard < numShards; shard++ {
+		for i := 0; i < virtualNodesPerShard; i++ {
+			points = append(points, ringPoint{hash: hash(fmt.Sprintf("shard-%d-%d", shard, i)), shard: shard})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].hash == points[j].hash {
+			return points[i].shard < points[j].shard
+		}
+		return points[i].hash < points[j].hash
+	})
+	return &Ring{numShards: numShards, points: points}
+}
+
+// NumShards returns the number of shards of the ring.
+func (r *Ring) NumShards() int {
+	return r.numShards
+}
+
+// Shard returns the shard of the Service key, which is the first point of the
+// ring following the hash of the key.
+func (r *Ring) Shard(key string) int {
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].shard
+}
+
+// ServiceKey returns the key of the Service used to assign it to a shard.
+func ServiceKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// hash returns a 64 bits hash of s. FNV is not used as it spreads similar
+// keys unevenly on the ring.
+func hash(s string) uint64 {
+	sum := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint64(sum[:8])
+}
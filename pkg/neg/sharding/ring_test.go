@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	const numServices = 10000
+	keys := make([]string, numServices)
+	for i := range keys {
+		keys[i] = ServiceKey(fmt.Sprintf("ns-%d", i%50), fmt.Sprintf("svc-%d", i))
+	}
+
+	ring := NewRing(8)
+	counts := make(map[int]int)
+	for _, key := range keys {
+		shard := ring.Shard(key)
+		if shard < 0 || shard >= 8 {
+			t.Fatalf("Shard(%q) = %d, want a shard in [0, 8)", key, shard)
+		}
+		if again := ring.Shard(key); again != shard {
+			t.Fatalf("Shard(%q) = %d then %d, want the same shard", key, shard, again)
+		}
+		counts[shard]++
+	}
+	for shard := 0; shard < 8; shard++ {
+		// Each shard should get 1/8 of the services, allow 50% skew.
+		if counts[shard] < numServices/16 || counts[shard] > numServices*3/16 {
+			t.Errorf("Shard %d got %d services, want about %d", shard, counts[shard], numServices/8)
+		}
+	}
+
+	// Adding a shard only moves services to the new shard.
+	largerRing := NewRing(9)
+	moved := 0
+	for _, key := range keys {
+		before, after := ring.Shard(key), largerRing.Shard(key)
+		if before != after {
+			moved++
+			if after != 8 {
+				t.Errorf("Service %q moved from shard %d to existing shard %d", key, before, after)
+			}
+		}
+	}
+	if moved == 0 || moved > numServices/4 {
+		t.Errorf("%d services moved to the new shard, want about %d", moved, numServices/9)
+	}
+
+	if got := NewRing(0).NumShards(); got != 1 {
+		t.Errorf("NewRing(0).NumShards() = %d, want 1", got)
+	}
+}
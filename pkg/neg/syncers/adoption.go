@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/network"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// negAdoption configures ensureNetworkEndpointGroup to take ownership of
+// existing NEGs which were not created by a NEG controller, e.g. NEGs created
+// by Terraform to bootstrap backend services before the cluster exists.
+type negAdoption struct {
+	// adoptedSelfLinks contains the self links of the NEGs which were
+	// adopted with their original description.
+	adoptedSelfLinks sets.String
+}
+
+// hasControllerDescription returns true if the NEG has the description of a
+// NEG created by a NEG controller.
+func hasControllerDescription(neg *composite.NetworkEndpointGroup) bool {
+	var desc utils.NegDescription
+	if err := json.Unmarshal([]byte(neg.Description), &desc); err != nil {
+		return false
+	}
+	return desc.ClusterUID != ""
+}
+
+// adopt takes ownership of the NEG if it is empty, and has the expected type
+// and network. NEG descriptions cannot be updated, so the NEG is deleted to be
+// recreated with the description of the controller, and adopt returns false.
+// If the NEG is in use, e.g. by a backend service, it is adopted with its
+// original description instead, and adopt returns true. The NEG reference
+// then records the adoption for the next syncs.
+func (a *negAdoption) adopt(neg *composite.NetworkEndpointGroup, zone string, version meta.Version, networkEndpointType negtypes.NetworkEndpointType, cloud negtypes.NetworkEndpointGroupCloud, networkInfo network.NetworkInfo, logger klog.Logger) (bool, error) {
+	if neg.NetworkEndpointType != string(networkEndpointType) {
+		return false, fmt.Errorf("cannot adopt neg %s in %s: expected network endpoint type %s, but got %s", neg.Name, zone, networkEndpointType, neg.NetworkEndpointType)
+	}
+	// Non-GCP NEGs do not have associated subnetwork, and internet NEGs do
+	// not have associated network and subnetwork.
+	if networkEndpointType != negtypes.NonGCPPrivateEndpointType && !negtypes.IsGlobalNetworkEndpointType(networkEndpointType) &&
+		(!utils.EqualResourceIDs(neg.Network, networkInfo.NetworkURL) || !utils.EqualResourceIDs(neg.Subnetwork, networkInfo.SubnetworkURL)) {
+		return false, fmt.Errorf("cannot adopt neg %s in %s: expected network %s and subnetwork %s, but got %s and %s", neg.Name, zone, networkInfo.NetworkURL, networkInfo.SubnetworkURL, neg.Network, neg.Subnetwork)
+	}
+	endpoints, err := cloud.ListNetworkEndpoints(neg.Name, zone, false, version, logger)
+	if err != nil {
+		return false, err
+	}
+	if len(endpoints) != 0 {
+		return false, fmt.Errorf("cannot adopt neg %s in %s: it has %d network endpoint(s)", neg.Name, zone, len(endpoints))
+	}
+
+	err = cloud.DeleteNetworkEndpointGroup(neg.Name, zone, version, logger)
+	if err == nil {
+		logger.Info("Deleted existing NEG to recreate it with the description of the controller")
+		return false, nil
+	}
+	if utils.IsInUsedByError(err) {
+		logger.Info("Adopting existing NEG in use with its original description", "description", neg.Description, "err", err)
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/network"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// inUseNEGCloud fails to delete NEGs as if they were used by a backend
+// service.
+type inUseNEGCloud struct {
+	negtypes.NetworkEndpointGroupCloud
+}
+
+func (c *inUseNEGCloud) DeleteNetworkEndpointGroup(name string, zone string, version meta.Version, logger klog.Logger) error {
+	return &googleapi.Error{
+		Code:    http.StatusBadRequest,
+		Message: "The network_endpoint_group resource '" + name + "' is already being used by 'backend-service'",
+	}
+}
+
+func TestEnsureNetworkEndpointGroupAdoption(t *testing.T) {
+	var (
+		testZone          = "test-zone"
+		testNetwork       = cloud.ResourcePath("network", &meta.Key{Zone: testZone, Name: "test-network"})
+		testSubnetwork    = cloud.ResourcePath("subnetwork", &meta.Key{Zone: testZone, Name: "test-subnetwork"})
+		testKubesystemUID = "cluster-uid"
+		testPort          = "80"
+		negName           = "terraform-neg"
+		apiVersion        = meta.VersionGA
+		networkInfo       = network.NetworkInfo{
+			NetworkURL:    testNetwork,
+			SubnetworkURL: testSubnetwork,
+		}
+	)
+
+	controllerNegDesc := utils.NegDescription{
+		ClusterUID:  testKubesystemUID,
+		Namespace:   testServiceNamespace,
+		ServiceName: testServiceName,
+		Port:        testPort,
+	}.String()
+
+	anotherNegDesc := utils.NegDescription{
+		ClusterUID:  "another-cluster",
+		Namespace:   testServiceNamespace,
+		ServiceName: testServiceName,
+		Port:        testPort,
+	}.String()
+
+	testCases := []struct {
+		desc             string
+		negType          negtypes.NetworkEndpointType
+		negDescription   string
+		numEndpoints     int
+		inUse            bool
+		adoptedSelfLinks bool
+		expectAdopted    bool
+		// expectAdoptEvent indicates that an Adopt event is emitted for
+		// the Service.
+		expectAdoptEvent bool
+		expectDesc       string
+		expectError      bool
+	}{
+		{
+			desc:           "empty NEG not in use is recreated",
+			negType:        negtypes.VmIpPortEndpointType,
+			negDescription: "created by terraform",
+			expectDesc:     controllerNegDesc,
+		},
+		{
+			desc:             "empty NEG in use is adopted",
+			negType:          negtypes.VmIpPortEndpointType,
+			negDescription:   "created by terraform",
+			inUse:            true,
+			expectAdopted:    true,
+			expectAdoptEvent: true,
+			expectDesc:       "created by terraform",
+		},
+		{
+			desc:             "previously adopted NEG with endpoints",
+			negType:          negtypes.VmIpPortEndpointType,
+			negDescription:   "created by terraform",
+			numEndpoints:     2,
+			inUse:            true,
+			adoptedSelfLinks: true,
+			expectAdopted:    true,
+			expectDesc:       "created by terraform",
+		},
+		{
+			desc:           "NEG with endpoints is not adopted",
+			negType:        negtypes.VmIpPortEndpointType,
+			negDescription: "created by terraform",
+			numEndpoints:   2,
+			expectDesc:     "created by terraform",
+			expectError:    true,
+		},
+		{
+			desc:           "NEG with another network endpoint type is not adopted",
+			negType:        negtypes.VmIpEndpointType,
+			negDescription: "created by terraform",
+			expectDesc:     "created by terraform",
+			expectError:    true,
+		},
+		{
+			desc:           "NEG of another cluster is not adopted",
+			negType:        negtypes.VmIpPortEndpointType,
+			negDescription: anotherNegDesc,
+			expectDesc:     anotherNegDesc,
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			negtypes.MockNetworkEndpointAPIs(fakeGCE)
+			var fakeCloud negtypes.NetworkEndpointGroupCloud = negtypes.NewAdapterWithNetwork(fakeGCE, testNetwork, testSubnetwork)
+			if err := fakeCloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{
+				Version:             apiVersion,
+				Name:                negName,
+				NetworkEndpointType: string(tc.negType),
+				Network:             testNetwork,
+				Subnetwork:          testSubnetwork,
+				Description:         tc.negDescription,
+			}, testZone, klog.TODO()); err != nil {
+				t.Fatalf("Failed to create NEG: %v", err)
+			}
+			if tc.numEndpoints > 0 {
+				endpointSet, _, _ := genTestEndpoints(tc.numEndpoints, negtypes.VmIpPortEndpointType, false)
+				var endpoints []*composite.NetworkEndpoint
+				for _, endpoint := range endpointSet.List() {
+					endpoints = append(endpoints, &composite.NetworkEndpoint{IpAddress: endpoint.IP, Instance: endpoint.Node, Port: 80})
+				}
+				if err := fakeCloud.AttachNetworkEndpoints(negName, testZone, endpoints, apiVersion, klog.TODO()); err != nil {
+					t.Fatalf("Failed to attach endpoints: %v", err)
+				}
+			}
+			existingNeg, err := fakeCloud.GetNetworkEndpointGroup(negName, testZone, apiVersion, klog.TODO())
+			if err != nil {
+				t.Fatalf("Failed to get NEG: %v", err)
+			}
+			if tc.inUse {
+				fakeCloud = &inUseNEGCloud{NetworkEndpointGroupCloud: fakeCloud}
+			}
+			adoption := &negAdoption{adoptedSelfLinks: sets.NewString()}
+			if tc.adoptedSelfLinks {
+				adoption.adoptedSelfLinks.Insert(existingNeg.SelfLink)
+			}
+
+			serviceLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			serviceLister.Add(&apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: testServiceNamespace, Name: testServiceName}})
+			recorder := record.NewFakeRecorder(10)
+
+			negRef, err := ensureNetworkEndpointGroup(
+				testServiceNamespace,
+				testServiceName,
+				negName,
+				testZone,
+				testNamedPort,
+				testKubesystemUID,
+				testPort,
+				negtypes.VmIpPortEndpointType,
+				fakeCloud,
+				serviceLister,
+				recorder,
+				apiVersion,
+				true,
+				adoption,
+				networkInfo,
+				klog.TODO(),
+			)
+			if gotErr := err != nil; gotErr != tc.expectError {
+				t.Fatalf("ensureNetworkEndpointGroup() = %v, want error: %v", err, tc.expectError)
+			}
+			if err == nil && negRef.Adopted != tc.expectAdopted {
+				t.Errorf("Got NEG reference with Adopted=%v, want %v", negRef.Adopted, tc.expectAdopted)
+			}
+
+			neg, err := fakeCloud.GetNetworkEndpointGroup(negName, testZone, apiVersion, klog.TODO())
+			if err != nil {
+				t.Fatalf("Failed to get NEG: %v", err)
+			}
+			if neg.Description != tc.expectDesc {
+				t.Errorf("Got NEG description %q, want %q", neg.Description, tc.expectDesc)
+			}
+			close(recorder.Events)
+			gotAdoptEvent := false
+			for event := range recorder.Events {
+				gotAdoptEvent = gotAdoptEvent || strings.HasPrefix(event, apiv1.EventTypeNormal+" Adopt ")
+			}
+			if gotAdoptEvent != tc.expectAdoptEvent {
+				t.Errorf("Got Adopt event: %v, want %v", gotAdoptEvent, tc.expectAdoptEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+const (
+	// maxCheckpointSize caps the approximate size in bytes of the endpoints
+	// recorded in a checkpoint, to keep the NEG CR below the 1.5MiB object
+	// size limit. Syncers whose endpoints do not fit do not record a
+	// checkpoint.
+	maxCheckpointSize = 1 << 20
+	// checkpointEndpointOverhead is the approximate size in bytes of the
+	// field names and separators of a serialized checkpoint endpoint.
+	checkpointEndpointOverhead = 40
+	// maxCheckpointAge is the age after which a checkpoint is no longer
+	// trusted by a starting syncer.
+	maxCheckpointAge = time.Hour
+	// checkpointVerifyDelay is the minimum delay after which a syncer that
+	// resumed from a checkpoint lists the NEGs to verify it. The actual delay
+	// is jittered up to twice this value to spread out the NEG API calls.
+	checkpointVerifyDelay = 10 * time.Minute
+)
+
+// newCheckpoint returns a checkpoint of the endpoints committed to the NEGs,
+// which were listed at listedAt. It returns nil if the endpoints are too large
+// to record.
+func newCheckpoint(committed map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, listedAt metav1.Time) *negv1beta1.NegCheckpoint {
+	size := 0
+	for _, endpointSet := range committed {
+		for endpoint := range endpointSet {
+			size += checkpointEndpointSize(endpoint)
+		}
+	}
+	if size > maxCheckpointSize {
+		return nil
+	}
+
+	checkpoint := &negv1beta1.NegCheckpoint{Timestamp: listedAt}
+	for endpointGroupInfo, endpointSet := range committed {
+		group := negv1beta1.CheckpointEndpointGroup{
+			Zone:   endpointGroupInfo.Zone,
+			Subnet: endpointGroupInfo.Subnet,
+		}
+		for endpoint := range endpointSet {
+			group.Endpoints = append(group.Endpoints, toCheckpointEndpoint(endpoint))
+		}
+		sort.Slice(group.Endpoints, func(i, j int) bool {
+			return checkpointEndpointLess(group.Endpoints[i], group.Endpoints[j])
+		})
+		checkpoint.EndpointGroups = append(checkpoint.EndpointGroups, group)
+	}
+	sort.Slice(checkpoint.EndpointGroups, func(i, j int) bool {
+		a, b := checkpoint.EndpointGroups[i], checkpoint.EndpointGroups[j]
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		return a.Subnet < b.Subnet
+	})
+	return checkpoint
+}
+
+// checkpointOperations returns the operations in the transaction table, in
+// a stable order.
+func checkpointOperations(transactions networkEndpointTransactionTable) []negv1beta1.CheckpointOperation {
+	var operations []negv1beta1.CheckpointOperation
+	for _, endpoint := range transactions.Keys() {
+		entry, ok := transactions.Get(endpoint)
+		if !ok {
+			continue
+		}
+		operations = append(operations, negv1beta1.CheckpointOperation{
+			Operation: entry.Operation.String(),
+			Zone:      entry.Zone,
+			Subnet:    entry.Subnet,
+			Endpoint:  toCheckpointEndpoint(endpoint),
+		})
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		if a.Subnet != b.Subnet {
+			return a.Subnet < b.Subnet
+		}
+		return checkpointEndpointLess(a.Endpoint, b.Endpoint)
+	})
+	return operations
+}
+
+// pendingOperations returns the operations that are about to be issued to
+// attach and detach the given endpoints, in a stable order.
+func pendingOperations(addEndpoints, removeEndpoints map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) []negv1beta1.CheckpointOperation {
+	transactions := NewTransactionTable()
+	for operation, endpointMap := range map[transactionOp]map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{attachOp: addEndpoints, detachOp: removeEndpoints} {
+		for endpointGroupInfo, endpointSet := range endpointMap {
+			for endpoint := range endpointSet {
+				transactions.Put(endpoint, transactionEntry{Operation: operation, Zone: endpointGroupInfo.Zone, Subnet: endpointGroupInfo.Subnet})
+			}
+		}
+	}
+	return checkpointOperations(transactions)
+}
+
+// endpointMapFromCheckpoint returns the endpoints committed to the NEGs as
+// recorded in the checkpoint. It returns an error if the checkpoint cannot be
+// trusted: it has pending operations whose outcome is unknown, it is older
+// than maxCheckpointAge, or it refers to a subnet the syncer does not manage.
+func endpointMapFromCheckpoint(checkpoint *negv1beta1.NegCheckpoint, subnetToNegMapping map[string]string, now time.Time) (map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, error) {
+	if checkpoint == nil {
+		return nil, fmt.Errorf("no checkpoint")
+	}
+	if len(checkpoint.PendingOperations) != 0 {
+		return nil, fmt.Errorf("checkpoint has %d pending operations", len(checkpoint.PendingOperations))
+	}
+	if age := now.Sub(checkpoint.Timestamp.Time); age > maxCheckpointAge {
+		return nil, fmt.Errorf("checkpoint is %v old, older than %v", age, maxCheckpointAge)
+	}
+
+	endpointMap := make(map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet)
+	for _, group := range checkpoint.EndpointGroups {
+		if _, ok := subnetToNegMapping[group.Subnet]; !ok {
+			return nil, fmt.Errorf("checkpoint refers to unknown subnet %q", group.Subnet)
+		}
+		endpointSet := negtypes.NewNetworkEndpointSet()
+		for _, endpoint := range group.Endpoints {
+			endpointSet.Insert(negtypes.NetworkEndpoint{
+				IP:   endpoint.IP,
+				IPv6: endpoint.IPv6,
+				FQDN: endpoint.FQDN,
+				Port: endpoint.Port,
+				Node: endpoint.Node,
+			})
+		}
+		endpointMap[negtypes.EndpointGroupInfo{Zone: group.Zone, Subnet: group.Subnet}] = endpointSet
+	}
+	return endpointMap, nil
+}
+
+// checkpointEndpointSize returns the approximate size in bytes of the
+// endpoint once serialized in a checkpoint.
+func checkpointEndpointSize(endpoint negtypes.NetworkEndpoint) int {
+	return len(endpoint.IP) + len(endpoint.IPv6) + len(endpoint.FQDN) + len(endpoint.Port) + len(endpoint.Node) + checkpointEndpointOverhead
+}
+
+func toCheckpointEndpoint(endpoint negtypes.NetworkEndpoint) negv1beta1.CheckpointEndpoint {
+	return negv1beta1.CheckpointEndpoint{
+		IP:   endpoint.IP,
+		IPv6: endpoint.IPv6,
+		FQDN: endpoint.FQDN,
+		Port: endpoint.Port,
+		Node: endpoint.Node,
+	}
+}
+
+func checkpointEndpointLess(a, b negv1beta1.CheckpointEndpoint) bool {
+	if a.IP != b.IP {
+		return a.IP < b.IP
+	}
+	if a.IPv6 != b.IPv6 {
+		return a.IPv6 < b.IPv6
+	}
+	if a.FQDN != b.FQDN {
+		return a.FQDN < b.FQDN
+	}
+	if a.Port != b.Port {
+		return a.Port < b.Port
+	}
+	return a.Node < b.Node
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/klog/v2"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	now := time.Now()
+	subnetToNegMapping := map[string]string{defaultTestSubnet: testNegName}
+	committed := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+			negtypes.NetworkEndpoint{IP: "10.100.1.2", Port: "80", Node: testInstance2},
+			negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: testInstance1},
+		),
+		{Zone: testZone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(),
+	}
+
+	checkpoint := newCheckpoint(committed, metav1.NewTime(now))
+	if checkpoint == nil {
+		t.Fatalf("newCheckpoint() = nil, want a checkpoint")
+	}
+	if len(checkpoint.EndpointGroups) != 2 || checkpoint.EndpointGroups[0].Zone != testZone1 {
+		t.Fatalf("newCheckpoint() endpoint groups = %+v, want groups for %s and %s in order", checkpoint.EndpointGroups, testZone1, testZone2)
+	}
+	if got := checkpoint.EndpointGroups[0].Endpoints[0].IP; got != "10.100.1.1" {
+		t.Errorf("newCheckpoint() first endpoint IP = %q, want endpoints sorted", got)
+	}
+
+	got, err := endpointMapFromCheckpoint(checkpoint, subnetToNegMapping, now)
+	if err != nil {
+		t.Fatalf("endpointMapFromCheckpoint() = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(got, committed) {
+		t.Errorf("endpointMapFromCheckpoint() = %+v, want %+v", got, committed)
+	}
+}
+
+func TestNewCheckpointTooManyEndpoints(t *testing.T) {
+	endpointSet := negtypes.NewNetworkEndpointSet()
+	for i := 0; i < 10000; i++ {
+		endpointSet.Insert(negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: strconv.Itoa(i), Node: testInstance1})
+	}
+	committed := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone1, Subnet: defaultTestSubnet}: endpointSet,
+	}
+	if checkpoint := newCheckpoint(committed, metav1.Now()); checkpoint == nil {
+		t.Errorf("newCheckpoint() with %d endpoints = nil, want a checkpoint", endpointSet.Len())
+	}
+
+	for i := 0; endpointSet.Len()*checkpointEndpointOverhead <= maxCheckpointSize; i++ {
+		endpointSet.Insert(negtypes.NetworkEndpoint{IP: "10.100.1.2", Port: strconv.Itoa(i), Node: testInstance1})
+	}
+	if checkpoint := newCheckpoint(committed, metav1.Now()); checkpoint != nil {
+		t.Errorf("newCheckpoint() with %d endpoints = %+v, want nil", endpointSet.Len(), checkpoint)
+	}
+}
+
+func TestPendingOperations(t *testing.T) {
+	add := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80"}),
+		{Zone: testZone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(),
+	}
+	remove := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.100.2.1", Port: "80"}),
+	}
+	want := []negv1beta1.CheckpointOperation{
+		{Operation: "Attach", Zone: testZone1, Subnet: defaultTestSubnet, Endpoint: negv1beta1.CheckpointEndpoint{IP: "10.100.1.1", Port: "80"}},
+		{Operation: "Detach", Zone: testZone2, Subnet: defaultTestSubnet, Endpoint: negv1beta1.CheckpointEndpoint{IP: "10.100.2.1", Port: "80"}},
+	}
+	if got := pendingOperations(add, remove); !reflect.DeepEqual(got, want) {
+		t.Errorf("pendingOperations() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckpointOperations(t *testing.T) {
+	table := NewTransactionTable()
+	table.Put(negtypes.NetworkEndpoint{IP: "10.100.2.1", Port: "80"}, transactionEntry{Operation: detachOp, Zone: testZone2, Subnet: defaultTestSubnet})
+	table.Put(negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80"}, transactionEntry{Operation: attachOp, Zone: testZone1, Subnet: defaultTestSubnet})
+
+	want := []negv1beta1.CheckpointOperation{
+		{Operation: "Attach", Zone: testZone1, Subnet: defaultTestSubnet, Endpoint: negv1beta1.CheckpointEndpoint{IP: "10.100.1.1", Port: "80"}},
+		{Operation: "Detach", Zone: testZone2, Subnet: defaultTestSubnet, Endpoint: negv1beta1.CheckpointEndpoint{IP: "10.100.2.1", Port: "80"}},
+	}
+	if got := checkpointOperations(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("checkpointOperations() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEndpointMapFromCheckpointUntrusted(t *testing.T) {
+	now := time.Now()
+	subnetToNegMapping := map[string]string{defaultTestSubnet: testNegName}
+	group := negv1beta1.CheckpointEndpointGroup{
+		Zone:      testZone1,
+		Subnet:    defaultTestSubnet,
+		Endpoints: []negv1beta1.CheckpointEndpoint{{IP: "10.100.1.1", Port: "80", Node: testInstance1}},
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		checkpoint *negv1beta1.NegCheckpoint
+	}{
+		{
+			desc: "no checkpoint",
+		},
+		{
+			desc: "pending operations",
+			checkpoint: &negv1beta1.NegCheckpoint{
+				Timestamp:      metav1.NewTime(now),
+				EndpointGroups: []negv1beta1.CheckpointEndpointGroup{group},
+				PendingOperations: []negv1beta1.CheckpointOperation{
+					{Operation: "Attach", Zone: testZone1, Subnet: defaultTestSubnet, Endpoint: negv1beta1.CheckpointEndpoint{IP: "10.100.1.2", Port: "80"}},
+				},
+			},
+		},
+		{
+			desc: "stale checkpoint",
+			checkpoint: &negv1beta1.NegCheckpoint{
+				Timestamp:      metav1.NewTime(now.Add(-2 * maxCheckpointAge)),
+				EndpointGroups: []negv1beta1.CheckpointEndpointGroup{group},
+			},
+		},
+		{
+			desc: "unknown subnet",
+			checkpoint: &negv1beta1.NegCheckpoint{
+				Timestamp: metav1.NewTime(now),
+				EndpointGroups: []negv1beta1.CheckpointEndpointGroup{
+					{Zone: testZone1, Subnet: "other-subnet"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got, err := endpointMapFromCheckpoint(tc.checkpoint, subnetToNegMapping, now); err == nil {
+				t.Errorf("endpointMapFromCheckpoint() = %+v, nil, want error", got)
+			}
+		})
+	}
+}
+
+func TestRetrieveCurrentEndpointsFromCheckpoint(t *testing.T) {
+	checkpointTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	checkpointed := negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: testInstance1}
+	// attached is only known to the NEG, so a NEG endpoint map containing it
+	// was listed rather than resumed from the checkpoint.
+	attached := negtypes.NetworkEndpoint{IP: "10.100.1.2", Port: "80", Node: testInstance1}
+	checkpointMap := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(checkpointed),
+	}
+	listedMap := func(endpoints ...negtypes.NetworkEndpoint) map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet {
+		return map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+			{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(endpoints...),
+			{Zone: negtypes.TestZone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(),
+			{Zone: negtypes.TestZone4, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(),
+		}
+	}
+
+	for _, tc := range []struct {
+		desc string
+		// negEndpoints are the endpoints in the NEG of zone1.
+		negEndpoints []negtypes.NetworkEndpoint
+		// operationsStarted simulates a controller that issued operations
+		// after it wrote the checkpoint, and restarted before the end of the
+		// sync.
+		operationsStarted bool
+		wantMap           map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+		wantResumed       bool
+	}{
+		{
+			desc:         "NEG sizes match the checkpoint",
+			negEndpoints: []negtypes.NetworkEndpoint{attached},
+			wantMap:      checkpointMap,
+			wantResumed:  true,
+		},
+		{
+			desc:         "NEG sizes differ from the checkpoint",
+			negEndpoints: []negtypes.NetworkEndpoint{checkpointed, attached},
+			wantMap:      listedMap(checkpointed, attached),
+		},
+		{
+			desc:              "operations started after the checkpoint",
+			negEndpoints:      []negtypes.NetworkEndpoint{attached},
+			operationsStarted: true,
+			wantMap:           listedMap(attached),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			testNetwork := cloud.ResourcePath("network", &meta.Key{Name: "test-network"})
+			fakeCloud := negtypes.NewFakeNetworkEndpointGroupCloud(defaultTestSubnetURL, testNetwork)
+			for _, zone := range []string{negtypes.TestZone1, negtypes.TestZone2, negtypes.TestZone4} {
+				if err := fakeCloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{Name: testNegName}, zone, klog.TODO()); err != nil {
+					t.Fatalf("CreateNetworkEndpointGroup(%s) = %v", zone, err)
+				}
+			}
+			for _, endpoint := range tc.negEndpoints {
+				port, _ := strconv.ParseInt(endpoint.Port, 10, 64)
+				networkEndpoint := &composite.NetworkEndpoint{IpAddress: endpoint.IP, Port: port, Instance: endpoint.Node}
+				if err := fakeCloud.AttachNetworkEndpoints(testNegName, negtypes.TestZone1, []*composite.NetworkEndpoint{networkEndpoint}, meta.VersionGA, klog.TODO()); err != nil {
+					t.Fatalf("AttachNetworkEndpoints() = %v", err)
+				}
+			}
+			subnetToNegMapping := map[string]string{defaultTestSubnet: testNegName}
+
+			negCR := createNegCR(testNegName, checkpointTime, true, true, nil)
+			negCR.Status.Checkpoint = newCheckpoint(checkpointMap, checkpointTime)
+			if tc.operationsStarted {
+				_, previous := newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+				previous.enableCheckpoint = true
+				if _, err := previous.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Create(context.Background(), negCR, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create NEG CR: %v", err)
+				}
+				if err := previous.svcNegLister.Add(negCR); err != nil {
+					t.Fatalf("Failed to add NEG CR to the store: %v", err)
+				}
+				add := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+					{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(attached),
+				}
+				remove := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+					{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(checkpointed),
+				}
+				if err := previous.recordOperationIntent(add, remove); err != nil {
+					t.Fatalf("recordOperationIntent() = %v", err)
+				}
+				var err error
+				negCR, err = previous.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Get(context.Background(), testNegName, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Failed to get NEG CR: %v", err)
+				}
+				if got := len(negCR.Status.Checkpoint.PendingOperations); got != 2 {
+					t.Fatalf("Got %d pending operations in the checkpoint, want 2", got)
+				}
+			}
+
+			_, syncer := newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+			syncer.enableCheckpoint = true
+			syncer.resumeFromCheckpoint = true
+			if err := syncer.svcNegLister.Add(negCR); err != nil {
+				t.Fatalf("Failed to add NEG CR to the store: %v", err)
+			}
+
+			currentMap, _, listedAt, err := syncer.retrieveCurrentEndpoints(subnetToNegMapping)
+			if err != nil {
+				t.Fatalf("retrieveCurrentEndpoints() = %v, want nil", err)
+			}
+			if !reflect.DeepEqual(currentMap, tc.wantMap) {
+				t.Errorf("retrieveCurrentEndpoints() = %+v, want %+v", currentMap, tc.wantMap)
+			}
+			if resumed := listedAt.Equal(&checkpointTime); resumed != tc.wantResumed {
+				t.Errorf("retrieveCurrentEndpoints() listed at %v, resumed from the checkpoint at %v = %v, want %v", listedAt, checkpointTime, resumed, tc.wantResumed)
+			}
+
+			// The checkpoint is only used once, later calls list the NEGs.
+			currentMap, _, _, err = syncer.retrieveCurrentEndpoints(subnetToNegMapping)
+			if err != nil {
+				t.Fatalf("retrieveCurrentEndpoints() = %v, want nil", err)
+			}
+			if want := listedMap(tc.negEndpoints...); !reflect.DeepEqual(currentMap, want) {
+				t.Errorf("retrieveCurrentEndpoints() = %+v, want %+v", currentMap, want)
+			}
+		})
+	}
+}
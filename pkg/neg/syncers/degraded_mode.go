@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+// Causes of degraded mode, used as the reason of the DegradedMode condition.
+const (
+	degradedModeCauseInvalidEndpointSlice  = "InvalidEndpointSlice"
+	degradedModeCauseEndpointCountMismatch = "EndpointCountMismatch"
+	degradedModeCauseMissingNode           = "MissingNode"
+	degradedModeCauseMissingPod            = "MissingPod"
+	degradedModeCausePodIPMismatch         = "PodIPMismatch"
+	degradedModeCauseInvalidEndpointBatch  = "InvalidEndpointBatch"
+
+	// degradedModeReasonNormalMode is the reason of the DegradedMode
+	// condition when the syncer is not in degraded mode.
+	degradedModeReasonNormalMode = "NormalMode"
+
+	// maxDegradedModeDiffEndpoints is the maximum number of endpoints listed
+	// for each side of the comparison of the normal and degraded mode
+	// calculations.
+	maxDegradedModeDiffEndpoints = 5
+)
+
+// degradedModeCause returns the cause of degraded mode for the reason of an
+// error state error.
+func degradedModeCause(reason negtypes.Reason) string {
+	switch reason {
+	case negtypes.ReasonEPNodeMissing, negtypes.ReasonEPZoneMissing, negtypes.ReasonEPSEndpointCountZero, negtypes.ReasonEPServiceNotFound:
+		return degradedModeCauseInvalidEndpointSlice
+	case negtypes.ReasonEPCountsDiffer, negtypes.ReasonEPCalculationCountZero:
+		return degradedModeCauseEndpointCountMismatch
+	case negtypes.ReasonEPNodeNotFound, negtypes.ReasonEPNodePodCIDRNotSet, negtypes.ReasonEPNodeTypeAssertionFailed:
+		return degradedModeCauseMissingNode
+	case negtypes.ReasonEPPodMissing, negtypes.ReasonEPPodNotFound, negtypes.ReasonEPPodTypeAssertionFailed, negtypes.ReasonEPPodTerminal:
+		return degradedModeCauseMissingPod
+	case negtypes.ReasonEPIPInvalid, negtypes.ReasonEPIPNotFromPod, negtypes.ReasonEPIPOutOfPodCIDR, negtypes.ReasonEPPodLabelMismatch:
+		return degradedModeCausePodIPMismatch
+	case negtypes.ReasonInvalidAPIResponse, negtypes.ReasonInvalidEPAttach, negtypes.ReasonInvalidEPDetach:
+		return degradedModeCauseInvalidEndpointBatch
+	default:
+		return string(reason)
+	}
+}
+
+// degradedModeComparison is the comparison of the normal and degraded mode
+// endpoint calculations of a sync.
+type degradedModeComparison struct {
+	// normalChanges and degradedChanges are the number of endpoints the
+	// normal and degraded mode calculations would attach or detach.
+	normalChanges   int
+	degradedChanges int
+	// notInDegraded and onlyInDegraded contain the endpoints only the normal
+	// and only the degraded mode calculation include.
+	notInDegraded  map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+	onlyInDegraded map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+}
+
+// newDegradedModeComparison compares the normal and degraded mode target
+// endpoints against the current endpoints.
+func newDegradedModeComparison(currentMap, targetMap, degradedTargetMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) degradedModeComparison {
+	notInDegraded, onlyInDegraded := calculateNetworkEndpointDifference(targetMap, degradedTargetMap)
+	return degradedModeComparison{
+		normalChanges:   endpointChanges(targetMap, currentMap),
+		degradedChanges: endpointChanges(degradedTargetMap, currentMap),
+		notInDegraded:   notInDegraded,
+		onlyInDegraded:  onlyInDegraded,
+	}
+}
+
+// agree returns true if the normal and degraded mode calculations have the
+// same result.
+func (c degradedModeComparison) agree() bool {
+	return len(c.notInDegraded) == 0 && len(c.onlyInDegraded) == 0
+}
+
+// String returns the endpoint changes of each calculation, and at most
+// maxDegradedModeDiffEndpoints of the endpoints only each of them includes.
+func (c degradedModeComparison) String() string {
+	msg := fmt.Sprintf("Normal mode would change %d endpoint(s), degraded mode would change %d endpoint(s).", c.normalChanges, c.degradedChanges)
+	if c.agree() {
+		return msg
+	}
+	return fmt.Sprintf("%s Only in normal mode: %s. Only in degraded mode: %s.", msg, boundedEndpointList(c.notInDegraded), boundedEndpointList(c.onlyInDegraded))
+}
+
+// endpointChanges returns the number of endpoints to attach or detach to get
+// from the current to the target endpoints.
+func endpointChanges(targetMap, currentMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) int {
+	addEndpoints, removeEndpoints := calculateNetworkEndpointDifference(targetMap, currentMap)
+	count := 0
+	for _, endpointSet := range addEndpoints {
+		count += endpointSet.Len()
+	}
+	for _, endpointSet := range removeEndpoints {
+		count += endpointSet.Len()
+	}
+	return count
+}
+
+// boundedEndpointList formats at most maxDegradedModeDiffEndpoints of the
+// endpoints in sorted order, and the number of endpoints left out.
+func boundedEndpointList(endpointMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) string {
+	var endpoints []string
+	for endpointGroupInfo, endpointSet := range endpointMap {
+		for _, endpoint := range endpointSet.List() {
+			ip := endpoint.IP
+			if ip == "" {
+				ip = endpoint.IPv6
+			}
+			endpoints = append(endpoints, fmt.Sprintf("%s/%s/%s", endpointGroupInfo.Zone, endpoint.Node, net.JoinHostPort(ip, endpoint.Port)))
+		}
+	}
+	if len(endpoints) == 0 {
+		return "[]"
+	}
+	sort.Strings(endpoints)
+	if len(endpoints) <= maxDegradedModeDiffEndpoints {
+		return "[" + strings.Join(endpoints, ", ") + "]"
+	}
+	return fmt.Sprintf("[%s, and %d more]", strings.Join(endpoints[:maxDegradedModeDiffEndpoints], ", "), len(endpoints)-maxDegradedModeDiffEndpoints)
+}
+
+// degradedModeDecider decides when a syncer enters and exits degraded mode,
+// and reports why it is in degraded mode.
+// The syncLock of the syncer must be held to call its methods.
+type degradedModeDecider interface {
+	// Enter records an error state error of the syncer, and returns true if
+	// the syncer enters degraded mode because of it.
+	Enter(syncErr negtypes.NegSyncError, now time.Time) bool
+	// Observe records the comparison of the endpoint calculations of a sync
+	// without error state errors, and returns true if the syncer exits
+	// degraded mode because of it.
+	Observe(comparison degradedModeComparison) bool
+	// InDegradedMode returns true if the syncer is in degraded mode.
+	InDegradedMode() bool
+	// Condition returns the DegradedMode condition of the NEG CR.
+	Condition() negv1beta1.Condition
+}
+
+// hysteresisDecider enters degraded mode on any error state error, and exits
+// degraded mode after exitThreshold consecutive syncs in which the normal and
+// degraded mode calculations agree, so that a flapping syncer stays in
+// degraded mode.
+// Its condition only changes when the syncer enters or exits degraded mode, so
+// that the NEG CR is not updated on every sync.
+type hysteresisDecider struct {
+	exitThreshold int
+
+	inDegradedMode bool
+	// enteredAt is the time at which the syncer entered degraded mode.
+	enteredAt time.Time
+	// enteredErr is the error state error the syncer entered degraded mode
+	// with.
+	enteredErr negtypes.NegSyncError
+	// agreeingSyncs is the number of consecutive syncs in which the
+	// calculations agreed since the last error state error.
+	agreeingSyncs int
+	// exitComparison is the comparison of the sync in which the syncer
+	// exited degraded mode, if it did.
+	exitComparison *degradedModeComparison
+}
+
+// newHysteresisDecider returns a hysteresisDecider that exits degraded mode
+// after exitThreshold agreeing syncs, and at least one.
+func newHysteresisDecider(exitThreshold int) *hysteresisDecider {
+	if exitThreshold < 1 {
+		exitThreshold = 1
+	}
+	return &hysteresisDecider{exitThreshold: exitThreshold}
+}
+
+func (d *hysteresisDecider) Enter(syncErr negtypes.NegSyncError, now time.Time) bool {
+	d.agreeingSyncs = 0
+	if d.inDegradedMode {
+		return false
+	}
+	d.inDegradedMode = true
+	d.enteredAt = now
+	d.enteredErr = syncErr
+	d.exitComparison = nil
+	return true
+}
+
+func (d *hysteresisDecider) Observe(comparison degradedModeComparison) bool {
+	if !comparison.agree() {
+		d.agreeingSyncs = 0
+		return false
+	}
+	d.agreeingSyncs++
+	if !d.inDegradedMode || d.agreeingSyncs < d.exitThreshold {
+		return false
+	}
+	d.inDegradedMode = false
+	d.exitComparison = &comparison
+	return true
+}
+
+func (d *hysteresisDecider) InDegradedMode() bool {
+	return d.inDegradedMode
+}
+
+func (d *hysteresisDecider) Condition() negv1beta1.Condition {
+	if !d.inDegradedMode {
+		message := "Using normal mode endpoint calculation."
+		if d.exitComparison != nil {
+			message = fmt.Sprintf("%s Exited degraded mode after %d consecutive agreeing syncs, the last one: %s", message, d.exitThreshold, d.exitComparison)
+		}
+		return negv1beta1.Condition{
+			Type:               negv1beta1.DegradedMode,
+			Status:             corev1.ConditionFalse,
+			Reason:             degradedModeReasonNormalMode,
+			LastTransitionTime: metav1.Now(),
+			Message:            message,
+		}
+	}
+	return negv1beta1.Condition{
+		Type:               negv1beta1.DegradedMode,
+		Status:             corev1.ConditionTrue,
+		Reason:             degradedModeCause(d.enteredErr.Reason),
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Entered degraded mode at %s because of error: %v. Exits degraded mode after %d consecutive syncs in which the normal and degraded mode endpoint calculations agree.",
+			d.enteredAt.UTC().Format(time.RFC3339), d.enteredErr, d.exitThreshold),
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+func TestHysteresisDecider(t *testing.T) {
+	t.Parallel()
+
+	group := negtypes.EndpointGroupInfo{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}
+	agreeing := degradedModeComparison{}
+	disagreeing := degradedModeComparison{
+		normalChanges:   1,
+		degradedChanges: 2,
+		onlyInDegraded: map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+			group: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: "instance1"}),
+		},
+	}
+
+	type step struct {
+		// err is entered if set, otherwise comparison is observed.
+		err            *negtypes.NegSyncError
+		comparison     degradedModeComparison
+		wantTransition bool
+		wantDegraded   bool
+	}
+	testCases := []struct {
+		desc          string
+		exitThreshold int
+		steps         []step
+	}{
+		{
+			desc:          "exit on the first agreeing sync",
+			exitThreshold: 1,
+			steps: []step{
+				{comparison: agreeing, wantTransition: false, wantDegraded: false},
+				{err: &negtypes.ErrEPNodeMissing, wantTransition: true, wantDegraded: true},
+				{err: &negtypes.ErrEPPodNotFound, wantTransition: false, wantDegraded: true},
+				{comparison: disagreeing, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: true, wantDegraded: false},
+			},
+		},
+		{
+			desc:          "flapping syncer stays in degraded mode",
+			exitThreshold: 3,
+			steps: []step{
+				{err: &negtypes.ErrEPIPOutOfPodCIDR, wantTransition: true, wantDegraded: true},
+				{comparison: agreeing, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: false, wantDegraded: true},
+				{err: &negtypes.ErrEPIPOutOfPodCIDR, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: false, wantDegraded: true},
+				{comparison: disagreeing, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: false, wantDegraded: true},
+				{comparison: agreeing, wantTransition: true, wantDegraded: false},
+			},
+		},
+		{
+			desc:          "invalid exit threshold",
+			exitThreshold: 0,
+			steps: []step{
+				{err: &negtypes.ErrEPCountsDiffer, wantTransition: true, wantDegraded: true},
+				{comparison: agreeing, wantTransition: true, wantDegraded: false},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			d := newHysteresisDecider(tc.exitThreshold)
+			for i, s := range tc.steps {
+				var transition bool
+				if s.err != nil {
+					transition = d.Enter(*s.err, time.Now())
+				} else {
+					transition = d.Observe(s.comparison)
+				}
+				if transition != s.wantTransition {
+					t.Errorf("step %d: got transition %v, want %v", i, transition, s.wantTransition)
+				}
+				if got := d.InDegradedMode(); got != s.wantDegraded {
+					t.Errorf("step %d: InDegradedMode() = %v, want %v", i, got, s.wantDegraded)
+				}
+				wantStatus := corev1.ConditionFalse
+				if s.wantDegraded {
+					wantStatus = corev1.ConditionTrue
+				}
+				if condition := d.Condition(); condition.Type != negv1beta1.DegradedMode || condition.Status != wantStatus {
+					t.Errorf("step %d: Condition() = %+v, want type %s with status %s", i, condition, negv1beta1.DegradedMode, wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestHysteresisDeciderCondition(t *testing.T) {
+	t.Parallel()
+
+	d := newHysteresisDecider(2)
+	d.Enter(negtypes.ErrEPNodeNotFound, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	entered := d.Condition()
+	if entered.Reason != degradedModeCauseMissingNode {
+		t.Errorf("got condition reason %q, want %q", entered.Reason, degradedModeCauseMissingNode)
+	}
+	for _, want := range []string{
+		"2024-01-01T00:00:00Z",
+		negtypes.ErrEPNodeNotFound.Error(),
+		"after 2 consecutive syncs",
+	} {
+		if !strings.Contains(entered.Message, want) {
+			t.Errorf("got condition message %q, want it to contain %q", entered.Message, want)
+		}
+	}
+
+	// Syncs that do not change the state of the syncer should not change its
+	// condition.
+	d.Enter(negtypes.ErrEPCountsDiffer, time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC))
+	d.Observe(degradedModeComparison{normalChanges: 3, degradedChanges: 3})
+	if condition := d.Condition(); condition.Reason != entered.Reason || condition.Message != entered.Message {
+		t.Errorf("got condition %s: %q after staying in degraded mode, want %s: %q", condition.Reason, condition.Message, entered.Reason, entered.Message)
+	}
+
+	d.Observe(degradedModeComparison{normalChanges: 2, degradedChanges: 2})
+	exited := d.Condition()
+	if exited.Reason != degradedModeReasonNormalMode {
+		t.Errorf("got condition reason %q, want %q", exited.Reason, degradedModeReasonNormalMode)
+	}
+	if want := "Normal mode would change 2 endpoint(s), degraded mode would change 2 endpoint(s)."; !strings.Contains(exited.Message, want) {
+		t.Errorf("got condition message %q, want it to contain %q", exited.Message, want)
+	}
+	d.Observe(degradedModeComparison{normalChanges: 1, degradedChanges: 1})
+	if condition := d.Condition(); condition.Message != exited.Message {
+		t.Errorf("got condition message %q after staying in normal mode, want %q", condition.Message, exited.Message)
+	}
+}
+
+func TestDegradedModeComparison(t *testing.T) {
+	t.Parallel()
+
+	group1 := negtypes.EndpointGroupInfo{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}
+	group2 := negtypes.EndpointGroupInfo{Zone: negtypes.TestZone2, Subnet: defaultTestSubnet}
+	endpoint := func(i int) negtypes.NetworkEndpoint {
+		return negtypes.NetworkEndpoint{IP: fmt.Sprintf("10.100.1.%d", i), Port: "80", Node: "instance1"}
+	}
+
+	currentMap := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		group1: negtypes.NewNetworkEndpointSet(endpoint(1), endpoint(2)),
+	}
+	targetMap := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		group1: negtypes.NewNetworkEndpointSet(endpoint(1), endpoint(3)),
+	}
+	degradedTargetMap := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		group1: negtypes.NewNetworkEndpointSet(endpoint(1), endpoint(2), endpoint(4), endpoint(5), endpoint(6), endpoint(7), endpoint(8)),
+		group2: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IPv6: "a:b::1", Port: "80", Node: "instance2"}),
+	}
+
+	comparison := newDegradedModeComparison(currentMap, targetMap, degradedTargetMap)
+	if comparison.agree() {
+		t.Errorf("agree() = true, want false")
+	}
+	// Normal mode attaches endpoint 3 and detaches endpoint 2.
+	if comparison.normalChanges != 2 {
+		t.Errorf("got %d normal mode changes, want 2", comparison.normalChanges)
+	}
+	// Degraded mode attaches endpoints 4 to 8 and the IPv6 endpoint.
+	if comparison.degradedChanges != 6 {
+		t.Errorf("got %d degraded mode changes, want 6", comparison.degradedChanges)
+	}
+
+	want := "Normal mode would change 2 endpoint(s), degraded mode would change 6 endpoint(s). " +
+		"Only in normal mode: [zone1/instance1/10.100.1.3:80]. " +
+		"Only in degraded mode: [zone1/instance1/10.100.1.2:80, zone1/instance1/10.100.1.4:80, zone1/instance1/10.100.1.5:80, zone1/instance1/10.100.1.6:80, zone1/instance1/10.100.1.7:80, and 2 more]."
+	if got := comparison.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if !newDegradedModeComparison(currentMap, targetMap, targetMap).agree() {
+		t.Errorf("agree() = false for the same calculations, want true")
+	}
+}
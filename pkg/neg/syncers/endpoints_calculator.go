@@ -18,17 +18,23 @@ package syncers
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/types"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/network"
+	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/zonegetter"
 	"k8s.io/klog/v2"
 )
@@ -48,6 +54,8 @@ type LocalL4EndpointsCalculator struct {
 	svcId           string
 	logger          klog.Logger
 	networkInfo     *network.NetworkInfo
+	// epStateCount is the endpoint count by state of the last calculation.
+	epStateCount types.StateCountMap
 }
 
 func NewLocalL4EndpointsCalculator(nodeLister listers.NodeLister, zoneGetter *zonegetter.ZoneGetter, svcId string, logger klog.Logger, networkInfo *network.NetworkInfo, lbType types.L4LBType) *LocalL4EndpointsCalculator {
@@ -76,18 +84,30 @@ func (l *LocalL4EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsDat
 	// List all nodes where the service endpoints are running. Get a subset of the desired count.
 	zoneNodeMap := make(map[string][]*nodeWithSubnet)
 	processedNodes := sets.String{}
+	// droppedNodes maps the nodes which are left out because of an error to
+	// the state the endpoints on them are counted in.
+	droppedNodes := make(map[string]types.State)
+	epStateCount := make(types.StateCountMap)
+	defer func() { l.epStateCount = epStateCount }()
 	numEndpoints := 0
 	for _, ed := range eds {
 		for _, addr := range ed.Addresses {
+			epStateCount[negtypes.Total]++
 			if addr.NodeName == nil {
 				l.logger.V(2).Info("Address inside Endpoints does not have an associated node. Skipping", "address", addr.Addresses, "endpoints", klog.KRef(ed.Meta.Namespace, ed.Meta.Name))
+				epStateCount[negtypes.NodeMissing]++
 				continue
 			}
 			if addr.TargetRef == nil {
 				l.logger.V(2).Info("Address inside Endpoints does not have an associated pod. Skipping", "address", addr.Addresses, "endpoints", klog.KRef(ed.Meta.Namespace, ed.Meta.Name))
+				epStateCount[negtypes.PodInvalid]++
 				continue
 			}
 			numEndpoints++
+			if state, ok := droppedNodes[*addr.NodeName]; ok {
+				epStateCount[state]++
+				continue
+			}
 			if processedNodes.Has(*addr.NodeName) {
 				continue
 			}
@@ -96,6 +116,8 @@ func (l *LocalL4EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsDat
 			if err != nil {
 				l.logger.Error(err, "failed to retrieve node object", "nodeName", *addr.NodeName)
 				metrics.PublishNegControllerErrorCountMetrics(err, true)
+				droppedNodes[*addr.NodeName] = negtypes.NodeNotFound
+				epStateCount[negtypes.NodeNotFound]++
 				continue
 			}
 			if ok := l.zoneGetter.IsNodeSelectedByFilter(node, zonegetter.CandidateAndUnreadyNodesFilter, l.logger); !ok {
@@ -110,6 +132,8 @@ func (l *LocalL4EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsDat
 			if err != nil || zone == zonegetter.EmptyZone {
 				l.logger.Error(err, "Unable to find zone for node, skipping", "nodeName", node.Name)
 				metrics.PublishNegControllerErrorCountMetrics(err, true)
+				droppedNodes[node.Name] = negtypes.ZoneMissing
+				epStateCount[negtypes.ZoneMissing]++
 				continue
 			}
 			zoneNodeMap[zone] = append(zoneNodeMap[zone], newNodeWithSubnet(node, subnet))
@@ -126,6 +150,13 @@ func (l *LocalL4EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsDat
 	return subsetMap, nil, 0, err
 }
 
+// EndpointStateCounts returns the endpoint count by state of the last calculation.
+// The endpoints whose node cannot be found or has no zone are counted, as
+// well as the endpoints without a node or a pod.
+func (l *LocalL4EndpointsCalculator) EndpointStateCounts() negtypes.StateCountMap {
+	return l.epStateCount
+}
+
 func (l *LocalL4EndpointsCalculator) CalculateEndpointsDegradedMode(eds []types.EndpointsData, currentMap map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, error) {
 	// this should be the same as CalculateEndpoints for L4 ec
 	subsetMap, podMap, _, err := l.CalculateEndpoints(eds, currentMap)
@@ -221,6 +252,8 @@ type L7EndpointsCalculator struct {
 	enableMultiSubnetCluster bool
 	logger                   klog.Logger
 	syncMetricsCollector     *metricscollector.SyncerMetrics
+	// epStateCount is the endpoint count by state of the last calculation.
+	epStateCount types.StateCountMap
 }
 
 func NewL7EndpointsCalculator(zoneGetter *zonegetter.ZoneGetter, podLister, nodeLister, serviceLister cache.Indexer, syncerKey types.NegSyncerKey, logger klog.Logger, enableDualStackNEG bool, syncMetricsCollector *metricscollector.SyncerMetrics) *L7EndpointsCalculator {
@@ -249,6 +282,7 @@ func (l *L7EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsData, _
 	result, err := toZoneNetworkEndpointMap(eds, l.zoneGetter, l.podLister, l.servicePortName, l.networkEndpointType, l.enableDualStackNEG, l.enableMultiSubnetCluster, l.logger)
 	if err == nil { // If current calculation ends up in error, we trigger and emit metrics in degraded mode.
 		l.syncMetricsCollector.UpdateSyncerEPMetrics(l.syncerKey, result.EPCount, result.EPSCount)
+		l.epStateCount = result.EPCount
 	}
 	return result.NetworkEndpointSet, result.EndpointPodMap, result.EPCount[negtypes.Duplicate] + result.EPCount[negtypes.NodeInNonDefaultSubnet], err
 }
@@ -257,9 +291,15 @@ func (l *L7EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsData, _
 func (l *L7EndpointsCalculator) CalculateEndpointsDegradedMode(eds []types.EndpointsData, _ map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, error) {
 	result := toZoneNetworkEndpointMapDegradedMode(eds, l.zoneGetter, l.podLister, l.nodeLister, l.serviceLister, l.servicePortName, l.networkEndpointType, l.enableDualStackNEG, l.enableMultiSubnetCluster, l.logger)
 	l.syncMetricsCollector.UpdateSyncerEPMetrics(l.syncerKey, result.EPCount, result.EPSCount)
+	l.epStateCount = result.EPCount
 	return result.NetworkEndpointSet, result.EndpointPodMap, nil
 }
 
+// EndpointStateCounts returns the endpoint count by state of the last calculation.
+func (l *L7EndpointsCalculator) EndpointStateCounts() negtypes.StateCountMap {
+	return l.epStateCount
+}
+
 func nodeMapToString(nodeMap map[string][]*nodeWithSubnet) string {
 	var str []string
 	for zone, nodeList := range nodeMap {
@@ -298,3 +338,261 @@ func (l *L7EndpointsCalculator) ValidateEndpoints(endpointData []types.Endpoints
 	}
 	return nil
 }
+
+// endpointStateReporter is implemented by endpoints calculators that count
+// the endpoints left out of the NEGs, by state.
+type endpointStateReporter interface {
+	// EndpointStateCounts returns the endpoint count by state of the last
+	// calculation.
+	EndpointStateCounts() negtypes.StateCountMap
+}
+
+// topologyHintsReporter is implemented by endpoints calculators that follow
+// the topology hints of the service endpoints.
+type topologyHintsReporter interface {
+	// TopologyHintsCondition returns the TopologyAware condition for the last
+	// calculation, and false if no calculation has run yet.
+	TopologyHintsCondition() (negv1beta1.Condition, bool)
+}
+
+// TopologyAwareL7EndpointsCalculator implements methods to calculate Network endpoints
+// for GCE_VM_IP_PORT NEGs of services that use topology-aware routing.
+// An endpoint is only included in the NEG of its zone if the topology hints of the
+// endpoint include that zone, so that each zonal NEG serves the endpoints that the
+// cluster routes the traffic of the zone to. If any ready endpoint has no hints, or
+// a zone with ready endpoints has none hinted to it, it falls back to the L7
+// calculation, in the same way kube-proxy ignores hints that cannot be used. A zonal
+// NEG cannot serve the traffic that the hints send to the endpoints of another zone,
+// so the endpoints of a zone are never all left out.
+type TopologyAwareL7EndpointsCalculator struct {
+	*L7EndpointsCalculator
+	// calculated is true once CalculateEndpoints has run.
+	calculated bool
+	// fallbackReason and fallbackMessage explain why the last calculation did
+	// not follow the topology hints. fallbackReason is empty if it did.
+	fallbackReason  string
+	fallbackMessage string
+}
+
+func NewTopologyAwareL7EndpointsCalculator(zoneGetter *zonegetter.ZoneGetter, podLister, nodeLister, serviceLister cache.Indexer, syncerKey types.NegSyncerKey, logger klog.Logger, enableDualStackNEG bool, syncMetricsCollector *metricscollector.SyncerMetrics) *TopologyAwareL7EndpointsCalculator {
+	l7 := NewL7EndpointsCalculator(zoneGetter, podLister, nodeLister, serviceLister, syncerKey, logger, enableDualStackNEG, syncMetricsCollector)
+	l7.logger = logger.WithName("TopologyAwareL7EndpointsCalculator")
+	return &TopologyAwareL7EndpointsCalculator{L7EndpointsCalculator: l7}
+}
+
+// Mode indicates the mode that the EndpointsCalculator is operating in.
+func (l *TopologyAwareL7EndpointsCalculator) Mode() types.EndpointsCalculatorMode {
+	return types.L7TopologyAwareMode
+}
+
+// CalculateEndpoints determines the endpoints in the NEGs based on the current service endpoints and their topology hints.
+func (l *TopologyAwareL7EndpointsCalculator) CalculateEndpoints(eds []types.EndpointsData, currentMap map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, int, error) {
+	filtered, reason, message := filterEndpointsByTopologyHints(eds)
+	if reason != l.fallbackReason || !l.calculated {
+		l.logger.Info("Topology hints status changed", "reason", reason, "message", message)
+	}
+	l.calculated = true
+	l.fallbackReason, l.fallbackMessage = reason, message
+	return l.L7EndpointsCalculator.CalculateEndpoints(filtered, currentMap)
+}
+
+// CalculateEndpointsDegradedMode determines the endpoints in the NEGs based on the current service endpoints and their topology hints.
+func (l *TopologyAwareL7EndpointsCalculator) CalculateEndpointsDegradedMode(eds []types.EndpointsData, currentMap map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, error) {
+	filtered, _, _ := filterEndpointsByTopologyHints(eds)
+	return l.L7EndpointsCalculator.CalculateEndpointsDegradedMode(filtered, currentMap)
+}
+
+// ValidateEndpoints checks if endpoint information is correct.
+// The endpoints left out by the topology hints are not counted.
+func (l *TopologyAwareL7EndpointsCalculator) ValidateEndpoints(endpointData []types.EndpointsData, endpointPodMap types.EndpointPodMap, endpointsExcludedInCalculation int) error {
+	filtered, _, _ := filterEndpointsByTopologyHints(endpointData)
+	return l.L7EndpointsCalculator.ValidateEndpoints(filtered, endpointPodMap, endpointsExcludedInCalculation)
+}
+
+// TopologyHintsCondition returns the TopologyAware condition for the last calculation.
+func (l *TopologyAwareL7EndpointsCalculator) TopologyHintsCondition() (negv1beta1.Condition, bool) {
+	if !l.calculated {
+		return negv1beta1.Condition{}, false
+	}
+	if l.fallbackReason != "" {
+		return negv1beta1.Condition{
+			Type:               negv1beta1.TopologyAware,
+			Status:             corev1.ConditionFalse,
+			Reason:             l.fallbackReason,
+			LastTransitionTime: metav1.Now(),
+			Message:            fmt.Sprintf("Using %s endpoint calculation: %s", types.L7Mode, l.fallbackMessage),
+		}, true
+	}
+	return negv1beta1.Condition{
+		Type:               negv1beta1.TopologyAware,
+		Status:             corev1.ConditionTrue,
+		Reason:             negtypes.TopologyHintsApplied,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Using %s endpoint calculation", types.L7TopologyAwareMode),
+	}, true
+}
+
+// filterEndpointsByTopologyHints returns the endpoints data without the addresses
+// whose topology hints do not include their zone. Endpoints that are not ready
+// may have no hints, and are kept. If the hints cannot be used, or would leave
+// a zone with ready endpoints without any, it returns the endpoints data
+// unchanged, with the reason and a message.
+func filterEndpointsByTopologyHints(eds []types.EndpointsData) ([]types.EndpointsData, string, string) {
+	result := make([]types.EndpointsData, 0, len(eds))
+	kept := 0
+	// readyZones are the zones with ready endpoints, and coveredZones the
+	// zones with ready endpoints hinted to them.
+	readyZones, coveredZones := sets.NewString(), sets.NewString()
+	for _, ed := range eds {
+		filtered := ed
+		filtered.Addresses = make([]types.AddressData, 0, len(ed.Addresses))
+		for _, address := range ed.Addresses {
+			if address.Hints == nil || len(address.Hints.ForZones) == 0 {
+				if address.Ready {
+					return eds, negtypes.TopologyHintsMissing, fmt.Sprintf("endpoint %v in %s/%s has no topology hints", address.Addresses, ed.Meta.Namespace, ed.Meta.Name)
+				}
+				filtered.Addresses = append(filtered.Addresses, address)
+				continue
+			}
+			if address.Zone != nil && address.Ready {
+				readyZones.Insert(*address.Zone)
+			}
+			if address.Zone == nil || !hintsIncludeZone(address.Hints, *address.Zone) {
+				continue
+			}
+			filtered.Addresses = append(filtered.Addresses, address)
+			if address.Ready {
+				kept++
+				coveredZones.Insert(*address.Zone)
+			}
+		}
+		result = append(result, filtered)
+	}
+	if kept == 0 {
+		return eds, negtypes.TopologyHintsNoEndpoints, "no ready endpoint is hinted to its own zone"
+	}
+	if uncovered := readyZones.Difference(coveredZones); uncovered.Len() != 0 {
+		return eds, negtypes.TopologyHintsZoneNotCovered, fmt.Sprintf("no ready endpoint in zone(s) %v is hinted to its own zone", uncovered.List())
+	}
+	return result, "", ""
+}
+
+func hintsIncludeZone(hints *discovery.EndpointHints, zone string) bool {
+	for _, forZone := range hints.ForZones {
+		if forZone.Name == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalEndpointsCalculator implements methods to calculate Network endpoints for
+// NEGs whose endpoints are outside of the cluster: INTERNET_FQDN_PORT, INTERNET_IP_PORT
+// and NON_GCP_PRIVATE_IP_PORT NEGs.
+// The addresses of the service endpoints are used as is, without looking up pods or nodes.
+// Internet NEGs are global, so all endpoints are placed in GlobalZone. Hybrid NEGs are zonal,
+// and the endpoints are placed in the zone set on the endpoint if the cluster has candidate
+// nodes in that zone, or the first candidate zone otherwise.
+type ExternalEndpointsCalculator struct {
+	zoneGetter          *zonegetter.ZoneGetter
+	servicePortName     string
+	networkEndpointType types.NetworkEndpointType
+	networkInfo         *network.NetworkInfo
+	logger              klog.Logger
+}
+
+func NewExternalEndpointsCalculator(zoneGetter *zonegetter.ZoneGetter, syncerKey types.NegSyncerKey, logger klog.Logger, networkInfo *network.NetworkInfo) *ExternalEndpointsCalculator {
+	return &ExternalEndpointsCalculator{
+		zoneGetter:          zoneGetter,
+		servicePortName:     syncerKey.PortTuple.Name,
+		networkEndpointType: syncerKey.NegType,
+		networkInfo:         networkInfo,
+		logger:              logger.WithName("ExternalEndpointsCalculator"),
+	}
+}
+
+// Mode indicates the mode that the EndpointsCalculator is operating in.
+func (l *ExternalEndpointsCalculator) Mode() types.EndpointsCalculatorMode {
+	return types.ExternalMode
+}
+
+// CalculateEndpoints determines the endpoints in the NEGs based on the current service endpoints and the current NEGs.
+func (l *ExternalEndpointsCalculator) CalculateEndpoints(eds []types.EndpointsData, _ map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, int, error) {
+	subnet, err := utils.KeyName(l.networkInfo.SubnetworkURL)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var zones sets.String
+	var defaultZone string
+	if !types.IsGlobalNetworkEndpointType(l.networkEndpointType) {
+		candidateZones, err := l.zoneGetter.ListZones(zonegetter.CandidateNodesFilter, l.logger)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if len(candidateZones) == 0 {
+			return nil, nil, 0, fmt.Errorf("no candidate zones for %s NEG", l.networkEndpointType)
+		}
+		sort.Strings(candidateZones)
+		zones = sets.NewString(candidateZones...)
+		defaultZone = candidateZones[0]
+	}
+
+	result := map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet{}
+	for _, ed := range eds {
+		matchPort := ""
+		for _, port := range ed.Ports {
+			if port.Name == l.servicePortName {
+				matchPort = fmt.Sprint(port.Port)
+				break
+			}
+		}
+		if matchPort == "" {
+			continue
+		}
+		for _, addr := range ed.Addresses {
+			if !addr.Ready || len(addr.Addresses) == 0 {
+				continue
+			}
+			// Only the first address of an endpoint is used, the same way kube-proxy does.
+			networkEndpoint := types.NetworkEndpoint{Port: matchPort}
+			switch {
+			case addr.AddressType == discovery.AddressTypeFQDN && l.networkEndpointType == types.InternetFQDNEndpointType:
+				networkEndpoint.FQDN = addr.Addresses[0]
+			case addr.AddressType == discovery.AddressTypeIPv4 && l.networkEndpointType != types.InternetFQDNEndpointType:
+				networkEndpoint.IP = parseIPAddress(addr.Addresses[0])
+			default:
+				l.logger.V(2).Info("Skipping address not supported by NEG type", "address", addr.Addresses, "addressType", addr.AddressType, "negType", l.networkEndpointType, "endpoints", klog.KRef(ed.Meta.Namespace, ed.Meta.Name))
+				continue
+			}
+			if networkEndpoint.FQDN == "" && networkEndpoint.IP == "" {
+				l.logger.V(2).Info("Skipping invalid address", "address", addr.Addresses, "endpoints", klog.KRef(ed.Meta.Namespace, ed.Meta.Name))
+				continue
+			}
+
+			zone := types.GlobalZone
+			if zones != nil {
+				zone = defaultZone
+				if addr.Zone != nil && zones.Has(*addr.Zone) {
+					zone = *addr.Zone
+				}
+			}
+			groupInfo := negtypes.EndpointGroupInfo{Zone: zone, Subnet: subnet}
+			if result[groupInfo] == nil {
+				result[groupInfo] = negtypes.NewNetworkEndpointSet()
+			}
+			result[groupInfo].Insert(networkEndpoint)
+		}
+	}
+	return result, types.EndpointPodMap{}, 0, nil
+}
+
+func (l *ExternalEndpointsCalculator) CalculateEndpointsDegradedMode(eds []types.EndpointsData, currentMap map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet) (map[negtypes.EndpointGroupInfo]types.NetworkEndpointSet, types.EndpointPodMap, error) {
+	// External endpoints are not backed by pods or nodes, so there is nothing to degrade.
+	result, podMap, _, err := l.CalculateEndpoints(eds, currentMap)
+	return result, podMap, err
+}
+
+func (l *ExternalEndpointsCalculator) ValidateEndpoints(endpointData []types.EndpointsData, endpointPodMap types.EndpointPodMap, endpointsExcludedInCalculation int) error {
+	// this should be a no-op since external endpoints have no pods
+	return nil
+}
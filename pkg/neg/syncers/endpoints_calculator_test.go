@@ -179,6 +179,48 @@ func TestLocalGetEndpointSet(t *testing.T) {
 	}
 }
 
+// TestLocalEndpointStateCounts verifies that the LocalL4EndpointsCalculator
+// counts the endpoints it leaves out, by state.
+func TestLocalEndpointStateCounts(t *testing.T) {
+	nodeInformer := zonegetter.FakeNodeInformer()
+	zoneGetter := zonegetter.NewFakeZoneGetter(nodeInformer, zonegetter.FakeNodeTopologyInformer(), defaultTestSubnetURL, false)
+	zonegetter.PopulateFakeNodeInformer(nodeInformer, false)
+	zonegetter.SetNodeTopologyHasSynced(zoneGetter, func() bool { return true })
+	defaultNetwork := network.NetworkInfo{IsDefault: true, K8sNetwork: "default", SubnetworkURL: defaultTestSubnetURL}
+
+	instance1, unknownNode := testInstance1, "unknown-node"
+	podRef := func(name string) *v1.ObjectReference {
+		return &v1.ObjectReference{Kind: "Pod", Namespace: testServiceNamespace, Name: name}
+	}
+	endpointsData := []negtypes.EndpointsData{{
+		Meta: &metav1.ObjectMeta{Namespace: testServiceNamespace, Name: testServiceName},
+		Addresses: []negtypes.AddressData{
+			{TargetRef: podRef("pod1"), NodeName: &instance1, Addresses: []string{"10.100.1.1"}, Ready: true},
+			{TargetRef: podRef("pod2"), Addresses: []string{"10.100.1.2"}, Ready: true},
+			{NodeName: &instance1, Addresses: []string{"10.100.1.3"}, Ready: true},
+			{TargetRef: podRef("pod4"), NodeName: &unknownNode, Addresses: []string{"10.100.1.4"}, Ready: true},
+			{TargetRef: podRef("pod5"), NodeName: &unknownNode, Addresses: []string{"10.100.1.5"}, Ready: true},
+		},
+	}}
+
+	ec := NewLocalL4EndpointsCalculator(listers.NewNodeLister(nodeInformer.GetIndexer()), zoneGetter, "svc", klog.TODO(), &defaultNetwork, negtypes.L4InternalLB)
+	if got := ec.EndpointStateCounts(); got != nil {
+		t.Errorf("EndpointStateCounts() = %v before any calculation, want nil", got)
+	}
+	if _, _, _, err := ec.CalculateEndpoints(endpointsData, nil); err != nil {
+		t.Fatalf("CalculateEndpoints() = %v, want nil", err)
+	}
+	want := negtypes.StateCountMap{
+		negtypes.Total:        5,
+		negtypes.NodeMissing:  1,
+		negtypes.PodInvalid:   1,
+		negtypes.NodeNotFound: 2,
+	}
+	if diff := cmp.Diff(want, ec.EndpointStateCounts()); diff != "" {
+		t.Errorf("EndpointStateCounts() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
 func nodeInterfacesAnnotation(t *testing.T, network, ip string) string {
 	t.Helper()
 	annotation, err := networkv1.MarshalNorthInterfacesAnnotation(networkv1.NorthInterfacesAnnotation{
@@ -345,6 +387,339 @@ func TestClusterGetEndpointSet(t *testing.T) {
 	}
 }
 
+// TestExternalGetEndpointSet verifies the GetEndpointSet method implemented by the ExternalEndpointsCalculator.
+func TestExternalGetEndpointSet(t *testing.T) {
+	nodeInformer := zonegetter.FakeNodeInformer()
+	zoneGetter := zonegetter.NewFakeZoneGetter(nodeInformer, zonegetter.FakeNodeTopologyInformer(), defaultTestSubnetURL, false)
+	zonegetter.PopulateFakeNodeInformer(nodeInformer, false)
+	zonegetter.SetNodeTopologyHasSynced(zoneGetter, func() bool { return true })
+	defaultNetwork := network.NetworkInfo{IsDefault: true, K8sNetwork: "default", SubnetworkURL: defaultTestSubnetURL}
+	testExternalPortName := "https"
+
+	port := negtypes.PortData{Name: testExternalPortName, Port: 443}
+	ipEndpointsData := []negtypes.EndpointsData{
+		{
+			Meta:  &metav1.ObjectMeta{Name: testServiceName, Namespace: testServiceNamespace},
+			Ports: []negtypes.PortData{port},
+			Addresses: []negtypes.AddressData{
+				{Addresses: []string{"203.0.113.1"}, Ready: true, AddressType: discovery.AddressTypeIPv4},
+				{Addresses: []string{"203.0.113.2"}, Ready: true, AddressType: discovery.AddressTypeIPv4, Zone: utils.NewStringPointer(negtypes.TestZone2)},
+				{Addresses: []string{"203.0.113.3"}, Ready: false, AddressType: discovery.AddressTypeIPv4},
+				{Addresses: []string{"203.0.113.4"}, Ready: true, AddressType: discovery.AddressTypeIPv4, Zone: utils.NewStringPointer("unknown-zone")},
+			},
+		},
+	}
+	fqdnEndpointsData := negtypes.EndpointsDataFromExternalNameService(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: testServiceName, Namespace: testServiceNamespace},
+		Spec: v1.ServiceSpec{
+			Type:         v1.ServiceTypeExternalName,
+			ExternalName: "api.example.com",
+		},
+	}, negtypes.SvcPortTuple{Name: testExternalPortName, Port: 443})
+
+	testCases := []struct {
+		desc                string
+		networkEndpointType negtypes.NetworkEndpointType
+		endpointsData       []negtypes.EndpointsData
+		wantEndpointSets    map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+	}{
+		{
+			desc:                "internet fqdn NEG with ExternalName service",
+			networkEndpointType: negtypes.InternetFQDNEndpointType,
+			endpointsData:       fqdnEndpointsData,
+			wantEndpointSets: map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+				{Zone: negtypes.GlobalZone, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{FQDN: "api.example.com", Port: "443"}),
+			},
+		},
+		{
+			desc:                "internet fqdn NEG ignores IP addresses",
+			networkEndpointType: negtypes.InternetFQDNEndpointType,
+			endpointsData:       ipEndpointsData,
+			wantEndpointSets:    map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{},
+		},
+		{
+			desc:                "internet ip NEG puts all ready endpoints in the global zone",
+			networkEndpointType: negtypes.InternetIPEndpointType,
+			endpointsData:       ipEndpointsData,
+			wantEndpointSets: map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+				{Zone: negtypes.GlobalZone, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+					negtypes.NetworkEndpoint{IP: "203.0.113.1", Port: "443"},
+					negtypes.NetworkEndpoint{IP: "203.0.113.2", Port: "443"},
+					negtypes.NetworkEndpoint{IP: "203.0.113.4", Port: "443"},
+				),
+			},
+		},
+		{
+			desc:                "hybrid NEG uses the endpoint zone if it has candidate nodes",
+			networkEndpointType: negtypes.NonGCPPrivateEndpointType,
+			endpointsData:       ipEndpointsData,
+			wantEndpointSets: map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+				{Zone: negtypes.TestZone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+					negtypes.NetworkEndpoint{IP: "203.0.113.1", Port: "443"},
+					negtypes.NetworkEndpoint{IP: "203.0.113.4", Port: "443"},
+				),
+				{Zone: negtypes.TestZone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+					negtypes.NetworkEndpoint{IP: "203.0.113.2", Port: "443"},
+				),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			syncerKey := negtypes.NegSyncerKey{
+				PortTuple: negtypes.SvcPortTuple{Name: testExternalPortName, Port: 443},
+				NegType:   tc.networkEndpointType,
+			}
+			ec := NewExternalEndpointsCalculator(zoneGetter, syncerKey, klog.TODO(), &defaultNetwork)
+			retSet, retMap, _, err := ec.CalculateEndpoints(tc.endpointsData, nil)
+			if err != nil {
+				t.Fatalf("CalculateEndpoints() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(tc.wantEndpointSets, retSet); diff != "" {
+				t.Errorf("CalculateEndpoints() returned unexpected endpoint sets (-want +got):\n%s", diff)
+			}
+			if len(retMap) != 0 {
+				t.Errorf("CalculateEndpoints() returned endpoint pod map %v, want empty", retMap)
+			}
+		})
+	}
+}
+
+func TestTopologyAwareGetEndpointSet(t *testing.T) {
+	testPortName := ""
+	port80 := int32(80)
+	protocolTCP := v1.ProtocolTCP
+	instance1 := negtypes.TestInstance1
+	instance2 := negtypes.TestInstance2
+	instance3 := negtypes.TestInstance3
+	zone1 := negtypes.TestZone1
+	zone2 := negtypes.TestZone2
+	svcPort := negtypes.NegSyncerKey{
+		Namespace: testServiceNamespace,
+		Name:      testServiceName,
+		NegType:   negtypes.VmIpPortEndpointType,
+		PortTuple: negtypes.SvcPortTuple{
+			Port:       80,
+			TargetPort: "8080",
+			Name:       testPortName,
+		},
+		NegName: testNegName,
+	}
+
+	testContext := negtypes.NewTestContext()
+	podLister := testContext.PodInformer.GetIndexer()
+	addPodsToLister(podLister, getDefaultEndpointSlices())
+	nodeLister := testContext.NodeInformer.GetIndexer()
+	serviceLister := testContext.ServiceInformer.GetIndexer()
+	zonegetter.PopulateFakeNodeInformer(testContext.NodeInformer, false)
+	zoneGetter := zonegetter.NewFakeZoneGetter(testContext.NodeInformer, zonegetter.FakeNodeTopologyInformer(), defaultTestSubnetURL, false)
+
+	forZones := func(zones ...string) *discovery.EndpointHints {
+		hints := &discovery.EndpointHints{}
+		for _, zone := range zones {
+			hints.ForZones = append(hints.ForZones, discovery.ForZone{Name: zone})
+		}
+		return hints
+	}
+	endpointSlice := func(pod3Hints, pod4Hints *discovery.EndpointHints) []*discovery.EndpointSlice {
+		return []*discovery.EndpointSlice{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceName,
+					Namespace: testServiceNamespace,
+					Labels: map[string]string{
+						discovery.LabelServiceName: testServiceName,
+					},
+				},
+				AddressType: "IPv4",
+				Endpoints: []discovery.Endpoint{
+					{
+						Addresses: []string{"10.100.1.1"},
+						NodeName:  &instance1,
+						Zone:      &zone1,
+						Hints:     forZones(zone1),
+						TargetRef: &v1.ObjectReference{Namespace: testServiceNamespace, Name: "pod1"},
+					},
+					{
+						Addresses: []string{"10.100.2.1"},
+						NodeName:  &instance2,
+						Zone:      &zone1,
+						Hints:     pod3Hints,
+						TargetRef: &v1.ObjectReference{Namespace: testServiceNamespace, Name: "pod3"},
+					},
+					{
+						Addresses: []string{"10.100.3.1"},
+						NodeName:  &instance3,
+						Zone:      &zone2,
+						Hints:     pod4Hints,
+						TargetRef: &v1.ObjectReference{Namespace: testServiceNamespace, Name: "pod4"},
+					},
+				},
+				Ports: []discovery.EndpointPort{
+					{
+						Name:     &testPortName,
+						Port:     &port80,
+						Protocol: &protocolTCP,
+					},
+				},
+			},
+		}
+	}
+	allEndpoints := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: zone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+			negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: instance1},
+			negtypes.NetworkEndpoint{IP: "10.100.2.1", Port: "80", Node: instance2},
+		),
+		{Zone: zone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+			negtypes.NetworkEndpoint{IP: "10.100.3.1", Port: "80", Node: instance3},
+		),
+	}
+
+	testCases := []struct {
+		desc             string
+		endpointSlices   []*discovery.EndpointSlice
+		wantEndpointSets map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+		wantStatus       v1.ConditionStatus
+		wantReason       string
+	}{
+		{
+			desc:             "endpoints hinted to all zones",
+			endpointSlices:   endpointSlice(forZones(zone1, zone2), forZones(zone1, zone2)),
+			wantEndpointSets: allEndpoints,
+			wantStatus:       v1.ConditionTrue,
+			wantReason:       negtypes.TopologyHintsApplied,
+		},
+		{
+			desc:           "endpoint hinted away from its zone is left out",
+			endpointSlices: endpointSlice(forZones(zone2), forZones(zone2)),
+			wantEndpointSets: map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+				{Zone: zone1, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+					negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: instance1},
+				),
+				{Zone: zone2, Subnet: defaultTestSubnet}: negtypes.NewNetworkEndpointSet(
+					negtypes.NetworkEndpoint{IP: "10.100.3.1", Port: "80", Node: instance3},
+				),
+			},
+			wantStatus: v1.ConditionTrue,
+			wantReason: negtypes.TopologyHintsApplied,
+		},
+		{
+			desc:             "ready endpoint without hints falls back to L7 calculation",
+			endpointSlices:   endpointSlice(forZones(zone2), nil),
+			wantEndpointSets: allEndpoints,
+			wantStatus:       v1.ConditionFalse,
+			wantReason:       negtypes.TopologyHintsMissing,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ec := NewTopologyAwareL7EndpointsCalculator(zoneGetter, podLister, nodeLister, serviceLister, svcPort, klog.TODO(), false, metricscollector.FakeSyncerMetrics())
+			if _, ok := ec.TopologyHintsCondition(); ok {
+				t.Fatalf("TopologyHintsCondition() returned a condition before any calculation")
+			}
+			eds := negtypes.EndpointsDataFromEndpointSlices(tc.endpointSlices)
+			retSet, retMap, excluded, err := ec.CalculateEndpoints(eds, nil)
+			if err != nil {
+				t.Fatalf("CalculateEndpoints() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(tc.wantEndpointSets, retSet); diff != "" {
+				t.Errorf("CalculateEndpoints() returned unexpected endpoint sets (-want +got):\n%s", diff)
+			}
+			if err := ec.ValidateEndpoints(eds, retMap, excluded); err != nil {
+				t.Errorf("ValidateEndpoints() = %v, want nil", err)
+			}
+			condition, ok := ec.TopologyHintsCondition()
+			if !ok {
+				t.Fatalf("TopologyHintsCondition() returned no condition after a calculation")
+			}
+			if condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("TopologyHintsCondition() = %s/%s, want %s/%s", condition.Status, condition.Reason, tc.wantStatus, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestFilterEndpointsByTopologyHints(t *testing.T) {
+	zone1 := negtypes.TestZone1
+	zone2 := negtypes.TestZone2
+	meta := &metav1.ObjectMeta{Name: testServiceName, Namespace: testServiceNamespace}
+	hints := func(zone string) *discovery.EndpointHints {
+		return &discovery.EndpointHints{ForZones: []discovery.ForZone{{Name: zone}}}
+	}
+
+	testCases := []struct {
+		desc          string
+		addresses     []negtypes.AddressData
+		wantAddresses []string
+		wantReason    string
+	}{
+		{
+			desc: "unready endpoints without hints are kept",
+			addresses: []negtypes.AddressData{
+				{Addresses: []string{"10.100.1.1"}, Zone: &zone1, Ready: true, Hints: hints(zone1)},
+				{Addresses: []string{"10.100.1.2"}, Zone: &zone1, Ready: false},
+				{Addresses: []string{"10.100.1.3"}, Zone: &zone1, Ready: true, Hints: hints(zone2)},
+			},
+			wantAddresses: []string{"10.100.1.1", "10.100.1.2"},
+		},
+		{
+			desc: "no endpoint hinted to its own zone",
+			addresses: []negtypes.AddressData{
+				{Addresses: []string{"10.100.1.1"}, Zone: &zone1, Ready: true, Hints: hints(zone2)},
+				{Addresses: []string{"10.100.3.1"}, Zone: &zone2, Ready: true, Hints: hints(zone1)},
+			},
+			wantAddresses: []string{"10.100.1.1", "10.100.3.1"},
+			wantReason:    negtypes.TopologyHintsNoEndpoints,
+		},
+		{
+			desc: "zone with all its endpoints hinted to another zone",
+			addresses: []negtypes.AddressData{
+				{Addresses: []string{"10.100.1.1"}, Zone: &zone1, Ready: true, Hints: hints(zone1)},
+				{Addresses: []string{"10.100.1.2"}, Zone: &zone1, Ready: true, Hints: hints(zone1)},
+				{Addresses: []string{"10.100.3.1"}, Zone: &zone2, Ready: true, Hints: hints(zone1)},
+			},
+			wantAddresses: []string{"10.100.1.1", "10.100.1.2", "10.100.3.1"},
+			wantReason:    negtypes.TopologyHintsZoneNotCovered,
+		},
+		{
+			desc: "endpoint hinted to another zone is left out of a covered zone",
+			addresses: []negtypes.AddressData{
+				{Addresses: []string{"10.100.1.1"}, Zone: &zone1, Ready: true, Hints: hints(zone1)},
+				{Addresses: []string{"10.100.1.2"}, Zone: &zone1, Ready: true, Hints: hints(zone2)},
+				{Addresses: []string{"10.100.3.1"}, Zone: &zone2, Ready: true, Hints: hints(zone2)},
+			},
+			wantAddresses: []string{"10.100.1.1", "10.100.3.1"},
+		},
+		{
+			desc: "ready endpoint with empty hints",
+			addresses: []negtypes.AddressData{
+				{Addresses: []string{"10.100.1.1"}, Zone: &zone1, Ready: true, Hints: hints(zone1)},
+				{Addresses: []string{"10.100.1.2"}, Zone: &zone1, Ready: true, Hints: &discovery.EndpointHints{}},
+			},
+			wantAddresses: []string{"10.100.1.1", "10.100.1.2"},
+			wantReason:    negtypes.TopologyHintsMissing,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			eds := []negtypes.EndpointsData{{Meta: meta, Addresses: tc.addresses}}
+			got, reason, _ := filterEndpointsByTopologyHints(eds)
+			if reason != tc.wantReason {
+				t.Errorf("filterEndpointsByTopologyHints() reason = %q, want %q", reason, tc.wantReason)
+			}
+			var gotAddresses []string
+			for _, ed := range got {
+				for _, address := range ed.Addresses {
+					gotAddresses = append(gotAddresses, address.Addresses...)
+				}
+			}
+			if diff := cmp.Diff(tc.wantAddresses, gotAddresses); diff != "" {
+				t.Errorf("filterEndpointsByTopologyHints() returned unexpected addresses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateEndpoints(t *testing.T) {
 	testPortName := ""
 	emptyNamedPort := ""
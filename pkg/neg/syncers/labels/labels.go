@@ -30,6 +30,9 @@ import (
 // PodLabelPropagationConfig contains a list of configurations for labels to be propagated to GCE network endpoints.
 type PodLabelPropagationConfig struct {
 	Labels []Label
+	// Rules contains configurations for labels and annotations to be propagated
+	// to the GCE network endpoints of the Services they select.
+	Rules []Rule `json:",omitempty"`
 }
 
 // Label contains configuration for a label to be propagated to GCE network endpoints.
@@ -61,27 +64,27 @@ const minLabelLength = 5
 
 // GetPodLabelMap will return the label map extracted from a pod according to PodLabelPropagationConfig.
 // The returned map has the pod label key as key and label value as value.
+// All the rules of the config are applied, use ForService to select the rules of a Service.
+// If several labels or annotations are propagated with the same key, only the first one in the config is propagated.
 // This function will raise an error if pod label truncation happens or truncation fails.
 func GetPodLabelMap(pod *v1.Pod, lpConfig PodLabelPropagationConfig) (PodLabelMap, error) {
 	labelMap := PodLabelMap{}
 	var errs []error
-	for _, label := range lpConfig.Labels {
-		val, ok := pod.Labels[label.Key]
-		if ok {
-			lpKey := label.Key
-			if label.ShortKey != "" {
-				lpKey = label.ShortKey
-			}
-			labelVal, err := truncatePodLabel(lpKey, val, label.MaxLabelSizeBytes)
-			if err != nil {
-				errs = append(errs, err)
-				publishLabelPropagationTruncationMetrics(err)
-			}
-
-			// Add the label to the map only if the truncation result is valid
-			if err == nil || errors.Is(err, ErrLabelTruncated) {
-				labelMap[lpKey] = labelVal
-			}
+	seenKeys := make(map[string]bool)
+	for _, pv := range lpConfig.propagatedValues(pod.Labels, pod.Annotations) {
+		if seenKeys[pv.key] {
+			continue
+		}
+		seenKeys[pv.key] = true
+		labelVal, err := truncatePodLabel(pv.key, pv.value, pv.maxLabelSizeBytes)
+		if err != nil {
+			errs = append(errs, err)
+			publishLabelPropagationTruncationMetrics(err)
+		}
+
+		// Add the label to the map only if the truncation result is valid
+		if err == nil || errors.Is(err, ErrLabelTruncated) {
+			labelMap[pv.key] = labelVal
 		}
 	}
 	if len(errs) != 0 {
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Rule contains configuration for the pod labels and annotations to be
+// propagated to the GCE network endpoints of the Services it selects.
+type Rule struct {
+	// Namespace and Service select the Services whose pods the rule applies
+	// to. An empty value matches all namespaces or Services.
+	Namespace string
+	Service   string
+	// Labels are the pod labels to be propagated.
+	Labels []Label
+	// LabelPrefixes select the pod labels to be propagated by key prefix.
+	LabelPrefixes []LabelPrefix
+	// Annotations are the pod annotations to be propagated. Their Key is the
+	// pod annotation key.
+	Annotations []Label
+}
+
+// LabelPrefix contains configuration for the pod labels with a key prefix to
+// be propagated to GCE network endpoints.
+type LabelPrefix struct {
+	Prefix string
+	// ShortPrefix replaces Prefix in the propagated keys if set.
+	ShortPrefix       string
+	MaxLabelSizeBytes int
+}
+
+// matches returns true if the rule applies to the pods of the Service.
+func (r Rule) matches(namespace, service string) bool {
+	return (r.Namespace == "" || r.Namespace == namespace) && (r.Service == "" || r.Service == service)
+}
+
+// Validate returns an error if the config has a label, prefix or annotation
+// without key, or a prefix which would propagate labels with an empty key.
+func (c PodLabelPropagationConfig) Validate() error {
+	for _, label := range c.Labels {
+		if label.Key == "" {
+			return fmt.Errorf("label without key in pod label propagation config")
+		}
+	}
+	for i, rule := range c.Rules {
+		for _, label := range rule.Labels {
+			if label.Key == "" {
+				return fmt.Errorf("label without key in pod label propagation rule %d", i)
+			}
+		}
+		for _, annotation := range rule.Annotations {
+			if annotation.Key == "" {
+				return fmt.Errorf("annotation without key in pod label propagation rule %d", i)
+			}
+		}
+		for _, prefix := range rule.LabelPrefixes {
+			if prefix.Prefix == "" {
+				return fmt.Errorf("label prefix without prefix in pod label propagation rule %d", i)
+			}
+		}
+	}
+	return nil
+}
+
+// ForService returns the config for the pods of the Service, with only the
+// rules selecting the Service.
+func (c PodLabelPropagationConfig) ForService(namespace, service string) PodLabelPropagationConfig {
+	config := PodLabelPropagationConfig{Labels: c.Labels}
+	for _, rule := range c.Rules {
+		if rule.matches(namespace, service) {
+			config.Rules = append(config.Rules, rule)
+		}
+	}
+	return config
+}
+
+// propagatedValue is a pod label or annotation value to be propagated with
+// its key on GCE network endpoints.
+type propagatedValue struct {
+	key               string
+	value             string
+	maxLabelSizeBytes int
+}
+
+// propagatedValues returns the pod labels and annotations to be propagated
+// according to the labels of the config, followed by the rules in order.
+func (c PodLabelPropagationConfig) propagatedValues(podLabels, podAnnotations map[string]string) []propagatedValue {
+	var values []propagatedValue
+	addLabels := func(labels []Label, source map[string]string) {
+		for _, label := range labels {
+			val, ok := source[label.Key]
+			if !ok {
+				continue
+			}
+			key := label.Key
+			if label.ShortKey != "" {
+				key = label.ShortKey
+			}
+			values = append(values, propagatedValue{key: key, value: val, maxLabelSizeBytes: label.MaxLabelSizeBytes})
+		}
+	}
+
+	addLabels(c.Labels, podLabels)
+	for _, rule := range c.Rules {
+		addLabels(rule.Labels, podLabels)
+		for _, prefix := range rule.LabelPrefixes {
+			var keys []string
+			for key := range podLabels {
+				if strings.HasPrefix(key, prefix.Prefix) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				lpKey := key
+				if prefix.ShortPrefix != "" {
+					lpKey = prefix.ShortPrefix + strings.TrimPrefix(key, prefix.Prefix)
+				}
+				values = append(values, propagatedValue{key: lpKey, value: podLabels[key], maxLabelSizeBytes: prefix.MaxLabelSizeBytes})
+			}
+		}
+		addLabels(rule.Annotations, podAnnotations)
+	}
+	return values
+}
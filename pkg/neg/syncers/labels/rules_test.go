@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPodLabelMapWithRules(t *testing.T) {
+	t.Parallel()
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "n1",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":  "app",
+				"mesh.example.com/policy": "strict",
+				"mesh.example.com/tier":   "frontend-with-long-name",
+				"foo-key":                 "foo",
+			},
+			Annotations: map[string]string{
+				"example.com/owner": "team-a",
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		lpConfig  PodLabelPropagationConfig
+		expect    PodLabelMap
+		expectErr bool
+	}{
+		{
+			desc: "label prefix with short prefix",
+			lpConfig: PodLabelPropagationConfig{
+				Rules: []Rule{
+					{
+						LabelPrefixes: []LabelPrefix{{Prefix: "mesh.example.com/", ShortPrefix: "mesh-", MaxLabelSizeBytes: 40}},
+					},
+				},
+			},
+			expect: PodLabelMap{
+				"mesh-policy": "strict",
+				"mesh-tier":   "frontend-with-long-name",
+			},
+		},
+		{
+			desc: "label prefix without short prefix, truncation needed for one label",
+			lpConfig: PodLabelPropagationConfig{
+				Rules: []Rule{
+					{
+						LabelPrefixes: []LabelPrefix{{Prefix: "mesh.example.com/", MaxLabelSizeBytes: 30}},
+					},
+				},
+			},
+			expect: PodLabelMap{
+				"mesh.example.com/policy": "strict",
+				"mesh.example.com/tier":   "frontend-",
+			},
+			expectErr: true,
+		},
+		{
+			desc: "annotations with short key",
+			lpConfig: PodLabelPropagationConfig{
+				Rules: []Rule{
+					{
+						Annotations: []Label{
+							{Key: "example.com/owner", ShortKey: "owner", MaxLabelSizeBytes: 20},
+							{Key: "example.com/missing", MaxLabelSizeBytes: 40},
+						},
+					},
+				},
+			},
+			expect: PodLabelMap{
+				"owner": "team-a",
+			},
+		},
+		{
+			desc: "labels of the config take precedence over rules",
+			lpConfig: PodLabelPropagationConfig{
+				Labels: []Label{{Key: "foo-key", ShortKey: "key", MaxLabelSizeBytes: 20}},
+				Rules: []Rule{
+					{
+						Labels:      []Label{{Key: "app.kubernetes.io/name", ShortKey: "key", MaxLabelSizeBytes: 20}},
+						Annotations: []Label{{Key: "example.com/owner", ShortKey: "key", MaxLabelSizeBytes: 20}},
+					},
+				},
+			},
+			expect: PodLabelMap{
+				"key": "foo",
+			},
+		},
+	} {
+		ret, err := GetPodLabelMap(pod, tc.lpConfig)
+		if !reflect.DeepEqual(ret, tc.expect) {
+			t.Errorf("For test case %q, got label map %+v, want %+v", tc.desc, ret, tc.expect)
+		}
+		if gotErr := err != nil; gotErr != tc.expectErr {
+			t.Errorf("For test case %q, got error %v, want error: %v", tc.desc, err, tc.expectErr)
+		}
+	}
+}
+
+func TestForService(t *testing.T) {
+	t.Parallel()
+
+	allRule := Rule{Labels: []Label{{Key: "all"}}}
+	namespaceRule := Rule{Namespace: "ns1", Labels: []Label{{Key: "namespace"}}}
+	serviceRule := Rule{Namespace: "ns1", Service: "svc1", Labels: []Label{{Key: "service"}}}
+	serviceNameRule := Rule{Service: "svc1", Labels: []Label{{Key: "service-name"}}}
+	lpConfig := PodLabelPropagationConfig{
+		Labels: []Label{{Key: "global"}},
+		Rules:  []Rule{allRule, namespaceRule, serviceRule, serviceNameRule},
+	}
+
+	for _, tc := range []struct {
+		namespace, service string
+		expectRules        []Rule
+	}{
+		{namespace: "ns1", service: "svc1", expectRules: []Rule{allRule, namespaceRule, serviceRule, serviceNameRule}},
+		{namespace: "ns1", service: "svc2", expectRules: []Rule{allRule, namespaceRule}},
+		{namespace: "ns2", service: "svc1", expectRules: []Rule{allRule, serviceNameRule}},
+		{namespace: "ns2", service: "svc2", expectRules: []Rule{allRule}},
+	} {
+		got := lpConfig.ForService(tc.namespace, tc.service)
+		want := PodLabelPropagationConfig{Labels: lpConfig.Labels, Rules: tc.expectRules}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ForService(%q, %q) = %+v, want %+v", tc.namespace, tc.service, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc      string
+		lpConfig  PodLabelPropagationConfig
+		expectErr bool
+	}{
+		{
+			desc: "valid config",
+			lpConfig: PodLabelPropagationConfig{
+				Labels: []Label{{Key: "app"}},
+				Rules: []Rule{{
+					Labels:        []Label{{Key: "app"}},
+					LabelPrefixes: []LabelPrefix{{Prefix: "mesh/"}},
+					Annotations:   []Label{{Key: "owner"}},
+				}},
+			},
+		},
+		{
+			desc:      "label without key",
+			lpConfig:  PodLabelPropagationConfig{Labels: []Label{{ShortKey: "app"}}},
+			expectErr: true,
+		},
+		{
+			desc:      "rule label without key",
+			lpConfig:  PodLabelPropagationConfig{Rules: []Rule{{Labels: []Label{{ShortKey: "app"}}}}},
+			expectErr: true,
+		},
+		{
+			desc:      "empty label prefix",
+			lpConfig:  PodLabelPropagationConfig{Rules: []Rule{{LabelPrefixes: []LabelPrefix{{ShortPrefix: "mesh-"}}}}},
+			expectErr: true,
+		},
+		{
+			desc:      "annotation without key",
+			lpConfig:  PodLabelPropagationConfig{Rules: []Rule{{Annotations: []Label{{ShortKey: "owner"}}}}},
+			expectErr: true,
+		},
+	} {
+		err := tc.lpConfig.Validate()
+		if gotErr := err != nil; gotErr != tc.expectErr {
+			t.Errorf("For test case %q, Validate() = %v, want error: %v", tc.desc, err, tc.expectErr)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// ConfigMapConfigKey is the key of the PodLabelPropagationConfig JSON in the
+// data of the pod label propagation ConfigMap.
+const ConfigMapConfigKey = "config"
+
+// PodLabelPropagationConfigSource provides the current
+// PodLabelPropagationConfig, which may change while the controller runs.
+type PodLabelPropagationConfigSource interface {
+	Current() PodLabelPropagationConfig
+}
+
+// Current returns the config itself, so a PodLabelPropagationConfig is a
+// source of a config which never changes.
+func (c PodLabelPropagationConfig) Current() PodLabelPropagationConfig {
+	return c
+}
+
+// ConfigMapConfigSource loads the PodLabelPropagationConfig from a ConfigMap,
+// and reloads it whenever the ConfigMap changes. It falls back to a default
+// config when the ConfigMap does not exist. Invalid configs are reported with
+// events on the ConfigMap and ignored, keeping the previous config.
+//
+// A reloaded config only applies to the NEG endpoints attached after the
+// reload, as the annotations of attached endpoints cannot be updated without
+// detaching them. Endpoints keep their labels until their pods are replaced.
+type ConfigMapConfigSource struct {
+	configMapNamespace string
+	configMapName      string
+	defaultConfig      PodLabelPropagationConfig
+	recorder           record.EventRecorder
+
+	mu            sync.RWMutex
+	currentConfig PodLabelPropagationConfig
+
+	logger klog.Logger
+}
+
+// NewConfigMapConfigSource returns a ConfigMapConfigSource for the ConfigMap
+// configMapNamespace/configMapName, which provides defaultConfig until the
+// ConfigMap is loaded.
+func NewConfigMapConfigSource(configMapNamespace, configMapName string, defaultConfig PodLabelPropagationConfig, recorder record.EventRecorder, logger klog.Logger) *ConfigMapConfigSource {
+	return &ConfigMapConfigSource{
+		configMapNamespace: configMapNamespace,
+		configMapName:      configMapName,
+		defaultConfig:      defaultConfig,
+		currentConfig:      defaultConfig,
+		recorder:           recorder,
+		logger:             logger.WithName("PodLabelPropagationConfigSource"),
+	}
+}
+
+// Current returns the config currently loaded.
+func (s *ConfigMapConfigSource) Current() PodLabelPropagationConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentConfig
+}
+
+// RegisterInformer registers handlers on the configMapInformer to reload the
+// config when the target ConfigMap is created, updated or deleted.
+func (s *ConfigMapConfigSource) RegisterInformer(configMapInformer cache.SharedIndexInformer) {
+	configMapInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cm, ok := obj.(*v1.ConfigMap); ok && s.isTarget(cm) {
+				s.load(cm)
+			}
+		},
+		UpdateFunc: func(_, cur interface{}) {
+			if cm, ok := cur.(*v1.ConfigMap); ok && s.isTarget(cm) {
+				s.load(cm)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if cm, ok := obj.(*v1.ConfigMap); ok && s.isTarget(cm) {
+				s.logger.Info("Pod label propagation ConfigMap was deleted, using the default config", "defaultConfig", s.defaultConfig)
+				s.setConfig(s.defaultConfig)
+			}
+		},
+	})
+}
+
+func (s *ConfigMapConfigSource) isTarget(cm *v1.ConfigMap) bool {
+	return cm.Namespace == s.configMapNamespace && cm.Name == s.configMapName
+}
+
+// load parses and validates the config of the ConfigMap, and replaces the
+// current config with it if it is valid.
+func (s *ConfigMapConfigSource) load(cm *v1.ConfigMap) {
+	config, err := parseConfigMap(cm)
+	if err != nil {
+		s.logger.Error(err, "Ignoring invalid pod label propagation config", "configMap", klog.KObj(cm))
+		if s.recorder != nil {
+			s.recorder.Eventf(cm, v1.EventTypeWarning, "InvalidConfig", "Ignoring invalid pod label propagation config: %v", err)
+		}
+		return
+	}
+	s.logger.Info("Loaded pod label propagation config, which applies to the NEG endpoints attached from now on", "configMap", klog.KObj(cm), "config", config)
+	s.setConfig(config)
+}
+
+func (s *ConfigMapConfigSource) setConfig(config PodLabelPropagationConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentConfig = config
+}
+
+// parseConfigMap returns the PodLabelPropagationConfig stored in the
+// ConfigMap.
+func parseConfigMap(cm *v1.ConfigMap) (PodLabelPropagationConfig, error) {
+	var config PodLabelPropagationConfig
+	data, ok := cm.Data[ConfigMapConfigKey]
+	if !ok {
+		return config, fmt.Errorf("key %q not found", ConfigMapConfigKey)
+	}
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		return config, fmt.Errorf("failed to parse %q: %w", ConfigMapConfigKey, err)
+	}
+	if err := config.Validate(); err != nil {
+		return config, err
+	}
+	return config, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+func TestConfigMapConfigSource(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace = "kube-system"
+		name      = "label-propagation-config"
+	)
+	defaultConfig := PodLabelPropagationConfig{Labels: []Label{{Key: "app", MaxLabelSizeBytes: 20}}}
+	ruleConfig := PodLabelPropagationConfig{
+		Rules: []Rule{{Namespace: "ns1", LabelPrefixes: []LabelPrefix{{Prefix: "mesh/", MaxLabelSizeBytes: 40}}}},
+	}
+
+	kubeClient := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0, informers.WithNamespace(namespace))
+	recorder := record.NewFakeRecorder(10)
+	source := NewConfigMapConfigSource(namespace, name, defaultConfig, recorder, klog.TODO())
+	source.RegisterInformer(informerFactory.Core().V1().ConfigMaps().Informer())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	waitForConfig := func(step string, want PodLabelPropagationConfig) {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+			return reflect.DeepEqual(source.Current(), want), nil
+		}); err != nil {
+			t.Fatalf("%s: got config %+v, want %+v", step, source.Current(), want)
+		}
+	}
+	configMap := func(data string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{ConfigMapConfigKey: data},
+		}
+	}
+
+	waitForConfig("no ConfigMap", defaultConfig)
+
+	cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap(`{"Rules":[{"Namespace":"ns1","LabelPrefixes":[{"Prefix":"mesh/","MaxLabelSizeBytes":40}]}]}`), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitForConfig("ConfigMap created", ruleConfig)
+
+	cm.Data[ConfigMapConfigKey] = `{"Rules":[{"LabelPrefixes":[{"ShortPrefix":"mesh-"}]}]}`
+	if _, err := kubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Logf("Got event %q", event)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected an event for the invalid config")
+	}
+	waitForConfig("ConfigMap updated with invalid config", ruleConfig)
+
+	if err := kubeClient.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitForConfig("ConfigMap deleted", defaultConfig)
+}
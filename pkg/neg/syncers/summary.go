@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/neg/readiness"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+// negHealthFunc returns the last health status of a NEG.
+type negHealthFunc func(negName, zone string) (readiness.NegHealth, bool)
+
+// newEndpointSummary returns the summary of the endpoints of a syncer.
+// attached is the number of endpoints in each NEG, before the in-flight
+// operations in transactions complete. degradedModeOnly contains the
+// endpoints only the degraded mode calculation includes, and epStateCount
+// the endpoint counts by state from the last endpoint calculation.
+func newEndpointSummary(
+	attached map[negtypes.EndpointGroupInfo]int,
+	transactions networkEndpointTransactionTable,
+	degradedModeOnly map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet,
+	epStateCount negtypes.StateCountMap,
+	subnetToNegMapping map[string]string,
+	health negHealthFunc,
+	now metav1.Time,
+) *negv1beta1.NegEndpointSummary {
+	groups := make(map[negtypes.EndpointGroupInfo]*negv1beta1.NegEndpointGroupSummary)
+	group := func(endpointGroupInfo negtypes.EndpointGroupInfo) *negv1beta1.NegEndpointGroupSummary {
+		if summary, ok := groups[endpointGroupInfo]; ok {
+			return summary
+		}
+		summary := &negv1beta1.NegEndpointGroupSummary{
+			Name:   subnetToNegMapping[endpointGroupInfo.Subnet],
+			Zone:   endpointGroupInfo.Zone,
+			Subnet: endpointGroupInfo.Subnet,
+		}
+		groups[endpointGroupInfo] = summary
+		return summary
+	}
+
+	for endpointGroupInfo, count := range attached {
+		group(endpointGroupInfo).Attached = int64(count)
+	}
+	for _, endpoint := range transactions.Keys() {
+		entry, ok := transactions.Get(endpoint)
+		if !ok {
+			continue
+		}
+		summary := group(negtypes.EndpointGroupInfo{Zone: entry.Zone, Subnet: entry.Subnet})
+		switch entry.Operation {
+		case attachOp:
+			summary.PendingAttach++
+		case detachOp:
+			summary.PendingDetach++
+		}
+	}
+	for endpointGroupInfo, endpointSet := range degradedModeOnly {
+		if endpointSet.Len() != 0 {
+			group(endpointGroupInfo).DegradedModeOnly = int64(endpointSet.Len())
+		}
+	}
+
+	summary := &negv1beta1.NegEndpointSummary{LastUpdateTime: now}
+	for _, groupSummary := range groups {
+		if negHealth, ok := health(groupSummary.Name, groupSummary.Zone); ok {
+			groupSummary.Health = &negv1beta1.NegHealthSummary{
+				LastPollTime: metav1.NewTime(negHealth.PollTime),
+				Healthy:      int64(negHealth.Healthy),
+				Unhealthy:    int64(negHealth.Unhealthy),
+				Unknown:      int64(negHealth.Unknown),
+			}
+		}
+		summary.NetworkEndpointGroups = append(summary.NetworkEndpointGroups, *groupSummary)
+	}
+	sort.Slice(summary.NetworkEndpointGroups, func(i, j int) bool {
+		a, b := summary.NetworkEndpointGroups[i], summary.NetworkEndpointGroups[j]
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		return a.Subnet < b.Subnet
+	})
+
+	for state, count := range epStateCount {
+		if count == 0 || !isExclusionState(state) {
+			continue
+		}
+		summary.ExcludedEndpoints = append(summary.ExcludedEndpoints, negv1beta1.ExcludedEndpointCount{
+			Reason: string(state),
+			Count:  int64(count),
+		})
+	}
+	sort.Slice(summary.ExcludedEndpoints, func(i, j int) bool {
+		return summary.ExcludedEndpoints[i].Reason < summary.ExcludedEndpoints[j].Reason
+	})
+	return summary
+}
+
+// isExclusionState returns true if endpoints in the state are left out of
+// the NEGs. Duplicate endpoints are still attached once, and the total and
+// dual stack migration counts are not reasons.
+func isExclusionState(state negtypes.State) bool {
+	switch state {
+	case negtypes.Total, negtypes.Duplicate, negtypes.DualStackMigration:
+		return false
+	}
+	return true
+}
+
+// endpointCounts returns the number of endpoints in each endpoint group.
+func endpointCounts(endpointMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) map[negtypes.EndpointGroupInfo]int {
+	counts := make(map[negtypes.EndpointGroupInfo]int, len(endpointMap))
+	for endpointGroupInfo, endpointSet := range endpointMap {
+		counts[endpointGroupInfo] = endpointSet.Len()
+	}
+	return counts
+}
+
+// negHealth returns the last health status of a NEG of the syncer, from the
+// readiness reflector.
+func (s *transactionSyncer) negHealth(negName, zone string) (readiness.NegHealth, bool) {
+	syncerKey := s.NegSyncerKey
+	if flags.F.EnableMultiSubnetClusterPhase1 {
+		// The reflector is keyed by the NEG name, see commitPods.
+		syncerKey.NegName = negName
+	}
+	return s.reflector.NegHealth(syncerKey, negName, zone)
+}
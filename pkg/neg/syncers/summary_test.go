@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/neg/readiness"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+func TestNewEndpointSummary(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	pollTime := now.Add(-time.Minute)
+	subnetToNegMapping := map[string]string{defaultTestSubnet: testNegName}
+	zone1 := negtypes.EndpointGroupInfo{Zone: testZone1, Subnet: defaultTestSubnet}
+	zone2 := negtypes.EndpointGroupInfo{Zone: testZone2, Subnet: defaultTestSubnet}
+
+	attached := map[negtypes.EndpointGroupInfo]int{zone1: 3}
+	transactions := NewTransactionTable()
+	transactions.Put(negtypes.NetworkEndpoint{IP: "10.100.1.4", Port: "80"}, transactionEntry{Operation: attachOp, Zone: testZone1, Subnet: defaultTestSubnet})
+	transactions.Put(negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80"}, transactionEntry{Operation: detachOp, Zone: testZone1, Subnet: defaultTestSubnet})
+	transactions.Put(negtypes.NetworkEndpoint{IP: "10.100.2.1", Port: "80"}, transactionEntry{Operation: attachOp, Zone: testZone2, Subnet: defaultTestSubnet})
+	degradedModeOnly := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		zone2: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.100.2.2", Port: "80"}),
+	}
+	epStateCount := negtypes.StateCountMap{
+		negtypes.Total:        6,
+		negtypes.Duplicate:    1,
+		negtypes.PodTerminal:  2,
+		negtypes.NodeNotFound: 1,
+		negtypes.IPInvalid:    0,
+	}
+	health := func(negName, zone string) (readiness.NegHealth, bool) {
+		if negName != testNegName || zone != testZone1 {
+			return readiness.NegHealth{}, false
+		}
+		return readiness.NegHealth{PollTime: pollTime, Healthy: 2, Unhealthy: 1}, true
+	}
+
+	want := &negv1beta1.NegEndpointSummary{
+		LastUpdateTime: now,
+		NetworkEndpointGroups: []negv1beta1.NegEndpointGroupSummary{
+			{
+				Name:          testNegName,
+				Zone:          testZone1,
+				Subnet:        defaultTestSubnet,
+				Attached:      3,
+				PendingAttach: 1,
+				PendingDetach: 1,
+				Health: &negv1beta1.NegHealthSummary{
+					LastPollTime: metav1.NewTime(pollTime),
+					Healthy:      2,
+					Unhealthy:    1,
+				},
+			},
+			{
+				Name:             testNegName,
+				Zone:             testZone2,
+				Subnet:           defaultTestSubnet,
+				PendingAttach:    1,
+				DegradedModeOnly: 1,
+			},
+		},
+		ExcludedEndpoints: []negv1beta1.ExcludedEndpointCount{
+			{Reason: string(negtypes.NodeNotFound), Count: 1},
+			{Reason: string(negtypes.PodTerminal), Count: 2},
+		},
+	}
+	got := newEndpointSummary(attached, transactions, degradedModeOnly, epStateCount, subnetToNegMapping, health, now)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newEndpointSummary() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -35,6 +35,12 @@ type syncerCore interface {
 	sync() error
 }
 
+// syncerCleaner is implemented by the syncer cores which keep state in other
+// components, to release it once the syncer has stopped.
+type syncerCleaner interface {
+	cleanup()
+}
+
 // syncer is a NEG syncer skeleton.
 // It handles state transitions and backoff retry operations.
 type syncer struct {
@@ -114,6 +120,9 @@ func (s *syncer) Start() error {
 			select {
 			case _, open := <-s.syncCh:
 				if !open {
+					if cleaner, ok := s.core.(syncerCleaner); ok {
+						cleaner.cleanup()
+					}
 					s.stateLock.Lock()
 					s.shuttingDown = false
 					s.stateLock.Unlock()
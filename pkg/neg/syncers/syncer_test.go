@@ -47,8 +47,10 @@ type syncerTester struct {
 	syncError bool
 	// blockSync is true, then sync function is blocked on channel
 	blockSync bool
-	ch        chan interface{}
-	mu        sync.Mutex
+	// cleanupCount is the number of times the syncer cleaned up after stopping
+	cleanupCount int
+	ch           chan interface{}
+	mu           sync.Mutex
 }
 
 // sync sleeps for 3 seconds
@@ -65,6 +67,12 @@ func (t *syncerTester) sync() error {
 	return nil
 }
 
+func (t *syncerTester) cleanup() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cleanupCount += 1
+}
+
 func newSyncerTester() *syncerTester {
 	testNegName := "test-neg-name"
 	testContext := negtypes.NewTestContext()
@@ -136,6 +144,11 @@ func TestStartAndStopNoopSyncer(t *testing.T) {
 	}); err != nil {
 		t.Fatalf("Syncer failed to shutdown: %v", err)
 	}
+	syncerTester.mu.Lock()
+	if syncerTester.cleanupCount != 1 {
+		t.Errorf("Syncer cleaned up %d times after Stop, want 1", syncerTester.cleanupCount)
+	}
+	syncerTester.mu.Unlock()
 
 	if err := syncerTester.syncer.Start(); err != nil {
 		t.Fatalf("Failed to restart syncer: %v", err)
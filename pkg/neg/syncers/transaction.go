@@ -42,12 +42,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/backoff"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/neg/coalescer"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
@@ -87,12 +89,18 @@ type transactionSyncer struct {
 	cloud               negtypes.NetworkEndpointGroupCloud
 	zoneGetter          *zonegetter.ZoneGetter
 	endpointsCalculator negtypes.NetworkEndpointsCalculator
+	// coalescer makes the attach and detach calls if it is not nil, to merge
+	// them with the calls of other syncers under a shared concurrency limit.
+	coalescer *coalescer.Coalescer
 
 	// retry handles back off retry for NEG API operations
 	retry backoff.RetryHandler
 
 	// reflector handles NEG readiness gate and conditions for pods in NEG.
 	reflector readiness.Reflector
+	// committedSyncerKeys are the syncer keys the pods were committed to the
+	// reflector with, to forget them once the syncer has stopped.
+	committedSyncerKeys map[negtypes.NegSyncerKey]struct{}
 
 	//kubeSystemUID used to populate Cluster UID on Neg Description when using NEG CRD
 	kubeSystemUID string
@@ -102,16 +110,21 @@ type transactionSyncer struct {
 
 	// customName indicates whether the NEG name is a generated one or custom one
 	customName bool
+	// adoptExistingNEG indicates whether the syncer takes ownership of the
+	// existing NEGs which were not created by a NEG controller. It requires
+	// NEG CRs to record the adopted NEGs.
+	adoptExistingNEG bool
 
 	logger klog.Logger
 
-	// errorState indicates if the syncer is in any of 4 error scenarios
+	// degradedMode decides if the syncer is in error state, which it enters
+	// in any of 4 error scenarios
 	// 1. Endpoint counts from EPS is different from calculated endpoint list
 	// 2. EndpontSlice has missing or invalid data
 	// 3. Attach/Detach EP fails due to incorrect batch information
 	// 4. Endpoint count from EPS or calculated endpoint list is 0
 	// Need to grab syncLock first for any reads or writes based on this value
-	errorState bool
+	degradedMode degradedModeDecider
 
 	// syncMetricsCollector collect sync related metrics
 	syncMetricsCollector metricscollector.SyncerMetricsCollector
@@ -125,7 +138,7 @@ type transactionSyncer struct {
 	enableDualStackNEG bool
 
 	// podLabelPropagationConfig configures the pod label to be propagated to NEG endpoints
-	podLabelPropagationConfig labels.PodLabelPropagationConfig
+	podLabelPropagationConfig labels.PodLabelPropagationConfigSource
 
 	dsMigrator *dualstack.Migrator
 
@@ -134,12 +147,30 @@ type transactionSyncer struct {
 	networkInfo network.NetworkInfo
 
 	namer namer.NonDefaultSubnetNEGNamer
+
+	// enableCheckpoint indicates whether the syncer records a checkpoint of
+	// the NEG endpoints in the NEG CR status, and resumes from it on start.
+	enableCheckpoint bool
+	// resumeFromCheckpoint is true until the first time the syncer retrieves
+	// the NEG endpoints, which may use the checkpoint instead of listing them.
+	resumeFromCheckpoint bool
+	// checkpoint is recorded by each sync and written to the NEG CR status.
+	// It is nil if no checkpoint should be written.
+	checkpoint *negv1beta1.NegCheckpoint
+
+	// enableEndpointSummary indicates whether the syncer writes a summary of
+	// the endpoint states in the NEG CR status.
+	enableEndpointSummary bool
+	// endpointSummary is computed by each sync that lists the NEG endpoints
+	// and written to the NEG CR status.
+	endpointSummary *negv1beta1.NegEndpointSummary
 }
 
 func NewTransactionSyncer(
 	negSyncerKey negtypes.NegSyncerKey,
 	recorder record.EventRecorder,
 	cloud negtypes.NetworkEndpointGroupCloud,
+	endpointCoalescer *coalescer.Coalescer,
 	zoneGetter *zonegetter.ZoneGetter,
 	podLister cache.Indexer,
 	serviceLister cache.Indexer,
@@ -152,8 +183,9 @@ func NewTransactionSyncer(
 	svcNegClient svcnegclient.Interface,
 	syncerMetrics *metricscollector.SyncerMetrics,
 	customName bool,
+	adoptExistingNEG bool,
 	log klog.Logger,
-	lpConfig labels.PodLabelPropagationConfig,
+	lpConfig labels.PodLabelPropagationConfigSource,
 	enableDualStackNEG bool,
 	networkInfo network.NetworkInfo,
 	namer namer.NonDefaultSubnetNEGNamer,
@@ -161,6 +193,13 @@ func NewTransactionSyncer(
 
 	logger := log.WithName("Syncer").WithValues("service", klog.KRef(negSyncerKey.Namespace, negSyncerKey.Name), "negName", negSyncerKey.NegName)
 
+	// Without degraded mode there is no calculation to fall back to, so the
+	// error state is reset by the first sync without error state errors.
+	degradedModeExitSyncs := 1
+	if flags.F.EnableDegradedMode {
+		degradedModeExitSyncs = flags.F.DegradedModeExitSyncs
+	}
+
 	// TransactionSyncer implements the syncer core
 	ts := &transactionSyncer{
 		NegSyncerKey:              negSyncerKey,
@@ -173,6 +212,7 @@ func NewTransactionSyncer(
 		svcNegLister:              svcNegLister,
 		recorder:                  recorder,
 		cloud:                     cloud,
+		coalescer:                 endpointCoalescer,
 		zoneGetter:                zoneGetter,
 		endpointsCalculator:       epc,
 		reflector:                 reflector,
@@ -180,7 +220,8 @@ func NewTransactionSyncer(
 		svcNegClient:              svcNegClient,
 		syncMetricsCollector:      syncerMetrics,
 		customName:                customName,
-		errorState:                false,
+		adoptExistingNEG:          adoptExistingNEG && svcNegClient != nil,
+		degradedMode:              newHysteresisDecider(degradedModeExitSyncs),
 		logger:                    logger,
 		enableDegradedMode:        flags.F.EnableDegradedMode,
 		enableDegradedModeMetrics: flags.F.EnableDegradedModeMetrics,
@@ -188,7 +229,10 @@ func NewTransactionSyncer(
 		podLabelPropagationConfig: lpConfig,
 		networkInfo:               networkInfo,
 		namer:                     namer,
+		enableCheckpoint:          flags.F.EnableNEGCheckpoint && svcNegClient != nil,
+		enableEndpointSummary:     flags.F.EnableNEGEndpointSummary && svcNegClient != nil,
 	}
+	ts.resumeFromCheckpoint = ts.enableCheckpoint
 	// Syncer implements life cycle logic
 	syncer := newSyncer(negSyncerKey, serviceLister, recorder, ts, logger)
 	// transactionSyncer needs syncer interface for internals
@@ -209,6 +253,21 @@ func GetEndpointsCalculator(podLister, nodeLister, serviceLister cache.Indexer,
 			return NewClusterL4EndpointsCalculator(nodeLister, zoneGetter, serviceKey, logger, networkInfo, l4LBType)
 		}
 	}
+	if mode == negtypes.ExternalMode {
+		return NewExternalEndpointsCalculator(zoneGetter, syncerKey, logger, networkInfo)
+	}
+	if mode == negtypes.L7TopologyAwareMode {
+		return NewTopologyAwareL7EndpointsCalculator(
+			zoneGetter,
+			podLister,
+			nodeLister,
+			serviceLister,
+			syncerKey,
+			logger,
+			enableDualStackNEG,
+			syncMetricsCollector,
+		)
+	}
 	return NewL7EndpointsCalculator(
 		zoneGetter,
 		podLister,
@@ -239,11 +298,7 @@ func (s *transactionSyncer) syncInternal() error {
 	err := s.syncInternalImpl()
 	if err != nil {
 		if syncErr := negtypes.ClassifyError(err); syncErr.IsErrorState {
-			s.logger.Info("Enter degraded mode", "reason", syncErr.Reason)
-			if s.enableDegradedMode {
-				s.recordEvent(apiv1.EventTypeWarning, "EnterDegradedMode", fmt.Sprintf("Entering degraded mode for NEG %s due to sync err: %v", s.NegSyncerKey.String(), syncErr))
-			}
-			s.setErrorState()
+			s.setErrorState(syncErr)
 		}
 	}
 	s.updateStatus(err)
@@ -257,6 +312,8 @@ func (s *transactionSyncer) syncInternalImpl() error {
 		s.logger.V(3).Info("Skip syncing NEG", "negSyncerKey", s.NegSyncerKey.String())
 		return nil
 	}
+	s.checkpoint = nil
+	s.endpointSummary = nil
 	if s.needInit || s.isZoneChange() {
 		if err := s.ensureNetworkEndpointGroups(); err != nil {
 			return fmt.Errorf("%w: %v", negtypes.ErrNegNotFound, err)
@@ -271,7 +328,9 @@ func (s *transactionSyncer) syncInternalImpl() error {
 		return err
 	}
 	subnetToNegMapping := map[string]string{defaultSubnet: s.NegSyncerKey.NegName}
-	if flags.F.EnableMultiSubnetClusterPhase1 {
+	// External endpoints are not in any subnet of the cluster, so they are
+	// only synced to the NEG of the default subnet.
+	if flags.F.EnableMultiSubnetClusterPhase1 && s.EpCalculatorMode != negtypes.ExternalMode {
 		subnetConfigs, err := s.zoneGetter.ListSubnets(s.logger)
 		if err != nil {
 			s.logger.Error(err, "Errored when listing subnets from zoneGetter")
@@ -290,12 +349,36 @@ func (s *transactionSyncer) syncInternalImpl() error {
 		}
 	}
 
-	currentMap, currentPodLabelMap, err := retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, s.zoneGetter, s.cloud, s.NegSyncerKey.GetAPIVersion(), s.endpointsCalculator.Mode(), s.enableDualStackNEG, s.logger)
+	currentMap, currentPodLabelMap, listedAt, err := s.retrieveCurrentEndpoints(subnetToNegMapping)
 	if err != nil {
 		return fmt.Errorf("%w: %w", negtypes.ErrCurrentNegEPNotFound, err)
 	}
 	s.logStats(currentMap, "current NEG endpoints")
 
+	if s.enableCheckpoint {
+		// The committed endpoints are recorded before currentMap is merged
+		// with the transactions, and the transactions once this sync has
+		// started its operations.
+		if checkpoint := newCheckpoint(currentMap, listedAt); checkpoint != nil {
+			defer func() {
+				checkpoint.PendingOperations = checkpointOperations(s.transactions)
+				s.checkpoint = checkpoint
+			}()
+		}
+	}
+
+	var degradedTargetMap, onlyInDegraded map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
+	if s.enableEndpointSummary {
+		attached := endpointCounts(currentMap)
+		defer func() {
+			var epStateCount negtypes.StateCountMap
+			if reporter, ok := s.endpointsCalculator.(endpointStateReporter); ok {
+				epStateCount = reporter.EndpointStateCounts()
+			}
+			s.endpointSummary = newEndpointSummary(attached, s.transactions, onlyInDegraded, epStateCount, subnetToNegMapping, s.negHealth, metav1.Now())
+		}()
+	}
+
 	// Merge the current state from cloud with the transaction table together
 	// The combined state represents the eventual result when all transactions completed
 	mergeTransactionIntoZoneEndpointMap(currentMap, s.transactions, s.logger)
@@ -303,31 +386,44 @@ func (s *transactionSyncer) syncInternalImpl() error {
 
 	var targetMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
 	var endpointPodMap negtypes.EndpointPodMap
-	slices, err := s.endpointSliceLister.ByIndex(endpointslices.EndpointSlicesByServiceIndex, endpointslices.FormatEndpointSlicesServiceKey(s.Namespace, s.Name))
-	if err != nil {
-		return fmt.Errorf("%w: %v", negtypes.ErrEPSNotFound, err)
-	}
-	if len(slices) < 1 {
-		s.logger.Error(nil, "Endpoint slices for the service doesn't exist. Skipping NEG sync")
-		return nil
-	}
-	endpointSlices := convertUntypedToEPS(slices)
-	s.computeEPSStaleness(endpointSlices)
+	var endpointsData []negtypes.EndpointsData
+	if s.NegType == negtypes.InternetFQDNEndpointType {
+		// ExternalName services do not have endpoint slices.
+		svc := getService(s.serviceLister, s.Namespace, s.Name, s.logger)
+		if svc == nil {
+			s.logger.Error(nil, "Service doesn't exist. Skipping NEG sync")
+			return nil
+		}
+		endpointsData = negtypes.EndpointsDataFromExternalNameService(svc, s.PortTuple)
+	} else {
+		slices, err := s.endpointSliceLister.ByIndex(endpointslices.EndpointSlicesByServiceIndex, endpointslices.FormatEndpointSlicesServiceKey(s.Namespace, s.Name))
+		if err != nil {
+			return fmt.Errorf("%w: %v", negtypes.ErrEPSNotFound, err)
+		}
+		if len(slices) < 1 {
+			s.logger.Error(nil, "Endpoint slices for the service doesn't exist. Skipping NEG sync")
+			return nil
+		}
+		endpointSlices := convertUntypedToEPS(slices)
+		s.computeEPSStaleness(endpointSlices)
 
-	endpointsData := negtypes.EndpointsDataFromEndpointSlices(endpointSlices)
+		endpointsData = negtypes.EndpointsDataFromEndpointSlices(endpointSlices)
+	}
 	targetMap, endpointPodMap, err = s.getEndpointsCalculation(endpointsData, currentMap)
 
-	var degradedTargetMap, notInDegraded, onlyInDegraded map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet
 	var degradedPodMap negtypes.EndpointPodMap
 	var degradedModeErr error
+	var comparison degradedModeComparison
 	if s.enableDegradedModeMetrics || s.enableDegradedMode {
 		degradedTargetMap, degradedPodMap, degradedModeErr = s.endpointsCalculator.CalculateEndpointsDegradedMode(endpointsData, currentMap)
 		if degradedModeErr == nil { // we collect metrics when the normal calculation doesn't run into error
 			s.logStats(targetMap, "normal mode desired NEG endpoints")
 			s.logStats(degradedTargetMap, "degraded mode desired NEG endpoints")
-			notInDegraded, onlyInDegraded = calculateNetworkEndpointDifference(targetMap, degradedTargetMap)
+			comparison = newDegradedModeComparison(currentMap, targetMap, degradedTargetMap)
+			s.logger.V(3).Info("Compared normal and degraded mode endpoint calculations", "comparison", comparison.String())
+			onlyInDegraded = comparison.onlyInDegraded
 			if err == nil {
-				computeDegradedModeCorrectness(notInDegraded, onlyInDegraded, string(s.NegSyncerKey.NegType), s.logger)
+				computeDegradedModeCorrectness(comparison.notInDegraded, comparison.onlyInDegraded, string(s.NegSyncerKey.NegType), s.logger)
 			}
 		}
 	}
@@ -354,14 +450,13 @@ func (s *transactionSyncer) syncInternalImpl() error {
 	}
 	// When the flags are not enabled, error state should be reset when no
 	// error occurs in the sync.
-	// notInDegraded and onlyInDegraded are not populated when the flags are
-	// not enabled, so they would always be empty and reset error state.
-	if len(notInDegraded) == 0 && len(onlyInDegraded) == 0 {
+	// The comparison is empty when the flags are not enabled, so the
+	// calculations would always agree and count towards exiting error state.
+	if s.degradedMode.Observe(comparison) {
 		s.logger.Info("Exit degraded mode")
-		if s.enableDegradedMode && s.inErrorState() {
+		if s.enableDegradedMode {
 			s.recordEvent(apiv1.EventTypeNormal, "ExitDegradedMode", fmt.Sprintf("NEG %s is no longer in degraded mode", s.NegSyncerKey.String()))
 		}
-		s.resetErrorState()
 	}
 	s.logStats(targetMap, "desired NEG endpoints")
 
@@ -385,16 +480,24 @@ func (s *transactionSyncer) syncInternalImpl() error {
 	filterEndpointByTransaction(committedEndpoints, s.transactions, s.logger)
 
 	var endpointPodLabelMap labels.EndpointPodLabelMap
-	// Only fetch label from pod for L7 endpoints
+	// Only fetch label from pod for L7 endpoints. Labels are only set when
+	// endpoints are attached, as the annotations of attached endpoints
+	// cannot be updated, so a reloaded config only applies to the endpoints
+	// attached after the reload.
 	if flags.F.EnableNEGLabelPropagation && s.NegType == negtypes.VmIpPortEndpointType {
-		endpointPodLabelMap = getEndpointPodLabelMap(addEndpoints, endpointPodMap, s.podLister, s.podLabelPropagationConfig, s.recorder, s.logger)
+		endpointPodLabelMap = getEndpointPodLabelMap(addEndpoints, endpointPodMap, s.podLister, s.podLabelPropagationConfig.Current().ForService(s.Namespace, s.Name), s.recorder, s.logger)
 		publishAnnotationSizeMetrics(addEndpoints, endpointPodLabelMap)
 	}
 
 	s.syncMetricsCollector.SetLabelPropagationStats(s.NegSyncerKey, collectLabelStats(currentPodLabelMap, endpointPodLabelMap, targetMap))
 
 	if s.needCommit() {
-		s.commitPods(committedEndpoints, endpointPodMap)
+		if s.NegType == negtypes.VmIpEndpointType {
+			// GCE_VM_IP endpoints are nodes, so commit the pods scheduled on them.
+			s.commitPods(podEndpointsForNodes(committedEndpoints, endpointsData))
+		} else {
+			s.commitPods(committedEndpoints, endpointPodMap)
+		}
 	}
 
 	if len(addEndpoints) == 0 && len(removeEndpoints) == 0 {
@@ -404,9 +507,82 @@ func (s *transactionSyncer) syncInternalImpl() error {
 	s.logEndpoints(addEndpoints, "adding endpoint")
 	s.logEndpoints(removeEndpoints, "removing endpoint")
 
+	if err := s.recordOperationIntent(addEndpoints, removeEndpoints); err != nil {
+		return err
+	}
 	return s.syncNetworkEndpoints(addEndpoints, removeEndpoints, endpointPodLabelMap, migrationZone)
 }
 
+// retrieveCurrentEndpoints returns the endpoints currently in the NEGs, and
+// the time at which they were listed. The first time it is called for a
+// syncer with checkpoints enabled, it uses the checkpoint in the NEG CR status
+// if it can be trusted and the size of each NEG matches the checkpoint, and
+// schedules a sync to verify the endpoints against the NEGs later. Otherwise,
+// it lists the NEGs.
+func (s *transactionSyncer) retrieveCurrentEndpoints(subnetToNegMapping map[string]string) (map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, labels.EndpointPodLabelMap, metav1.Time, error) {
+	if s.resumeFromCheckpoint {
+		s.resumeFromCheckpoint = false
+		var checkpoint *negv1beta1.NegCheckpoint
+		if negCR, err := getNegFromStore(s.svcNegLister, s.Namespace, s.NegSyncerKey.NegName); err == nil {
+			checkpoint = negCR.Status.Checkpoint
+		}
+		currentMap, err := endpointMapFromCheckpoint(checkpoint, subnetToNegMapping, time.Now())
+		if err == nil {
+			err = s.verifyCheckpointSizes(currentMap, subnetToNegMapping)
+		}
+		if err == nil {
+			s.logger.Info("Resumed NEG endpoints from checkpoint", "checkpointTime", checkpoint.Timestamp)
+			time.AfterFunc(wait.Jitter(checkpointVerifyDelay, 1.0), func() { s.syncer.Sync() })
+			return currentMap, labels.EndpointPodLabelMap{}, checkpoint.Timestamp, nil
+		}
+		s.logger.V(2).Info("Not resuming NEG endpoints from checkpoint", "reason", err.Error())
+	}
+	listedAt := metav1.Now()
+	currentMap, currentPodLabelMap, err := retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, s.zoneGetter, s.cloud, s.NegSyncerKey.GetAPIVersion(), s.NegType, s.endpointsCalculator.Mode(), s.enableDualStackNEG, s.logger)
+	return currentMap, currentPodLabelMap, listedAt, err
+}
+
+// verifyCheckpointSizes returns an error if the number of endpoints of a NEG
+// differs from the number of endpoints recorded for it in the checkpoint,
+// which costs a single NEG API call per zone instead of listing the NEGs.
+func (s *transactionSyncer) verifyCheckpointSizes(currentMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, subnetToNegMapping map[string]string) error {
+	for endpointGroupInfo, endpointSet := range currentMap {
+		negName := subnetToNegMapping[endpointGroupInfo.Subnet]
+		neg, err := s.cloud.GetNetworkEndpointGroup(negName, endpointGroupInfo.Zone, s.NegSyncerKey.GetAPIVersion(), s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to get NEG %s in zone %q: %w", negName, endpointGroupInfo.Zone, err)
+		}
+		if neg.Size != int64(endpointSet.Len()) {
+			return fmt.Errorf("NEG %s in zone %q has %d endpoints, checkpoint has %d", negName, endpointGroupInfo.Zone, neg.Size, endpointSet.Len())
+		}
+	}
+	return nil
+}
+
+// recordOperationIntent marks the checkpoint in the NEG CR status as having
+// pending operations before the operations are issued, so that a controller
+// that restarts before the end of the sync does not trust a checkpoint
+// which no longer reflects the NEGs. It only patches the NEG CR if the
+// checkpoint it holds has no pending operations.
+func (s *transactionSyncer) recordOperationIntent(addEndpoints, removeEndpoints map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet) error {
+	if !s.enableCheckpoint {
+		return nil
+	}
+	negCR, err := getNegFromStore(s.svcNegLister, s.Namespace, s.NegSyncerKey.NegName)
+	if err != nil {
+		return fmt.Errorf("failed to get NEG CR to record the pending operations: %w", err)
+	}
+	if negCR.Status.Checkpoint == nil || len(negCR.Status.Checkpoint.PendingOperations) != 0 {
+		return nil
+	}
+	newStatus := negCR.Status.DeepCopy()
+	newStatus.Checkpoint.PendingOperations = pendingOperations(addEndpoints, removeEndpoints)
+	if _, err := patchNegStatus(s.svcNegClient, negCR.Status, *newStatus, s.Namespace, s.NegSyncerKey.NegName); err != nil {
+		return fmt.Errorf("failed to record the pending operations in the NEG CR checkpoint: %w", err)
+	}
+	return nil
+}
+
 func (s *transactionSyncer) getEndpointsCalculation(
 	endpointsData []negtypes.EndpointsData,
 	currentMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet,
@@ -426,7 +602,7 @@ func (s *transactionSyncer) getEndpointsCalculation(
 
 // syncLock must already be acquired before execution
 func (s *transactionSyncer) inErrorState() bool {
-	return s.errorState
+	return s.degradedMode.InDegradedMode()
 }
 
 // InErrorState is a wrapper for exporting inErrorState().
@@ -434,20 +610,32 @@ func (s *transactionSyncer) InErrorState() bool {
 	return s.inErrorState()
 }
 
+// setErrorState records the error state error, which puts the syncer in
+// error state if it is not already.
 // syncLock must already be acquired before execution
-func (s *transactionSyncer) setErrorState() {
-	s.errorState = true
+func (s *transactionSyncer) setErrorState(syncErr negtypes.NegSyncError) {
+	if !s.degradedMode.Enter(syncErr, time.Now()) {
+		s.logger.V(2).Info("Still in degraded mode", "reason", syncErr.Reason)
+		return
+	}
+	s.logger.Info("Enter degraded mode", "reason", syncErr.Reason)
+	if s.enableDegradedMode {
+		s.recordEvent(apiv1.EventTypeWarning, "EnterDegradedMode", fmt.Sprintf("Entering degraded mode for NEG %s due to sync err: %v", s.NegSyncerKey.String(), syncErr))
+	}
 }
 
-// syncLock must already be acquired before execution
-func (s *transactionSyncer) resetErrorState() {
-	s.errorState = false
+// negZones returns the zones the NEGs of the syncer should exist in.
+func (s *transactionSyncer) negZones() ([]string, error) {
+	if negtypes.IsGlobalNetworkEndpointType(s.NegType) {
+		return []string{negtypes.GlobalZone}, nil
+	}
+	// NEGs should be created in zones with candidate nodes only.
+	return s.zoneGetter.ListZones(negtypes.NodeFilterForEndpointCalculatorMode(s.EpCalculatorMode), s.logger)
 }
 
 // ensureNetworkEndpointGroups ensures NEGs are created and configured correctly in the corresponding zones.
 func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
-	// NEGs should be created in zones with candidate nodes only.
-	zones, err := s.zoneGetter.ListZones(negtypes.NodeFilterForEndpointCalculatorMode(s.EpCalculatorMode), s.logger)
+	zones, err := s.negZones()
 	if err != nil {
 		return err
 	}
@@ -471,11 +659,19 @@ func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
 		return err
 	}
 
+	var adoption *negAdoption
+	if s.adoptExistingNEG {
+		adoption = s.negAdoption()
+	}
+
 	for _, subnetConfig := range subnetConfigs {
 		negName := s.NegSyncerKey.NegName
 		networkInfo := s.networkInfo
 
 		if subnetConfig.Name != defaultSubnet {
+			if s.EpCalculatorMode == negtypes.ExternalMode {
+				continue
+			}
 			// Determine the NEG name for the non-default subnet NEGs.
 			negName, err = s.getNonDefaultSubnetNEGName(subnetConfig.Name)
 			if err != nil {
@@ -511,6 +707,7 @@ func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
 				s.recorder,
 				s.NegSyncerKey.GetAPIVersion(),
 				s.customName,
+				adoption,
 				networkInfo,
 				s.logger,
 			)
@@ -539,6 +736,23 @@ func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
 	return utilerrors.NewAggregate(errList)
 }
 
+// negAdoption returns the adoption of the existing NEGs, with the NEGs already
+// adopted according to the NEG CR.
+func (s *transactionSyncer) negAdoption() *negAdoption {
+	adoption := &negAdoption{adoptedSelfLinks: sets.NewString()}
+	negCR, err := getNegFromStore(s.svcNegLister, s.Namespace, s.NegSyncerKey.NegName)
+	if err != nil {
+		s.logger.Error(err, "Failed to get NEG CR to find the adopted NEGs")
+		return adoption
+	}
+	for _, negRef := range negCR.Status.NetworkEndpointGroups {
+		if negRef.Adopted {
+			adoption.adoptedSelfLinks.Insert(negRef.SelfLink)
+		}
+	}
+	return adoption
+}
+
 // syncNetworkEndpoints spins off go routines to execute NEG operations
 func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, endpointPodLabelMap labels.EndpointPodLabelMap, migrationZone negtypes.EndpointGroupInfo) error {
 	syncFunc := func(endpointMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, operation transactionOp) error {
@@ -631,10 +845,18 @@ func (s *transactionSyncer) operationInternal(operation transactionOp, zone stri
 	}
 
 	if operation == attachOp {
-		err = s.cloud.AttachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		if s.coalescer != nil {
+			err = s.coalescer.AttachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		} else {
+			err = s.cloud.AttachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		}
 	}
 	if operation == detachOp {
-		err = s.cloud.DetachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		if s.coalescer != nil {
+			err = s.coalescer.DetachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		} else {
+			err = s.cloud.DetachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion(), logger)
+		}
 	}
 
 	if err == nil {
@@ -650,11 +872,7 @@ func (s *transactionSyncer) operationInternal(operation transactionOp, zone stri
 		if syncErr.IsErrorState {
 			s.logger.Error(err, "Detected unexpected error when checking endpoint update response", "operation", operation)
 			s.syncLock.Lock()
-			s.logger.Info("Enter degraded mode", "reason", syncErr.Reason)
-			if s.enableDegradedMode {
-				s.recordEvent(apiv1.EventTypeWarning, "EnterDegradedMode", fmt.Sprintf("Entering degraded mode for NEG %s due to sync err: %v", s.NegSyncerKey.String(), syncErr))
-			}
-			s.setErrorState()
+			s.setErrorState(syncErr)
 			s.syncLock.Unlock()
 		}
 		s.syncMetricsCollector.UpdateSyncerStatusInMetrics(s.NegSyncerKey, syncErr, s.inErrorState())
@@ -736,10 +954,28 @@ func (s *transactionSyncer) commitTransaction(err error, networkEndpointMap map[
 	s.syncer.Sync()
 }
 
+// cleanup makes the readiness reflector forget the NEGs of the syncer once it
+// has stopped, so that their endpoints and health status are not kept.
+func (s *transactionSyncer) cleanup() {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+	for syncerKey := range s.committedSyncerKeys {
+		s.reflector.ForgetSyncer(syncerKey)
+	}
+	s.committedSyncerKeys = nil
+}
+
 // needCommit determines if commitPods need to be invoked.
 func (s *transactionSyncer) needCommit() bool {
-	// commitPods will be a no-op in case of VM_IP NEGs, but skip it to avoid printing non-relevant warning logs.
-	return s.NegType != negtypes.VmIpEndpointType
+	// commitPods will be a no-op in case of VM_IP NEGs without readiness gate
+	// and NEGs with external endpoints, but skip it to avoid printing
+	// non-relevant warning logs.
+	if s.NegType == negtypes.VmIpEndpointType {
+		// Nodes are only in VM_IP NEGs because they run pods of the service
+		// in Local mode, so their attachment only reflects on those pods then.
+		return flags.F.EnableL4NEGReadinessGate && s.EpCalculatorMode == negtypes.L4LocalMode
+	}
+	return s.EpCalculatorMode != negtypes.ExternalMode
 }
 
 // commitPods groups the endpoints by zone and signals the readiness reflector to poll pods of the NEG
@@ -773,6 +1009,10 @@ func (s *transactionSyncer) commitPods(endpointMap map[negtypes.EndpointGroupInf
 			// To ensure syncerKey has the same information as the passed in NEG name.
 			syncerKey.NegName = negName
 		}
+		if s.committedSyncerKeys == nil {
+			s.committedSyncerKeys = make(map[negtypes.NegSyncerKey]struct{})
+		}
+		s.committedSyncerKeys[syncerKey] = struct{}{}
 		s.reflector.CommitPods(syncerKey, negName, endpointGroupInfo.Zone, zoneEndpointMap)
 	}
 }
@@ -798,7 +1038,7 @@ func (s *transactionSyncer) isZoneChange() bool {
 		existingZones.Insert(id.Key.Zone)
 	}
 
-	zones, err := s.zoneGetter.ListZones(negtypes.NodeFilterForEndpointCalculatorMode(s.EpCalculatorMode), s.logger)
+	zones, err := s.negZones()
 	if err != nil {
 		s.logger.Error(err, "unable to list zones")
 		metrics.PublishNegControllerErrorCountMetrics(err, true)
@@ -926,6 +1166,19 @@ func (s *transactionSyncer) updateStatus(syncErr error) {
 	if len(neg.Status.NetworkEndpointGroups) == 0 {
 		s.needInit = true
 	}
+	if reporter, ok := s.endpointsCalculator.(topologyHintsReporter); ok {
+		if condition, ok := reporter.TopologyHintsCondition(); ok {
+			ensureCondition(neg, condition)
+		}
+	} else {
+		// The Service no longer uses topology-aware routing.
+		removeCondition(neg, negv1beta1.TopologyAware)
+	}
+	if s.enableDegradedMode {
+		ensureCondition(neg, s.degradedMode.Condition())
+	}
+	neg.Status.Checkpoint = s.checkpoint
+	neg.Status.EndpointSummary = s.endpointSummary
 
 	_, err = patchNegStatus(s.svcNegClient, origNeg.Status, neg.Status, s.Namespace, s.NegSyncerKey.NegName)
 	if err != nil {
@@ -1034,6 +1287,13 @@ func ensureCondition(neg *negv1beta1.ServiceNetworkEndpointGroup, expectedCondit
 	return expectedCondition
 }
 
+// removeCondition removes the condition of the given type from the neg object if it exists
+func removeCondition(neg *negv1beta1.ServiceNetworkEndpointGroup, conditionType string) {
+	if _, index, exists := findCondition(neg.Status.Conditions, conditionType); exists {
+		neg.Status.Conditions = append(neg.Status.Conditions[:index], neg.Status.Conditions[index+1:]...)
+	}
+}
+
 // getInactiveNegRefs creates NEG references for NEGs in Inactive State.
 // Inactive NEG are NEGs that are no longer needed.
 func getInactiveNegRefs(oldNegRefs []negv1beta1.NegObjectReference, currentNegRefs []negv1beta1.NegObjectReference, logger klog.Logger) []negv1beta1.NegObjectReference {
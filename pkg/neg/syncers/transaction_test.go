@@ -31,6 +31,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
@@ -46,6 +47,7 @@ import (
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/neg/coalescer"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
 	"k8s.io/ingress-gce/pkg/neg/syncers/labels"
@@ -258,6 +260,41 @@ func TestTransactionSyncNetworkEndpoints(t *testing.T) {
 	}
 }
 
+func TestTransactionSyncNetworkEndpointsWithCoalescer(t *testing.T) {
+	t.Parallel()
+
+	fakeCloud := negtypes.NewFakeNetworkEndpointGroupCloud(defaultTestSubnetURL, "test-network")
+	_, transactionSyncer := newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+	transactionSyncer.coalescer = coalescer.NewCoalescer(fakeCloud, MAX_NETWORK_ENDPOINTS_PER_BATCH, 1, klog.TODO())
+
+	zone1Endpoints := generateEndpointSet(net.ParseIP("1.1.1.1"), 10, testInstance1, "8080")
+	zone2Endpoints := generateEndpointSet(net.ParseIP("1.1.3.1"), 10, testInstance3, "8080")
+	addEndpoints := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone1}: negtypes.NewNetworkEndpointSet().Union(zone1Endpoints),
+		{Zone: testZone2}: negtypes.NewNetworkEndpointSet().Union(zone2Endpoints),
+	}
+	if err := transactionSyncer.syncNetworkEndpoints(addEndpoints, nil, labels.EndpointPodLabelMap{}, negtypes.EndpointGroupInfo{}); err != nil {
+		t.Fatalf("syncNetworkEndpoints() = %v, want nil", err)
+	}
+	if err := waitForTransactions(transactionSyncer); err != nil {
+		t.Fatalf("waitForTransactions() = %v, want nil", err)
+	}
+
+	for zone, want := range map[string]negtypes.NetworkEndpointSet{testZone1: zone1Endpoints, testZone2: zone2Endpoints} {
+		list, err := fakeCloud.ListNetworkEndpoints(transactionSyncer.NegSyncerKey.NegName, zone, false, transactionSyncer.NegSyncerKey.GetAPIVersion(), klog.TODO())
+		if err != nil {
+			t.Fatalf("ListNetworkEndpoints() = %v, want nil", err)
+		}
+		got := negtypes.NewNetworkEndpointSet()
+		for _, ep := range list {
+			got.Insert(negtypes.NetworkEndpoint{IP: ep.NetworkEndpoint.IpAddress, Node: ep.NetworkEndpoint.Instance, Port: strconv.FormatInt(ep.NetworkEndpoint.Port, 10)})
+		}
+		if !want.Equal(got) {
+			t.Errorf("In zone %q, got endpoints %v, want %v", zone, got, want)
+		}
+	}
+}
+
 func TestSyncNetworkEndpointLabel(t *testing.T) {
 
 	var (
@@ -1209,6 +1246,42 @@ func TestCommitPodsMSC(t *testing.T) {
 	}
 }
 
+func TestCleanupForgetsCommittedSyncerKeys(t *testing.T) {
+	vals := gce.DefaultTestClusterValues()
+	vals.SubnetworkURL = defaultTestSubnetURL
+	_, transactionSyncer := newTestTransactionSyncer(negtypes.NewAdapter(gce.NewFakeGCECloud(vals)), negtypes.VmIpPortEndpointType, false)
+	reflector := &testReflector{}
+	reflector.Flush()
+	transactionSyncer.reflector = reflector
+
+	prevFlag := flags.F.EnableMultiSubnetClusterPhase1
+	defer func() { flags.F.EnableMultiSubnetClusterPhase1 = prevFlag }()
+	flags.F.EnableMultiSubnetClusterPhase1 = true
+
+	defaultSubnetSyncerKey := transactionSyncer.NegSyncerKey
+	nonDefaultSubnetSyncerKey := transactionSyncer.NegSyncerKey
+	nonDefaultSubnetSyncerKey.NegName = transactionSyncer.namer.NonDefaultSubnetNEG(transactionSyncer.NegSyncerKey.Namespace, transactionSyncer.NegSyncerKey.Name, additionalTestSubnet, transactionSyncer.NegSyncerKey.PortTuple.Port)
+
+	endpointSet1, endpointMap1 := generateEndpointSetAndMap(net.ParseIP("1.1.1.1"), 10, testInstance1, "8080")
+	endpointSet2, endpointMap2 := generateEndpointSetAndMap(net.ParseIP("1.1.2.1"), 10, testInstance2, "8080")
+	transactionSyncer.commitPods(map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		{Zone: testZone1, Subnet: defaultTestSubnet}:    endpointSet1,
+		{Zone: testZone2, Subnet: defaultTestSubnet}:    negtypes.NewNetworkEndpointSet(),
+		{Zone: testZone1, Subnet: additionalTestSubnet}: endpointSet2,
+	}, unionEndpointMap(endpointMap1, endpointMap2))
+
+	transactionSyncer.cleanup()
+
+	wantForgotten := []negtypes.NegSyncerKey{defaultSubnetSyncerKey, nonDefaultSubnetSyncerKey}
+	sortKeys := cmpopts.SortSlices(func(a, b negtypes.NegSyncerKey) bool { return a.String() < b.String() })
+	if diff := cmp.Diff(wantForgotten, reflector.forgotten, sortKeys); diff != "" {
+		t.Errorf("Forgotten syncer keys mismatch (-want +got):\n%s", diff)
+	}
+	if len(transactionSyncer.committedSyncerKeys) != 0 {
+		t.Errorf("committedSyncerKeys = %v, want empty after cleanup", transactionSyncer.committedSyncerKeys)
+	}
+}
+
 func TestTransactionSyncerWithNegCR(t *testing.T) {
 	testNetwork := cloud.ResourcePath("network", &meta.Key{Name: "test-network"})
 	testSubnetwork := defaultTestSubnetURL
@@ -1947,6 +2020,42 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateStatusRemovesTopologyAwareCondition(t *testing.T) {
+	testNetwork := cloud.ResourcePath("network", &meta.Key{Name: "test-network"})
+	fakeCloud := negtypes.NewFakeNetworkEndpointGroupCloud(defaultTestSubnetURL, testNetwork)
+	_, syncer := newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+	svcNegClient := syncer.svcNegClient
+
+	// The NEG CR was last updated by a syncer of the Service when it used
+	// topology-aware routing.
+	creationTS := v1.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC)
+	origCR := createNegCR(testNegName, creationTS, true, true, nil)
+	origCR.Status.Conditions = append(origCR.Status.Conditions, negv1beta1.Condition{
+		Type:               negv1beta1.TopologyAware,
+		Status:             corev1.ConditionTrue,
+		Reason:             negtypes.TopologyHintsApplied,
+		LastTransitionTime: creationTS,
+	})
+	origCR, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Create(context.Background(), origCR, v1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create test NEG CR: %s", err)
+	}
+	syncer.svcNegLister.Add(origCR)
+
+	syncer.updateStatus(nil)
+
+	negCR, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Get(context.Background(), testNegName, v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get test NEG CR: %s", err)
+	}
+	if condition, _, exists := findCondition(negCR.Status.Conditions, negv1beta1.TopologyAware); exists {
+		t.Errorf("Found condition %+v, want no %s condition once the Service no longer uses topology-aware routing", condition, negv1beta1.TopologyAware)
+	}
+	if _, _, exists := findCondition(negCR.Status.Conditions, negv1beta1.Synced); !exists {
+		t.Errorf("Condition %s was removed", negv1beta1.Synced)
+	}
+}
+
 func TestIsZoneChange(t *testing.T) {
 	testNetwork := cloud.ResourcePath("network", &meta.Key{Name: "test-network"})
 	testSubnetwork := defaultTestSubnetURL
@@ -2157,7 +2266,7 @@ func TestUnknownNodes(t *testing.T) {
 	}
 
 	// Check that unknown zone did not cause endpoints to be removed
-	out, _, err := retrieveExistingZoneNetworkEndpointMap(map[string]string{defaultTestSubnet: testNegName}, zoneGetter, fakeCloud, meta.VersionGA, negtypes.L7Mode, false, klog.TODO())
+	out, _, err := retrieveExistingZoneNetworkEndpointMap(map[string]string{defaultTestSubnet: testNegName}, zoneGetter, fakeCloud, meta.VersionGA, negtypes.VmIpPortEndpointType, negtypes.L7Mode, false, klog.TODO())
 	if err != nil {
 		t.Errorf("errored retrieving existing network endpoints")
 	}
@@ -2280,7 +2389,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "enable degraded mode, not error state, include invalid endpoints that would trigger error state before API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = true
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-1",
 			testEndpointSlices:   nodeMissingEndpointSlices,
@@ -2292,7 +2401,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "enable degraded mode, in error state, include invalid endpoints that would trigger error state before API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = true
-				ts.setErrorState()
+				ts.setErrorState(negtypes.ErrEPNodeMissing)
 			},
 			negName:              "neg-2",
 			testEndpointSlices:   nodeMissingEndpointSlices,
@@ -2304,7 +2413,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "enable degraded mode, not error state, no invalid endpoints",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = true
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-3",
 			testEndpointSlices:   validEndpointSlice,
@@ -2316,7 +2425,8 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "enable degraded mode, in error state, no invalid endpoints",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = true
-				ts.setErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
+				ts.setErrorState(negtypes.ErrEPNodeMissing)
 			},
 			negName:              "neg-4",
 			testEndpointSlices:   validEndpointSlice,
@@ -2328,7 +2438,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "disable degraded mode, not error state, include invalid endpoints that would trigger error state before API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = false
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-5",
 			testEndpointSlices:   nodeMissingEndpointSlices,
@@ -2340,7 +2450,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "disable degraded mode, and in error state, include invalid endpoints that would trigger error state before API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = false
-				ts.setErrorState()
+				ts.setErrorState(negtypes.ErrEPNodeMissing)
 			},
 			negName:              "neg-6",
 			testEndpointSlices:   nodeMissingEndpointSlices,
@@ -2352,7 +2462,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "disable degraded mode, and not error state, no invalid endpoints",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = false
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-7",
 			testEndpointSlices:   validEndpointSlice,
@@ -2364,7 +2474,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "disable degraded mode, and in error state, no invalid endpoints",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = false
-				ts.setErrorState()
+				ts.setErrorState(negtypes.ErrEPNodeMissing)
 			},
 			negName:              "neg-8",
 			testEndpointSlices:   validEndpointSlice,
@@ -2376,7 +2486,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "enable degraded mode, and not in error state, include invalid endpoints that would trigger error state after API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = true
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-9",
 			testEndpointSlices:   ipOutOfCIDREndpointSlices,
@@ -2388,7 +2498,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			desc: "disable degraded mode, and not in error state, include invalid endpoints that would trigger error state after API calls",
 			modify: func(ts *transactionSyncer) {
 				ts.enableDegradedMode = false
-				ts.resetErrorState()
+				ts.degradedMode = newHysteresisDecider(1)
 			},
 			negName:              "neg-10",
 			testEndpointSlices:   ipOutOfCIDREndpointSlices,
@@ -2396,6 +2506,19 @@ func TestEnableDegradedMode(t *testing.T) {
 			expectedInErrorState: true,
 			expectErr:            nil,
 		},
+		{
+			desc: "enable degraded mode, in error state, no invalid endpoints, more than one agreeing sync required to exit error state",
+			modify: func(ts *transactionSyncer) {
+				ts.enableDegradedMode = true
+				ts.degradedMode = newHysteresisDecider(3)
+				ts.setErrorState(negtypes.ErrEPNodeMissing)
+			},
+			negName:              "neg-11",
+			testEndpointSlices:   validEndpointSlice,
+			expectedEndpoints:    updateSucceedEndpoints,
+			expectedInErrorState: true, // a single agreeing sync should not reset error state
+			expectErr:            nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2456,7 +2579,7 @@ func TestEnableDegradedMode(t *testing.T) {
 			tc.modify(s)
 
 			subnetToNegMapping := map[string]string{defaultTestSubnet: tc.negName}
-			out, _, err := retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, zoneGetter, fakeCloud, meta.VersionGA, negtypes.L7Mode, false, klog.TODO())
+			out, _, err := retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, zoneGetter, fakeCloud, meta.VersionGA, negtypes.VmIpPortEndpointType, negtypes.L7Mode, false, klog.TODO())
 			if err != nil {
 				t.Errorf("errored retrieving existing network endpoints")
 			}
@@ -2469,7 +2592,7 @@ func TestEnableDegradedMode(t *testing.T) {
 				t.Errorf("syncInternal returned %v, expected %v", err, tc.expectErr)
 			}
 			err = wait.PollImmediate(time.Second, 3*time.Second, func() (bool, error) {
-				out, _, err = retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, zoneGetter, fakeCloud, meta.VersionGA, negtypes.L7Mode, false, klog.TODO())
+				out, _, err = retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping, zoneGetter, fakeCloud, meta.VersionGA, negtypes.VmIpPortEndpointType, negtypes.L7Mode, false, klog.TODO())
 				if err != nil {
 					return false, nil
 				}
@@ -2902,6 +3025,7 @@ func newTestTransactionSyncer(fakeGCE negtypes.NetworkEndpointGroupCloud, negTyp
 	negsyncer := NewTransactionSyncer(svcPort,
 		record.NewFakeRecorder(100),
 		fakeGCE,
+		nil,
 		fakeZoneGetter,
 		testContext.PodInformer.GetIndexer(),
 		testContext.ServiceInformer.GetIndexer(),
@@ -2915,6 +3039,7 @@ func newTestTransactionSyncer(fakeGCE negtypes.NetworkEndpointGroupCloud, negTyp
 		testContext.SvcNegClient,
 		metricscollector.FakeSyncerMetrics(),
 		customName,
+		false,
 		klog.TODO(),
 		labels.PodLabelPropagationConfig{},
 		testContext.EnableDualStackNEG,
@@ -3020,6 +3145,8 @@ type testReflector struct {
 	negNames []string
 
 	pollMap map[negMeta]negtypes.EndpointPodMap
+
+	forgotten []negtypes.NegSyncerKey
 }
 
 func (tr *testReflector) Flush() {
@@ -3039,6 +3166,10 @@ func (tr *testReflector) CommitPods(syncerKey negtypes.NegSyncerKey, negName str
 	tr.pollMap[key] = endpointMap
 }
 
+func (tr *testReflector) ForgetSyncer(syncerKey negtypes.NegSyncerKey) {
+	tr.forgotten = append(tr.forgotten, syncerKey)
+}
+
 func validateTransactionTableEquality(t *testing.T, desc string, table, expectTable networkEndpointTransactionTable) {
 	for _, key := range table.Keys() {
 		expectEntry, ok := expectTable.Get(key)
@@ -125,7 +125,7 @@ func getService(serviceLister cache.Indexer, namespace, name string, logger klog
 }
 
 // ensureNetworkEndpointGroup ensures corresponding NEG is configured correctly in the specified zone.
-func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negServicePortName, kubeSystemUID, port string, networkEndpointType negtypes.NetworkEndpointType, cloud negtypes.NetworkEndpointGroupCloud, serviceLister cache.Indexer, recorder record.EventRecorder, version meta.Version, customName bool, networkInfo network.NetworkInfo, logger klog.Logger) (negv1beta1.NegObjectReference, error) {
+func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negServicePortName, kubeSystemUID, port string, networkEndpointType negtypes.NetworkEndpointType, cloud negtypes.NetworkEndpointGroupCloud, serviceLister cache.Indexer, recorder record.EventRecorder, version meta.Version, customName bool, adoption *negAdoption, networkInfo network.NetworkInfo, logger klog.Logger) (negv1beta1.NegObjectReference, error) {
 	negLogger := logger.WithValues("negName", negName, "zone", zone)
 	var negRef negv1beta1.NegObjectReference
 	neg, err := cloud.GetNetworkEndpointGroup(negName, zone, version, logger)
@@ -139,9 +139,29 @@ func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negService
 	}
 
 	needToCreate := false
+	adopted := false
+	if neg != nil && adoption != nil && !hasControllerDescription(neg) {
+		if adoption.adoptedSelfLinks.Has(neg.SelfLink) {
+			adopted = true
+		} else {
+			if adopted, err = adoption.adopt(neg, zone, version, networkEndpointType, cloud, networkInfo, negLogger); err != nil {
+				return negRef, err
+			}
+			if !adopted {
+				// The NEG was deleted to be recreated with the description
+				// of the controller, so it is not adopted.
+				neg = nil
+			} else if recorder != nil && serviceLister != nil {
+				if svc := getService(serviceLister, svcNamespace, svcName, logger); svc != nil {
+					recorder.Eventf(svc, apiv1.EventTypeNormal, "Adopt", "Adopted existing NEG %q for %s in %q.", negName, negServicePortName, zone)
+				}
+			}
+		}
+	}
+
 	if neg == nil {
 		needToCreate = true
-	} else {
+	} else if !adopted {
 		expectedDesc := utils.NegDescription{
 			ClusterUID:  kubeSystemUID,
 			Namespace:   svcNamespace,
@@ -158,9 +178,9 @@ func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negService
 			return negv1beta1.NegObjectReference{}, fmt.Errorf("found conflicting description in neg %s: %w", negName, err)
 		}
 
-		if networkEndpointType != negtypes.NonGCPPrivateEndpointType &&
-			// Only perform the following checks when the NEGs are not Non-GCP NEGs.
-			// Non-GCP NEGs do not have associated network and subnetwork.
+		if networkEndpointType != negtypes.NonGCPPrivateEndpointType && !negtypes.IsGlobalNetworkEndpointType(networkEndpointType) &&
+			// Only perform the following checks when the NEGs are not Non-GCP or internet NEGs.
+			// Non-GCP NEGs do not have associated subnetwork, and internet NEGs do not have associated network and subnetwork.
 			(!utils.EqualResourceIDs(neg.Network, networkInfo.NetworkURL) ||
 				!utils.EqualResourceIDs(neg.Subnetwork, networkInfo.SubnetworkURL)) {
 
@@ -179,14 +199,16 @@ func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negService
 	}
 
 	if needToCreate {
-		var subnetwork string
+		var network, subnetwork string
 		switch networkEndpointType {
 		case negtypes.NonGCPPrivateEndpointType:
-			subnetwork = ""
+			network = networkInfo.NetworkURL
+		case negtypes.InternetFQDNEndpointType, negtypes.InternetIPEndpointType:
 		default:
+			network = networkInfo.NetworkURL
 			subnetwork = networkInfo.SubnetworkURL
 		}
-		negLogger.Info("Creating NEG", "negServicePortName", negServicePortName, "network", networkInfo.NetworkURL, "subnetwork", subnetwork)
+		negLogger.Info("Creating NEG", "negServicePortName", negServicePortName, "network", network, "subnetwork", subnetwork)
 		desc := ""
 		negDesc := utils.NegDescription{
 			ClusterUID:  kubeSystemUID,
@@ -200,7 +222,7 @@ func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negService
 			Version:             version,
 			Name:                negName,
 			NetworkEndpointType: string(networkEndpointType),
-			Network:             networkInfo.NetworkURL,
+			Network:             network,
 			Subnetwork:          subnetwork,
 			Description:         desc,
 		}, zone, logger)
@@ -227,6 +249,7 @@ func ensureNetworkEndpointGroup(svcNamespace, svcName, negName, zone, negService
 		Id:                  fmt.Sprint(neg.Id),
 		SelfLink:            neg.SelfLink,
 		NetworkEndpointType: negv1beta1.NetworkEndpointType(neg.NetworkEndpointType),
+		Adopted:             adopted,
 	}
 	if flags.F.EnableMultiSubnetClusterPhase1 {
 		negRef.State = negv1beta1.ActiveState
@@ -623,6 +646,44 @@ func ipsForPod(eds []negtypes.EndpointsData) map[types.NamespacedName]negtypes.N
 	return result
 }
 
+// podEndpointsForNodes returns the endpoints of the pods scheduled on the nodes
+// of the given GCE_VM_IP endpoints, grouped like the node endpoints, and the
+// mapping from these pod endpoints to the pods. A pod endpoint has the IPs of
+// the pod and the name of its node, which the readiness reflector uses to
+// match the health status of the node to the pods.
+func podEndpointsForNodes(endpointMap map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, eds []negtypes.EndpointsData) (map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, negtypes.EndpointPodMap) {
+	podIPs := ipsForPod(eds)
+	nodePods := make(map[string]negtypes.EndpointPodMap)
+	for _, ed := range eds {
+		for _, address := range ed.Addresses {
+			if address.TargetRef == nil || address.NodeName == nil {
+				continue
+			}
+			podNN := types.NamespacedName{Namespace: address.TargetRef.Namespace, Name: address.TargetRef.Name}
+			podEndpoint := podIPs[podNN]
+			podEndpoint.Node = *address.NodeName
+			if nodePods[podEndpoint.Node] == nil {
+				nodePods[podEndpoint.Node] = negtypes.EndpointPodMap{}
+			}
+			nodePods[podEndpoint.Node][podEndpoint] = podNN
+		}
+	}
+
+	podEndpointMap := make(map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet)
+	endpointPodMap := negtypes.EndpointPodMap{}
+	for endpointGroupInfo, endpointSet := range endpointMap {
+		podEndpoints := negtypes.NewNetworkEndpointSet()
+		for _, endpoint := range endpointSet.List() {
+			for podEndpoint, podNN := range nodePods[endpoint.Node] {
+				podEndpoints.Insert(podEndpoint)
+				endpointPodMap[podEndpoint] = podNN
+			}
+		}
+		podEndpointMap[endpointGroupInfo] = podEndpoints
+	}
+	return podEndpointMap, endpointPodMap
+}
+
 // podContainsEndpointAddress checks if the given endpoint's IP
 // matches one of the pod's IPs, and return if it doesn't.
 // If this is a dual stack endpoint, we would validate both IPs.
@@ -698,19 +759,28 @@ func podBelongsToService(pod *apiv1.Pod, service *apiv1.Service) error {
 }
 
 // retrieveExistingZoneNetworkEndpointMap lists existing network endpoints in the neg and return the zone and endpoints map.
-func retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping map[string]string, zoneGetter *zonegetter.ZoneGetter, cloud negtypes.NetworkEndpointGroupCloud, version meta.Version, mode negtypes.EndpointsCalculatorMode, enableDualStackNEG bool, logger klog.Logger) (map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, labels.EndpointPodLabelMap, error) {
-	// Include zones that have non-candidate nodes currently. It is possible that NEGs were created in those zones previously and the endpoints now became non-candidates.
-	// Endpoints in those NEGs now need to be removed. This mostly applies to VM_IP_NEGs where the endpoints are nodes.
-	zones, err := zoneGetter.ListZones(zonegetter.AllNodesFilter, logger)
-	if err != nil {
-		return nil, nil, err
-	}
+func retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping map[string]string, zoneGetter *zonegetter.ZoneGetter, cloud negtypes.NetworkEndpointGroupCloud, version meta.Version, negType negtypes.NetworkEndpointType, mode negtypes.EndpointsCalculatorMode, enableDualStackNEG bool, logger klog.Logger) (map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet, labels.EndpointPodLabelMap, error) {
+	var zones []string
+	var candidateZonesMap sets.String
+	if negtypes.IsGlobalNetworkEndpointType(negType) {
+		// Global NEGs are not in any zone.
+		zones = []string{negtypes.GlobalZone}
+		candidateZonesMap = sets.NewString(negtypes.GlobalZone)
+	} else {
+		var err error
+		// Include zones that have non-candidate nodes currently. It is possible that NEGs were created in those zones previously and the endpoints now became non-candidates.
+		// Endpoints in those NEGs now need to be removed. This mostly applies to VM_IP_NEGs where the endpoints are nodes.
+		zones, err = zoneGetter.ListZones(zonegetter.AllNodesFilter, logger)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	candidateNodeZones, err := zoneGetter.ListZones(negtypes.NodeFilterForEndpointCalculatorMode(mode), logger)
-	if err != nil {
-		return nil, nil, err
+		candidateNodeZones, err := zoneGetter.ListZones(negtypes.NodeFilterForEndpointCalculatorMode(mode), logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidateZonesMap = sets.NewString(candidateNodeZones...)
 	}
-	candidateZonesMap := sets.NewString(candidateNodeZones...)
 
 	zoneNetworkEndpointMap := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{}
 	endpointPodLabelMap := labels.EndpointPodLabelMap{}
@@ -729,7 +799,7 @@ func retrieveExistingZoneNetworkEndpointMap(subnetToNegMapping map[string]string
 			}
 			zoneNetworkEndpointMap[negtypes.EndpointGroupInfo{Zone: zone, Subnet: subnet}] = negtypes.NewNetworkEndpointSet()
 			for _, ne := range networkEndpointsWithHealthStatus {
-				newNE := negtypes.NetworkEndpoint{IP: ne.NetworkEndpoint.IpAddress, Node: ne.NetworkEndpoint.Instance}
+				newNE := negtypes.NetworkEndpoint{IP: ne.NetworkEndpoint.IpAddress, FQDN: ne.NetworkEndpoint.Fqdn, Node: ne.NetworkEndpoint.Instance}
 				if ne.NetworkEndpoint.Port != 0 {
 					newNE.Port = strconv.FormatInt(ne.NetworkEndpoint.Port, 10)
 				}
@@ -768,6 +838,7 @@ func makeEndpointBatch(endpoints negtypes.NetworkEndpointSet, negType negtypes.N
 				Instance:    networkEndpoint.Node,
 				IpAddress:   networkEndpoint.IP,
 				Ipv6Address: networkEndpoint.IPv6,
+				Fqdn:        networkEndpoint.FQDN,
 				Port:        int64(portNum),
 			}
 			if flags.F.EnableNEGLabelPropagation {
@@ -422,6 +422,7 @@ func TestEnsureNetworkEndpointGroup(t *testing.T) {
 				nil,
 				tc.apiVersion,
 				false,
+				nil,
 				tc.networkInfo,
 				klog.TODO(),
 			)
@@ -480,6 +481,7 @@ func TestEnsureNetworkEndpointGroup(t *testing.T) {
 				nil,
 				tc.apiVersion,
 				false,
+				nil,
 				tc.networkInfo,
 				klog.TODO(),
 			)
@@ -746,6 +748,80 @@ func TestIpsForPod(t *testing.T) {
 	}
 }
 
+func TestPodEndpointsForNodes(t *testing.T) {
+	t.Parallel()
+	node1, node2, node3 := "node1", "node2", "node3"
+	eds := negtypes.EndpointsDataFromEndpointSlices([]*discovery.EndpointSlice{
+		{
+			AddressType: discovery.AddressTypeIPv4,
+			Endpoints: []discovery.Endpoint{
+				{
+					Addresses: []string{"10.100.1.1"},
+					NodeName:  &node1,
+					TargetRef: &v1.ObjectReference{Namespace: "ns", Name: "pod1"},
+				},
+				{
+					Addresses: []string{"10.100.1.2"},
+					NodeName:  &node1,
+					TargetRef: &v1.ObjectReference{Namespace: "ns", Name: "pod2"},
+				},
+				{
+					Addresses: []string{"10.100.2.1"},
+					NodeName:  &node2,
+					TargetRef: &v1.ObjectReference{Namespace: "ns", Name: "pod3"},
+				},
+				{
+					Addresses: []string{"10.100.3.1"},
+					NodeName:  &node3,
+					TargetRef: &v1.ObjectReference{Namespace: "ns", Name: "pod4"},
+				},
+				{
+					// Endpoints without a pod are skipped.
+					Addresses: []string{"10.100.1.3"},
+					NodeName:  &node1,
+				},
+			},
+		},
+		{
+			AddressType: discovery.AddressTypeIPv6,
+			Endpoints: []discovery.Endpoint{
+				{
+					Addresses: []string{"a:b::1"},
+					NodeName:  &node1,
+					TargetRef: &v1.ObjectReference{Namespace: "ns", Name: "pod1"},
+				},
+			},
+		},
+	})
+	zone1 := negtypes.EndpointGroupInfo{Zone: "zone1", Subnet: defaultTestSubnet}
+	zone2 := negtypes.EndpointGroupInfo{Zone: "zone2", Subnet: defaultTestSubnet}
+	nodeEndpoints := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		zone1: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.0.0.1", Node: node1}),
+		zone2: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "10.0.0.2", Node: node2}),
+	}
+
+	pod1 := negtypes.NetworkEndpoint{IP: "10.100.1.1", IPv6: "a:b::1", Node: node1}
+	pod2 := negtypes.NetworkEndpoint{IP: "10.100.1.2", Node: node1}
+	pod3 := negtypes.NetworkEndpoint{IP: "10.100.2.1", Node: node2}
+	wantEndpoints := map[negtypes.EndpointGroupInfo]negtypes.NetworkEndpointSet{
+		zone1: negtypes.NewNetworkEndpointSet(pod1, pod2),
+		zone2: negtypes.NewNetworkEndpointSet(pod3),
+	}
+	wantPodMap := negtypes.EndpointPodMap{
+		pod1: {Namespace: "ns", Name: "pod1"},
+		pod2: {Namespace: "ns", Name: "pod2"},
+		pod3: {Namespace: "ns", Name: "pod3"},
+	}
+
+	gotEndpoints, gotPodMap := podEndpointsForNodes(nodeEndpoints, eds)
+	if diff := cmp.Diff(wantEndpoints, gotEndpoints); diff != "" {
+		t.Errorf("podEndpointsForNodes() returned unexpected endpoints diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantPodMap, gotPodMap); diff != "" {
+		t.Errorf("podEndpointsForNodes() returned unexpected endpoint pod map diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 	nodeInformer := zonegetter.FakeNodeInformer()
 	zonegetter.PopulateFakeNodeInformer(nodeInformer, false)
@@ -1232,7 +1308,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 	for _, tc := range testCases {
 		tc.mutate(negCloud)
 		// tc.mode of "" will result in the default node predicate being selected, which is ok for this test.
-		endpointSets, annotationMap, err := retrieveExistingZoneNetworkEndpointMap(tc.subnetToNegMapping, zoneGetter, negCloud, meta.VersionGA, tc.mode, false, klog.TODO())
+		endpointSets, annotationMap, err := retrieveExistingZoneNetworkEndpointMap(tc.subnetToNegMapping, zoneGetter, negCloud, meta.VersionGA, negtypes.VmIpPortEndpointType, tc.mode, false, klog.TODO())
 
 		if tc.expectErr {
 			if err == nil {
@@ -1402,6 +1478,7 @@ func TestNameUniqueness(t *testing.T) {
 		nil,
 		apiVersion,
 		false,
+		nil,
 		networkInfo,
 		klog.TODO(),
 	)
@@ -1433,6 +1510,7 @@ func TestNameUniqueness(t *testing.T) {
 		nil,
 		apiVersion,
 		false,
+		nil,
 		networkInfo,
 		klog.TODO(),
 	)
@@ -1481,6 +1559,7 @@ func TestNegObjectCrd(t *testing.T) {
 			nil,
 			apiVersion,
 			false,
+			nil,
 			networkInfo,
 			klog.TODO(),
 		)
@@ -1530,6 +1609,7 @@ func TestNegObjectCrd(t *testing.T) {
 			nil,
 			apiVersion,
 			false,
+			nil,
 			networkInfo,
 			klog.TODO(),
 		)
@@ -1701,6 +1781,7 @@ func TestNEGRecreate(t *testing.T) {
 			nil,
 			apiVersion,
 			tc.customName,
+			nil,
 			networkInfo,
 			klog.TODO(),
 		)
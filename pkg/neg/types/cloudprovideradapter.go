@@ -80,7 +80,13 @@ type cloudProviderAdapter struct {
 // GetNetworkEndpointGroup implements NetworkEndpointGroupCloud.
 func (a *cloudProviderAdapter) GetNetworkEndpointGroup(name string, zone string, version meta.Version, logger klog.Logger) (*composite.NetworkEndpointGroup, error) {
 	start := time.Now()
-	neg, err := composite.GetNetworkEndpointGroup(a.c, meta.ZonalKey(name, zone), version, logger)
+	var neg *composite.NetworkEndpointGroup
+	var err error
+	if zone == GlobalZone {
+		neg, err = composite.GetGlobalNetworkEndpointGroup(a.c, meta.GlobalKey(name), logger)
+	} else {
+		neg, err = composite.GetNetworkEndpointGroup(a.c, meta.ZonalKey(name, zone), version, logger)
+	}
 	metrics.PublishGCERequestCountMetrics(start, metrics.GetRequest, err)
 	return neg, err
 
@@ -106,7 +112,12 @@ func (a *cloudProviderAdapter) AggregatedListNetworkEndpointGroup(version meta.V
 // CreateNetworkEndpointGroup implements NetworkEndpointGroupCloud.
 func (a *cloudProviderAdapter) CreateNetworkEndpointGroup(neg *composite.NetworkEndpointGroup, zone string, logger klog.Logger) error {
 	start := time.Now()
-	err := composite.CreateNetworkEndpointGroup(a.c, meta.ZonalKey(neg.Name, zone), neg, logger)
+	var err error
+	if zone == GlobalZone {
+		err = composite.CreateGlobalNetworkEndpointGroup(a.c, meta.GlobalKey(neg.Name), neg, logger)
+	} else {
+		err = composite.CreateNetworkEndpointGroup(a.c, meta.ZonalKey(neg.Name, zone), neg, logger)
+	}
 	metrics.PublishGCERequestCountMetrics(start, metrics.CreateRequest, err)
 	return err
 }
@@ -114,7 +125,12 @@ func (a *cloudProviderAdapter) CreateNetworkEndpointGroup(neg *composite.Network
 // DeleteNetworkEndpointGroup implements NetworkEndpointGroupCloud.
 func (a *cloudProviderAdapter) DeleteNetworkEndpointGroup(name string, zone string, version meta.Version, logger klog.Logger) error {
 	start := time.Now()
-	err := composite.DeleteNetworkEndpointGroup(a.c, meta.ZonalKey(name, zone), version, logger)
+	var err error
+	if zone == GlobalZone {
+		err = composite.DeleteGlobalNetworkEndpointGroup(a.c, meta.GlobalKey(name), logger)
+	} else {
+		err = composite.DeleteNetworkEndpointGroup(a.c, meta.ZonalKey(name, zone), version, logger)
+	}
 	metrics.PublishGCERequestCountMetrics(start, metrics.DeleteRequest, err)
 	return err
 }
@@ -123,7 +139,12 @@ func (a *cloudProviderAdapter) DeleteNetworkEndpointGroup(name string, zone stri
 func (a cloudProviderAdapter) AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
 	req := &composite.NetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}
 	start := time.Now()
-	err := composite.AttachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	var err error
+	if zone == GlobalZone {
+		err = composite.AttachGlobalNetworkEndpoints(a.c, meta.GlobalKey(name), req, logger)
+	} else {
+		err = composite.AttachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	}
 	metrics.PublishGCERequestCountMetrics(start, metrics.AttachNERequest, err)
 	_, strategyUsed := a.strategyKeys[fmt.Sprintf("%s.%s.%s", version, negServiceName, attachNetworkEndpoints)]
 	if utils.IsQuotaExceededError(err) && strategyUsed {
@@ -136,7 +157,12 @@ func (a cloudProviderAdapter) AttachNetworkEndpoints(name, zone string, endpoint
 func (a *cloudProviderAdapter) DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version, logger klog.Logger) error {
 	req := &composite.NetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}
 	start := time.Now()
-	err := composite.DetachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	var err error
+	if zone == GlobalZone {
+		err = composite.DetachGlobalNetworkEndpoints(a.c, meta.GlobalKey(name), req, logger)
+	} else {
+		err = composite.DetachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	}
 	metrics.PublishGCERequestCountMetrics(start, metrics.DetachNERequest, err)
 	_, strategyUsed := a.strategyKeys[fmt.Sprintf("%s.%s.%s", version, negServiceName, detachNetworkEndpoints)]
 	if utils.IsQuotaExceededError(err) && strategyUsed {
@@ -155,7 +181,13 @@ func (a *cloudProviderAdapter) ListNetworkEndpoints(name, zone string, showHealt
 	}
 	req := &composite.NetworkEndpointGroupsListEndpointsRequest{HealthStatus: healthStatus}
 	start := time.Now()
-	networkEndpoints, err := composite.ListNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	var networkEndpoints []*composite.NetworkEndpointWithHealthStatus
+	var err error
+	if zone == GlobalZone {
+		networkEndpoints, err = composite.ListGlobalNetworkEndpoints(a.c, meta.GlobalKey(name), logger)
+	} else {
+		networkEndpoints, err = composite.ListNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req, logger)
+	}
 	_, strategyUsed := a.strategyKeys[fmt.Sprintf("%s.%s.%s", version, negServiceName, listNetworkEndpoints)]
 	if utils.IsQuotaExceededError(err) && strategyUsed {
 		err = &StrategyQuotaError{Err: err}
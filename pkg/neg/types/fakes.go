@@ -88,6 +88,7 @@ func (f *FakeNetworkEndpointGroupCloud) GetNetworkEndpointGroup(name string, zon
 	if ok {
 		for _, neg := range negs {
 			if neg.Name == name {
+				neg.Size = int64(len(f.NetworkEndpoints[networkEndpointKey(name, zone)]))
 				return neg, nil
 			}
 		}
@@ -25,6 +25,8 @@ import (
 )
 
 // NetworkEndpointGroupCloud is an interface for managing gce network endpoint group.
+// Methods that take a zone operate on the global network endpoint group when
+// the zone is GlobalZone.
 type NetworkEndpointGroupCloud interface {
 	GetNetworkEndpointGroup(name string, zone string, version meta.Version, logger klog.Logger) (*composite.NetworkEndpointGroup, error)
 	ListNetworkEndpointGroup(zone string, version meta.Version, logger klog.Logger) ([]*composite.NetworkEndpointGroup, error)
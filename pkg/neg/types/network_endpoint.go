@@ -24,6 +24,7 @@ import (
 type NetworkEndpoint struct {
 	IP   string
 	IPv6 string
+	FQDN string
 	Port string
 	Node string
 }
@@ -45,9 +45,18 @@ const (
 	VmIpPortEndpointType      = NetworkEndpointType("GCE_VM_IP_PORT")
 	VmIpEndpointType          = NetworkEndpointType("GCE_VM_IP")
 	NonGCPPrivateEndpointType = NetworkEndpointType("NON_GCP_PRIVATE_IP_PORT")
+	ServerlessEndpointType    = NetworkEndpointType("SERVERLESS")
+	InternetFQDNEndpointType  = NetworkEndpointType("INTERNET_FQDN_PORT")
+	InternetIPEndpointType    = NetworkEndpointType("INTERNET_IP_PORT")
 	L7Mode                    = EndpointsCalculatorMode("L7")
 	L4LocalMode               = EndpointsCalculatorMode("L4, ExternalTrafficPolicy:Local")
 	L4ClusterMode             = EndpointsCalculatorMode("L4, ExternalTrafficPolicy:Cluster")
+	ExternalMode              = EndpointsCalculatorMode("External")
+	L7TopologyAwareMode       = EndpointsCalculatorMode("L7, TopologyAware")
+
+	// GlobalZone is the zone used for global NEGs, such as INTERNET_FQDN_PORT
+	// and INTERNET_IP_PORT NEGs, which do not belong to any zone.
+	GlobalZone = ""
 
 	// These keys are to be used as label keys for NEG CRs when enabled
 
@@ -56,15 +65,24 @@ const (
 	NegCRServicePortKey = "networking.gke.io/service-port"
 	// NegCRControllerValue is used as the value for the managed-by label on NEG CRs when enabled.
 	NegCRControllerValue = "neg-controller"
+	// NegCRServerlessNEGControllerValue is used as the value for the
+	// managed-by label on the NEG CRs that report serverless NEGs.
+	NegCRServerlessNEGControllerValue = "serverless-neg-controller"
 
 	// NEG CR Condition Reasons
 	NegSyncSuccessful           = "NegSyncSuccessful"
 	NegSyncFailed               = "NegSyncFailed"
 	NegInitializationSuccessful = "NegInitializationSuccessful"
 	NegInitializationFailed     = "NegInitializationFailed"
+	TopologyHintsApplied        = "TopologyHintsApplied"
+	TopologyHintsMissing        = "TopologyHintsMissing"
+	TopologyHintsNoEndpoints    = "TopologyHintsNoEndpoints"
+	TopologyHintsZoneNotCovered = "TopologyHintsZoneNotCovered"
 
 	// NEG CRD Enabled Garbage Collection Event Reasons
-	NegGCError = "NegCRError"
+	NegGCError     = "NegCRError"
+	NegGCSkipped   = "NegGCSkipped"
+	NegGCScheduled = "NegGCScheduled"
 
 	// L4LBTypes are used to mark what type of LB the calculator is determinig endpoints for.
 	L4InternalLB = L4LBType("INTERNAL")
@@ -127,6 +145,9 @@ type PortInfo struct {
 
 	// NegName is the name of the NEG
 	NegName string
+	// AdoptExistingNEG indicates that the syncer takes ownership of the NEGs
+	// which already exist and were not created by a NEG controller.
+	AdoptExistingNEG bool
 	// ReadinessGate indicates if the NEG associated with the port has NEG readiness gate enabled
 	// This is enabled with service port is reference by ingress.
 	// If the service port is only exposed as stand alone NEG, it should not be enabled.
@@ -134,12 +155,18 @@ type PortInfo struct {
 	// EpCalculatorMode indicates if the endpoints for the NEG associated with this port need to
 	// be selected at random(L4ClusterMode), or by following service endpoints(L4LocalMode).
 	// This is applicable in GCE_VM_IP NEGs where the endpoints are the nodes instead of pods.
-	// L7 NEGs will have either "" or L7Mode.
+	// L7 NEGs will have either "", L7Mode, or L7TopologyAwareMode if the service uses
+	// topology-aware routing.
 	EpCalculatorMode EndpointsCalculatorMode
 	// NetworkInfo specifies the network (K8s and VPC) and subnetwork the service port belongs to.
 	NetworkInfo network.NetworkInfo
 	// The type of the L4 LB. For L7 this should be left empty.
 	L4LBType L4LBType
+	// NetworkEndpointType is the type of the NEG associated with this port
+	// when its endpoints are outside of the cluster, and is used together with
+	// the ExternalMode calculator mode. Otherwise it should be left empty and
+	// the type is derived from the port tuple.
+	NetworkEndpointType NetworkEndpointType
 }
 
 // PortInfoMapKey is the Key of PortInfoMap
@@ -215,14 +242,18 @@ func (p1 PortInfoMap) Merge(p2 PortInfoMap) error {
 				return fmt.Errorf("For service port %v, Existing map has Calculator mode %v, but the merge map has %v", mapKey, existingPortInfo.EpCalculatorMode, portInfo.EpCalculatorMode)
 			}
 			mergedInfo.ReadinessGate = existingPortInfo.ReadinessGate
+			mergedInfo.AdoptExistingNEG = existingPortInfo.AdoptExistingNEG
 		}
 		mergedInfo.PortTuple = portInfo.PortTuple
 		mergedInfo.NegName = portInfo.NegName
 		// Turn on the readiness gate if one of them is on
 		mergedInfo.ReadinessGate = mergedInfo.ReadinessGate || portInfo.ReadinessGate
+		// Adopt existing NEGs if one of them does
+		mergedInfo.AdoptExistingNEG = mergedInfo.AdoptExistingNEG || portInfo.AdoptExistingNEG
 		mergedInfo.EpCalculatorMode = portInfo.EpCalculatorMode
 		mergedInfo.NetworkInfo = portInfo.NetworkInfo
 		mergedInfo.L4LBType = portInfo.L4LBType
+		mergedInfo.NetworkEndpointType = portInfo.NetworkEndpointType
 
 		p1[mapKey] = mergedInfo
 	}
@@ -346,9 +377,12 @@ type PortData struct {
 type AddressData struct {
 	TargetRef   *apiv1.ObjectReference
 	NodeName    *string
+	Zone        *string
 	Addresses   []string
 	Ready       bool
 	AddressType discovery.AddressType
+	// Hints are the topology hints of the endpoint, if any.
+	Hints *discovery.EndpointHints
 }
 
 // Converts API EndpointSlice list to the EndpointsData abstraction.
@@ -376,13 +410,60 @@ func EndpointsDataFromEndpointSlices(slices []*discovery.EndpointSlice) []Endpoi
 				nodeNameFromTopology := ep.DeprecatedTopology[apiv1.LabelHostname]
 				nodeName = &nodeNameFromTopology
 			}
-			addresses = append(addresses, AddressData{TargetRef: ep.TargetRef, NodeName: nodeName, Addresses: ep.Addresses, Ready: ready, AddressType: slice.AddressType})
+			addresses = append(addresses, AddressData{TargetRef: ep.TargetRef, NodeName: nodeName, Zone: ep.Zone, Addresses: ep.Addresses, Ready: ready, AddressType: slice.AddressType, Hints: ep.Hints})
 		}
 		result = append(result, EndpointsData{Meta: &slice.ObjectMeta, Ports: ports, Addresses: addresses})
 	}
 	return result
 }
 
+// EndpointsDataFromExternalNameService converts an ExternalName Service to the
+// EndpointsData abstraction. The external name is the only address, and is
+// served on the service port of the given port tuple.
+func EndpointsDataFromExternalNameService(service *apiv1.Service, portTuple SvcPortTuple) []EndpointsData {
+	if service.Spec.Type != apiv1.ServiceTypeExternalName || service.Spec.ExternalName == "" {
+		return nil
+	}
+	return []EndpointsData{
+		{
+			Meta:  &service.ObjectMeta,
+			Ports: []PortData{{Name: portTuple.Name, Port: portTuple.Port}},
+			Addresses: []AddressData{
+				{
+					Addresses:   []string{service.Spec.ExternalName},
+					Ready:       true,
+					AddressType: discovery.AddressTypeFQDN,
+				},
+			},
+		},
+	}
+}
+
+// IsGlobalNetworkEndpointType returns true if NEGs of the given type are
+// global instead of zonal.
+func IsGlobalNetworkEndpointType(negType NetworkEndpointType) bool {
+	return negType == InternetFQDNEndpointType || negType == InternetIPEndpointType
+}
+
+// ExternalNEGType returns the type of NEG to use for the service if its
+// endpoints are outside of the cluster, and an empty type otherwise.
+func ExternalNEGType(service *apiv1.Service) (NetworkEndpointType, error) {
+	if service.Spec.Type == apiv1.ServiceTypeExternalName {
+		return InternetFQDNEndpointType, nil
+	}
+	endpointType, found, err := annotations.FromService(service).NEGEndpointType()
+	if err != nil || !found {
+		return "", err
+	}
+	if len(service.Spec.Selector) != 0 {
+		return "", fmt.Errorf("annotation %s is only supported on services without a selector", annotations.NEGEndpointTypeKey)
+	}
+	if endpointType == annotations.NEGEndpointTypeHybrid {
+		return NonGCPPrivateEndpointType, nil
+	}
+	return InternetIPEndpointType, nil
+}
+
 // NodeFilterForEndpointCalculatorMode returns the filter type to select candidate nodes, given the endpoints calculator mode.
 func NodeFilterForEndpointCalculatorMode(mode EndpointsCalculatorMode) zonegetter.Filter {
 	// VM_IP NEGs can include unready and upgrading nodes.
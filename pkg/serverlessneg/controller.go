@@ -0,0 +1,326 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlessneg
+
+import (
+	context2 "context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/common/crcontroller"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/context"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	serverlessnegclient "k8s.io/ingress-gce/pkg/serverlessneg/client/clientset/versioned"
+	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+	"k8s.io/ingress-gce/pkg/utils/slice"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// gcPeriod is the period of the retried deletion of the backend services
+// that were still used by url maps.
+const gcPeriod = 2 * time.Minute
+
+// Controller manages the serverless NEGs and backend services of
+// ServerlessNEG CRs.
+type Controller struct {
+	controller *crcontroller.Controller
+
+	cloud  *gce.Cloud
+	client serverlessnegclient.Interface
+	// svcNegClient is used to report the serverless NEGs in
+	// ServiceNetworkEndpointGroup CRs. It is nil if NEG CRs are not enabled.
+	svcNegClient svcnegclient.Interface
+	namer        namer.ServerlessNEGNamer
+	lister       cache.Indexer
+	recorder     func(string) record.EventRecorder
+
+	stopCh <-chan struct{}
+
+	logger klog.Logger
+}
+
+// NewController returns a controller that manages the serverless NEGs and
+// backend services of ServerlessNEG CRs.
+func NewController(ctx *context.ControllerContext, stopCh <-chan struct{}, logger klog.Logger) *Controller {
+	logger = logger.WithName("ServerlessNEGController")
+	c := &Controller{
+		cloud:        ctx.Cloud,
+		client:       ctx.ServerlessNEGClient,
+		svcNegClient: ctx.SvcNegClient,
+		namer:        namer.NewServerlessNEGNamer(ctx.ClusterNamer, string(ctx.KubeSystemUID)),
+		lister:       ctx.ServerlessNEGInformer.GetIndexer(),
+		recorder:     ctx.Recorder,
+		stopCh:       stopCh,
+		logger:       logger,
+	}
+	c.controller = crcontroller.New(crcontroller.Config{
+		Kind:      "ServerlessNEG",
+		Informer:  ctx.ServerlessNEGInformer,
+		Recorder:  ctx.Recorder,
+		HasSynced: ctx.HasSynced,
+		Sync:      c.processServerlessNEG,
+		GCPeriod:  gcPeriod,
+	}, logger)
+	return c
+}
+
+// Run waits for the initial sync and processes keys in the queue until
+// signaled.
+func (c *Controller) Run() {
+	c.controller.Run(c.stopCh)
+}
+
+// processServerlessNEG ensures that the serverless NEG and backend service of
+// the ServerlessNEG CR with the given key match its spec, or that they are
+// deleted if the CR is being deleted.
+func (c *Controller) processServerlessNEG(key string) error {
+	obj, exists, err := c.lister.GetByKey(key)
+	if err != nil {
+		return fmt.Errorf("errored getting serverless NEG from store: %w", err)
+	}
+	if !exists {
+		// The finalizer ensures that the GCE resources were deleted.
+		return nil
+	}
+	sneg := obj.(*serverlessnegv1beta1.ServerlessNEG)
+	name := c.namer.ServerlessNEG(sneg.Namespace, sneg.Name, string(sneg.UID))
+	snegLogger := c.logger.WithValues("serverlessNEGKey", key, "serverlessNEGName", name)
+
+	if !sneg.DeletionTimestamp.IsZero() {
+		return c.deleteServerlessNEG(sneg, name, snegLogger)
+	}
+
+	sneg, err = c.ensureFinalizer(sneg)
+	if err != nil {
+		return fmt.Errorf("errored adding finalizer on ServerlessNEG CR %s: %w", key, err)
+	}
+
+	neg, negLink, err := c.ensureNetworkEndpointGroup(sneg, name, snegLogger)
+	if err != nil {
+		return err
+	}
+	bsLink, err := c.ensureBackendService(sneg, name, negLink, snegLogger)
+	if err != nil {
+		return err
+	}
+	if err := c.ensureSvcNeg(sneg, name, neg, negLink); err != nil {
+		return fmt.Errorf("failed to report serverless NEG %s in NEG CR: %w", name, err)
+	}
+	return c.updateStatus(sneg, negLink, bsLink)
+}
+
+// ensureNetworkEndpointGroup ensures that the serverless NEG of the given CR
+// exists and returns it with its link. The region and target of a serverless
+// NEG cannot be updated, so changing them requires recreating the CR.
+func (c *Controller) ensureNetworkEndpointGroup(sneg *serverlessnegv1beta1.ServerlessNEG, name string, snegLogger klog.Logger) (*composite.NetworkEndpointGroup, string, error) {
+	expected, err := toNetworkEndpointGroup(sneg, name)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid ServerlessNEG spec: %w", err)
+	}
+	key := meta.RegionalKey(name, sneg.Spec.Region)
+	negLink := cloud.SelfLink(meta.VersionGA, c.cloud.ProjectID(), "networkEndpointGroups", key)
+	if sneg.Status.NetworkEndpointGroup != "" && !utils.EqualResourceIDs(sneg.Status.NetworkEndpointGroup, negLink) {
+		return nil, "", fmt.Errorf("region of serverless NEG %s cannot be changed from %s", name, sneg.Status.NetworkEndpointGroup)
+	}
+
+	existing, err := composite.GetRegionalNetworkEndpointGroup(c.cloud, key, snegLogger)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return nil, "", fmt.Errorf("failed to get serverless NEG %s: %w", name, err)
+	}
+	if existing == nil {
+		snegLogger.V(2).Info("Creating serverless NEG", "region", sneg.Spec.Region)
+		if err := composite.CreateRegionalNetworkEndpointGroup(c.cloud, key, expected, snegLogger); err != nil {
+			return nil, "", fmt.Errorf("failed to create serverless NEG %s: %w", name, err)
+		}
+		c.recorder(sneg.Namespace).Eventf(sneg, v1.EventTypeNormal, "ServerlessNEGCreated", "Serverless NEG %s was successfully created.", name)
+		// The id of the NEG is assigned by GCE.
+		if existing, err = composite.GetRegionalNetworkEndpointGroup(c.cloud, key, snegLogger); err != nil {
+			return nil, "", fmt.Errorf("failed to get serverless NEG %s: %w", name, err)
+		}
+		return existing, negLink, nil
+	}
+	if !targetEqual(existing, expected) {
+		return nil, "", fmt.Errorf("target of serverless NEG %s cannot be changed", name)
+	}
+	return existing, negLink, nil
+}
+
+// ensureBackendService ensures that the global backend service of the given
+// CR serves the serverless NEG with the given link and returns its link.
+// Fields of an existing backend service other than its backends, such as an
+// edge security policy set by the Ingress, are preserved.
+func (c *Controller) ensureBackendService(sneg *serverlessnegv1beta1.ServerlessNEG, name, negLink string, snegLogger klog.Logger) (string, error) {
+	key := meta.GlobalKey(name)
+	bsLink := cloud.SelfLink(meta.VersionGA, c.cloud.ProjectID(), "backendServices", key)
+
+	existing, err := composite.GetBackendService(c.cloud, key, meta.VersionGA, snegLogger)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return "", fmt.Errorf("failed to get backend service %s: %w", name, err)
+	}
+	if existing == nil {
+		expected, err := toBackendService(sneg, name, negLink)
+		if err != nil {
+			return "", err
+		}
+		snegLogger.V(2).Info("Creating backend service for serverless NEG")
+		if err := composite.CreateBackendService(c.cloud, key, expected, snegLogger); err != nil {
+			return "", fmt.Errorf("failed to create backend service %s: %w", name, err)
+		}
+		return bsLink, nil
+	}
+	if backendsEqual(existing, negLink) {
+		return bsLink, nil
+	}
+	snegLogger.V(2).Info("Updating backends of backend service for serverless NEG")
+	existing.Backends = []*composite.Backend{{Group: negLink}}
+	if err := composite.UpdateBackendService(c.cloud, key, existing, snegLogger); err != nil {
+		return "", fmt.Errorf("failed to update backend service %s: %w", name, err)
+	}
+	return bsLink, nil
+}
+
+// ensureSvcNeg reports the serverless NEG of the given CR in a
+// ServiceNetworkEndpointGroup CR with the name of the NEG, like the NEGs of
+// Services. The NEG CR is owned by the ServerlessNEG CR and is not garbage
+// collected by the NEG controller.
+func (c *Controller) ensureSvcNeg(sneg *serverlessnegv1beta1.ServerlessNEG, name string, neg *composite.NetworkEndpointGroup, negLink string) error {
+	if c.svcNegClient == nil {
+		return nil
+	}
+	ownerRef := metav1.NewControllerRef(sneg, serverlessnegv1beta1.SchemeGroupVersion.WithKind("ServerlessNEG"))
+	ownerRef.BlockOwnerDeletion = ptr.To(false)
+	negRefs := []negv1beta1.NegObjectReference{{
+		Id:                  fmt.Sprint(neg.Id),
+		SelfLink:            negLink,
+		NetworkEndpointType: negv1beta1.ServerlessEndpointType,
+	}}
+
+	svcNegs := c.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(sneg.Namespace)
+	svcNeg, err := svcNegs.Get(context2.Background(), name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		svcNeg, err = svcNegs.Create(context2.Background(), &negv1beta1.ServiceNetworkEndpointGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       sneg.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*ownerRef},
+				Labels:          map[string]string{negtypes.NegCRManagedByKey: negtypes.NegCRServerlessNEGControllerValue},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	if svcNeg.Labels[negtypes.NegCRManagedByKey] != negtypes.NegCRServerlessNEGControllerValue {
+		return fmt.Errorf("NEG CR %s/%s is not managed by the serverless NEG controller", sneg.Namespace, name)
+	}
+	if reflect.DeepEqual(svcNeg.Status.NetworkEndpointGroups, negRefs) {
+		return nil
+	}
+	updated := svcNeg.DeepCopy()
+	updated.Status.NetworkEndpointGroups = negRefs
+	updated.Status.LastSyncTime = metav1.Now()
+	_, err = svcNegs.UpdateStatus(context2.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteServerlessNEG deletes the backend service and serverless NEG of the
+// given CR and removes the finalizer. Deletion fails while the backend service
+// is used by a url map; it is retried every gc period until the Ingresses no
+// longer reference it.
+func (c *Controller) deleteServerlessNEG(sneg *serverlessnegv1beta1.ServerlessNEG, name string, snegLogger klog.Logger) error {
+	if !common.HasGivenFinalizer(sneg.ObjectMeta, common.ServerlessNEGFinalizerKey) {
+		return nil
+	}
+	snegLogger.V(2).Info("Deleting backend service for serverless NEG")
+	if err := utils.IgnoreHTTPNotFound(composite.DeleteBackendService(c.cloud, meta.GlobalKey(name), meta.VersionGA, snegLogger)); err != nil {
+		if utils.IsInUsedByError(err) {
+			return &crcontroller.InUseError{Resource: fmt.Sprintf("Backend service %s", name), Err: err}
+		}
+		return fmt.Errorf("failed to delete backend service %s: %w", name, err)
+	}
+	if sneg.Spec.Region != "" {
+		snegLogger.V(2).Info("Deleting serverless NEG", "region", sneg.Spec.Region)
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteRegionalNetworkEndpointGroup(c.cloud, meta.RegionalKey(name, sneg.Spec.Region), snegLogger)); err != nil {
+			return fmt.Errorf("failed to delete serverless NEG %s: %w", name, err)
+		}
+	}
+	if c.svcNegClient != nil {
+		err := c.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(sneg.Namespace).Delete(context2.Background(), name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete NEG CR %s/%s: %w", sneg.Namespace, name, err)
+		}
+	}
+	updated := sneg.DeepCopy()
+	updated.Finalizers = slice.RemoveString(updated.Finalizers, common.ServerlessNEGFinalizerKey, nil)
+	_, err := c.patch(sneg, updated)
+	return err
+}
+
+// ensureFinalizer ensures that the ServerlessNEG finalizer exists on the
+// given CR.
+func (c *Controller) ensureFinalizer(sneg *serverlessnegv1beta1.ServerlessNEG) (*serverlessnegv1beta1.ServerlessNEG, error) {
+	if common.HasGivenFinalizer(sneg.ObjectMeta, common.ServerlessNEGFinalizerKey) {
+		return sneg, nil
+	}
+	updated := sneg.DeepCopy()
+	updated.Finalizers = append(updated.Finalizers, common.ServerlessNEGFinalizerKey)
+	return c.patch(sneg, updated)
+}
+
+// updateStatus updates the status of the given CR with the links of its
+// serverless NEG and backend service.
+func (c *Controller) updateStatus(sneg *serverlessnegv1beta1.ServerlessNEG, negLink, bsLink string) error {
+	if sneg.Status.NetworkEndpointGroup == negLink && sneg.Status.BackendService == bsLink {
+		return nil
+	}
+	updated := sneg.DeepCopy()
+	updated.Status.NetworkEndpointGroup = negLink
+	updated.Status.BackendService = bsLink
+	updated.Status.LastSyncTime = metav1.Now()
+	_, err := c.client.NetworkingV1beta1().ServerlessNEGs(sneg.Namespace).UpdateStatus(context2.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// patch patches the original CR to the updated CR.
+func (c *Controller) patch(original, updated *serverlessnegv1beta1.ServerlessNEG) (*serverlessnegv1beta1.ServerlessNEG, error) {
+	patchBytes, err := patch.MergePatchBytes(original, updated)
+	if err != nil {
+		return original, err
+	}
+	return c.client.NetworkingV1beta1().ServerlessNEGs(original.Namespace).Patch(context2.Background(), updated.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+}
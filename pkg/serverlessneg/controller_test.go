@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlessneg
+
+import (
+	context2 "context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	serverlessnegfake "k8s.io/ingress-gce/pkg/serverlessneg/client/clientset/versioned/fake"
+	svcnegfake "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testRegion    = "us-central1"
+	kubeSystemUID = "kube-system-uid"
+)
+
+func newTestController() *Controller {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockBackendServices.UpdateHook = mock.UpdateBackendServiceHook
+	return &Controller{
+		cloud:        fakeGCE,
+		client:       serverlessnegfake.NewSimpleClientset(),
+		svcNegClient: svcnegfake.NewSimpleClientset(),
+		namer:        namer.NewServerlessNEGNamer(namer.NewNamer("cluster-uid", "", klog.TODO()), kubeSystemUID),
+		lister:       cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+		recorder:     func(string) record.EventRecorder { return record.NewFakeRecorder(100) },
+		logger:       klog.TODO(),
+	}
+}
+
+func TestProcessServerlessNEG(t *testing.T) {
+	c := newTestController()
+	snegs := c.client.NetworkingV1beta1().ServerlessNEGs(testNamespace)
+	test.CreateInLister(t, snegs, c.lister, &serverlessnegv1beta1.ServerlessNEG{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "run", UID: "sneg-uid"},
+		Spec: serverlessnegv1beta1.ServerlessNEGSpec{
+			Region:   testRegion,
+			CloudRun: &serverlessnegv1beta1.CloudRunTarget{Service: "hello"},
+		},
+	})
+	key := testNamespace + "/run"
+	name := c.namer.ServerlessNEG(testNamespace, "run", "sneg-uid")
+
+	if err := c.processServerlessNEG(key); err != nil {
+		t.Fatalf("processServerlessNEG(%s) = %v", key, err)
+	}
+	neg, err := composite.GetRegionalNetworkEndpointGroup(c.cloud, meta.RegionalKey(name, testRegion), klog.TODO())
+	if err != nil {
+		t.Fatalf("GetRegionalNetworkEndpointGroup(%s) = %v", name, err)
+	}
+	if neg.NetworkEndpointType != string(negtypes.ServerlessEndpointType) || neg.CloudRun == nil || neg.CloudRun.Service != "hello" {
+		t.Errorf("Got serverless NEG %+v, want SERVERLESS NEG for Cloud Run service hello", neg)
+	}
+	bs, err := composite.GetBackendService(c.cloud, meta.GlobalKey(name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatalf("GetBackendService(%s) = %v", name, err)
+	}
+	if len(bs.Backends) != 1 || !utils.EqualResourceIDs(bs.Backends[0].Group, neg.SelfLink) {
+		t.Errorf("Got backends %+v, want the serverless NEG %s", bs.Backends, neg.SelfLink)
+	}
+	if len(bs.HealthChecks) != 0 {
+		t.Errorf("Got health checks %v, want none for serverless NEGs", bs.HealthChecks)
+	}
+
+	svcNeg, err := c.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testNamespace).Get(context2.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get NEG CR %s = %v", name, err)
+	}
+	wantRefs := []negv1beta1.NegObjectReference{{Id: fmt.Sprint(neg.Id), SelfLink: neg.SelfLink, NetworkEndpointType: negv1beta1.ServerlessEndpointType}}
+	if diff := cmp.Diff(wantRefs, svcNeg.Status.NetworkEndpointGroups); diff != "" {
+		t.Errorf("Unexpected NEG CR status (-want +got):\n%s", diff)
+	}
+	if svcNeg.Labels[negtypes.NegCRManagedByKey] != negtypes.NegCRServerlessNEGControllerValue || len(svcNeg.OwnerReferences) != 1 || svcNeg.OwnerReferences[0].Name != "run" {
+		t.Errorf("Got NEG CR labels %v and owners %v, want managed by the serverless NEG controller and owned by the ServerlessNEG", svcNeg.Labels, svcNeg.OwnerReferences)
+	}
+
+	sneg := test.SyncLister(t, snegs, c.lister, "run")
+	if !common.HasGivenFinalizer(sneg.ObjectMeta, common.ServerlessNEGFinalizerKey) {
+		t.Errorf("Finalizer %s was not added, got %v", common.ServerlessNEGFinalizerKey, sneg.Finalizers)
+	}
+	if !utils.EqualResourceIDs(sneg.Status.NetworkEndpointGroup, neg.SelfLink) || !utils.EqualResourceIDs(sneg.Status.BackendService, bs.SelfLink) {
+		t.Errorf("Got status %+v, want links to %s and %s", sneg.Status, neg.SelfLink, bs.SelfLink)
+	}
+
+	// The target of a serverless NEG cannot be changed.
+	sneg = sneg.DeepCopy()
+	sneg.Spec.CloudRun.Service = "other"
+	if err := c.lister.Update(sneg); err != nil {
+		t.Fatalf("lister.Update(%s) = %v", sneg.Name, err)
+	}
+	if err := c.processServerlessNEG(key); err == nil {
+		t.Errorf("processServerlessNEG(%s) = nil, want error for a changed target", key)
+	}
+
+	sneg = test.SyncLister(t, snegs, c.lister, "run").DeepCopy()
+	now := metav1.Now()
+	sneg.DeletionTimestamp = &now
+	if err := c.lister.Update(sneg); err != nil {
+		t.Fatalf("lister.Update(%s) = %v", sneg.Name, err)
+	}
+	if err := c.processServerlessNEG(key); err != nil {
+		t.Fatalf("processServerlessNEG(%s) = %v", key, err)
+	}
+	if _, err := composite.GetBackendService(c.cloud, meta.GlobalKey(name), meta.VersionGA, klog.TODO()); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("GetBackendService(%s) = %v, want not found", name, err)
+	}
+	if _, err := composite.GetRegionalNetworkEndpointGroup(c.cloud, meta.RegionalKey(name, testRegion), klog.TODO()); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("GetRegionalNetworkEndpointGroup(%s) = %v, want not found", name, err)
+	}
+	if _, err := c.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testNamespace).Get(context2.TODO(), name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get NEG CR %s = %v, want not found", name, err)
+	}
+	sneg = test.SyncLister(t, snegs, c.lister, "run")
+	if common.HasGivenFinalizer(sneg.ObjectMeta, common.ServerlessNEGFinalizerKey) {
+		t.Errorf("Finalizer %s was not removed, got %v", common.ServerlessNEGFinalizerKey, sneg.Finalizers)
+	}
+}
+
+func TestToNetworkEndpointGroupValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc    string
+		spec    serverlessnegv1beta1.ServerlessNEGSpec
+		wantErr bool
+	}{
+		{
+			desc: "app engine",
+			spec: serverlessnegv1beta1.ServerlessNEGSpec{Region: testRegion, AppEngine: &serverlessnegv1beta1.AppEngineTarget{}},
+		},
+		{
+			desc:    "missing region",
+			spec:    serverlessnegv1beta1.ServerlessNEGSpec{CloudFunction: &serverlessnegv1beta1.CloudFunctionTarget{Function: "fn"}},
+			wantErr: true,
+		},
+		{
+			desc:    "no target",
+			spec:    serverlessnegv1beta1.ServerlessNEGSpec{Region: testRegion},
+			wantErr: true,
+		},
+		{
+			desc: "multiple targets",
+			spec: serverlessnegv1beta1.ServerlessNEGSpec{
+				Region:        testRegion,
+				CloudRun:      &serverlessnegv1beta1.CloudRunTarget{Service: "hello"},
+				CloudFunction: &serverlessnegv1beta1.CloudFunctionTarget{Function: "fn"},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sneg := &serverlessnegv1beta1.ServerlessNEG{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "sneg"},
+				Spec:       tc.spec,
+			}
+			_, err := toNetworkEndpointGroup(sneg, "name")
+			if (err != nil) != tc.wantErr {
+				t.Errorf("toNetworkEndpointGroup() = %v, want error? %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlessneg
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/annotations"
+	apisserverlessneg "k8s.io/ingress-gce/pkg/apis/serverlessneg"
+	serverlessnegv1beta1 "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/crd"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisserverlessneg.GroupName,
+		apisserverlessneg.Kind,
+		"ServerlessNEGList",
+		"serverlessneg",
+		"serverlessnegs",
+		[]*crd.Version{
+			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/serverlessneg/v1beta1.ServerlessNEG", serverlessnegv1beta1.GetOpenAPIDefinitions, false),
+		},
+	)
+	return meta
+}
+
+// description is stored in the description of the serverless NEG and its
+// backend service to identify the ServerlessNEG CR that manages them.
+type description struct {
+	ServerlessNEG string `json:"networking.gke.io/serverless-neg"`
+}
+
+func toDescription(sneg *serverlessnegv1beta1.ServerlessNEG) (string, error) {
+	desc, err := json.Marshal(description{ServerlessNEG: fmt.Sprintf("%s/%s", sneg.Namespace, sneg.Name)})
+	if err != nil {
+		return "", err
+	}
+	return string(desc), nil
+}
+
+// validate returns an error if the spec of the given ServerlessNEG does not
+// set a region and exactly one target.
+func validate(sneg *serverlessnegv1beta1.ServerlessNEG) error {
+	if sneg.Spec.Region == "" {
+		return fmt.Errorf("region must be set")
+	}
+	targets := 0
+	if sneg.Spec.CloudRun != nil {
+		targets++
+	}
+	if sneg.Spec.AppEngine != nil {
+		targets++
+	}
+	if sneg.Spec.CloudFunction != nil {
+		targets++
+	}
+	if targets != 1 {
+		return fmt.Errorf("exactly one of cloudRun, appEngine and cloudFunction must be set, got %d", targets)
+	}
+	return nil
+}
+
+// toNetworkEndpointGroup returns the serverless NEG with the given name that
+// is expected for the given ServerlessNEG CR.
+func toNetworkEndpointGroup(sneg *serverlessnegv1beta1.ServerlessNEG, name string) (*composite.NetworkEndpointGroup, error) {
+	if err := validate(sneg); err != nil {
+		return nil, err
+	}
+	desc, err := toDescription(sneg)
+	if err != nil {
+		return nil, err
+	}
+	neg := &composite.NetworkEndpointGroup{
+		Version:             meta.VersionGA,
+		Scope:               meta.Regional,
+		Name:                name,
+		Description:         desc,
+		NetworkEndpointType: string(negtypes.ServerlessEndpointType),
+		Region:              sneg.Spec.Region,
+	}
+	switch {
+	case sneg.Spec.CloudRun != nil:
+		neg.CloudRun = &composite.NetworkEndpointGroupCloudRun{
+			Service: sneg.Spec.CloudRun.Service,
+			Tag:     sneg.Spec.CloudRun.Tag,
+			UrlMask: sneg.Spec.CloudRun.UrlMask,
+		}
+	case sneg.Spec.AppEngine != nil:
+		neg.AppEngine = &composite.NetworkEndpointGroupAppEngine{
+			Service: sneg.Spec.AppEngine.Service,
+			Version: sneg.Spec.AppEngine.Version,
+			UrlMask: sneg.Spec.AppEngine.UrlMask,
+		}
+	case sneg.Spec.CloudFunction != nil:
+		neg.CloudFunction = &composite.NetworkEndpointGroupCloudFunction{
+			Function: sneg.Spec.CloudFunction.Function,
+			UrlMask:  sneg.Spec.CloudFunction.UrlMask,
+		}
+	}
+	return neg, nil
+}
+
+// targetEqual returns true if both serverless NEGs target the same serverless
+// application. The target of a NEG cannot be updated.
+func targetEqual(a, b *composite.NetworkEndpointGroup) bool {
+	switch {
+	case a.CloudRun != nil && b.CloudRun != nil:
+		return a.CloudRun.Service == b.CloudRun.Service && a.CloudRun.Tag == b.CloudRun.Tag && a.CloudRun.UrlMask == b.CloudRun.UrlMask
+	case a.AppEngine != nil && b.AppEngine != nil:
+		return a.AppEngine.Service == b.AppEngine.Service && a.AppEngine.Version == b.AppEngine.Version && a.AppEngine.UrlMask == b.AppEngine.UrlMask
+	case a.CloudFunction != nil && b.CloudFunction != nil:
+		return a.CloudFunction.Function == b.CloudFunction.Function && a.CloudFunction.UrlMask == b.CloudFunction.UrlMask
+	}
+	return false
+}
+
+// toBackendService returns the global backend service with the given name
+// that serves the serverless NEG with the given link. Serverless NEGs do not
+// support health checks, named ports or balancing modes.
+func toBackendService(sneg *serverlessnegv1beta1.ServerlessNEG, name, negLink string) (*composite.BackendService, error) {
+	desc, err := toDescription(sneg)
+	if err != nil {
+		return nil, err
+	}
+	return &composite.BackendService{
+		Version:             meta.VersionGA,
+		Scope:               meta.Global,
+		Name:                name,
+		Description:         desc,
+		Protocol:            string(annotations.ProtocolHTTPS),
+		LoadBalancingScheme: string(cloud.SchemeExternal),
+		Backends:            []*composite.Backend{{Group: negLink}},
+	}, nil
+}
+
+// backendsEqual returns true if the existing backend service only serves the
+// serverless NEG with the given link.
+func backendsEqual(existing *composite.BackendService, negLink string) bool {
+	if len(existing.Backends) != 1 {
+		return false
+	}
+	return utils.EqualResourceIDs(existing.Backends[0].Group, negLink)
+}
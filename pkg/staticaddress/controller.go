@@ -0,0 +1,398 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package staticaddress
+
+import (
+	context2 "context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/annotations"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
+	"k8s.io/ingress-gce/pkg/context"
+	staticaddressclient "k8s.io/ingress-gce/pkg/staticaddress/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+	"k8s.io/ingress-gce/pkg/utils/slice"
+	"k8s.io/klog/v2"
+)
+
+// cloudAddresses is the subset of the compute API used to manage static
+// addresses.
+type cloudAddresses interface {
+	Get(name string, global bool) (*compute.Address, error)
+	Reserve(addr *compute.Address, global bool) error
+	Delete(name string, global bool) error
+}
+
+// gceAddresses implements cloudAddresses with the compute API.
+type gceAddresses struct {
+	cloud *gce.Cloud
+}
+
+func (g *gceAddresses) Get(name string, global bool) (*compute.Address, error) {
+	if global {
+		return g.cloud.GetGlobalAddress(name)
+	}
+	return g.cloud.GetRegionAddress(name, g.cloud.Region())
+}
+
+func (g *gceAddresses) Reserve(addr *compute.Address, global bool) error {
+	if global {
+		return g.cloud.ReserveGlobalAddress(addr)
+	}
+	return g.cloud.ReserveRegionAddress(addr, g.cloud.Region())
+}
+
+func (g *gceAddresses) Delete(name string, global bool) error {
+	if global {
+		return g.cloud.DeleteGlobalAddress(name)
+	}
+	return g.cloud.DeleteRegionAddress(name, g.cloud.Region())
+}
+
+// Controller manages the compute Addresses of StaticAddress CRs, and tracks
+// the Services and Ingresses that use them.
+type Controller struct {
+	cloud         cloudAddresses
+	region        string
+	subnetwork    string
+	client        staticaddressclient.Interface
+	queue         workqueue.RateLimitingInterface
+	namer         namer.StaticAddressNamer
+	lister        cache.Indexer
+	serviceLister cache.Indexer
+	ingressLister cache.Indexer
+	recorder      func(string) record.EventRecorder
+
+	hasSynced func() bool
+	stopCh    <-chan struct{}
+
+	logger klog.Logger
+}
+
+// NewController returns a controller that manages the compute Addresses of
+// StaticAddress CRs.
+func NewController(ctx *context.ControllerContext, stopCh <-chan struct{}, logger klog.Logger) *Controller {
+	logger = logger.WithName("StaticAddressController")
+	c := &Controller{
+		cloud:         &gceAddresses{cloud: ctx.Cloud},
+		region:        ctx.Cloud.Region(),
+		subnetwork:    ctx.Cloud.SubnetworkURL(),
+		client:        ctx.StaticAddressClient,
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		namer:         namer.NewStaticAddressNamer(ctx.ClusterNamer, string(ctx.KubeSystemUID)),
+		lister:        ctx.StaticAddressInformer.GetIndexer(),
+		serviceLister: ctx.ServiceInformer.GetIndexer(),
+		ingressLister: ctx.IngressInformer.GetIndexer(),
+		recorder:      ctx.Recorder,
+		hasSynced:     ctx.HasSynced,
+		stopCh:        stopCh,
+		logger:        logger,
+	}
+	ctx.StaticAddressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(old, cur interface{}) {
+			oldSA := old.(*staticaddressv1beta1.StaticAddress)
+			curSA := cur.(*staticaddressv1beta1.StaticAddress)
+			// Status updates by this controller do not change the generation.
+			if oldSA.Generation != curSA.Generation || !curSA.DeletionTimestamp.IsZero() {
+				c.enqueue(cur)
+			}
+		},
+	})
+	// The consumers of a StaticAddress are recomputed whenever a Service or
+	// Ingress that references it changes.
+	consumerHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueReferenced,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueReferenced(old)
+			c.enqueueReferenced(cur)
+		},
+		DeleteFunc: c.enqueueReferenced,
+	}
+	ctx.ServiceInformer.AddEventHandler(consumerHandler)
+	ctx.IngressInformer.AddEventHandler(consumerHandler)
+	return c
+}
+
+// Run waits for the initial sync and processes keys in the queue until
+// signaled.
+func (c *Controller) Run() {
+	wait.PollUntil(5*time.Second, func() (bool, error) {
+		c.logger.V(2).Info("Waiting for initial sync")
+		return c.hasSynced(), nil
+	}, c.stopCh)
+
+	c.logger.V(2).Info("Starting static address controller")
+	defer func() {
+		c.logger.V(2).Info("Shutting down static address controller")
+		c.queue.ShutDown()
+	}()
+
+	go wait.Until(c.worker, time.Second, c.stopCh)
+
+	<-c.stopCh
+}
+
+// worker keeps processing static address keys in the queue until the queue is
+// shut down.
+func (c *Controller) worker() {
+	for {
+		key, quit := c.queue.Get()
+		if quit {
+			return
+		}
+		err := c.processStaticAddress(key.(string))
+		c.handleErr(err, key)
+		c.queue.Done(key)
+	}
+}
+
+// handleErr requeues the key and reports the error as an event on the
+// StaticAddress CR.
+func (c *Controller) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+	eventMsg := fmt.Sprintf("error processing static address %q: %q", key, err)
+	c.logger.Error(err, eventMsg)
+	if obj, exists, err := c.lister.GetByKey(key.(string)); err != nil {
+		c.logger.Info("failed to retrieve static address from the store", "staticAddressKey", key.(string), "err", err)
+	} else if exists {
+		sa := obj.(*staticaddressv1beta1.StaticAddress)
+		c.recorder(sa.Namespace).Eventf(sa, v1.EventTypeWarning, "ProcessStaticAddressFailed", eventMsg)
+	}
+	c.queue.AddRateLimited(key)
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		c.logger.Error(err, "Failed to generate static address key")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueReferenced enqueues the StaticAddress referenced by the given
+// Service or Ingress, if any.
+func (c *Controller) enqueueReferenced(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	var namespace, name string
+	switch o := obj.(type) {
+	case *v1.Service:
+		namespace, name = o.Namespace, annotations.FromService(o).StaticAddress()
+	case *networkingv1.Ingress:
+		namespace, name = o.Namespace, annotations.FromIngress(o).StaticAddress()
+	}
+	if name == "" {
+		return
+	}
+	c.queue.Add(fmt.Sprintf("%s/%s", namespace, name))
+}
+
+// processStaticAddress ensures that the compute Address of the StaticAddress
+// CR with the given key is reserved and that its status is up to date, or
+// that it is released if the CR is being deleted and no longer in use.
+func (c *Controller) processStaticAddress(key string) error {
+	obj, exists, err := c.lister.GetByKey(key)
+	if err != nil {
+		return fmt.Errorf("errored getting static address from store: %w", err)
+	}
+	if !exists {
+		// The finalizer ensures that the compute Address was released.
+		return nil
+	}
+	sa := obj.(*staticaddressv1beta1.StaticAddress)
+	name := c.namer.StaticAddress(sa.Namespace, sa.Name)
+	saLogger := c.logger.WithValues("staticAddressKey", key, "addressName", name)
+
+	consumers, err := c.consumers(sa)
+	if err != nil {
+		return err
+	}
+
+	if !sa.DeletionTimestamp.IsZero() {
+		return c.deleteStaticAddress(sa, name, consumers, saLogger)
+	}
+
+	if err := validate(sa); err != nil {
+		return utils.NewUserError(fmt.Errorf("invalid StaticAddress %s: %w", key, err))
+	}
+
+	sa, err = c.ensureFinalizer(sa)
+	if err != nil {
+		return fmt.Errorf("errored adding finalizer on StaticAddress CR %s: %w", key, err)
+	}
+
+	existing, err := c.cloud.Get(name, sa.Spec.Global)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return fmt.Errorf("failed to get compute Address %s: %w", name, err)
+	}
+	if existing == nil {
+		expected, err := toComputeAddress(sa, name, c.subnetwork)
+		if err != nil {
+			return err
+		}
+		saLogger.V(2).Info("Reserving static address")
+		if err := c.cloud.Reserve(expected, sa.Spec.Global); err != nil {
+			return fmt.Errorf("failed to reserve compute Address %s: %w", name, err)
+		}
+		if existing, err = c.cloud.Get(name, sa.Spec.Global); err != nil {
+			return fmt.Errorf("failed to get compute Address %s: %w", name, err)
+		}
+		c.recorder(sa.Namespace).Eventf(sa, v1.EventTypeNormal, "StaticAddressReserved", "Static address %s was successfully reserved with IP %s.", name, existing.Address)
+	} else if sa.Spec.Address != "" && sa.Spec.Address != existing.Address {
+		// Compute Addresses are immutable, the CR has to be recreated with
+		// the Delete reclaim policy to change the IP.
+		c.recorder(sa.Namespace).Eventf(sa, v1.EventTypeWarning, "StaticAddressMismatch", "Static address %s has IP %s instead of %s, addresses cannot be changed once reserved.", name, existing.Address, sa.Spec.Address)
+	}
+
+	status := staticaddressv1beta1.StaticAddressStatus{
+		AddressName: name,
+		Address:     existing.Address,
+		NetworkTier: existing.NetworkTier,
+		SelfLink:    existing.SelfLink,
+		Consumers:   consumers,
+	}
+	if !sa.Spec.Global {
+		status.Region = c.region
+	}
+	return c.updateStatus(sa, status)
+}
+
+// deleteStaticAddress releases the compute Address of the given CR, unless
+// it is retained, and removes the finalizer. The CR is kept while it has
+// consumers, and is processed again when they stop referencing it.
+func (c *Controller) deleteStaticAddress(sa *staticaddressv1beta1.StaticAddress, name string, consumers []staticaddressv1beta1.StaticAddressConsumer, saLogger klog.Logger) error {
+	if !common.HasGivenFinalizer(sa.ObjectMeta, common.StaticAddressFinalizerKey) {
+		return nil
+	}
+	if len(consumers) > 0 {
+		saLogger.V(2).Info("Static address is still in use, not deleting it", "consumers", consumers)
+		c.recorder(sa.Namespace).Eventf(sa, v1.EventTypeWarning, "StaticAddressInUse", "Static address %s cannot be deleted while it is used by %s.", name, consumersString(consumers))
+		status := sa.Status
+		status.Consumers = consumers
+		return c.updateStatus(sa, status)
+	}
+	if sa.Spec.ReclaimPolicy == staticaddressv1beta1.ReclaimPolicyRetain {
+		saLogger.V(2).Info("Retaining static address")
+	} else {
+		saLogger.V(2).Info("Releasing static address")
+		if err := utils.IgnoreHTTPNotFound(c.cloud.Delete(name, sa.Spec.Global)); err != nil {
+			return fmt.Errorf("failed to release compute Address %s: %w", name, err)
+		}
+	}
+	updated := sa.DeepCopy()
+	updated.Finalizers = slice.RemoveString(updated.Finalizers, common.StaticAddressFinalizerKey, nil)
+	_, err := c.patch(sa, updated)
+	return err
+}
+
+// consumers returns the LoadBalancer Services and the Ingresses in the
+// namespace of the given CR that reference it, sorted by kind and name.
+func (c *Controller) consumers(sa *staticaddressv1beta1.StaticAddress) ([]staticaddressv1beta1.StaticAddressConsumer, error) {
+	var consumers []staticaddressv1beta1.StaticAddressConsumer
+	services, err := c.serviceLister.ByIndex(cache.NamespaceIndex, sa.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in namespace %s: %w", sa.Namespace, err)
+	}
+	for _, obj := range services {
+		svc := obj.(*v1.Service)
+		if svc.Spec.Type == v1.ServiceTypeLoadBalancer && annotations.FromService(svc).StaticAddress() == sa.Name {
+			consumers = append(consumers, staticaddressv1beta1.StaticAddressConsumer{Kind: staticaddressv1beta1.ConsumerKindService, Name: svc.Name})
+		}
+	}
+	ingresses, err := c.ingressLister.ByIndex(cache.NamespaceIndex, sa.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses in namespace %s: %w", sa.Namespace, err)
+	}
+	for _, obj := range ingresses {
+		ing := obj.(*networkingv1.Ingress)
+		if annotations.FromIngress(ing).StaticAddress() == sa.Name {
+			consumers = append(consumers, staticaddressv1beta1.StaticAddressConsumer{Kind: staticaddressv1beta1.ConsumerKindIngress, Name: ing.Name})
+		}
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		if consumers[i].Kind != consumers[j].Kind {
+			return consumers[i].Kind < consumers[j].Kind
+		}
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers, nil
+}
+
+func consumersString(consumers []staticaddressv1beta1.StaticAddressConsumer) string {
+	var ret []string
+	for _, consumer := range consumers {
+		ret = append(ret, fmt.Sprintf("%s %s", consumer.Kind, consumer.Name))
+	}
+	return strings.Join(ret, ", ")
+}
+
+// ensureFinalizer ensures that the StaticAddress finalizer exists on the
+// given CR.
+func (c *Controller) ensureFinalizer(sa *staticaddressv1beta1.StaticAddress) (*staticaddressv1beta1.StaticAddress, error) {
+	if common.HasGivenFinalizer(sa.ObjectMeta, common.StaticAddressFinalizerKey) {
+		return sa, nil
+	}
+	updated := sa.DeepCopy()
+	updated.Finalizers = append(updated.Finalizers, common.StaticAddressFinalizerKey)
+	return c.patch(sa, updated)
+}
+
+// updateStatus updates the status of the given CR, unless only the last sync
+// time would change.
+func (c *Controller) updateStatus(sa *staticaddressv1beta1.StaticAddress, status staticaddressv1beta1.StaticAddressStatus) error {
+	status.LastSyncTime = sa.Status.LastSyncTime
+	if reflect.DeepEqual(sa.Status, status) {
+		return nil
+	}
+	updated := sa.DeepCopy()
+	updated.Status = status
+	updated.Status.LastSyncTime = metav1.Now()
+	_, err := c.client.NetworkingV1beta1().StaticAddresses(sa.Namespace).UpdateStatus(context2.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// patch patches the original CR to the updated CR.
+func (c *Controller) patch(original, updated *staticaddressv1beta1.StaticAddress) (*staticaddressv1beta1.StaticAddress, error) {
+	patchBytes, err := patch.MergePatchBytes(original, updated)
+	if err != nil {
+		return original, err
+	}
+	return c.client.NetworkingV1beta1().StaticAddresses(original.Namespace).Patch(context2.Background(), updated.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package staticaddress
+
+import (
+	context2 "context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/ingress-gce/pkg/annotations"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
+	staticaddressfake "k8s.io/ingress-gce/pkg/staticaddress/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testRegion    = "us-central1"
+	kubeSystemUID = "kube-system-uid"
+)
+
+// fakeAddresses is an in-memory cloudAddresses that allocates sequential
+// IPs.
+type fakeAddresses struct {
+	addresses map[string]*compute.Address
+	inUse     bool
+	next      int
+}
+
+func (f *fakeAddresses) key(name string, global bool) string {
+	if global {
+		return "global/" + name
+	}
+	return testRegion + "/" + name
+}
+
+func (f *fakeAddresses) Get(name string, global bool) (*compute.Address, error) {
+	addr, ok := f.addresses[f.key(name, global)]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return addr, nil
+}
+
+func (f *fakeAddresses) Reserve(addr *compute.Address, global bool) error {
+	copied := *addr
+	addr = &copied
+	if addr.Address == "" {
+		f.next++
+		addr.Address = fmt.Sprintf("10.0.0.%d", f.next)
+	}
+	if addr.NetworkTier == "" && addr.AddressType == string(cloud.SchemeExternal) {
+		addr.NetworkTier = cloud.NetworkTierPremium.ToGCEValue()
+	}
+	addr.SelfLink = "https://www.googleapis.com/compute/v1/projects/mock-project/" + f.key(addr.Name, global)
+	f.addresses[f.key(addr.Name, global)] = addr
+	return nil
+}
+
+func (f *fakeAddresses) Delete(name string, global bool) error {
+	if f.inUse {
+		return &googleapi.Error{Code: http.StatusBadRequest, Message: "resourceInUseByAnotherResource"}
+	}
+	if _, ok := f.addresses[f.key(name, global)]; !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+	delete(f.addresses, f.key(name, global))
+	return nil
+}
+
+func newTestController() (*Controller, *fakeAddresses) {
+	fakeCloud := &fakeAddresses{addresses: map[string]*compute.Address{}}
+	return &Controller{
+		cloud:         fakeCloud,
+		region:        testRegion,
+		subnetwork:    "default-subnet",
+		client:        staticaddressfake.NewSimpleClientset(),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		namer:         namer.NewStaticAddressNamer(namer.NewNamer("cluster-uid", "", klog.TODO()), kubeSystemUID),
+		lister:        cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+		serviceLister: cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer()),
+		ingressLister: cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer()),
+		recorder:      func(string) record.EventRecorder { return record.NewFakeRecorder(100) },
+		logger:        klog.TODO(),
+	}, fakeCloud
+}
+
+// markDeleted sets the deletion timestamp of the CR in the lister.
+func markDeleted(t *testing.T, c *Controller, namespace, name string) {
+	t.Helper()
+	sa := test.SyncLister(t, c.client.NetworkingV1beta1().StaticAddresses(namespace), c.lister, name).DeepCopy()
+	now := metav1.Now()
+	sa.DeletionTimestamp = &now
+	if err := c.lister.Update(sa); err != nil {
+		t.Fatalf("lister.Update(%s) = %v", sa.Name, err)
+	}
+}
+
+func loadBalancerService(name, staticAddress string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Annotations: map[string]string{annotations.StaticAddressKey: staticAddress}},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+	}
+}
+
+func TestProcessStaticAddress(t *testing.T) {
+	c, fakeCloud := newTestController()
+	addresses := c.client.NetworkingV1beta1().StaticAddresses(testNamespace)
+	test.CreateInLister(t, addresses, c.lister, &staticaddressv1beta1.StaticAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ip"},
+	})
+	key := testNamespace + "/ip"
+	name := c.namer.StaticAddress(testNamespace, "ip")
+
+	c.serviceLister.Add(loadBalancerService("svc", "ip"))
+	c.serviceLister.Add(loadBalancerService("other-svc", "other"))
+	clusterIPSvc := loadBalancerService("cluster-ip", "ip")
+	clusterIPSvc.Spec.Type = v1.ServiceTypeClusterIP
+	c.serviceLister.Add(clusterIPSvc)
+	c.ingressLister.Add(&networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ing", Annotations: map[string]string{annotations.StaticAddressKey: "ip"}},
+	})
+
+	if err := c.processStaticAddress(key); err != nil {
+		t.Fatalf("processStaticAddress(%s) = %v", key, err)
+	}
+	got, err := fakeCloud.Get(name, false)
+	if err != nil {
+		t.Fatalf("Get(%s) = %v", name, err)
+	}
+	if got.AddressType != string(cloud.SchemeExternal) {
+		t.Errorf("Got compute Address %+v, want EXTERNAL address", got)
+	}
+	sa := test.SyncLister(t, addresses, c.lister, "ip")
+	if !common.HasGivenFinalizer(sa.ObjectMeta, common.StaticAddressFinalizerKey) {
+		t.Errorf("Finalizer %s was not added, got %v", common.StaticAddressFinalizerKey, sa.Finalizers)
+	}
+	wantStatus := staticaddressv1beta1.StaticAddressStatus{
+		AddressName: name,
+		Address:     got.Address,
+		Region:      testRegion,
+		NetworkTier: cloud.NetworkTierPremium.ToGCEValue(),
+		SelfLink:    got.SelfLink,
+		Consumers: []staticaddressv1beta1.StaticAddressConsumer{
+			{Kind: staticaddressv1beta1.ConsumerKindIngress, Name: "ing"},
+			{Kind: staticaddressv1beta1.ConsumerKindService, Name: "svc"},
+		},
+		LastSyncTime: sa.Status.LastSyncTime,
+	}
+	if diff := cmp.Diff(wantStatus, sa.Status); diff != "" {
+		t.Errorf("Status mismatch (-want +got):\n%s", diff)
+	}
+
+	// The address is adopted again after the CR is processed again.
+	if err := c.processStaticAddress(key); err != nil {
+		t.Fatalf("processStaticAddress(%s) = %v", key, err)
+	}
+	if again, _ := fakeCloud.Get(name, false); again.Address != got.Address {
+		t.Errorf("Address changed from %s to %s", got.Address, again.Address)
+	}
+}
+
+func TestProcessStaticAddressInternal(t *testing.T) {
+	c, fakeCloud := newTestController()
+	test.CreateInLister(t, c.client.NetworkingV1beta1().StaticAddresses(testNamespace), c.lister, &staticaddressv1beta1.StaticAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ip"},
+		Spec:       staticaddressv1beta1.StaticAddressSpec{AddressType: string(cloud.SchemeInternal), Address: "10.1.2.3"},
+	})
+	key := testNamespace + "/ip"
+	name := c.namer.StaticAddress(testNamespace, "ip")
+
+	if err := c.processStaticAddress(key); err != nil {
+		t.Fatalf("processStaticAddress(%s) = %v", key, err)
+	}
+	got, err := fakeCloud.Get(name, false)
+	if err != nil {
+		t.Fatalf("Get(%s) = %v", name, err)
+	}
+	if got.Address != "10.1.2.3" || got.Subnetwork != "default-subnet" || got.NetworkTier != "" {
+		t.Errorf("Got compute Address %+v, want 10.1.2.3 in default-subnet", got)
+	}
+}
+
+func TestProcessStaticAddressInvalid(t *testing.T) {
+	c, fakeCloud := newTestController()
+	test.CreateInLister(t, c.client.NetworkingV1beta1().StaticAddresses(testNamespace), c.lister, &staticaddressv1beta1.StaticAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ip"},
+		Spec:       staticaddressv1beta1.StaticAddressSpec{AddressType: string(cloud.SchemeInternal), Global: true},
+	})
+	key := testNamespace + "/ip"
+
+	if err := c.processStaticAddress(key); !utils.IsUserError(err) {
+		t.Errorf("processStaticAddress(%s) = %v, want user error", key, err)
+	}
+	if len(fakeCloud.addresses) != 0 {
+		t.Errorf("Got addresses %v, want none", fakeCloud.addresses)
+	}
+}
+
+func TestDeleteStaticAddress(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		reclaimPolicy string
+		wantReleased  bool
+	}{
+		{desc: "default reclaim policy", wantReleased: true},
+		{desc: "delete reclaim policy", reclaimPolicy: staticaddressv1beta1.ReclaimPolicyDelete, wantReleased: true},
+		{desc: "retain reclaim policy", reclaimPolicy: staticaddressv1beta1.ReclaimPolicyRetain, wantReleased: false},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, fakeCloud := newTestController()
+			test.CreateInLister(t, c.client.NetworkingV1beta1().StaticAddresses(testNamespace), c.lister, &staticaddressv1beta1.StaticAddress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ip"},
+				Spec:       staticaddressv1beta1.StaticAddressSpec{Global: true, ReclaimPolicy: tc.reclaimPolicy},
+			})
+			key := testNamespace + "/ip"
+			name := c.namer.StaticAddress(testNamespace, "ip")
+			svc := loadBalancerService("svc", "ip")
+			c.serviceLister.Add(svc)
+
+			if err := c.processStaticAddress(key); err != nil {
+				t.Fatalf("processStaticAddress(%s) = %v", key, err)
+			}
+
+			// Deletion is blocked while the address has consumers.
+			markDeleted(t, c, testNamespace, "ip")
+			if err := c.processStaticAddress(key); err != nil {
+				t.Fatalf("processStaticAddress(%s) = %v", key, err)
+			}
+			if _, err := fakeCloud.Get(name, true); err != nil {
+				t.Errorf("Static address %s was released while in use", name)
+			}
+			sa, _ := c.client.NetworkingV1beta1().StaticAddresses(testNamespace).Get(context2.TODO(), "ip", metav1.GetOptions{})
+			if !common.HasGivenFinalizer(sa.ObjectMeta, common.StaticAddressFinalizerKey) {
+				t.Errorf("Finalizer %s was removed while in use, got %v", common.StaticAddressFinalizerKey, sa.Finalizers)
+			}
+
+			// Deletion is retried while the address is used by a forwarding rule.
+			c.serviceLister.Delete(svc)
+			fakeCloud.inUse = true
+			if err := c.processStaticAddress(key); tc.wantReleased && err == nil {
+				t.Errorf("processStaticAddress(%s) = nil, want error while the address is in use", key)
+			}
+
+			fakeCloud.inUse = false
+			if err := c.processStaticAddress(key); err != nil {
+				t.Fatalf("processStaticAddress(%s) = %v", key, err)
+			}
+			_, err := fakeCloud.Get(name, true)
+			if released := utils.IsHTTPErrorCode(err, http.StatusNotFound); released != tc.wantReleased {
+				t.Errorf("Get(%s) = %v, want released %v", name, err, tc.wantReleased)
+			}
+			sa, _ = c.client.NetworkingV1beta1().StaticAddresses(testNamespace).Get(context2.TODO(), "ip", metav1.GetOptions{})
+			if common.HasGivenFinalizer(sa.ObjectMeta, common.StaticAddressFinalizerKey) {
+				t.Errorf("Finalizer %s was not removed, got %v", common.StaticAddressFinalizerKey, sa.Finalizers)
+			}
+		})
+	}
+}
+
+func TestAddressFor(t *testing.T) {
+	lister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, sa := range []*staticaddressv1beta1.StaticAddress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "regional-external"},
+			Status:     staticaddressv1beta1.StaticAddressStatus{AddressName: "k8s1-ip-a", Address: "1.2.3.4"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "internal"},
+			Spec:       staticaddressv1beta1.StaticAddressSpec{AddressType: string(cloud.SchemeInternal)},
+			Status:     staticaddressv1beta1.StaticAddressStatus{AddressName: "k8s1-ip-b", Address: "10.0.0.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "global"},
+			Spec:       staticaddressv1beta1.StaticAddressSpec{Global: true},
+			Status:     staticaddressv1beta1.StaticAddressStatus{AddressName: "k8s1-ip-c", Address: "1.2.3.5"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pending"},
+		},
+	} {
+		lister.Add(sa)
+	}
+
+	for _, tc := range []struct {
+		desc          string
+		name          string
+		global        bool
+		addrType      cloud.LbScheme
+		wantAddress   string
+		wantErr       bool
+		wantUserError bool
+	}{
+		{desc: "regional external", name: "regional-external", addrType: cloud.SchemeExternal, wantAddress: "1.2.3.4"},
+		{desc: "internal", name: "internal", addrType: cloud.SchemeInternal, wantAddress: "10.0.0.1"},
+		{desc: "global", name: "global", global: true, addrType: cloud.SchemeExternal, wantAddress: "1.2.3.5"},
+		{desc: "not found", name: "missing", addrType: cloud.SchemeExternal, wantErr: true, wantUserError: true},
+		{desc: "global instead of regional", name: "global", addrType: cloud.SchemeExternal, wantErr: true, wantUserError: true},
+		{desc: "regional instead of global", name: "regional-external", global: true, addrType: cloud.SchemeExternal, wantErr: true, wantUserError: true},
+		{desc: "wrong address type", name: "internal", addrType: cloud.SchemeExternal, wantErr: true, wantUserError: true},
+		{desc: "not reserved yet", name: "pending", addrType: cloud.SchemeExternal, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sa, err := AddressFor(lister, testNamespace, tc.name, tc.global, tc.addrType)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("AddressFor(%s) = %v, want error %v", tc.name, err, tc.wantErr)
+			}
+			if err != nil {
+				if utils.IsUserError(err) != tc.wantUserError {
+					t.Errorf("AddressFor(%s) = %v, want user error %v", tc.name, err, tc.wantUserError)
+				}
+				return
+			}
+			if sa.Status.Address != tc.wantAddress {
+				t.Errorf("AddressFor(%s) returned address %s, want %s", tc.name, sa.Status.Address, tc.wantAddress)
+			}
+		})
+	}
+}
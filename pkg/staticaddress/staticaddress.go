@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package staticaddress
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/tools/cache"
+	apisstaticaddress "k8s.io/ingress-gce/pkg/apis/staticaddress"
+	staticaddressv1beta1 "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1"
+	"k8s.io/ingress-gce/pkg/crd"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisstaticaddress.GroupName,
+		apisstaticaddress.Kind,
+		"StaticAddressList",
+		"staticaddress",
+		"staticaddresses",
+		[]*crd.Version{
+			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/staticaddress/v1beta1.StaticAddress", staticaddressv1beta1.GetOpenAPIDefinitions, false),
+		},
+	)
+	return meta
+}
+
+// description is stored in the description of the compute Address to
+// identify the StaticAddress CR that manages it.
+type description struct {
+	StaticAddress string `json:"networking.gke.io/static-address"`
+}
+
+// addressType returns the GCE address type of the given CR.
+func addressType(sa *staticaddressv1beta1.StaticAddress) string {
+	if sa.Spec.AddressType == "" {
+		return string(cloud.SchemeExternal)
+	}
+	return sa.Spec.AddressType
+}
+
+// validate returns an error if the spec of the given CR is invalid.
+func validate(sa *staticaddressv1beta1.StaticAddress) error {
+	switch addressType(sa) {
+	case string(cloud.SchemeExternal):
+		if sa.Spec.Subnetwork != "" {
+			return fmt.Errorf("subnetwork can only be set for INTERNAL addresses")
+		}
+	case string(cloud.SchemeInternal):
+		if sa.Spec.Global {
+			return fmt.Errorf("global addresses must be EXTERNAL")
+		}
+		if sa.Spec.NetworkTier != "" {
+			return fmt.Errorf("networkTier can only be set for EXTERNAL addresses")
+		}
+	default:
+		return fmt.Errorf("invalid addressType %q, must be EXTERNAL or INTERNAL", sa.Spec.AddressType)
+	}
+	if sa.Spec.Global && sa.Spec.NetworkTier != "" && sa.Spec.NetworkTier != cloud.NetworkTierPremium.ToGCEValue() {
+		return fmt.Errorf("global addresses must use the PREMIUM network tier")
+	}
+	switch sa.Spec.ReclaimPolicy {
+	case "", staticaddressv1beta1.ReclaimPolicyDelete, staticaddressv1beta1.ReclaimPolicyRetain:
+	default:
+		return fmt.Errorf("invalid reclaimPolicy %q, must be Delete or Retain", sa.Spec.ReclaimPolicy)
+	}
+	return nil
+}
+
+// toComputeAddress returns the compute Address with the given name that is
+// reserved for the given StaticAddress CR.
+func toComputeAddress(sa *staticaddressv1beta1.StaticAddress, name, subnetwork string) (*compute.Address, error) {
+	desc, err := json.Marshal(description{StaticAddress: fmt.Sprintf("%s/%s", sa.Namespace, sa.Name)})
+	if err != nil {
+		return nil, err
+	}
+	ret := &compute.Address{
+		Name:        name,
+		Description: string(desc),
+		Address:     sa.Spec.Address,
+		AddressType: addressType(sa),
+		NetworkTier: sa.Spec.NetworkTier,
+	}
+	if ret.AddressType == string(cloud.SchemeInternal) {
+		ret.Subnetwork = subnetwork
+		if sa.Spec.Subnetwork != "" {
+			ret.Subnetwork = sa.Spec.Subnetwork
+		}
+	}
+	return ret, nil
+}
+
+// AddressFor returns the StaticAddress with the given name in the given
+// namespace, after checking that its address is reserved and can be used by
+// a load balancer of the given scope and address type. Errors caused by the
+// annotation of the consumer or by the StaticAddress it references are user
+// errors.
+func AddressFor(lister cache.Indexer, namespace, name string, global bool, addrType cloud.LbScheme) (*staticaddressv1beta1.StaticAddress, error) {
+	obj, exists, err := lister.GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.NewUserError(fmt.Errorf("StaticAddress %s/%s not found", namespace, name))
+	}
+	sa := obj.(*staticaddressv1beta1.StaticAddress)
+	if sa.Spec.Global != global {
+		if global {
+			return nil, utils.NewUserError(fmt.Errorf("StaticAddress %s/%s is regional, a global address is required", namespace, name))
+		}
+		return nil, utils.NewUserError(fmt.Errorf("StaticAddress %s/%s is global, a regional address is required", namespace, name))
+	}
+	if addressType(sa) != string(addrType) {
+		return nil, utils.NewUserError(fmt.Errorf("StaticAddress %s/%s has address type %s, want %s", namespace, name, addressType(sa), addrType))
+	}
+	if sa.Status.Address == "" {
+		// Not a user error, so that the consumer is retried until the
+		// address is reserved.
+		return nil, fmt.Errorf("StaticAddress %s/%s is not reserved yet", namespace, name)
+	}
+	return sa, nil
+}
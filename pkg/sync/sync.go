@@ -37,12 +37,15 @@ var ErrSkipBackendsSync = errors.New("ingress skip backends sync and beyond")
 // an implementation of Controller.
 type IngressSyncer struct {
 	controller Controller
+	// classifier determines the type of load balancer requested by an
+	// Ingress.
+	classifier utils.IngressClassifier
 
 	logger klog.Logger
 }
 
-func NewIngressSyncer(controller Controller, logger klog.Logger) Syncer {
-	return &IngressSyncer{controller, logger.WithName("IngressSyncer")}
+func NewIngressSyncer(controller Controller, classifier utils.IngressClassifier, logger klog.Logger) Syncer {
+	return &IngressSyncer{controller, classifier, logger.WithName("IngressSyncer")}
 }
 
 // Sync implements Syncer.
@@ -90,9 +93,9 @@ func (s *IngressSyncer) GC(ings []*v1.Ingress, currIng *v1.Ingress, frontendGCAl
 			return namer.FrontendNamingScheme(ing, s.logger) == namer.V1NamingScheme
 		})
 		// Partition these into ingresses those need cleanup and those don't.
-		toCleanupV1, toKeepV1 := v1Ingresses.Partition(utils.NeedsCleanup)
+		toCleanupV1, toKeepV1 := v1Ingresses.Partition(s.needsCleanup)
 		// Note that only GCE ingress associated resources are managed by this controller.
-		toKeepV1Gce := toKeepV1.Filter(utils.IsGCEIngress)
+		toKeepV1Gce := toKeepV1.Filter(s.isGCEIngress)
 		lbErr = s.controller.GCv1LoadBalancers(toKeepV1Gce.AsList())
 
 		defer func() {
@@ -115,7 +118,7 @@ func (s *IngressSyncer) GC(ings []*v1.Ingress, currIng *v1.Ingress, frontendGCAl
 	// 2) It is not a deletion candidate. A deletion candidate is an ingress
 	//    with deletion stamp and a finalizer.
 	toKeep := operator.Ingresses(ings).Filter(func(ing *v1.Ingress) bool {
-		return !utils.NeedsCleanup(ing)
+		return !s.needsCleanup(ing)
 	}).AsList()
 	if beErr := s.controller.GCBackends(toKeep, ingLogger); beErr != nil {
 		errs = append(errs, fmt.Errorf("error running backend garbage collection routine: %v", beErr))
@@ -125,3 +128,19 @@ func (s *IngressSyncer) GC(ings []*v1.Ingress, currIng *v1.Ingress, frontendGCAl
 	}
 	return err
 }
+
+// needsCleanup returns true if the given Ingress needs to have its associated
+// resources deleted. Ingresses whose IngressClass parameters cannot be
+// resolved are kept.
+func (s *IngressSyncer) needsCleanup(ing *v1.Ingress) bool {
+	needsCleanup, err := s.classifier.NeedsCleanup(ing)
+	return needsCleanup && err == nil
+}
+
+// isGCEIngress returns true if the given Ingress matches the class managed by
+// this controller. Ingresses whose IngressClass parameters cannot be resolved
+// are considered to match, so that their resources are kept.
+func (s *IngressSyncer) isGCEIngress(ing *v1.Ingress) bool {
+	isGCEIngress, err := s.classifier.IsGCEIngress(ing)
+	return isGCEIngress || err != nil
+}
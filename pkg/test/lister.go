@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ObjectClient is the subset of a generated typed client used to keep a
+// controller lister in sync in tests.
+type ObjectClient[T meta_v1.Object] interface {
+	Create(ctx context.Context, obj T, opts meta_v1.CreateOptions) (T, error)
+	Get(ctx context.Context, name string, opts meta_v1.GetOptions) (T, error)
+}
+
+// CreateInLister creates obj through client and adds the created object to
+// lister, as the informer would.
+func CreateInLister[T meta_v1.Object](t *testing.T, client ObjectClient[T], lister cache.Indexer, obj T) T {
+	t.Helper()
+	created, err := client.Create(context.TODO(), obj, meta_v1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create(%s) = %v", obj.GetName(), err)
+	}
+	if err := lister.Add(created); err != nil {
+		t.Fatalf("lister.Add(%s) = %v", obj.GetName(), err)
+	}
+	return created
+}
+
+// SyncLister replaces the object called name in lister with the one from
+// client, so that updates made through the client are visible to the
+// controller.
+func SyncLister[T meta_v1.Object](t *testing.T, client ObjectClient[T], lister cache.Indexer, name string) T {
+	t.Helper()
+	obj, err := client.Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(%s) = %v", name, err)
+	}
+	if err := lister.Update(obj); err != nil {
+		t.Fatalf("lister.Update(%s) = %v", name, err)
+	}
+	return obj
+}
@@ -21,12 +21,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	api_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -53,6 +56,9 @@ type Env struct {
 	Subnetwork string
 	Region     string
 	Project    string
+	// CertificateMap is the name of the Certificate Manager certificate map
+	// used by the target https proxy instead of ssl certificates.
+	CertificateMap string
 }
 
 // NewEnv returns an Env for the given Ingress.
@@ -193,6 +199,16 @@ func ToCompositeURLMap(g *utils.GCEURLMap, namer namer.IngressFrontendNamer, key
 		Name:           namer.UrlMap(),
 		DefaultService: resourceID.ResourcePath(),
 	}
+	if g.DefaultResource != nil {
+		m.DefaultService = pathRuleServiceLink(*g.DefaultResource, key)
+	}
+	// A UrlMap has either a default service or a default route action.
+	if len(g.DefaultWeightedBackends) > 0 {
+		m.DefaultService = ""
+		m.DefaultRouteAction = &composite.HttpRouteAction{
+			WeightedBackendServices: toCompositeWeightedBackendServices(g.DefaultWeightedBackends, key),
+		}
+	}
 
 	for _, hostRule := range g.HostRules {
 		// Create a host rule
@@ -205,27 +221,160 @@ func ToCompositeURLMap(g *utils.GCEURLMap, namer namer.IngressFrontendNamer, key
 		})
 
 		pathMatcher := &composite.PathMatcher{
-			Name:           pmName,
-			DefaultService: m.DefaultService,
-			PathRules:      []*composite.PathRule{},
+			Name:               pmName,
+			DefaultService:     m.DefaultService,
+			DefaultRouteAction: m.DefaultRouteAction,
+			PathRules:          []*composite.PathRule{},
+		}
+
+		// A PathMatcher cannot have both PathRules and RouteRules, so hosts
+		// with route rules translate their paths into route rules as well.
+		if len(hostRule.RouteRules) > 0 {
+			pathMatcher.PathRules = nil
+			pathMatcher.RouteRules = toCompositeRouteRules(hostRule, key)
+			m.PathMatchers = append(m.PathMatchers, pathMatcher)
+			continue
 		}
 
 		// GCE ensures that matched rule with longest prefix wins.
 		for _, rule := range hostRule.Paths {
-			beName := rule.Backend.BackendName()
-			key.Name = beName
-			resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: key}
-			beLink := resourceID.ResourcePath()
-			pathMatcher.PathRules = append(pathMatcher.PathRules, &composite.PathRule{
-				Paths:   []string{rule.Path},
-				Service: beLink,
-			})
+			pathRule := &composite.PathRule{Paths: []string{rule.Path}}
+			if len(rule.WeightedBackends) > 0 {
+				pathRule.RouteAction = &composite.HttpRouteAction{
+					WeightedBackendServices: toCompositeWeightedBackendServices(rule.WeightedBackends, key),
+				}
+			} else {
+				pathRule.Service = pathRuleServiceLink(rule, key)
+			}
+			pathMatcher.PathRules = append(pathMatcher.PathRules, pathRule)
 		}
 		m.PathMatchers = append(m.PathMatchers, pathMatcher)
 	}
 	return m
 }
 
+// toCompositeRouteRules translates the route rules and paths of the given host
+// rule into UrlMap route rules. Route rules are evaluated in priority order, so
+// the route rules come first, followed by the paths ordered from the most to
+// the least specific to preserve the longest prefix match of path rules.
+func toCompositeRouteRules(hostRule utils.HostRule, key *meta.Key) []*composite.HttpRouteRule {
+	var routeRules []*composite.HttpRouteRule
+	for _, rule := range hostRule.RouteRules {
+		routeRule := &composite.HttpRouteRule{
+			Priority:   int64(len(routeRules)),
+			MatchRules: []*composite.HttpRouteRuleMatch{toCompositeRouteRuleMatch(rule.Match)},
+		}
+		var routeAction composite.HttpRouteAction
+		if len(rule.Backends) == 1 {
+			routeRule.Service = backendServiceLink(rule.Backends[0].Backend, key)
+		} else {
+			routeAction.WeightedBackendServices = toCompositeWeightedBackendServices(rule.Backends, key)
+		}
+		if rule.UrlRewrite != nil {
+			routeAction.UrlRewrite = &composite.UrlRewrite{
+				PathPrefixRewrite: rule.UrlRewrite.PathPrefixRewrite,
+				HostRewrite:       rule.UrlRewrite.HostRewrite,
+			}
+		}
+		if routeAction.UrlRewrite != nil || len(routeAction.WeightedBackendServices) > 0 {
+			routeRule.RouteAction = &routeAction
+		}
+		routeRules = append(routeRules, routeRule)
+	}
+
+	paths := make([]utils.PathRule, len(hostRule.Paths))
+	copy(paths, hostRule.Paths)
+	sort.SliceStable(paths, func(i, j int) bool {
+		iPrefix, jPrefix := strings.HasSuffix(paths[i].Path, "*"), strings.HasSuffix(paths[j].Path, "*")
+		if iPrefix != jPrefix {
+			return !iPrefix
+		}
+		return len(paths[i].Path) > len(paths[j].Path)
+	})
+	for _, rule := range paths {
+		match := &composite.HttpRouteRuleMatch{}
+		if strings.HasSuffix(rule.Path, "*") {
+			match.PrefixMatch = strings.TrimSuffix(rule.Path, "*")
+		} else {
+			match.FullPathMatch = rule.Path
+		}
+		routeRule := &composite.HttpRouteRule{
+			Priority:   int64(len(routeRules)),
+			MatchRules: []*composite.HttpRouteRuleMatch{match},
+		}
+		if len(rule.WeightedBackends) > 0 {
+			routeRule.RouteAction = &composite.HttpRouteAction{
+				WeightedBackendServices: toCompositeWeightedBackendServices(rule.WeightedBackends, key),
+			}
+		} else {
+			routeRule.Service = pathRuleServiceLink(rule, key)
+		}
+		routeRules = append(routeRules, routeRule)
+	}
+	return routeRules
+}
+
+func toCompositeWeightedBackendServices(backends []utils.WeightedBackend, key *meta.Key) []*composite.WeightedBackendService {
+	var ret []*composite.WeightedBackendService
+	for _, backend := range backends {
+		ret = append(ret, &composite.WeightedBackendService{
+			BackendService: backendServiceLink(backend.Backend, key),
+			Weight:         backend.Weight,
+			// A weight of 0 is valid and must be sent explicitly.
+			ForceSendFields: []string{"Weight"},
+		})
+	}
+	return ret
+}
+
+func toCompositeRouteRuleMatch(match frontendconfigv1beta1.RouteMatch) *composite.HttpRouteRuleMatch {
+	ret := &composite.HttpRouteRuleMatch{
+		PrefixMatch:   match.PathPrefix,
+		FullPathMatch: match.FullPath,
+	}
+	for _, header := range match.Headers {
+		ret.HeaderMatches = append(ret.HeaderMatches, &composite.HttpHeaderMatch{
+			HeaderName:   header.Name,
+			ExactMatch:   header.ExactMatch,
+			PrefixMatch:  header.PrefixMatch,
+			RegexMatch:   header.RegexMatch,
+			PresentMatch: header.PresentMatch,
+			InvertMatch:  header.InvertMatch,
+		})
+	}
+	for _, param := range match.QueryParameters {
+		ret.QueryParameterMatches = append(ret.QueryParameterMatches, &composite.HttpQueryParameterMatch{
+			Name:         param.Name,
+			ExactMatch:   param.ExactMatch,
+			RegexMatch:   param.RegexMatch,
+			PresentMatch: param.PresentMatch,
+		})
+	}
+	return ret
+}
+
+// backendServiceLink returns the resource path of the backend service of the
+// given ServicePort in the scope of the given key.
+func backendServiceLink(sp utils.ServicePort, key *meta.Key) string {
+	key.Name = sp.BackendName()
+	resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: key}
+	return resourceID.ResourcePath()
+}
+
+// pathRuleServiceLink returns the resource path of the backend service or
+// backend bucket that serves the given path rule.
+func pathRuleServiceLink(rule utils.PathRule, key *meta.Key) string {
+	if rule.BackendBucket != "" {
+		resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendBuckets", Key: meta.GlobalKey(rule.BackendBucket)}
+		return resourceID.ResourcePath()
+	}
+	if rule.BackendService != "" {
+		resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: meta.GlobalKey(rule.BackendService)}
+		return resourceID.ResourcePath()
+	}
+	return backendServiceLink(rule.Backend, key)
+}
+
 // ToRedirectUrlMap returns the UrlMap used for HTTPS Redirects on a L7 ELB
 // This function returns nil if no url map needs to be created
 func (t *Translator) ToRedirectUrlMap(env *Env, version meta.Version) *composite.UrlMap {
@@ -248,6 +397,56 @@ func (t *Translator) ToRedirectUrlMap(env *Env, version meta.Version) *composite
 	return expectedMap
 }
 
+// ToCustomErrorResponsePolicy returns the default custom error response
+// policy of the UrlMap described by the FrontendConfig. The policy must have
+// been validated with frontendconfig.ValidateCustomErrorResponsePolicy, and an
+// error Service must be one of the backends of the given GCEURLMap.
+// This function returns nil if the UrlMap has no custom error response policy.
+func (t *Translator) ToCustomErrorResponsePolicy(env *Env, g *utils.GCEURLMap) (*composite.CustomErrorResponsePolicy, error) {
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.CustomErrorResponsePolicy == nil {
+		return nil, nil
+	}
+	if t.IsL7ILB || t.IsL7XLBRegional {
+		return nil, fmt.Errorf("custom error response policies are only supported for global external Ingresses")
+	}
+
+	policy := env.FrontendConfig.Spec.CustomErrorResponsePolicy
+	ret := &composite.CustomErrorResponsePolicy{}
+	if policy.ErrorService != nil {
+		sp, err := errorServicePort(env.Ing, policy.ErrorService, g)
+		if err != nil {
+			return nil, err
+		}
+		ret.ErrorService = backendServiceLink(sp, meta.GlobalKey(""))
+	} else {
+		resourceID := cloud.ResourceID{Resource: "backendBuckets", Key: meta.GlobalKey(policy.ErrorBackendBucket)}
+		ret.ErrorService = resourceID.ResourcePath()
+	}
+	for _, rule := range policy.ErrorResponseRules {
+		ret.ErrorResponseRules = append(ret.ErrorResponseRules, &composite.CustomErrorResponsePolicyCustomErrorResponseRule{
+			MatchResponseCodes:   rule.MatchResponseCodes,
+			Path:                 rule.Path,
+			OverrideResponseCode: int64(rule.OverrideResponseCode),
+		})
+	}
+	return ret, nil
+}
+
+// errorServicePort returns the ServicePort of the given GCEURLMap that the
+// error Service references.
+func errorServicePort(ing *v1.Ingress, svc *frontendconfigv1beta1.ErrorServiceBackend, g *utils.GCEURLMap) (utils.ServicePort, error) {
+	id := utils.ServicePortID{
+		Service: types.NamespacedName{Namespace: ing.Namespace, Name: svc.ServiceName},
+		Port:    v1.ServiceBackendPort{Name: svc.ServicePort.Name, Number: svc.ServicePort.Number},
+	}
+	for _, sp := range g.AllServicePorts() {
+		if sp.ID == id {
+			return sp, nil
+		}
+	}
+	return utils.ServicePort{}, fmt.Errorf("custom error response policy errorService %v is not a backend of the Ingress", id)
+}
+
 // getNameForPathMatcher returns a name for a pathMatcher based on the given host rule.
 // The host rule can be a regex, the path matcher name used to associate the 2 cannot.
 func getNameForPathMatcher(hostRule string) string {
@@ -329,6 +528,14 @@ func (t *Translator) ToCompositeTargetHttpsProxy(env *Env, description string, v
 		SslCertificates: certs,
 		Version:         version,
 	}
+	if env.CertificateMap != "" {
+		if t.IsL7ILB || t.IsL7XLBRegional {
+			return nil, false, fmt.Errorf("certificate maps are only supported for global external Ingresses")
+		}
+		// The ssl certificates are ignored when a certificate map is set.
+		proxy.CertificateMap = CertificateMapLink(env.Project, env.CertificateMap)
+		proxy.SslCertificates = nil
+	}
 	var sslPolicySet bool
 	if flags.F.EnableFrontendConfig {
 		sslPolicy, err := sslPolicyLink(env, t.IsL7XLBRegional)
@@ -381,6 +588,12 @@ func (t *Translator) ToCompositeSSLCertificates(env *Env, tlsName string, tls []
 	return certs
 }
 
+// CertificateMapLink returns the Certificate Manager URL of the global
+// certificate map with the given name.
+func CertificateMapLink(project, name string) string {
+	return fmt.Sprintf("//certificatemanager.googleapis.com/projects/%s/locations/global/certificateMaps/%s", project, name)
+}
+
 // sslPolicyLink returns the ref to the ssl policy that is described by the
 // frontend config.  Since Ssl Policy is a *string, there are three possible I/O situations
 // 1) policy is nil -> this returns nil
@@ -26,6 +26,7 @@ import (
 	api_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
@@ -121,6 +122,303 @@ func TestToComputeURLMap(t *testing.T) {
 	}
 }
 
+func TestToComputeURLMapRouteRules(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "foo.bar.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/*",
+						Backend: utils.ServicePort{NodePort: 33500, BackendNamer: namer},
+					},
+					{
+						Path:    "/web/*",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+					{
+						Path:    "/",
+						Backend: utils.ServicePort{NodePort: 33000, BackendNamer: namer},
+					},
+				},
+				RouteRules: []utils.RouteRule{
+					{
+						Match: frontendconfigv1beta1.RouteMatch{
+							PathPrefix:      "/api/",
+							QueryParameters: []frontendconfigv1beta1.QueryParameterMatch{{Name: "debug", PresentMatch: true}},
+						},
+						Backends:   []utils.WeightedBackend{{Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer}}},
+						UrlRewrite: &frontendconfigv1beta1.UrlRewrite{PathPrefixRewrite: "/", HostRewrite: "api.internal"},
+					},
+					{
+						Match: frontendconfigv1beta1.RouteMatch{PathPrefix: "/"},
+						Backends: []utils.WeightedBackend{
+							{Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer}, Weight: 100},
+							{Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer}, Weight: 0},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"foo.bar.com"},
+				PathMatcher: "host2d50cf9711f59181be6a5e5658e42c21",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host2d50cf9711f59181be6a5e5658e42c21",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority: 0,
+						MatchRules: []*composite.HttpRouteRuleMatch{
+							{
+								PrefixMatch:           "/api/",
+								QueryParameterMatches: []*composite.HttpQueryParameterMatch{{Name: "debug", PresentMatch: true}},
+							},
+						},
+						Service: "global/backendServices/k8s-be-32500--uid1",
+						RouteAction: &composite.HttpRouteAction{
+							UrlRewrite: &composite.UrlRewrite{PathPrefixRewrite: "/", HostRewrite: "api.internal"},
+						},
+					},
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 100, ForceSendFields: []string{"Weight"}},
+								{BackendService: "global/backendServices/k8s-be-32500--uid1", Weight: 0, ForceSendFields: []string{"Weight"}},
+							},
+						},
+					},
+					{
+						Priority:   2,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/"}},
+						Service:    "global/backendServices/k8s-be-33000--uid1",
+					},
+					{
+						Priority:   3,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/web/"}},
+						Service:    "global/backendServices/k8s-be-32000--uid1",
+					},
+					{
+						Priority:   4,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						Service:    "global/backendServices/k8s-be-33500--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapTrafficSplit(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/web",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+						WeightedBackends: []utils.WeightedBackend{
+							{Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer}, Weight: 80},
+							{Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer}, Weight: 20},
+						},
+					},
+					{
+						Path:    "/other",
+						Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths: []string{"/web"},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 80, ForceSendFields: []string{"Weight"}},
+								{BackendService: "global/backendServices/k8s-be-32500--uid1", Weight: 20, ForceSendFields: []string{"Weight"}},
+							},
+						},
+					},
+					{
+						Paths:   []string{"/other"},
+						Service: "global/backendServices/k8s-be-32500--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapDefaultTrafficSplit(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		DefaultWeightedBackends: []utils.WeightedBackend{
+			{Backend: utils.ServicePort{NodePort: 30000, BackendNamer: namer}, Weight: 90},
+			{Backend: utils.ServicePort{NodePort: 30500, BackendNamer: namer}, Weight: 10},
+		},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths:    []utils.PathRule{{Path: "/web", Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer}}},
+			},
+		},
+	}
+
+	defaultRouteAction := &composite.HttpRouteAction{
+		WeightedBackendServices: []*composite.WeightedBackendService{
+			{BackendService: "global/backendServices/k8s-be-30000--uid1", Weight: 90, ForceSendFields: []string{"Weight"}},
+			{BackendService: "global/backendServices/k8s-be-30500--uid1", Weight: 10, ForceSendFields: []string{"Weight"}},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:               "k8s-um-lb-name",
+		DefaultRouteAction: defaultRouteAction,
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultRouteAction: defaultRouteAction,
+				Name:               "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/web"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapResourceBackends(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:          "/static/*",
+						BackendBucket: "k8s1-bb-uid1-default-static-abcd1234",
+					},
+					{
+						Path:           "/run/*",
+						BackendService: "k8s1-sneg-uid1-default-run-abcd1234",
+					},
+					{
+						Path:    "/web",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/static/*"},
+						Service: "global/backendBuckets/k8s1-bb-uid1-default-static-abcd1234",
+					},
+					{
+						Paths:   []string{"/run/*"},
+						Service: "global/backendServices/k8s1-sneg-uid1-default-run-abcd1234",
+					},
+					{
+						Paths:   []string{"/web"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
 func TestToRedirectUrlMap(t *testing.T) {
 	t.Parallel()
 
@@ -168,6 +466,108 @@ func TestToRedirectUrlMap(t *testing.T) {
 	}
 }
 
+func TestToCustomErrorResponsePolicy(t *testing.T) {
+	t.Parallel()
+
+	feConfig := func(policy *frontendconfigv1beta1.CustomErrorResponsePolicy) *frontendconfigv1beta1.FrontendConfig {
+		return &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{CustomErrorResponsePolicy: policy}}
+	}
+	rules := []frontendconfigv1beta1.CustomErrorResponseRule{
+		{MatchResponseCodes: []string{"4xx", "503"}, Path: "/errors/default.html"},
+		{MatchResponseCodes: []string{"5xx"}, Path: "/errors/5xx.html", OverrideResponseCode: 200},
+	}
+	compositeRules := []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{
+		{MatchResponseCodes: []string{"4xx", "503"}, Path: "/errors/default.html"},
+		{MatchResponseCodes: []string{"5xx"}, Path: "/errors/5xx.html", OverrideResponseCode: 200},
+	}
+
+	ing := &v1.Ingress{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "ing"}}
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	errorsPort := utils.ServicePort{
+		ID: utils.ServicePortID{
+			Service: types.NamespacedName{Namespace: "default", Name: "errors"},
+			Port:    v1.ServiceBackendPort{Name: "http"},
+		},
+		NodePort:     30500,
+		BackendNamer: namer,
+	}
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{Hostname: "example.com", Paths: []utils.PathRule{{Path: "/errors", Backend: errorsPort}}},
+		},
+	}
+
+	testCases := []struct {
+		desc       string
+		fc         *frontendconfigv1beta1.FrontendConfig
+		isL7ILB    bool
+		isRegional bool
+		expect     *composite.CustomErrorResponsePolicy
+		wantErr    bool
+	}{
+		{
+			desc:   "Not included in FrontendConfig",
+			fc:     feConfig(nil),
+			expect: nil,
+		},
+		{
+			desc: "Backend bucket",
+			fc:   feConfig(&frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: rules}),
+			expect: &composite.CustomErrorResponsePolicy{
+				ErrorService:       "global/backendBuckets/errors",
+				ErrorResponseRules: compositeRules,
+			},
+		},
+		{
+			desc: "Service",
+			fc: feConfig(&frontendconfigv1beta1.CustomErrorResponsePolicy{
+				ErrorService:       &frontendconfigv1beta1.ErrorServiceBackend{ServiceName: "errors", ServicePort: frontendconfigv1beta1.ServiceBackendPort{Name: "http"}},
+				ErrorResponseRules: rules,
+			}),
+			expect: &composite.CustomErrorResponsePolicy{
+				ErrorService:       "global/backendServices/" + errorsPort.BackendName(),
+				ErrorResponseRules: compositeRules,
+			},
+		},
+		{
+			desc: "Service that is not a backend of the Ingress",
+			fc: feConfig(&frontendconfigv1beta1.CustomErrorResponsePolicy{
+				ErrorService:       &frontendconfigv1beta1.ErrorServiceBackend{ServiceName: "errors", ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 80}},
+				ErrorResponseRules: rules,
+			}),
+			wantErr: true,
+		},
+		{
+			desc:    "Internal Ingress",
+			fc:      feConfig(&frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: rules}),
+			isL7ILB: true,
+			wantErr: true,
+		},
+		{
+			desc:       "Regional external Ingress",
+			fc:         feConfig(&frontendconfigv1beta1.CustomErrorResponsePolicy{ErrorBackendBucket: "errors", ErrorResponseRules: rules}),
+			isRegional: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := NewTranslator(tc.isL7ILB, tc.isRegional, &testNamer{"foo"})
+			env := &Env{FrontendConfig: tc.fc, Ing: ing}
+
+			result, err := tr.ToCustomErrorResponsePolicy(env, gceURLMap)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ToCustomErrorResponsePolicy() = %v, want err? %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.expect, result); diff != "" {
+				t.Errorf("Unexpected diff from ToCustomErrorResponsePolicy() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func testCompositeURLMap() *composite.UrlMap {
 	return &composite.UrlMap{
 		Name:           "k8s-um-lb-name",
@@ -581,8 +981,11 @@ func TestToCompositeTargetHttpsProxy(t *testing.T) {
 		urlMapKey *meta.Key
 		sslCerts  []*composite.SslCertificate
 		sslPolicy *string
+		certMap   string
+		isL7ILB   bool
 		version   meta.Version
 		want      *composite.TargetHttpsProxy
+		wantErr   bool
 	}{
 		{
 			desc:      "https xlb",
@@ -630,16 +1033,41 @@ func TestToCompositeTargetHttpsProxy(t *testing.T) {
 				SslPolicy:   "global/sslPolicies/test-policy",
 			},
 		},
+		{
+			desc:      "https xlb with certificate map",
+			urlMapKey: meta.GlobalKey("my-url-map"),
+			version:   meta.VersionGA,
+			sslCerts:  []*composite.SslCertificate{{Name: "cert", SelfLink: "global/sslCertificates/cert"}},
+			certMap:   "my-cert-map",
+			want: &composite.TargetHttpsProxy{
+				Name:           "foo-tp",
+				Description:    description,
+				Version:        meta.VersionGA,
+				UrlMap:         "global/urlMaps/my-url-map",
+				CertificateMap: "//certificatemanager.googleapis.com/projects/my-project/locations/global/certificateMaps/my-cert-map",
+			},
+		},
+		{
+			desc:      "https ilb with certificate map",
+			urlMapKey: meta.RegionalKey("my-url-map", "fakeRegion"),
+			version:   meta.VersionGA,
+			certMap:   "my-cert-map",
+			isL7ILB:   true,
+			wantErr:   true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			// isL7ILB or isL7XLBRegional doesn't affect the outcome here since the key is creating during ensure
-			tr := NewTranslator(false, false, &testNamer{"foo"})
-			env := &Env{FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: tc.sslPolicy}}}
+			// isL7ILB or isL7XLBRegional only affects the outcome for certificate maps since the key is creating during ensure
+			tr := NewTranslator(tc.isL7ILB, false, &testNamer{"foo"})
+			env := &Env{FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: tc.sslPolicy}}, Project: "my-project", CertificateMap: tc.certMap}
 			got, sslPolicySet, err := tr.ToCompositeTargetHttpsProxy(env, description, tc.version, tc.urlMapKey, tc.sslCerts)
-			if err != nil {
-				t.Fatal(err)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ToCompositeTargetHttpsProxy() = %v, want err? %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
 			}
 			wantSslPolicySet := tc.sslPolicy != nil
 			if sslPolicySet != wantSslPolicySet {
@@ -788,3 +1216,25 @@ func TestSslPolicyLink(t *testing.T) {
 		})
 	}
 }
+
+func TestToComputeURLMapDefaultResource(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend:  &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		DefaultResource: &utils.PathRule{BackendBucket: "k8s1-bb-uid1-default-static-abcd1234"},
+	}
+
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendBuckets/k8s1-bb-uid1-default-static-abcd1234",
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
@@ -43,6 +43,14 @@ const (
 	NetLBFinalizerV2 = "gke.networking.io/l4-netlb-v2"
 	// NetLBFinalizerV3 is the finalizer used by the NEG backed variant of the L4 External LoadBalancer services.
 	NetLBFinalizerV3 = "gke.networking.io/l4-netlb-v3"
+	// GatewayFinalizerKey is the finalizer used by the ingress controller to ensure the load balancer of a Gateway is deleted before the Gateway.
+	GatewayFinalizerKey = "networking.gke.io/gateway-finalizer"
+	// BackendBucketFinalizerKey is the finalizer used by the backend bucket controller to ensure the compute BackendBucket is deleted before the BackendBucket CR.
+	BackendBucketFinalizerKey = "networking.gke.io/backend-bucket-finalizer"
+	// ServerlessNEGFinalizerKey is the finalizer used by the serverless NEG controller to ensure the serverless NEG and its backend service are deleted before the ServerlessNEG CR.
+	ServerlessNEGFinalizerKey = "networking.gke.io/serverless-neg-finalizer"
+	// StaticAddressFinalizerKey is the finalizer used by the static address controller to ensure the StaticAddress CR is not deleted while it is in use, and that its compute Address is released before the CR.
+	StaticAddressFinalizerKey = "networking.gke.io/static-address-finalizer"
 	// LoadBalancerCleanupFinalizer added by original kubernetes service controller. This is not required in L4 RBS/ILB-subsetting services.
 	LoadBalancerCleanupFinalizer = "service.kubernetes.io/load-balancer-cleanup"
 )
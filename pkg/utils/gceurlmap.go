@@ -15,8 +15,10 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/klog/v2"
 )
 
@@ -28,6 +30,14 @@ import (
 //  3. Adding paths for a hostname replaces existing for that host.
 type GCEURLMap struct {
 	DefaultBackend *ServicePort
+	// DefaultWeightedBackends, if set, split the traffic of the default
+	// backend between them instead of sending it to DefaultBackend.
+	DefaultWeightedBackends []WeightedBackend
+	// DefaultResource, if set, is the backend bucket or unmanaged backend
+	// service that serves the default backend instead of DefaultBackend.
+	// DefaultBackend is still set, so that the url map always has a
+	// backend service of the Ingress.
+	DefaultResource *PathRule
 	// HostRules is an ordered list of hostnames, path rule tuples.
 	HostRules []HostRule
 	// hosts is a map of existing hosts.
@@ -37,15 +47,41 @@ type GCEURLMap struct {
 }
 
 // HostRule encapsulates the Hostname and its list of PathRules.
+// RouteRules, if any, take precedence over the PathRules of the host.
 type HostRule struct {
-	Hostname string
-	Paths    []PathRule
+	Hostname   string
+	Paths      []PathRule
+	RouteRules []RouteRule
 }
 
 // PathRule encapsulates the information for a single path -> backend mapping.
+// If WeightedBackends is set, the traffic of the path is split between them
+// instead of being sent to Backend.
 type PathRule struct {
-	Path    string
+	Path             string
+	Backend          ServicePort
+	WeightedBackends []WeightedBackend
+	// BackendBucket is the name of the compute BackendBucket that serves the
+	// path instead of Backend, if set.
+	BackendBucket string
+	// BackendService is the name of a global backend service that is not
+	// managed by the Ingress, such as the backend service of a serverless
+	// NEG. It serves the path instead of Backend, if set.
+	BackendService string
+}
+
+// RouteRule encapsulates the information for a single advanced route:
+// the match conditions, the weighted backends and the URL rewrite.
+type RouteRule struct {
+	Match      frontendconfigv1beta1.RouteMatch
+	Backends   []WeightedBackend
+	UrlRewrite *frontendconfigv1beta1.UrlRewrite
+}
+
+// WeightedBackend is a backend of a RouteRule with its relative weight.
+type WeightedBackend struct {
 	Backend ServicePort
+	Weight  int64
 }
 
 // NewGCEURLMap returns an empty GCEURLMap
@@ -62,6 +98,15 @@ func EqualMapping(a, b *GCEURLMap) bool {
 	if a.DefaultBackend != nil && a.DefaultBackend.ID != b.DefaultBackend.ID {
 		return false
 	}
+	if !equalWeightedBackends(a.DefaultWeightedBackends, b.DefaultWeightedBackends) {
+		return false
+	}
+	if (a.DefaultResource != nil) != (b.DefaultResource != nil) {
+		return false
+	}
+	if a.DefaultResource != nil && (a.DefaultResource.BackendBucket != b.DefaultResource.BackendBucket || a.DefaultResource.BackendService != b.DefaultResource.BackendService) {
+		return false
+	}
 
 	if len(a.HostRules) != len(b.HostRules) {
 		return false
@@ -85,6 +130,38 @@ func EqualMapping(a, b *GCEURLMap) bool {
 			if aPath.Backend.ID != bPath.Backend.ID {
 				return false
 			}
+			if aPath.BackendBucket != bPath.BackendBucket || aPath.BackendService != bPath.BackendService {
+				return false
+			}
+			if !equalWeightedBackends(aPath.WeightedBackends, bPath.WeightedBackends) {
+				return false
+			}
+		}
+
+		if len(aRules.RouteRules) != len(bRules.RouteRules) {
+			return false
+		}
+
+		for i, aRoute := range aRules.RouteRules {
+			bRoute := bRules.RouteRules[i]
+			if !reflect.DeepEqual(aRoute.Match, bRoute.Match) || !reflect.DeepEqual(aRoute.UrlRewrite, bRoute.UrlRewrite) {
+				return false
+			}
+			if !equalWeightedBackends(aRoute.Backends, bRoute.Backends) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalWeightedBackends(a, b []WeightedBackend) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Backend.ID != b[i].Backend.ID || a[i].Weight != b[i].Weight {
+			return false
 		}
 	}
 	return true
@@ -119,6 +196,7 @@ func (g *GCEURLMap) PutPathRulesForHost(hostname string, pathRules []PathRule) {
 	_, exists := g.hosts[hostname]
 	if exists {
 		g.logger.V(4).Info("Overwriting path rules for host", "host", hostname)
+		hr.RouteRules = g.routeRulesForHost(hostname)
 		g.deleteHost(hostname)
 	}
 
@@ -127,6 +205,28 @@ func (g *GCEURLMap) PutPathRulesForHost(hostname string, pathRules []PathRule) {
 	return
 }
 
+// PutRouteRulesForHost sets the route rules for a single hostname, keeping
+// its path rules. The host is added if it does not exist yet.
+func (g *GCEURLMap) PutRouteRulesForHost(hostname string, routeRules []RouteRule) {
+	for i := range g.HostRules {
+		if g.HostRules[i].Hostname == hostname {
+			g.HostRules[i].RouteRules = routeRules
+			return
+		}
+	}
+	g.HostRules = append(g.HostRules, HostRule{Hostname: hostname, RouteRules: routeRules})
+	g.hosts[hostname] = true
+}
+
+func (g *GCEURLMap) routeRulesForHost(hostname string) []RouteRule {
+	for _, hostRule := range g.HostRules {
+		if hostRule.Hostname == hostname {
+			return hostRule.RouteRules
+		}
+	}
+	return nil
+}
+
 // AllServicePorts return a list of all ServicePorts contained in the GCEURLMap.
 func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 
@@ -135,13 +235,36 @@ func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 		svcPorts = append(svcPorts, *g.DefaultBackend)
 		uniqueServerPorts[*&g.DefaultBackend.ID] = true
 	}
+	for _, backend := range g.DefaultWeightedBackends {
+		if !uniqueServerPorts[backend.Backend.ID] {
+			svcPorts = append(svcPorts, backend.Backend)
+			uniqueServerPorts[backend.Backend.ID] = true
+		}
+	}
 
 	for _, rules := range g.HostRules {
 		for _, rule := range rules.Paths {
+			if rule.BackendBucket != "" || rule.BackendService != "" {
+				continue
+			}
 			if !uniqueServerPorts[rule.Backend.ID] {
 				svcPorts = append(svcPorts, rule.Backend)
 				uniqueServerPorts[rule.Backend.ID] = true
 			}
+			for _, backend := range rule.WeightedBackends {
+				if !uniqueServerPorts[backend.Backend.ID] {
+					svcPorts = append(svcPorts, backend.Backend)
+					uniqueServerPorts[backend.Backend.ID] = true
+				}
+			}
+		}
+		for _, rule := range rules.RouteRules {
+			for _, backend := range rule.Backends {
+				if !uniqueServerPorts[backend.Backend.ID] {
+					svcPorts = append(svcPorts, backend.Backend)
+					uniqueServerPorts[backend.Backend.ID] = true
+				}
+			}
 		}
 	}
 
@@ -192,9 +315,27 @@ func (g *GCEURLMap) String() string {
 		b.WriteString(fmt.Sprintf("%v\n", hostRule.Hostname))
 		for _, rule := range hostRule.Paths {
 			b.WriteString(fmt.Sprintf("\t%v: ", rule.Path))
-			b.WriteString(fmt.Sprintf("%+v\n", rule.Backend))
+			b.WriteString(fmt.Sprintf("%+v", rule.Backend))
+			for _, backend := range rule.WeightedBackends {
+				b.WriteString(fmt.Sprintf(" %+v(weight %d)", backend.Backend.ID, backend.Weight))
+			}
+			b.WriteString("\n")
+		}
+		for _, rule := range hostRule.RouteRules {
+			b.WriteString(fmt.Sprintf("\troute %+v", rule.Match))
+			if rule.UrlRewrite != nil {
+				b.WriteString(fmt.Sprintf(" rewrite %+v", *rule.UrlRewrite))
+			}
+			b.WriteString(":")
+			for _, backend := range rule.Backends {
+				b.WriteString(fmt.Sprintf(" %+v(weight %d)", backend.Backend.ID, backend.Weight))
+			}
+			b.WriteString("\n")
 		}
 	}
 	b.WriteString(fmt.Sprintf("Default Backend: %+v", g.DefaultBackend))
+	for _, backend := range g.DefaultWeightedBackends {
+		b.WriteString(fmt.Sprintf(" %+v(weight %d)", backend.Backend.ID, backend.Weight))
+	}
 	return b.String()
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+const (
+	// maxBBDescriptiveLabel is the max length for prefix, namespace, and name for
+	// backend buckets. 63 - 1 (naming schema version prefix)
+	// - 2 (backend bucket identifier prefix) - 8 (truncated kube system id) - 8 (suffix hash)
+	// - 5 (hyphen connectors) = 39
+	maxBBDescriptiveLabel = 39
+)
+
+// V1BackendBucketNamer implements BackendBucketNamer. This is a wrapper on top of namer.Namer.
+type V1BackendBucketNamer struct {
+	kubeSystemUID string
+	prefix        string
+
+	// maxDescriptiveLabel is the max length for the namespace and name fields in the backend
+	// bucket name.
+	// maxBBDescriptiveLabel - len(prefix)
+	maxDescriptiveLabel int
+}
+
+// NewBackendBucketNamer returns a v1 namer for Backend Buckets
+func NewBackendBucketNamer(namer *Namer, kubeSystemUID string) BackendBucketNamer {
+	return &V1BackendBucketNamer{
+		kubeSystemUID:       kubeSystemUID,
+		prefix:              namer.prefix,
+		maxDescriptiveLabel: maxBBDescriptiveLabel - len(namer.prefix),
+	}
+}
+
+// BackendBucket returns the gce BackendBucket name based on the BackendBucket
+// CR name, and namespace. Backend Bucket naming convention:
+//
+// k8s{naming version}-bb-{cluster-uid}-{namespace}-{name}-{hash}
+// Output name is at most 63 characters.
+// Hash is generated from the KubeSystemUID, Namespace, Name, and BackendBucket CR UID
+// Cluster UID will be 8 characters, hash suffix will be 8 characters
+//
+// WARNING: Controllers will use the naming convention to correlate between
+// the BackendBucket CR and backend bucket resource in GCE,
+// so modifications must be backwards compatible.
+func (n *V1BackendBucketNamer) BackendBucket(namespace, name, bbUID string) string {
+	clusterUID := common.ContentHash(n.kubeSystemUID, clusterUIDLength)
+	hash := common.ContentHash(strings.Join([]string{n.kubeSystemUID, namespace, name, bbUID}, ";"), 8)
+	truncFields := TrimFieldsEvenly(n.maxDescriptiveLabel, namespace, name)
+	return fmt.Sprintf("%s%s-bb-%s-%s-%s-%s", n.prefix, schemaVersionV1, clusterUID, truncFields[0], truncFields[1], hash)
+}
+
+// IsBackendBucket returns true if the given name follows the backend bucket
+// naming convention of this cluster.
+func (n *V1BackendBucketNamer) IsBackendBucket(name string) bool {
+	clusterUID := common.ContentHash(n.kubeSystemUID, clusterUIDLength)
+	return strings.HasPrefix(name, fmt.Sprintf("%s%s-bb-%s-", n.prefix, schemaVersionV1, clusterUID))
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestNamerBackendBucket(t *testing.T) {
+	longstring := "01234567890123456789012345678901234567890123456789"
+	backendBucketUID := "backend-bucket-uid"
+	prefix := "prefix"
+	testCases := []struct {
+		desc                string
+		namespace           string
+		name                string
+		expectDefaultPrefix string
+		expectCustomPrefix  string
+	}{
+		{
+			"simple case",
+			"namespace",
+			"name",
+			"k8s1-bb-7kpbhpki-namespace-name-creyj3n0",
+			"prefix1-bb-7kpbhpki-namespace-name-creyj3n0",
+		},
+		{
+			"63 characters with default prefix k8s",
+			longstring[:18],
+			longstring[:18],
+			"k8s1-bb-7kpbhpki-012345678901234567-012345678901234567-vcxrqugs",
+			"prefix1-bb-7kpbhpki-01234567890123456-0123456789012345-vcxrqugs",
+		},
+		{
+			"long namespace",
+			longstring,
+			"name",
+			"k8s1-bb-7kpbhpki-0123456789012345678901234567890123-na-b6tamfcx",
+			"prefix1-bb-7kpbhpki-0123456789012345678901234567890-na-b6tamfcx",
+		},
+		{
+			"long name",
+			"namespace",
+			longstring,
+			"k8s1-bb-7kpbhpki-namesp-012345678901234567890123456789-d519jo1o",
+			"prefix1-bb-7kpbhpki-namesp-012345678901234567890123456-d519jo1o",
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, withPrefix := range []bool{true, false} {
+			var oldNamer *Namer
+			var expectedName string
+
+			if withPrefix {
+				oldNamer = NewNamer(clusterId, "", klog.TODO())
+				expectedName = tc.expectDefaultPrefix
+			} else {
+				oldNamer = NewNamerWithPrefix(prefix, clusterId, "", klog.TODO())
+				expectedName = tc.expectCustomPrefix
+			}
+
+			newNamer := NewBackendBucketNamer(oldNamer, kubeSystemUID)
+			res := newNamer.BackendBucket(tc.namespace, tc.name, backendBucketUID)
+			if len(res) > 63 {
+				t.Errorf("%s: got len(res) == %v, want <= 63", tc.desc, len(res))
+			}
+			if numHyphens := strings.Count(res, "-"); numHyphens != 5 {
+				t.Errorf("Expected to have 5 components to name delimited by `-`. Found only %d `-`", numHyphens)
+			}
+			if res != expectedName {
+				t.Errorf("%s: got %q, want %q", tc.desc, res, expectedName)
+			}
+			if !newNamer.IsBackendBucket(res) {
+				t.Errorf("%s: IsBackendBucket(%q) = false, want true", tc.desc, res)
+			}
+		}
+	}
+}
+
+func TestNamerIsBackendBucket(t *testing.T) {
+	newNamer := NewBackendBucketNamer(NewNamer(clusterId, "", klog.TODO()), kubeSystemUID)
+	otherCluster := NewBackendBucketNamer(NewNamer(clusterId, "", klog.TODO()), "other-kube-system-uid")
+	for _, name := range []string{
+		otherCluster.BackendBucket("namespace", "name", "backend-bucket-uid"),
+		"k8s1-sneg-7kpbhpki-namespace-name-creyj3n0",
+		"my-backend-bucket",
+	} {
+		if newNamer.IsBackendBucket(name) {
+			t.Errorf("IsBackendBucket(%q) = true, want false", name)
+		}
+	}
+}
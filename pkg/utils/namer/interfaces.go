@@ -101,6 +101,9 @@ type L4ResourcesNamer interface {
 	BackendNamer
 	// L4ForwardingRule returns the name of the forwarding rule for the given service and protocol.
 	L4ForwardingRule(namespace, name, protocol string) string
+	// L4AdditionalForwardingRule returns the name of an additional forwarding rule for the given service, protocol
+	// and rule name.
+	L4AdditionalForwardingRule(namespace, name, protocol, ruleName string) string
 	// L4Firewall returns the name of the firewall rule for the given service
 	L4Firewall(namespace, name string) string
 	// L4IPv6Firewall returns the name of the ipv6 firewall rule for the given service
@@ -122,3 +125,24 @@ type ServiceAttachmentNamer interface {
 	// name, and Service Attachment CR UID
 	ServiceAttachment(namespace, name, saUID string) string
 }
+
+type BackendBucketNamer interface {
+	// BackendBucket returns the name of the GCE Backend Bucket resource for the given namespace,
+	// name, and BackendBucket CR UID
+	BackendBucket(namespace, name, bbUID string) string
+	// IsBackendBucket returns true if the given name is the name of a GCE
+	// Backend Bucket resource of this cluster.
+	IsBackendBucket(name string) bool
+}
+
+type ServerlessNEGNamer interface {
+	// ServerlessNEG returns the name of the GCE serverless NEG and backend service resources for the
+	// given namespace, name, and ServerlessNEG CR UID
+	ServerlessNEG(namespace, name, snegUID string) string
+}
+
+type StaticAddressNamer interface {
+	// StaticAddress returns the name of the GCE Address resource for the given namespace
+	// and name of a StaticAddress CR
+	StaticAddress(namespace, name string) string
+}
@@ -132,6 +132,21 @@ func (namer *L4Namer) L4ForwardingRule(namespace, name, protocol string) string
 	}, "-")
 }
 
+// L4AdditionalForwardingRule returns the name of an additional L4 forwarding rule based on the service namespace,
+// name, protocol and the name of the rule in the service.
+// Naming convention:
+//
+//	k8s2-{protocol}-{rule}-{uid}-{ns}-{name}-{suffix}
+//
+// Output name is at most 63 characters.
+func (namer *L4Namer) L4AdditionalForwardingRule(namespace, name, protocol, ruleName string) string {
+	protocol = strings.ToLower(protocol)
+	if protocol == "l3_default" {
+		protocol = l3ProtocolWithoutUnderscore
+	}
+	return namer.L4ForwardingRule(namespace, name, protocol+"-"+ruleName)
+}
+
 // L4HealthCheck returns the name of the L4 LB Healthcheck
 func (namer *L4Namer) L4HealthCheck(namespace, name string, shared bool) string {
 	if shared {
@@ -190,3 +190,54 @@ func TestL4Namer(t *testing.T) {
 		}
 	}
 }
+
+// TestL4AdditionalForwardingRule verifies that the names of the additional L4 forwarding rules are of the expected
+// length and format.
+func TestL4AdditionalForwardingRule(t *testing.T) {
+	longstring1 := "012345678901234567890123456789012345678901234567890123456789abc"
+	longstring2 := "012345678901234567890123456789012345678901234567890123456789pqr"
+	testCases := []struct {
+		desc         string
+		namespace    string
+		name         string
+		proto        string
+		ruleName     string
+		expectFRName string
+	}{
+		{
+			desc:         "simple case",
+			namespace:    "namespace",
+			name:         "name",
+			proto:        "TCP",
+			ruleName:     "all",
+			expectFRName: "k8s2-tcp-all-7kpbhpki-namespace-name-956p2p7x",
+		},
+		{
+			desc:         "l3 protocol",
+			namespace:    "namespace",
+			name:         "name",
+			proto:        "L3_DEFAULT",
+			ruleName:     "all",
+			expectFRName: "k8s2-l3-all-7kpbhpki-namespace-name-956p2p7x",
+		},
+		{
+			desc:         "long svc and namespace name and rule name",
+			namespace:    longstring1,
+			name:         longstring2,
+			proto:        "UDP",
+			ruleName:     "abcdefghij",
+			expectFRName: "k8s2-udp-abcdefghij-7kpbhpki-012345678901-012345678901-hwm400mg",
+		},
+	}
+
+	newNamer := NewL4Namer(kubeSystemUID, nil)
+	for _, tc := range testCases {
+		frName := newNamer.L4AdditionalForwardingRule(tc.namespace, tc.name, tc.proto, tc.ruleName)
+		if len(frName) > maxResourceNameLength {
+			t.Errorf("%s: got len(frName) == %v, want <= %d", tc.desc, len(frName), maxResourceNameLength)
+		}
+		if frName != tc.expectFRName {
+			t.Errorf("%s AdditionalForwardingRuleName: got %q, want %q", tc.desc, frName, tc.expectFRName)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Variables of NEG name templates.
+const (
+	negNameTemplateNamespace = "{namespace}"
+	negNameTemplateService   = "{service}"
+	negNameTemplatePort      = "{port}"
+	negNameTemplateCluster   = "{cluster}"
+)
+
+var (
+	// ErrInvalidNEGNameTemplate is returned for NEG name templates with
+	// unknown or missing variables, and templates generating invalid NEG
+	// names.
+	ErrInvalidNEGNameTemplate = errors.New("invalid NEG name template")
+
+	negNameTemplateVariableRegex = regexp.MustCompile(`\{[^{}]*\}`)
+)
+
+// NEGNameTemplate generates NEG names from a template using the variables
+// {namespace}, {service}, {port} and {cluster}, e.g.
+// "{cluster}-{namespace}-{service}-{port}". The template must use
+// {namespace}, {service} and {port}, so that the NEGs of different service
+// ports do not get the same name.
+type NEGNameTemplate struct {
+	template string
+}
+
+// ParseNEGNameTemplate returns the NEGNameTemplate for the template, or an
+// error if it uses unknown variables or misses a required variable.
+func ParseNEGNameTemplate(template string) (*NEGNameTemplate, error) {
+	if template == "" {
+		return nil, fmt.Errorf("%w: template is empty", ErrInvalidNEGNameTemplate)
+	}
+	for _, variable := range negNameTemplateVariableRegex.FindAllString(template, -1) {
+		switch variable {
+		case negNameTemplateNamespace, negNameTemplateService, negNameTemplatePort, negNameTemplateCluster:
+		default:
+			return nil, fmt.Errorf("%w: unknown variable %s in %q", ErrInvalidNEGNameTemplate, variable, template)
+		}
+	}
+	for _, variable := range []string{negNameTemplateNamespace, negNameTemplateService, negNameTemplatePort} {
+		if !strings.Contains(template, variable) {
+			return nil, fmt.Errorf("%w: missing variable %s in %q", ErrInvalidNEGNameTemplate, variable, template)
+		}
+	}
+	return &NEGNameTemplate{template: template}, nil
+}
+
+// Name returns the NEG name for the service port. The name must be a valid
+// GCE resource name short enough to derive the names of the NEGs in
+// non-default subnets from it, like custom NEG names.
+func (t *NEGNameTemplate) Name(clusterName, namespace, name string, port int32) (string, error) {
+	if clusterName == "" && strings.Contains(t.template, negNameTemplateCluster) {
+		return "", fmt.Errorf("%w: cluster name is unknown for %q", ErrInvalidNEGNameTemplate, t.template)
+	}
+	negName := strings.NewReplacer(
+		negNameTemplateNamespace, namespace,
+		negNameTemplateService, name,
+		negNameTemplatePort, fmt.Sprint(port),
+		negNameTemplateCluster, clusterName,
+	).Replace(t.template)
+	if len(negName) > MaxDefaultSubnetNegNameLength {
+		return "", fmt.Errorf("%w: NEG name %q generated from %q exceeds %v characters limit", ErrInvalidNEGNameTemplate, negName, t.template, MaxDefaultSubnetNegNameLength)
+	}
+	if !isValidGCEResourceName(negName) {
+		return "", fmt.Errorf("%w: NEG name %q generated from %q is not a valid GCE resource name", ErrInvalidNEGNameTemplate, negName, t.template)
+	}
+	return negName, nil
+}
+
+// String returns the template.
+func (t *NEGNameTemplate) String() string {
+	return t.template
+}
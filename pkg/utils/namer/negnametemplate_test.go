@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNEGNameTemplate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc        string
+		template    string
+		clusterName string
+		wantName    string
+		wantErr     bool
+	}{
+		{
+			desc:        "all variables",
+			template:    "{cluster}-{namespace}-{service}-{port}",
+			clusterName: "cluster1",
+			wantName:    "cluster1-ns-svc-80",
+		},
+		{
+			desc:     "variables used several times",
+			template: "neg-{namespace}-{service}-{port}-{service}",
+			wantName: "neg-ns-svc-80-svc",
+		},
+		{
+			desc:     "constant name",
+			template: "neg",
+			wantErr:  true,
+		},
+		{
+			desc:     "missing namespace",
+			template: "neg-{service}-{port}",
+			wantErr:  true,
+		},
+		{
+			desc:     "missing port",
+			template: "{cluster}-{namespace}-{service}",
+			wantErr:  true,
+		},
+		{
+			desc:     "empty template",
+			template: "",
+			wantErr:  true,
+		},
+		{
+			desc:     "unknown variable",
+			template: "{namespace}-{service}-{port}-{name}",
+			wantErr:  true,
+		},
+		{
+			desc:     "unknown cluster name",
+			template: "{cluster}-{namespace}-{service}-{port}",
+			wantErr:  true,
+		},
+		{
+			desc:     "invalid name",
+			template: "{port}-{namespace}-{service}",
+			wantErr:  true,
+		},
+		{
+			desc:     "name too long",
+			template: "{namespace}-{service}-{port}-" + strings.Repeat("a", MaxDefaultSubnetNegNameLength),
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			template, err := ParseNEGNameTemplate(tc.template)
+			var name string
+			if err == nil {
+				name, err = template.Name(tc.clusterName, "ns", "svc", 80)
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidNEGNameTemplate) {
+					t.Errorf("got error %v, want %v", err, ErrInvalidNEGNameTemplate)
+				}
+				return
+			}
+			if name != tc.wantName {
+				t.Errorf("Name() = %q, want %q", name, tc.wantName)
+			}
+		})
+	}
+}
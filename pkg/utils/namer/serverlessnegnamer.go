@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+const (
+	// maxSNEGDescriptiveLabel is the max length for prefix, namespace, and name for
+	// serverless NEGs. 63 - 1 (naming schema version prefix)
+	// - 4 (serverless NEG identifier prefix) - 8 (truncated kube system id) - 8 (suffix hash)
+	// - 5 (hyphen connectors) = 37
+	maxSNEGDescriptiveLabel = 37
+)
+
+// V1ServerlessNEGNamer implements ServerlessNEGNamer. This is a wrapper on top of namer.Namer.
+type V1ServerlessNEGNamer struct {
+	kubeSystemUID string
+	prefix        string
+
+	// maxDescriptiveLabel is the max length for the namespace and name fields in the serverless
+	// NEG name.
+	// maxSNEGDescriptiveLabel - len(prefix)
+	maxDescriptiveLabel int
+}
+
+// NewServerlessNEGNamer returns a v1 namer for serverless NEGs
+func NewServerlessNEGNamer(namer *Namer, kubeSystemUID string) ServerlessNEGNamer {
+	return &V1ServerlessNEGNamer{
+		kubeSystemUID:       kubeSystemUID,
+		prefix:              namer.prefix,
+		maxDescriptiveLabel: maxSNEGDescriptiveLabel - len(namer.prefix),
+	}
+}
+
+// ServerlessNEG returns the name of the gce serverless NEG and its backend
+// service based on the ServerlessNEG CR name, and namespace. Serverless NEG
+// naming convention:
+//
+// k8s{naming version}-sneg-{cluster-uid}-{namespace}-{name}-{hash}
+// Output name is at most 63 characters.
+// Hash is generated from the KubeSystemUID, Namespace, Name, and ServerlessNEG CR UID
+// Cluster UID will be 8 characters, hash suffix will be 8 characters
+//
+// WARNING: Controllers will use the naming convention to correlate between
+// the ServerlessNEG CR and serverless NEG resources in GCE,
+// so modifications must be backwards compatible.
+func (n *V1ServerlessNEGNamer) ServerlessNEG(namespace, name, snegUID string) string {
+	clusterUID := common.ContentHash(n.kubeSystemUID, clusterUIDLength)
+	hash := common.ContentHash(strings.Join([]string{n.kubeSystemUID, namespace, name, snegUID}, ";"), 8)
+	truncFields := TrimFieldsEvenly(n.maxDescriptiveLabel, namespace, name)
+	return fmt.Sprintf("%s%s-sneg-%s-%s-%s-%s", n.prefix, schemaVersionV1, clusterUID, truncFields[0], truncFields[1], hash)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestNamerServerlessNEG(t *testing.T) {
+	longstring := "01234567890123456789012345678901234567890123456789"
+	serverlessNEGUID := "serverless-neg-uid"
+	prefix := "prefix"
+	testCases := []struct {
+		desc                string
+		namespace           string
+		name                string
+		expectDefaultPrefix string
+		expectCustomPrefix  string
+	}{
+		{
+			"simple case",
+			"namespace",
+			"name",
+			"k8s1-sneg-7kpbhpki-namespace-name-54eatifz",
+			"prefix1-sneg-7kpbhpki-namespace-name-54eatifz",
+		},
+		{
+			"63 characters with default prefix k8s",
+			longstring[:17],
+			longstring[:17],
+			"k8s1-sneg-7kpbhpki-01234567890123456-01234567890123456-wym8id25",
+			"prefix1-sneg-7kpbhpki-0123456789012345-012345678901234-wym8id25",
+		},
+		{
+			"long namespace",
+			longstring,
+			"name",
+			"k8s1-sneg-7kpbhpki-01234567890123456789012345678901-na-78rxnoub",
+			"prefix1-sneg-7kpbhpki-01234567890123456789012345678-na-78rxnoub",
+		},
+		{
+			"long name",
+			"namespace",
+			longstring,
+			"k8s1-sneg-7kpbhpki-namesp-0123456789012345678901234567-4b9xc810",
+			"prefix1-sneg-7kpbhpki-names-01234567890123456789012345-4b9xc810",
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, withPrefix := range []bool{true, false} {
+			var oldNamer *Namer
+			var expectedName string
+
+			if withPrefix {
+				oldNamer = NewNamer(clusterId, "", klog.TODO())
+				expectedName = tc.expectDefaultPrefix
+			} else {
+				oldNamer = NewNamerWithPrefix(prefix, clusterId, "", klog.TODO())
+				expectedName = tc.expectCustomPrefix
+			}
+
+			newNamer := NewServerlessNEGNamer(oldNamer, kubeSystemUID)
+			res := newNamer.ServerlessNEG(tc.namespace, tc.name, serverlessNEGUID)
+			if len(res) > 63 {
+				t.Errorf("%s: got len(res) == %v, want <= 63", tc.desc, len(res))
+			}
+			if numHyphens := strings.Count(res, "-"); numHyphens != 5 {
+				t.Errorf("Expected to have 5 components to name delimited by `-`. Found only %d `-`", numHyphens)
+			}
+			if res != expectedName {
+				t.Errorf("%s: got %q, want %q", tc.desc, res, expectedName)
+			}
+		}
+	}
+}
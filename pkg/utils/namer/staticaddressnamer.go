@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+const (
+	// maxIPDescriptiveLabel is the max length for prefix, namespace, and name for
+	// static addresses. 63 - 1 (naming schema version prefix)
+	// - 2 (static address identifier prefix) - 8 (truncated kube system id) - 8 (suffix hash)
+	// - 5 (hyphen connectors) = 39
+	maxIPDescriptiveLabel = 39
+)
+
+// V1StaticAddressNamer implements StaticAddressNamer. This is a wrapper on top of namer.Namer.
+type V1StaticAddressNamer struct {
+	kubeSystemUID string
+	prefix        string
+
+	// maxDescriptiveLabel is the max length for the namespace and name fields in the static
+	// address name.
+	// maxIPDescriptiveLabel - len(prefix)
+	maxDescriptiveLabel int
+}
+
+// NewStaticAddressNamer returns a v1 namer for Static Addresses
+func NewStaticAddressNamer(namer *Namer, kubeSystemUID string) StaticAddressNamer {
+	return &V1StaticAddressNamer{
+		kubeSystemUID:       kubeSystemUID,
+		prefix:              namer.prefix,
+		maxDescriptiveLabel: maxIPDescriptiveLabel - len(namer.prefix),
+	}
+}
+
+// StaticAddress returns the gce Address name based on the StaticAddress CR
+// name, and namespace. Static Address naming convention:
+//
+// k8s{naming version}-ip-{cluster-uid}-{namespace}-{name}-{hash}
+// Output name is at most 63 characters.
+// Hash is generated from the KubeSystemUID, Namespace and Name.
+// Cluster UID will be 8 characters, hash suffix will be 8 characters
+//
+// Unlike other CR backed resources, the name does not depend on the CR UID so
+// that a StaticAddress that is recreated adopts the address that was retained
+// for the previous one.
+//
+// WARNING: Controllers will use the naming convention to correlate between
+// the StaticAddress CR and address resource in GCE,
+// so modifications must be backwards compatible.
+func (n *V1StaticAddressNamer) StaticAddress(namespace, name string) string {
+	clusterUID := common.ContentHash(n.kubeSystemUID, clusterUIDLength)
+	hash := common.ContentHash(strings.Join([]string{n.kubeSystemUID, namespace, name}, ";"), 8)
+	truncFields := TrimFieldsEvenly(n.maxDescriptiveLabel, namespace, name)
+	return fmt.Sprintf("%s%s-ip-%s-%s-%s-%s", n.prefix, schemaVersionV1, clusterUID, truncFields[0], truncFields[1], hash)
+}
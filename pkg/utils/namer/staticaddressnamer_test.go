@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestNamerStaticAddress(t *testing.T) {
+	longstring := "01234567890123456789012345678901234567890123456789"
+	prefix := "prefix"
+	testCases := []struct {
+		desc                string
+		namespace           string
+		name                string
+		expectDefaultPrefix string
+		expectCustomPrefix  string
+	}{
+		{
+			"simple case",
+			"namespace",
+			"name",
+			"k8s1-ip-7kpbhpki-namespace-name-uhmwf5xi",
+			"prefix1-ip-7kpbhpki-namespace-name-uhmwf5xi",
+		},
+		{
+			"63 characters with default prefix k8s",
+			longstring[:18],
+			longstring[:18],
+			"k8s1-ip-7kpbhpki-012345678901234567-012345678901234567-00zb8w0o",
+			"prefix1-ip-7kpbhpki-01234567890123456-0123456789012345-00zb8w0o",
+		},
+		{
+			"long namespace",
+			longstring,
+			"name",
+			"k8s1-ip-7kpbhpki-0123456789012345678901234567890123-na-j9gpvlih",
+			"prefix1-ip-7kpbhpki-0123456789012345678901234567890-na-j9gpvlih",
+		},
+		{
+			"long name",
+			"namespace",
+			longstring,
+			"k8s1-ip-7kpbhpki-namesp-012345678901234567890123456789-bcr2vfap",
+			"prefix1-ip-7kpbhpki-namesp-012345678901234567890123456-bcr2vfap",
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, withPrefix := range []bool{true, false} {
+			var oldNamer *Namer
+			var expectedName string
+
+			if withPrefix {
+				oldNamer = NewNamer(clusterId, "", klog.TODO())
+				expectedName = tc.expectDefaultPrefix
+			} else {
+				oldNamer = NewNamerWithPrefix(prefix, clusterId, "", klog.TODO())
+				expectedName = tc.expectCustomPrefix
+			}
+
+			newNamer := NewStaticAddressNamer(oldNamer, kubeSystemUID)
+			res := newNamer.StaticAddress(tc.namespace, tc.name)
+			if len(res) > 63 {
+				t.Errorf("%s: got len(res) == %v, want <= 63", tc.desc, len(res))
+			}
+			if numHyphens := strings.Count(res, "-"); numHyphens != 5 {
+				t.Errorf("Expected to have 5 components to name delimited by `-`. Found only %d `-`", numHyphens)
+			}
+			if res != expectedName {
+				t.Errorf("%s: got %q, want %q", tc.desc, res, expectedName)
+			}
+		}
+	}
+}
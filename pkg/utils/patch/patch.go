@@ -84,3 +84,12 @@ func PatchServiceLoadBalancerStatus(client coreclient.CoreV1Interface, svc *core
 	_, err := svchelpers.PatchService(client, svc, newSvc)
 	return err
 }
+
+// PatchServiceConditions patches the given service's status conditions based
+// on new service's conditions.
+func PatchServiceConditions(client coreclient.CoreV1Interface, svc *corev1.Service, newConditions []metav1.Condition) error {
+	newSvc := svc.DeepCopy()
+	newSvc.Status.Conditions = newConditions
+	_, err := svchelpers.PatchService(client, svc, newSvc)
+	return err
+}
@@ -23,7 +23,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/apis/backendbucket"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/apis/serverlessneg"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 )
 
@@ -59,10 +61,14 @@ type ServicePort struct {
 	// Numerical port of the Service, retrieved from the Service
 	Port int32
 	// Name of the port of the Service, retrieved from the Service
-	PortName             string
-	Protocol             annotations.AppProtocol
-	TargetPort           intstr.IntOrString
-	NEGEnabled           bool
+	PortName   string
+	Protocol   annotations.AppProtocol
+	TargetPort intstr.IntOrString
+	NEGEnabled bool
+	// GlobalNEG is true if the NEGs of the backend are global internet NEGs
+	// (INTERNET_FQDN_PORT or INTERNET_IP_PORT), which do not support health
+	// checks.
+	GlobalNEG            bool
 	VMIPNEGEnabled       bool
 	L4RBSEnabled         bool
 	L7ILBEnabled         bool
@@ -121,6 +127,32 @@ func BackendToServicePortID(be v1.IngressBackend, namespace string) (ServicePort
 	}, nil
 }
 
+// BackendToBackendBucketName returns the name of the BackendBucket referenced
+// by the resource of the given IngressBackend. It returns false if the backend
+// does not reference a BackendBucket.
+func BackendToBackendBucketName(be v1.IngressBackend) (string, bool) {
+	if be.Resource == nil || be.Resource.APIGroup == nil {
+		return "", false
+	}
+	if *be.Resource.APIGroup != backendbucket.GroupName || be.Resource.Kind != backendbucket.Kind {
+		return "", false
+	}
+	return be.Resource.Name, true
+}
+
+// BackendToServerlessNEGName returns the name of the ServerlessNEG referenced
+// by the resource of the given IngressBackend. It returns false if the backend
+// does not reference a ServerlessNEG.
+func BackendToServerlessNEGName(be v1.IngressBackend) (string, bool) {
+	if be.Resource == nil || be.Resource.APIGroup == nil {
+		return "", false
+	}
+	if *be.Resource.APIGroup != serverlessneg.GroupName || be.Resource.Kind != serverlessneg.Kind {
+		return "", false
+	}
+	return be.Resource.Name, true
+}
+
 func newServicePortWithID(svcName, svcNamespace string, port v1.ServiceBackendPort) ServicePort {
 	return ServicePort{
 		ID: ServicePortID{
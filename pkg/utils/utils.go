@@ -38,6 +38,8 @@ import (
 	cloudprovider "k8s.io/cloud-provider"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	ingparamsv1beta1 "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/ingress-gce/pkg/utils/slice"
@@ -465,6 +467,100 @@ func EqualCloudResourceIDs(a, b *cloud.ResourceID) bool {
 	}
 }
 
+// IngressClassParamsFunc returns the GCPIngressParams for an Ingress that
+// selects an IngressClass handled by this controller through
+// spec.ingressClassName. It returns nil params and a nil error if the Ingress
+// does not select such an IngressClass.
+type IngressClassParamsFunc func(ing *networkingv1.Ingress) (*ingparamsv1beta1.GCPIngressParams, error)
+
+// IngressClassifier classifies Ingresses by the type of load balancer they
+// request. Params resolves the GCPIngressParams of Ingresses that use
+// spec.ingressClassName; if it is nil, only the ingress.class annotation is
+// considered. The zero value is ready to use.
+type IngressClassifier struct {
+	Params IngressClassParamsFunc
+}
+
+// gcpIngressParams returns the GCPIngressParams of the given Ingress and
+// whether the Ingress selects an IngressClass handled by this controller.
+// An Ingress with an ingress.class annotation never selects an IngressClass.
+func (c IngressClassifier) gcpIngressParams(ing *networkingv1.Ingress) (*ingparamsv1beta1.GCPIngressParams, bool, error) {
+	if c.Params == nil || ing == nil || ing.Spec.IngressClassName == nil {
+		return nil, false, nil
+	}
+	if annotations.FromIngress(ing).IngressClass() != "" {
+		return nil, false, nil
+	}
+	params, err := c.Params(ing)
+	if err != nil {
+		return nil, false, err
+	}
+	return params, params != nil, nil
+}
+
+// IsGCEIngress returns true if the Ingress matches the class managed by this
+// controller, either through the ingress.class annotation or through an
+// IngressClass whose parameters are handled by this controller. It returns
+// an error if the IngressClass parameters could not be resolved.
+func (c IngressClassifier) IsGCEIngress(ing *networkingv1.Ingress) (bool, error) {
+	params, ok, err := c.gcpIngressParams(ing)
+	switch {
+	case err != nil:
+		return false, err
+	case !ok:
+		return IsGCEIngress(ing), nil
+	case params.Spec.Internal:
+		return true, nil
+	case params.Spec.Regional:
+		return flags.F.EnableIngressRegionalExternal, nil
+	default:
+		return flags.F.EnableIngressGlobalExternal, nil
+	}
+}
+
+// IsL7ILB returns true if the given Ingress has ingress.class annotation set
+// to "gce-l7-ilb", or selects an IngressClass whose GCPIngressParams request
+// internal load balancing.
+func (c IngressClassifier) IsL7ILB(ing *networkingv1.Ingress) (bool, error) {
+	params, ok, err := c.gcpIngressParams(ing)
+	if err != nil || !ok {
+		return IsGCEL7ILBIngress(ing), err
+	}
+	return params.Spec.Internal, nil
+}
+
+// IsL7XLBRegional returns true if the given Ingress has ingress.class
+// annotation set to "gce-regional-external", or selects an IngressClass whose
+// GCPIngressParams request regional external load balancing.
+func (c IngressClassifier) IsL7XLBRegional(ing *networkingv1.Ingress) (bool, error) {
+	params, ok, err := c.gcpIngressParams(ing)
+	if err != nil || !ok {
+		return IsGCEL7XLBRegionalIngress(ing), err
+	}
+	return !params.Spec.Internal && params.Spec.Regional, nil
+}
+
+// IsGLBCIngress returns true if the given Ingress should be processed by GLBC.
+func (c IngressClassifier) IsGLBCIngress(ing *networkingv1.Ingress) (bool, error) {
+	if IsGCEMultiClusterIngress(ing) {
+		return true, nil
+	}
+	return c.IsGCEIngress(ing)
+}
+
+// NeedsCleanup returns true if the ingress needs to have its associated
+// resources deleted.
+func (c IngressClassifier) NeedsCleanup(ing *networkingv1.Ingress) (bool, error) {
+	if common.IsDeletionCandidate(ing.ObjectMeta) {
+		return true, nil
+	}
+	isGLBCIngress, err := c.IsGLBCIngress(ing)
+	if err != nil {
+		return false, err
+	}
+	return !isGLBCIngress, nil
+}
+
 // IsGCEIngress returns true if the Ingress matches the class managed by this
 // controller.
 func IsGCEIngress(ing *networkingv1.Ingress) bool {
@@ -475,9 +571,9 @@ func IsGCEIngress(ing *networkingv1.Ingress) bool {
 
 	switch class {
 	case "":
-		// Ingress controller does not have any ingress classes that can be
-		// specified by spec.IngressClassName. If spec.IngressClassName
-		// is nil, then consider GCEIngress.
+		// Ingresses that use spec.IngressClassName are only handled through
+		// IngressClassifier. If spec.IngressClassName is nil, then consider
+		// GCEIngress.
 		return ing.Spec.IngressClassName == nil
 	case annotations.GceIngressClass:
 		return flags.F.EnableIngressGlobalExternal
@@ -572,9 +668,11 @@ func JoinErrs(errs []error) error {
 	return errors.New(strings.Join(errStrs, "; "))
 }
 
-// TraverseIngressBackends traverse thru all backends specified in the input ingress and call process
+// TraverseIngressBackends traverse thru all backends specified in the input ingress, and in the
+// route rules of the FrontendConfig referenced by the ingress, and call process.
+// feConfig can be nil if the ingress does not reference a FrontendConfig.
 // If process return true, then return and stop traversing the backends
-func TraverseIngressBackends(ing *networkingv1.Ingress, process func(id ServicePortID) bool) {
+func TraverseIngressBackends(ing *networkingv1.Ingress, feConfig *frontendconfigv1beta1.FrontendConfig, process func(id ServicePortID) bool) {
 	if ing == nil {
 		return
 	}
@@ -598,6 +696,36 @@ func TraverseIngressBackends(ing *networkingv1.Ingress, process func(id ServiceP
 			}
 		}
 	}
+
+	// Check the target services of the traffic splits. Invalid splits are
+	// reported during translation.
+	splits, _ := annotations.FromIngress(ing).TrafficSplits()
+	targets := make([]annotations.TrafficSplitTarget, 0, len(splits))
+	for target := range splits {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].String() < targets[j].String() })
+	for _, target := range targets {
+		for _, backend := range splits[target] {
+			if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: backend.ServiceName}, Port: backend.ServicePort}) {
+				return
+			}
+		}
+	}
+
+	// Check the target services of the route rules. Invalid route rules are
+	// reported during translation.
+	if feConfig == nil || feConfig.Namespace != ing.Namespace {
+		return
+	}
+	for _, rule := range feConfig.Spec.RouteRules {
+		for _, backend := range rule.Backends {
+			port := networkingv1.ServiceBackendPort{Name: backend.ServicePort.Name, Number: backend.ServicePort.Number}
+			if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: backend.ServiceName}, Port: port}) {
+				return
+			}
+		}
+	}
 	return
 }
 
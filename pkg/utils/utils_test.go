@@ -40,6 +40,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	ingparamsv1beta1 "k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils/common"
 )
@@ -504,11 +506,57 @@ func TestTraverseIngressBackends(t *testing.T) {
 				},
 			},
 		},
+		{
+			"traffic split backends",
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.TrafficSplitKey: `{"foo-service:80": [{"serviceName": "foo-service", "servicePort": {"number": 80}, "weight": 90}, {"serviceName": "foo-canary", "servicePort": {"number": 8080}, "weight": 10}]}`,
+					},
+				},
+				Spec: networkingv1.IngressSpec{
+					DefaultBackend: &networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: "foo-service",
+							Port: networkingv1.ServiceBackendPort{
+								Number: 80,
+							},
+						},
+					},
+				},
+			},
+			[]networkingv1.IngressBackend{
+				{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: "foo-service",
+						Port: networkingv1.ServiceBackendPort{
+							Number: 80,
+						},
+					},
+				},
+				{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: "foo-service",
+						Port: networkingv1.ServiceBackendPort{
+							Number: 80,
+						},
+					},
+				},
+				{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: "foo-canary",
+						Port: networkingv1.ServiceBackendPort{
+							Number: 8080,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		counter := 0
-		TraverseIngressBackends(tc.ing, func(id ServicePortID) bool {
+		TraverseIngressBackends(tc.ing, nil, func(id ServicePortID) bool {
 			if tc.expectBackends[counter].Service.Name != id.Service.Name || tc.expectBackends[counter].Service.Port != id.Port {
 				t.Errorf("Test case %q, for backend %v, expecting service name %q and service port %+v, but got %q, %q", tc.desc, counter, tc.expectBackends[counter].Service.Name, tc.expectBackends[counter].Service.Port, id.Service.Name, id.Port.String())
 			}
@@ -518,6 +566,77 @@ func TestTraverseIngressBackends(t *testing.T) {
 	}
 }
 
+func TestTraverseIngressBackendsRouteRules(t *testing.T) {
+	t.Parallel()
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: "foo-service",
+					Port: networkingv1.ServiceBackendPort{Number: 80},
+				},
+			},
+		},
+	}
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "fc"},
+		Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			RouteRules: []frontendconfigv1beta1.RouteRule{
+				{
+					Backends: []frontendconfigv1beta1.RouteBackend{
+						{ServiceName: "bar-service", ServicePort: frontendconfigv1beta1.ServiceBackendPort{Name: "http"}, Weight: 90},
+						{ServiceName: "bar-canary", ServicePort: frontendconfigv1beta1.ServiceBackendPort{Number: 8080}, Weight: 10},
+					},
+				},
+			},
+		},
+	}
+	otherNamespaceFeConfig := feConfig.DeepCopy()
+	otherNamespaceFeConfig.Namespace = "other"
+
+	testCases := []struct {
+		desc     string
+		feConfig *frontendconfigv1beta1.FrontendConfig
+		want     []ServicePortID
+	}{
+		{
+			desc: "no FrontendConfig",
+			want: []ServicePortID{
+				{Service: types.NamespacedName{Namespace: "ns", Name: "foo-service"}, Port: networkingv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+		{
+			desc:     "route rule backends",
+			feConfig: feConfig,
+			want: []ServicePortID{
+				{Service: types.NamespacedName{Namespace: "ns", Name: "foo-service"}, Port: networkingv1.ServiceBackendPort{Number: 80}},
+				{Service: types.NamespacedName{Namespace: "ns", Name: "bar-service"}, Port: networkingv1.ServiceBackendPort{Name: "http"}},
+				{Service: types.NamespacedName{Namespace: "ns", Name: "bar-canary"}, Port: networkingv1.ServiceBackendPort{Number: 8080}},
+			},
+		},
+		{
+			desc:     "FrontendConfig in another namespace",
+			feConfig: otherNamespaceFeConfig,
+			want: []ServicePortID{
+				{Service: types.NamespacedName{Namespace: "ns", Name: "foo-service"}, Port: networkingv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []ServicePortID
+			TraverseIngressBackends(ing, tc.feConfig, func(id ServicePortID) bool {
+				got = append(got, id)
+				return false
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("TraverseIngressBackends() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // Do not run in parallel since modifies global flags
 // TODO(shance): remove l7-ilb flag tests once flag is removed
 func TestIsGCEIngress(t *testing.T) {
@@ -793,6 +912,148 @@ func TestIsGCEL7XLBRegionalIngress(t *testing.T) {
 	}
 }
 
+func TestIngressClassParams(t *testing.T) {
+	ourClass := "gcp-class"
+	otherClass := "other-class"
+	paramsByClass := map[string]*ingparamsv1beta1.GCPIngressParams{
+		ourClass: nil,
+	}
+	testCases := []struct {
+		desc            string
+		className       *string
+		annotation      string
+		params          *ingparamsv1beta1.GCPIngressParams
+		paramsErr       error
+		xlbRegionalFlag bool
+		xlbGlobalFlag   bool
+		wantGCE         bool
+		wantILB         bool
+		wantXLBRegional bool
+		wantErr         bool
+	}{
+		{
+			desc:          "IngressClass not handled by the controller",
+			className:     &otherClass,
+			xlbGlobalFlag: true,
+		},
+		{
+			desc:          "internal params",
+			className:     &ourClass,
+			params:        &ingparamsv1beta1.GCPIngressParams{Spec: ingparamsv1beta1.GCPIngressParamsSpec{Internal: true}},
+			xlbGlobalFlag: true,
+			wantGCE:       true,
+			wantILB:       true,
+		},
+		{
+			desc:          "internal params ignore regional",
+			className:     &ourClass,
+			params:        &ingparamsv1beta1.GCPIngressParams{Spec: ingparamsv1beta1.GCPIngressParamsSpec{Internal: true, Regional: true}},
+			xlbGlobalFlag: true,
+			wantGCE:       true,
+			wantILB:       true,
+		},
+		{
+			desc:            "regional external params",
+			className:       &ourClass,
+			params:          &ingparamsv1beta1.GCPIngressParams{Spec: ingparamsv1beta1.GCPIngressParamsSpec{Regional: true}},
+			xlbRegionalFlag: true,
+			wantGCE:         true,
+			wantXLBRegional: true,
+		},
+		{
+			desc:            "regional external params with regional external disabled",
+			className:       &ourClass,
+			params:          &ingparamsv1beta1.GCPIngressParams{Spec: ingparamsv1beta1.GCPIngressParamsSpec{Regional: true}},
+			xlbRegionalFlag: false,
+			wantXLBRegional: true,
+		},
+		{
+			desc:          "global external params",
+			className:     &ourClass,
+			params:        &ingparamsv1beta1.GCPIngressParams{},
+			xlbGlobalFlag: true,
+			wantGCE:       true,
+		},
+		{
+			desc:          "global external params with global external disabled",
+			className:     &ourClass,
+			params:        &ingparamsv1beta1.GCPIngressParams{},
+			xlbGlobalFlag: false,
+		},
+		{
+			desc:          "unresolvable params return the error",
+			className:     &ourClass,
+			paramsErr:     errors.New("not found"),
+			xlbGlobalFlag: true,
+			wantErr:       true,
+		},
+		{
+			desc:          "annotation does not resolve params",
+			annotation:    annotations.GceL7ILBIngressClass,
+			className:     &ourClass,
+			paramsErr:     errors.New("not found"),
+			xlbGlobalFlag: true,
+			wantGCE:       true,
+			wantILB:       true,
+		},
+		{
+			desc:          "annotation takes precedence over params",
+			className:     &ourClass,
+			annotation:    annotations.GceIngressClass,
+			params:        &ingparamsv1beta1.GCPIngressParams{Spec: ingparamsv1beta1.GCPIngressParamsSpec{Internal: true}},
+			xlbGlobalFlag: true,
+			wantGCE:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags.F.EnableIngressRegionalExternal = tc.xlbRegionalFlag
+			flags.F.EnableIngressGlobalExternal = tc.xlbGlobalFlag
+			defer func() {
+				flags.F.EnableIngressRegionalExternal = false
+				flags.F.EnableIngressGlobalExternal = true
+			}()
+
+			paramsByClass[ourClass] = tc.params
+			classifier := IngressClassifier{Params: func(ing *networkingv1.Ingress) (*ingparamsv1beta1.GCPIngressParams, error) {
+				if _, ok := paramsByClass[*ing.Spec.IngressClassName]; !ok {
+					return nil, nil
+				}
+				if tc.paramsErr != nil {
+					return nil, tc.paramsErr
+				}
+				return paramsByClass[*ing.Spec.IngressClassName], nil
+			}}
+
+			ing := &networkingv1.Ingress{
+				ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       networkingv1.IngressSpec{IngressClassName: tc.className},
+			}
+			if tc.annotation != "" {
+				ing.Annotations[annotations.IngressClassKey] = tc.annotation
+			}
+
+			got, err := classifier.IsGCEIngress(ing)
+			if (err != nil) != tc.wantErr || got != tc.wantGCE {
+				t.Errorf("IsGCEIngress() = %v, %v, want %v, error: %v", got, err, tc.wantGCE, tc.wantErr)
+			}
+			got, err = classifier.IsL7ILB(ing)
+			if (err != nil) != tc.wantErr || got != tc.wantILB {
+				t.Errorf("IsL7ILB() = %v, %v, want %v, error: %v", got, err, tc.wantILB, tc.wantErr)
+			}
+			got, err = classifier.IsL7XLBRegional(ing)
+			if (err != nil) != tc.wantErr || got != tc.wantXLBRegional {
+				t.Errorf("IsL7XLBRegional() = %v, %v, want %v, error: %v", got, err, tc.wantXLBRegional, tc.wantErr)
+			}
+			needsCleanup, err := classifier.NeedsCleanup(ing)
+			if (err != nil) != tc.wantErr || needsCleanup != (!tc.wantGCE && !tc.wantErr) {
+				t.Errorf("NeedsCleanup() = %v, %v, want %v, error: %v", needsCleanup, err, !tc.wantGCE && !tc.wantErr, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestNeedsCleanup(t *testing.T) {
 	testCases := []struct {
 		isGLBCIngress       bool
@@ -193,6 +193,39 @@ func (z *ZoneGetter) ListZones(filter Filter, logger klog.Logger) ([]string, err
 	return zones.List(), nil
 }
 
+// ListZonesMatchingSelector returns the list of zones in which all nodes that
+// satisfy the given node filtering mode match the given label selector, and
+// the list of zones in which only some of them match. Selectors are matched
+// per zone, so the matching nodes of the partially matching zones are not
+// selected.
+func (z *ZoneGetter) ListZonesMatchingSelector(filter Filter, selector labels.Selector, logger klog.Logger) ([]string, []string, error) {
+	if z.mode == NonGCP {
+		logger.Info("ZoneGetter in non-gcp mode, no zone matches the selector")
+		return nil, nil, nil
+	}
+
+	filterLogger := logger.WithValues("filter", filter, "selector", selector.String())
+	nodes, err := z.ListNodes(filter, logger)
+	if err != nil {
+		filterLogger.Error(err, "Failed to list nodes")
+		return nil, nil, err
+	}
+	matching, notMatching := sets.String{}, sets.String{}
+	for _, n := range nodes {
+		zone, err := getZone(n)
+		if err != nil || zone == EmptyZone {
+			filterLogger.Error(err, "Failed to get zone from providerID", "nodeName", n.Name)
+			continue
+		}
+		if selector.Matches(labels.Set(n.Labels)) {
+			matching.Insert(zone)
+		} else {
+			notMatching.Insert(zone)
+		}
+	}
+	return matching.Difference(notMatching).List(), matching.Intersection(notMatching).List(), nil
+}
+
 // ListSubnets returns the lists of subnets in the cluster based on the
 // NodeTopology CR.
 // If the CR does not exist or it is not ready, ListSubnets will return only the
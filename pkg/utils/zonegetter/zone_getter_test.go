@@ -26,6 +26,7 @@ import (
 	api_v1 "k8s.io/api/core/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog/v2"
@@ -77,6 +78,80 @@ func TestListZones(t *testing.T) {
 	}
 }
 
+func TestListZonesMatchingSelector(t *testing.T) {
+	t.Parallel()
+
+	nodeInformer := FakeNodeInformer()
+	for _, node := range []struct {
+		name, zone, role string
+	}{
+		{"instance1", "zone1", "primary"},
+		{"instance2", "zone1", "standby"},
+		{"instance3", "zone2", "standby"},
+		{"instance4", "zone2", "standby"},
+		{"instance5", "zone3", "primary"},
+	} {
+		if err := nodeInformer.GetIndexer().Add(&apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   node.name,
+				Labels: map[string]string{"role": node.role, "node": node.name},
+			},
+			Spec: apiv1.NodeSpec{
+				ProviderID: fmt.Sprintf("gce://foo-project/%s/%s", node.zone, node.name),
+				PodCIDR:    "10.100.1.0/24",
+			},
+		}); err != nil {
+			t.Fatalf("Failed to add node %s: %v", node.name, err)
+		}
+	}
+	zoneGetter := NewFakeZoneGetter(nodeInformer, FakeNodeTopologyInformer(), defaultTestSubnetURL, false)
+
+	for _, tc := range []struct {
+		desc        string
+		selector    labels.Selector
+		want        []string
+		wantPartial []string
+	}{
+		{
+			desc:        "all nodes of a zone match",
+			selector:    labels.SelectorFromSet(labels.Set{"role": "standby"}),
+			want:        []string{"zone2"},
+			wantPartial: []string{"zone1"},
+		},
+		{
+			desc:        "zone with non-matching nodes is excluded",
+			selector:    labels.SelectorFromSet(labels.Set{"role": "primary"}),
+			want:        []string{"zone3"},
+			wantPartial: []string{"zone1"},
+		},
+		{
+			desc:        "nodes match in no whole zone",
+			selector:    labels.SelectorFromSet(labels.Set{"node": "instance1"}),
+			want:        []string{},
+			wantPartial: []string{"zone1"},
+		},
+		{
+			desc:        "no node matches",
+			selector:    labels.Nothing(),
+			want:        []string{},
+			wantPartial: []string{},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			zones, partialZones, err := zoneGetter.ListZonesMatchingSelector(AllNodesFilter, tc.selector, klog.TODO())
+			if err != nil {
+				t.Fatalf("ListZonesMatchingSelector() returned error %v", err)
+			}
+			if !reflect.DeepEqual(zones, tc.want) {
+				t.Errorf("ListZonesMatchingSelector() = %v, want %v", zones, tc.want)
+			}
+			if !reflect.DeepEqual(partialZones, tc.wantPartial) {
+				t.Errorf("ListZonesMatchingSelector() partially matching zones = %v, want %v", partialZones, tc.wantPartial)
+			}
+		})
+	}
+}
+
 func TestListZonesMultipleSubnets(t *testing.T) {
 	t.Parallel()
 